@@ -0,0 +1,201 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package emoji
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/emojipack"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
+)
+
+type emoji struct {
+	state   *state.State
+	manager *emojipack.Manager
+}
+
+func setupEmoji(ctx context.Context) (*emoji, error) {
+	var state state.State
+
+	state.Caches.Init()
+	state.Caches.Start()
+
+	state.Workers.Start()
+
+	dbService, err := bundb.NewBunDBService(ctx, &state)
+	if err != nil {
+		return nil, fmt.Errorf("error creating dbservice: %w", err)
+	}
+	state.DB = dbService
+
+	//nolint:contextcheck
+	storage, err := gtsstorage.AutoConfig("")
+	if err != nil {
+		return nil, fmt.Errorf("error creating storage backend: %w", err)
+	}
+	state.Storage = storage
+
+	//nolint:contextcheck
+	mediaManager := media.NewManager(&state)
+
+	return &emoji{
+		state:   &state,
+		manager: emojipack.New(&state, mediaManager),
+	}, nil
+}
+
+func (e *emoji) shutdown() error {
+	errs := gtserror.NewMultiError(2)
+
+	if err := e.state.Storage.Close(); err != nil {
+		errs.Appendf("error closing storage backend: %w", err)
+	}
+
+	if err := e.state.DB.Close(); err != nil {
+		errs.Appendf("error stopping database: %w", err)
+	}
+
+	e.state.Workers.Stop()
+	e.state.Caches.Stop()
+
+	return errs.Combine()
+}
+
+// Import imports a pack of custom emojis from
+// a zip or tar.gz archive at the given path.
+var Import action.GTSAction = func(ctx context.Context) error {
+	path := config.GetAdminTransPath()
+	if path == "" {
+		return errors.New("no path set")
+	}
+
+	conflict, err := emojipack.ParseConflict(config.GetAdminEmojiConflict())
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("error opening %s: %w", path, err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", path, err)
+	}
+
+	pack, err := emojipack.Read(file, info.Size())
+	if err != nil {
+		return err
+	}
+
+	e, err := setupEmoji(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		// Ensure that state gets shut down on exit.
+		if err := e.shutdown(); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	totals := make(map[emojipack.Outcome]int)
+	for _, res := range e.manager.Import(ctx, pack, conflict, config.GetAdminEmojiCategory()) {
+		totals[res.Outcome]++
+
+		switch res.Outcome {
+		case emojipack.OutcomeFailed:
+			// Already logged by importer.
+		case emojipack.OutcomeRenamed:
+			log.Infof(ctx, "imported %s as %s", res.Shortcode, res.Emoji.Shortcode)
+		default:
+			log.Infof(ctx, "%s %s", res.Outcome, res.Shortcode)
+		}
+	}
+
+	log.Infof(ctx,
+		"created: %d, overwritten: %d, renamed: %d, skipped: %d, failed: %d",
+		totals[emojipack.OutcomeCreated],
+		totals[emojipack.OutcomeOverwritten],
+		totals[emojipack.OutcomeRenamed],
+		totals[emojipack.OutcomeSkipped],
+		totals[emojipack.OutcomeFailed],
+	)
+
+	return nil
+}
+
+// Export exports all local custom emojis to a pack
+// archive at the given path. The archive is written
+// as tar.gz if the path ends in .tar.gz or .tgz,
+// and as zip otherwise.
+var Export action.GTSAction = func(ctx context.Context) error {
+	path := config.GetAdminTransPath()
+	if path == "" {
+		return errors.New("no path set")
+	}
+
+	format := emojipack.FormatZip
+	if strings.HasSuffix(path, ".tar.gz") || strings.HasSuffix(path, ".tgz") {
+		format = emojipack.FormatTarGz
+	}
+
+	e, err := setupEmoji(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		// Ensure that state gets shut down on exit.
+		if err := e.shutdown(); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", path, err)
+	}
+
+	n, err := e.manager.Export(ctx, file, format)
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("error exporting emojis: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("error closing %s: %w", path, err)
+	}
+
+	log.Infof(ctx, "exported %d emojis to %s", n, path)
+	return nil
+}
@@ -0,0 +1,264 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
+)
+
+type migrate struct {
+	src      *gtsstorage.Driver
+	dst      *gtsstorage.Driver
+	dryRun   bool
+	throttle time.Duration
+
+	// Totals.
+	copied   int
+	existing int
+}
+
+// MigrateStorage copies all media from one storage backend to another,
+// verifying each copied file. Files already present in the destination
+// with identical contents are skipped, so an interrupted migration can
+// be resumed by running it again.
+var MigrateStorage action.GTSAction = func(ctx context.Context) error {
+	from := config.GetAdminMediaMigrateFrom()
+	to := config.GetAdminMediaMigrateTo()
+	if from == to {
+		return fmt.Errorf("storage backends to migrate from and to must differ, both were %s", from)
+	}
+
+	//nolint:contextcheck
+	src, err := gtsstorage.Open(from, "")
+	if err != nil {
+		return fmt.Errorf("error opening storage backend %s: %w", from, err)
+	}
+
+	//nolint:contextcheck
+	dst, err := gtsstorage.Open(to, "")
+	if err != nil {
+		_ = src.Close()
+		return fmt.Errorf("error opening storage backend %s: %w", to, err)
+	}
+
+	m := &migrate{
+		src:      src,
+		dst:      dst,
+		dryRun:   config.GetAdminMediaPruneDryRun(),
+		throttle: config.GetAdminMediaMigrateThrottle(),
+	}
+
+	defer func() {
+		// Ensure storage gets closed on exit.
+		if err := m.shutdown(); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	if m.dryRun {
+		log.Info(ctx, "migrate DRY RUN")
+	}
+
+	log.Infof(ctx, "migrating media from %s to %s", from, to)
+
+	if err := m.src.WalkKeys(ctx, m.migrateKey); err != nil {
+		return fmt.Errorf("error migrating storage (copied %d): %w", m.copied, err)
+	}
+
+	if m.dryRun {
+		log.Infof(ctx, "to copy: %d, already migrated: %d", m.copied, m.existing)
+	} else {
+		log.Infof(ctx, "copied: %d, already migrated: %d", m.copied, m.existing)
+	}
+
+	return nil
+}
+
+// migrateKey copies the file at key from src to dst storage,
+// unless an identical copy is already stored at key in dst.
+func (m *migrate) migrateKey(ctx context.Context, key string) error {
+	have, err := m.dst.Has(ctx, key)
+	if err != nil {
+		return gtserror.Newf("error checking destination for %s: %w", key, err)
+	}
+
+	if have {
+		// Already copied (eg., by a previous
+		// interrupted run), check it's intact.
+		same, err := m.compare(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		if same {
+			m.existing++
+			return nil
+		}
+
+		log.Warnf(ctx, "destination file differs from source, recopying: %s", key)
+
+		if !m.dryRun {
+			if err := m.dst.Delete(ctx, key); err != nil {
+				return gtserror.Newf("error removing %s from destination: %w", key, err)
+			}
+		}
+	}
+
+	if m.dryRun {
+		// Dry run, do nothing.
+		m.copied++
+		return nil
+	}
+
+	if err := m.copy(ctx, key); err != nil {
+		return err
+	}
+
+	m.copied++
+	log.Debugf(ctx, "copied %s", key)
+
+	if m.throttle > 0 {
+		// Wait before copying the
+		// next file, if throttled.
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(m.throttle):
+		}
+	}
+
+	return nil
+}
+
+// copy streams the file at key from src to dst storage,
+// reading it back from dst to verify its contents.
+func (m *migrate) copy(ctx context.Context, key string) error {
+	rc, err := m.src.GetStream(ctx, key)
+	if err != nil {
+		return gtserror.Newf("error opening %s in source: %w", key, err)
+	}
+	defer rc.Close()
+
+	// Hash contents as they're written.
+	srcHash := sha256.New()
+	r := io.TeeReader(rc, srcHash)
+
+	if _, err := m.dst.PutStream(ctx, key, r); err != nil {
+		return gtserror.Newf("error writing %s to destination: %w", key, err)
+	}
+
+	dstSum, err := sum(ctx, m.dst, key)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(srcHash.Sum(nil), dstSum) {
+		// Don't leave a broken copy lying around.
+		if err := m.dst.Delete(ctx, key); err != nil {
+			log.Errorf(ctx, "error removing %s from destination: %v", key, err)
+		}
+
+		return gtserror.Newf("verification failed for %s: destination contents differ from source", key)
+	}
+
+	return nil
+}
+
+// compare returns whether the files at key in src and dst
+// storage have the same contents. File sizes and ETags are
+// compared first, only hashing the contents of both files
+// when they're missing or inconclusive.
+func (m *migrate) compare(ctx context.Context, key string) (bool, error) {
+	srcInfo, err := m.src.Stat(ctx, key)
+	if err != nil {
+		return false, gtserror.Newf("error statting %s in source: %w", key, err)
+	}
+
+	dstInfo, err := m.dst.Stat(ctx, key)
+	if err != nil {
+		return false, gtserror.Newf("error statting %s in destination: %w", key, err)
+	}
+
+	if srcInfo.Size >= 0 && dstInfo.Size >= 0 &&
+		srcInfo.Size != dstInfo.Size {
+		// Sizes differ, so
+		// contents must too.
+		return false, nil
+	}
+
+	if srcInfo.ETag != "" && srcInfo.ETag == dstInfo.ETag {
+		// Matching ETags (ie., S3 to S3), the
+		// contents are the same. Differing ETags
+		// may just be down to multipart uploads.
+		return true, nil
+	}
+
+	srcSum, err := sum(ctx, m.src, key)
+	if err != nil {
+		return false, err
+	}
+
+	dstSum, err := sum(ctx, m.dst, key)
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(srcSum, dstSum), nil
+}
+
+func (m *migrate) shutdown() error {
+	errs := gtserror.NewMultiError(2)
+
+	if err := m.src.Close(); err != nil {
+		errs.Appendf("error closing source storage: %w", err)
+	}
+
+	if err := m.dst.Close(); err != nil {
+		errs.Appendf("error closing destination storage: %w", err)
+	}
+
+	return errs.Combine()
+}
+
+// sum returns the SHA-256 hash of
+// the file at key in given storage.
+func sum(ctx context.Context, storage *gtsstorage.Driver, key string) ([]byte, error) {
+	rc, err := storage.GetStream(ctx, key)
+	if err != nil {
+		return nil, gtserror.Newf("error opening %s: %w", key, err)
+	}
+	defer rc.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, rc); err != nil {
+		return nil, gtserror.Newf("error reading %s: %w", key, err)
+	}
+
+	return h.Sum(nil), nil
+}
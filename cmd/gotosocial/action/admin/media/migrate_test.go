@@ -0,0 +1,179 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"testing"
+
+	"codeberg.org/gruf/go-store/v2/storage"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
+)
+
+// newMigrate returns a migrate from a memory store containing
+// files "a" and "b", to a memory store containing a copy of "a".
+func newMigrate(t *testing.T, dryRun bool) *migrate {
+	ctx := context.Background()
+	src := &gtsstorage.Driver{Storage: storage.OpenMemory(10, false)}
+	dst := &gtsstorage.Driver{Storage: storage.OpenMemory(10, false)}
+
+	for key, value := range map[string]string{
+		"a": "file a",
+		"b": "file b",
+	} {
+		if _, err := src.Put(ctx, key, []byte(value)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := dst.Put(ctx, "a", []byte("file a")); err != nil {
+		t.Fatal(err)
+	}
+
+	return &migrate{
+		src:    src,
+		dst:    dst,
+		dryRun: dryRun,
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	ctx := context.Background()
+	m := newMigrate(t, false)
+
+	if err := m.src.WalkKeys(ctx, m.migrateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	// Already copied "a" should be
+	// skipped, and only "b" copied.
+	if m.copied != 1 || m.existing != 1 {
+		t.Fatalf("expected 1 copied and 1 existing, got %d and %d", m.copied, m.existing)
+	}
+
+	for key, expect := range map[string]string{
+		"a": "file a",
+		"b": "file b",
+	} {
+		b, err := m.dst.Get(ctx, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(b) != expect {
+			t.Fatalf("expected %q at %s, got %q", expect, key, b)
+		}
+	}
+
+	// Running again should
+	// have nothing to copy.
+	m.copied, m.existing = 0, 0
+	if err := m.src.WalkKeys(ctx, m.migrateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	if m.copied != 0 || m.existing != 2 {
+		t.Fatalf("expected 0 copied and 2 existing, got %d and %d", m.copied, m.existing)
+	}
+}
+
+func TestMigrateRecopiesDiffering(t *testing.T) {
+	ctx := context.Background()
+	m := newMigrate(t, false)
+
+	// Corrupt the copy of "a", eg.
+	// by an interrupted earlier run.
+	if err := m.dst.Delete(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := m.dst.Put(ctx, "a", []byte("file")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.src.WalkKeys(ctx, m.migrateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	if m.copied != 2 {
+		t.Fatalf("expected 2 copied, got %d", m.copied)
+	}
+
+	b, err := m.dst.Get(ctx, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != "file a" {
+		t.Fatalf("expected %q, got %q", "file a", b)
+	}
+}
+
+func TestMigrateRecopiesDifferingSameSize(t *testing.T) {
+	ctx := context.Background()
+	m := newMigrate(t, false)
+
+	// Replace the copy of "a" with
+	// different contents of the same
+	// size, which must be hashed to
+	// tell them apart.
+	if err := m.dst.Delete(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := m.dst.Put(ctx, "a", []byte("file c")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.src.WalkKeys(ctx, m.migrateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	if m.copied != 2 {
+		t.Fatalf("expected 2 copied, got %d", m.copied)
+	}
+
+	b, err := m.dst.Get(ctx, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != "file a" {
+		t.Fatalf("expected %q, got %q", "file a", b)
+	}
+}
+
+func TestMigrateDryRun(t *testing.T) {
+	ctx := context.Background()
+	m := newMigrate(t, true)
+
+	if err := m.src.WalkKeys(ctx, m.migrateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	// Counts should reflect what
+	// would have been copied...
+	if m.copied != 1 || m.existing != 1 {
+		t.Fatalf("expected 1 copied and 1 existing, got %d and %d", m.copied, m.existing)
+	}
+
+	// ...but nothing changed.
+	if have, _ := m.dst.Has(ctx, "b"); have {
+		t.Fatal("expected b not to be copied on dry run")
+	}
+}
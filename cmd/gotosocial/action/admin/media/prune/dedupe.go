@@ -0,0 +1,58 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package prune
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// Dedupe moves existing media in storage into content-addressed blobs.
+var Dedupe action.GTSAction = func(ctx context.Context) error {
+	// Setup pruning utilities.
+	prune, err := setupPrune(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		// Ensure pruner gets shutdown on exit.
+		if err := prune.shutdown(); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	if config.GetAdminMediaPruneDryRun() {
+		log.Info(ctx, "dedupe DRY RUN")
+		ctx = gtscontext.SetDryRun(ctx)
+	}
+
+	// Perform the actual deduping with logging.
+	prune.cleaner.Media().LogDedupe(ctx)
+
+	// Perform a cleanup of storage (for removed local dirs).
+	if err := prune.storage.Storage.Clean(ctx); err != nil {
+		log.Error(ctx, "error cleaning storage: %v", err)
+	}
+
+	return nil
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/trans"
 )
 
@@ -41,7 +42,7 @@ var Import action.GTSAction = func(ctx context.Context) error {
 	// Set the state DB connection
 	state.DB = dbConn
 
-	importer := trans.NewImporter(dbConn)
+	importer := trans.NewImporter(dbConn, nil)
 
 	path := config.GetAdminTransPath()
 	if path == "" {
@@ -54,3 +55,42 @@ var Import action.GTSAction = func(ctx context.Context) error {
 
 	return dbConn.Close()
 }
+
+// ImportFull restores a tar archive created by ExportFull into an empty database
+var ImportFull action.GTSAction = func(ctx context.Context) error {
+	var state state.State
+
+	dbConn, err := bundb.NewBunDBService(ctx, &state)
+	if err != nil {
+		return fmt.Errorf("error creating dbservice: %s", err)
+	}
+
+	// Set the state DB connection
+	state.DB = dbConn
+
+	//nolint:contextcheck
+	storage, err := gtsstorage.AutoConfig("")
+	if err != nil {
+		return fmt.Errorf("error creating storage backend: %s", err)
+	}
+
+	// Set the state storage driver
+	state.Storage = storage
+
+	importer := trans.NewImporter(dbConn, storage)
+
+	path := config.GetAdminTransPath()
+	if path == "" {
+		return errors.New("no path set")
+	}
+
+	if err := importer.ImportFull(ctx, path); err != nil {
+		return err
+	}
+
+	if err := storage.Close(); err != nil {
+		return fmt.Errorf("error closing storage backend: %s", err)
+	}
+
+	return dbConn.Close()
+}
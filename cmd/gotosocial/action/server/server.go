@@ -57,10 +57,12 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/router"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	internalweaver "github.com/superseriousbusiness/gotosocial/internal/weaver"
 	"github.com/superseriousbusiness/gotosocial/internal/web"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 
 	// Inherit memory limit if set from cgroup
 	_ "github.com/KimMachineGun/automemlimit"
@@ -174,6 +176,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		tlprocessor.HomeTimelineFilter(&state, visFilter),
 		tlprocessor.HomeTimelineStatusPrepare(&state, typeConverter),
 		tlprocessor.SkipInsert(),
+		tlprocessor.HomeTimelineStore(&state),
 	)
 	if err := state.Timelines.Home.Start(); err != nil {
 		return fmt.Errorf("error starting home timeline: %s", err)
@@ -184,6 +187,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		tlprocessor.ListTimelineFilter(&state, visFilter),
 		tlprocessor.ListTimelineStatusPrepare(&state, typeConverter),
 		tlprocessor.SkipInsert(),
+		tlprocessor.ListTimelineStore(&state),
 	)
 	if err := state.Timelines.List.Start(); err != nil {
 		return fmt.Errorf("error starting list timeline: %s", err)
@@ -201,6 +205,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		mediaManager,
 		&state,
 		emailSender,
+		webpush.NewSender(&state, client),
 	)
 
 	// Set state client / federator asynchronous worker enqueue functions
@@ -211,6 +216,20 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	state.Workers.ProcessFromClientAPI = processor.Workers().ProcessFromClientAPI
 	state.Workers.ProcessFromFediAPI = processor.Workers().ProcessFromFediAPI
 
+	// Relay streaming API messages between nodes, if configured.
+	if config.GetStreamingBackend() == config.StreamingBackendPostgres {
+		pgConfig, err := bundb.PostgresConnConfig()
+		if err != nil {
+			return fmt.Errorf("error deriving streaming postgres config: %w", err)
+		}
+
+		broker := stream.NewPostgresBroker(pgConfig)
+		if err := processor.Stream().Relay(broker); err != nil {
+			return fmt.Errorf("error starting streaming relay: %w", err)
+		}
+		defer broker.Close()
+	}
+
 	// Schedule tasks for all existing poll expiries.
 	if err := processor.Polls().ScheduleAll(ctx); err != nil {
 		return fmt.Errorf("error scheduling poll expiries: %w", err)
@@ -368,6 +387,10 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		return fmt.Errorf("error closing gotosocial service: %s", err)
 	}
 
+	if err := serviceWeaverAppContext.Shutdown(); err != nil {
+		return fmt.Errorf("error shutting down service weaver components: %w", err)
+	}
+
 	log.Info(ctx, "done! exiting...")
 	return nil
 }
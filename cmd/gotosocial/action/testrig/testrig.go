@@ -126,6 +126,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		tlprocessor.HomeTimelineFilter(&state, filter),
 		tlprocessor.HomeTimelineStatusPrepare(&state, typeConverter),
 		tlprocessor.SkipInsert(),
+		tlprocessor.HomeTimelineStore(&state),
 	)
 	if err := state.Timelines.Home.Start(); err != nil {
 		return fmt.Errorf("error starting home timeline: %s", err)
@@ -136,6 +137,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		tlprocessor.ListTimelineFilter(&state, filter),
 		tlprocessor.ListTimelineStatusPrepare(&state, typeConverter),
 		tlprocessor.SkipInsert(),
+		tlprocessor.ListTimelineStore(&state),
 	)
 	if err := state.Timelines.List.Start(); err != nil {
 		return fmt.Errorf("error starting list timeline: %s", err)
@@ -20,6 +20,7 @@ package main
 import (
 	"github.com/spf13/cobra"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/account"
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/emoji"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/media"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/media/prune"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/trans"
@@ -165,6 +166,32 @@ func adminCommands() *cobra.Command {
 	config.AddAdminTrans(adminImportCmd)
 	adminCmd.AddCommand(adminImportCmd)
 
+	adminExportFullCmd := &cobra.Command{
+		Use:   "export-full",
+		Short: "export the entire instance, including statuses and media, to a tar archive at the given path",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), trans.ExportFull)
+		},
+	}
+	config.AddAdminTrans(adminExportFullCmd)
+	adminCmd.AddCommand(adminExportFullCmd)
+
+	adminImportFullCmd := &cobra.Command{
+		Use:   "import-full",
+		Short: "restore an archive created with export-full into an empty database + storage",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), trans.ImportFull)
+		},
+	}
+	config.AddAdminTrans(adminImportFullCmd)
+	adminCmd.AddCommand(adminImportFullCmd)
+
 	/*
 		ADMIN MEDIA COMMANDS
 	*/
@@ -253,7 +280,70 @@ func adminCommands() *cobra.Command {
 
 	adminMediaCmd.AddCommand(adminMediaPruneCmd)
 
+	adminMediaDedupeCmd := &cobra.Command{
+		Use:   "dedupe",
+		Short: "move existing media into content-addressed storage, so that identical files are only stored once",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), prune.Dedupe)
+		},
+	}
+	config.AddAdminMediaPrune(adminMediaDedupeCmd)
+	adminMediaCmd.AddCommand(adminMediaDedupeCmd)
+
+	adminMediaMigrateStorageCmd := &cobra.Command{
+		Use:   "migrate-storage",
+		Short: "copy all media from one storage backend to another, eg. from local to s3",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), media.MigrateStorage)
+		},
+	}
+	config.AddAdminMediaMigrateStorage(adminMediaMigrateStorageCmd)
+	adminMediaCmd.AddCommand(adminMediaMigrateStorageCmd)
+
 	adminCmd.AddCommand(adminMediaCmd)
 
+	/*
+		ADMIN EMOJI COMMANDS
+	*/
+
+	adminEmojiCmd := &cobra.Command{
+		Use:   "emoji",
+		Short: "admin commands related to custom emojis",
+	}
+
+	adminEmojiImportCmd := &cobra.Command{
+		Use:   "import",
+		Short: "import a zip or tar.gz pack of custom emojis, optionally with a misskey or pleroma manifest",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), emoji.Import)
+		},
+	}
+	config.AddAdminEmojiImport(adminEmojiImportCmd)
+	adminEmojiCmd.AddCommand(adminEmojiImportCmd)
+
+	adminEmojiExportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "export all local custom emojis to a zip or tar.gz pack at the given path",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), emoji.Export)
+		},
+	}
+	config.AddAdminTrans(adminEmojiExportCmd)
+	adminEmojiCmd.AddCommand(adminEmojiExportCmd)
+
+	adminCmd.AddCommand(adminEmojiCmd)
+
 	return adminCmd
 }
@@ -52,8 +52,10 @@
 //	      write:lists: grants write access to lists
 //	      write:media: grants write access to media
 //	      write:mutes: grants write access to mutes
+//	      write:notifications: grants write access to notifications
 //	      write:statuses: grants write access to statuses
 //	      write:user: grants write access to user-level info
+//	      push: grants access to Web Push subscriptions
 //	      admin: grants admin access to everything
 //	      admin:accounts: grants admin access to accounts
 //	  OAuth2 Application:
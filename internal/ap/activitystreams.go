@@ -77,6 +77,30 @@ const (
 	// See https://www.w3.org/TR/activitystreams-vocabulary/#microsyntaxes
 	// and https://www.w3.org/TR/activitystreams-vocabulary/#dfn-tag
 	TagHashtag = "Hashtag"
+
+	// FeaturedTagsProperty is the Mastodon extension property
+	// pointing to an actor's collection of featured hashtags.
+	//
+	// See https://docs.joinmastodon.org/spec/activitypub/#as
+	FeaturedTagsProperty = "featuredTags"
+
+	// EndorsementsProperty is the Mastodon extension property
+	// pointing to an actor's collection of endorsed accounts.
+	EndorsementsProperty = "endorsements"
+
+	// QuoteURLProperty is the property used by Misskey
+	// and others to point to the URI of a quoted status.
+	//
+	// See https://misskey-hub.net/ns#quoteurl
+	QuoteURLProperty = "quoteUrl"
+
+	// MisskeyQuoteProperty is Misskey's own
+	// (older) form of the quoteUrl property.
+	MisskeyQuoteProperty = "_misskey_quote"
+
+	// QuoteURIProperty is the form of the
+	// quoteUrl property used by Akkoma / Pleroma.
+	QuoteURIProperty = "quoteUri"
 )
 
 // isActivity returns whether AS type name is of an Activity (NOT IntransitiveActivity).
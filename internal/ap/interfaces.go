@@ -65,6 +65,7 @@ func ToAccountable(t vocab.Type) (Accountable, bool) {
 func IsStatusable(typeName string) bool {
 	switch typeName {
 	case ObjectArticle,
+		ObjectAudio,
 		ObjectDocument,
 		ObjectImage,
 		ObjectVideo,
@@ -160,6 +161,8 @@ type Accountable interface {
 	WithFollowing
 	WithFollowers
 	WithFeatured
+	WithFeaturedTags
+	WithEndorsements
 	WithMovedTo
 	WithAlsoKnownAs
 	WithManuallyApprovesFollowers
@@ -188,6 +191,7 @@ type Statusable interface {
 	WithAttachment
 	WithTag
 	WithReplies
+	WithQuote
 }
 
 // Pollable represents the minimum activitypub interface for representing a 'poll' (it's a subset of a status).
@@ -458,6 +462,28 @@ type WithFeatured interface {
 	SetTootFeatured(vocab.TootFeaturedProperty)
 }
 
+// WithFeaturedTags represents an activity which may have the
+// Mastodon featuredTags property. This has no generated vocab
+// property, so it is accessed via the unknown properties map.
+type WithFeaturedTags interface {
+	GetUnknownProperties() map[string]interface{}
+}
+
+// WithEndorsements represents an activity which may have the
+// Mastodon endorsements property. Like featuredTags, this has
+// no generated vocab property, so uses the unknown properties map.
+type WithEndorsements interface {
+	GetUnknownProperties() map[string]interface{}
+}
+
+// WithQuote represents an object which may have one of the
+// quoteUrl, _misskey_quote or quoteUri properties. None of
+// these have generated vocab properties, so they are accessed
+// via the unknown properties map.
+type WithQuote interface {
+	GetUnknownProperties() map[string]interface{}
+}
+
 // WithMovedTo represents an Object with ActivityStreamsMovedToProperty.
 type WithMovedTo interface {
 	GetActivityStreamsMovedTo() vocab.ActivityStreamsMovedToProperty
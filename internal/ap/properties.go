@@ -352,6 +352,77 @@ func SetFeatured(with WithFeatured, featured *url.URL) {
 	featuredProp.SetIRI(featured)
 }
 
+// GetFeaturedTags returns the IRI contained in the featuredTags property of 'with'.
+func GetFeaturedTags(with WithFeaturedTags) *url.URL {
+	raw, ok := with.GetUnknownProperties()[FeaturedTagsProperty].(string)
+	if !ok || raw == "" {
+		return nil
+	}
+	featuredTags, err := url.Parse(raw)
+	if err != nil {
+		return nil
+	}
+	return featuredTags
+}
+
+// SetFeaturedTags sets the given IRI on the featuredTags property of 'with'.
+func SetFeaturedTags(with WithFeaturedTags, featuredTags *url.URL) {
+	with.GetUnknownProperties()[FeaturedTagsProperty] = featuredTags.String()
+}
+
+// GetEndorsements returns the IRI contained in the endorsements property of 'with'.
+func GetEndorsements(with WithEndorsements) *url.URL {
+	raw, ok := with.GetUnknownProperties()[EndorsementsProperty].(string)
+	if !ok || raw == "" {
+		return nil
+	}
+	endorsements, err := url.Parse(raw)
+	if err != nil {
+		return nil
+	}
+	return endorsements
+}
+
+// SetEndorsements sets the given IRI on the endorsements property of 'with'.
+func SetEndorsements(with WithEndorsements, endorsements *url.URL) {
+	with.GetUnknownProperties()[EndorsementsProperty] = endorsements.String()
+}
+
+// quoteProperties are the properties that may contain the
+// IRI of a quoted status, in order of preference.
+var quoteProperties = []string{
+	QuoteURLProperty,
+	MisskeyQuoteProperty,
+	QuoteURIProperty,
+}
+
+// GetQuote returns the IRI of the status quoted by 'with', taken from the first
+// set of the quoteUrl, _misskey_quote or quoteUri properties, else nil.
+func GetQuote(with WithQuote) *url.URL {
+	unknown := with.GetUnknownProperties()
+	for _, prop := range quoteProperties {
+		raw, ok := unknown[prop].(string)
+		if !ok || raw == "" {
+			continue
+		}
+		quote, err := url.Parse(raw)
+		if err != nil {
+			continue
+		}
+		return quote
+	}
+	return nil
+}
+
+// SetQuote sets the given IRI on all of the quoteUrl, _misskey_quote
+// and quoteUri properties of 'with', for the widest compatibility.
+func SetQuote(with WithQuote, quote *url.URL) {
+	unknown := with.GetUnknownProperties()
+	for _, prop := range quoteProperties {
+		unknown[prop] = quote.String()
+	}
+}
+
 // GetMovedTo returns the IRI contained in the movedTo property of 'with'.
 func GetMovedTo(with WithMovedTo) *url.URL {
 	movedToProp := with.GetActivityStreamsMovedTo()
@@ -78,3 +78,60 @@ type SwaggerFeaturedCollection struct {
 	// example: 2
 	TotalItems int
 }
+
+// SwaggerFeaturedTagsCollection represents an ActivityPub Collection of Hashtags.
+// swagger:model swaggerFeaturedTagsCollection
+type SwaggerFeaturedTagsCollection struct {
+	// ActivityStreams JSON-LD context.
+	// A string or an array of strings, or more
+	// complex nested items.
+	// example: https://www.w3.org/ns/activitystreams
+	Context interface{} `json:"@context"`
+	// ActivityStreams ID.
+	// example: https://example.org/users/some_user/collections/tags
+	ID string `json:"id"`
+	// ActivityStreams type.
+	// example: Collection
+	Type string `json:"type"`
+	// List of Hashtag objects.
+	Items []SwaggerHashtag `json:"items"`
+	// Number of items in this collection.
+	// example: 1
+	TotalItems int
+}
+
+// SwaggerHashtag represents an ActivityPub Hashtag.
+// swagger:model swaggerHashtag
+type SwaggerHashtag struct {
+	// ActivityStreams type.
+	// example: Hashtag
+	Type string `json:"type"`
+	// Link to the hashtag.
+	// example: https://example.org/tags/helloworld
+	Href string `json:"href"`
+	// Name of the hashtag, including the # sign.
+	// example: #helloworld
+	Name string `json:"name"`
+}
+
+// SwaggerEndorsementsCollection represents an ActivityPub Collection of endorsed accounts.
+// swagger:model swaggerEndorsementsCollection
+type SwaggerEndorsementsCollection struct {
+	// ActivityStreams JSON-LD context.
+	// A string or an array of strings, or more
+	// complex nested items.
+	// example: https://www.w3.org/ns/activitystreams
+	Context interface{} `json:"@context"`
+	// ActivityStreams ID.
+	// example: https://example.org/users/some_user/collections/endorsements
+	ID string `json:"id"`
+	// ActivityStreams type.
+	// example: Collection
+	Type string `json:"type"`
+	// List of account URIs.
+	// example: ['https://example.org/users/some_other_user', 'https://another.example.com/users/another_user']
+	Items []string `json:"items"`
+	// Number of items in this collection.
+	// example: 2
+	TotalItems int
+}
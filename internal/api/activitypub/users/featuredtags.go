@@ -0,0 +1,87 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package users
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// FeaturedTagsGETHandler swagger:operation GET /users/{username}/collections/tags s2sFeaturedTagsGet
+//
+// Get the featured hashtags collection for a user.
+//
+// The response will contain a collection of Hashtag objects in the `items` property.
+//
+// HTTP signature is required on the request.
+//
+//	---
+//	tags:
+//	- s2s/federation
+//
+//	produces:
+//	- application/activity+json
+//
+//	responses:
+//		'200':
+//			in: body
+//			schema:
+//				"$ref": "#/definitions/swaggerFeaturedTagsCollection"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+func (m *Module) FeaturedTagsGETHandler(c *gin.Context) {
+	// usernames on our instance are always lowercase
+	requestedUsername := strings.ToLower(c.Param(UsernameKey))
+	if requestedUsername == "" {
+		err := errors.New("no username specified in request")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	contentType, err := apiutil.NegotiateAccept(c, apiutil.ActivityPubOrHTMLHeaders...)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if contentType == string(apiutil.TextHTML) {
+		// This isn't an ActivityPub request;
+		// redirect to the user's profile.
+		c.Redirect(http.StatusSeeOther, "/@"+requestedUsername)
+		return
+	}
+
+	resp, errWithCode := m.processor.Fedi().FeaturedTagsCollectionGet(c.Request.Context(), requestedUsername)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSONType(c, http.StatusOK, contentType, resp)
+}
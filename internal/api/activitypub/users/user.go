@@ -51,6 +51,10 @@ const (
 	FollowingPath = BasePath + "/" + uris.FollowingPath
 	// FeaturedCollectionPath is for serving GET requests to a user's list of featured (pinned) statuses.
 	FeaturedCollectionPath = BasePath + "/" + uris.CollectionsPath + "/" + uris.FeaturedPath
+	// FeaturedTagsPath is for serving GET requests to a user's list of featured hashtags.
+	FeaturedTagsPath = BasePath + "/" + uris.CollectionsPath + "/" + uris.TagsPath
+	// EndorsementsPath is for serving GET requests to a user's list of endorsed accounts.
+	EndorsementsPath = BasePath + "/" + uris.CollectionsPath + "/" + uris.EndorsementsPath
 	// StatusPath is for serving GET requests to a particular status by a user, with the given username key and status ID
 	StatusPath = BasePath + "/" + uris.StatusesPath + "/:" + StatusIDKey
 	// StatusRepliesPath is for serving the replies collection of a status.
@@ -73,6 +77,8 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, FollowersPath, m.FollowersGETHandler)
 	attachHandler(http.MethodGet, FollowingPath, m.FollowingGETHandler)
 	attachHandler(http.MethodGet, FeaturedCollectionPath, m.FeaturedCollectionGETHandler)
+	attachHandler(http.MethodGet, FeaturedTagsPath, m.FeaturedTagsGETHandler)
+	attachHandler(http.MethodGet, EndorsementsPath, m.EndorsementsGETHandler)
 	attachHandler(http.MethodGet, StatusPath, m.StatusGETHandler)
 	attachHandler(http.MethodGet, StatusRepliesPath, m.StatusRepliesGETHandler)
 	attachHandler(http.MethodGet, OutboxPath, m.OutboxGETHandler)
@@ -248,6 +248,9 @@ func (m *Module) AuthorizePOSTHandler(c *gin.Context) {
 		return
 	}
 
+	// The user has now signed in.
+	m.recordSignIn(c, user)
+
 	if redirectURI != oauth.OOBURI {
 		// we're done with the session now, so just clear it out
 		m.clearSession(s)
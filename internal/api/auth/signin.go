@@ -21,13 +21,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"time"
 
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -138,6 +142,28 @@ func (m *Module) ValidatePassword(ctx context.Context, email string, password st
 	return user.ID, nil
 }
 
+// recordSignIn updates the sign in details of the given user,
+// moving their current sign in to their last, so that instance
+// activity (eg., monthly active users) can be reported.
+func (m *Module) recordSignIn(c *gin.Context, user *gtsmodel.User) {
+	user.LastSignInAt = user.CurrentSignInAt
+	user.LastSignInIP = user.CurrentSignInIP
+	user.CurrentSignInAt = time.Now()
+	user.CurrentSignInIP = net.ParseIP(c.ClientIP())
+	user.SignInCount++
+
+	if err := m.db.UpdateUser(c.Request.Context(), user,
+		"last_sign_in_at",
+		"last_sign_in_ip",
+		"current_sign_in_at",
+		"current_sign_in_ip",
+		"sign_in_count",
+	); err != nil {
+		// Not worth failing sign in over.
+		log.Errorf(c.Request.Context(), "error recording sign in for user %s: %v", user.ID, err)
+	}
+}
+
 // incorrectPassword wraps the given error in a gtserror.WithCode, and returns
 // only a generic 'safe' error message to the user, to not give any info away.
 func incorrectPassword(err error) (string, gtserror.WithCode) {
@@ -27,10 +27,14 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/blocks"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/bookmarks"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/customemojis"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/endorsements"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/exports"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/favourites"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/featuredtags"
 	filter "github.com/superseriousbusiness/gotosocial/internal/api/client/filters"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/followedtags"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/followrequests"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/imports"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/instance"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/lists"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/markers"
@@ -38,10 +42,12 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/notifications"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/polls"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/preferences"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/push"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/reports"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/search"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/streaming"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/tags"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/timelines"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/user"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -61,21 +67,27 @@ type Client struct {
 	blocks         *blocks.Module         // api/v1/blocks
 	bookmarks      *bookmarks.Module      // api/v1/bookmarks
 	customEmojis   *customemojis.Module   // api/v1/custom_emojis
+	endorsements   *endorsements.Module   // api/v1/endorsements
+	exports        *exports.Module        // api/v1/exports
 	favourites     *favourites.Module     // api/v1/favourites
 	featuredTags   *featuredtags.Module   // api/v1/featured_tags
 	filters        *filter.Module         // api/v1/filters
+	followedTags   *followedtags.Module   // api/v1/followed_tags
 	followRequests *followrequests.Module // api/v1/follow_requests
+	imports        *imports.Module        // api/v1/import
 	instance       *instance.Module       // api/v1/instance
 	lists          *lists.Module          // api/v1/lists
 	markers        *markers.Module        // api/v1/markers
 	media          *media.Module          // api/v1/media, api/v2/media
-	notifications  *notifications.Module  // api/v1/notifications
+	notifications  *notifications.Module  // api/v1/notifications, api/v2/notifications
 	polls          *polls.Module          // api/v1/polls
 	preferences    *preferences.Module    // api/v1/preferences
+	push           *push.Module           // api/v1/push
 	reports        *reports.Module        // api/v1/reports
 	search         *search.Module         // api/v1/search, api/v2/search
 	statuses       *statuses.Module       // api/v1/statuses
 	streaming      *streaming.Module      // api/v1/streaming
+	tags           *tags.Module           // api/v1/tags
 	timelines      *timelines.Module      // api/v1/timelines
 	user           *user.Module           // api/v1/user
 }
@@ -103,10 +115,14 @@ func (c *Client) Route(r *router.Router, m ...gin.HandlerFunc) {
 	c.blocks.Route(h)
 	c.bookmarks.Route(h)
 	c.customEmojis.Route(h)
+	c.endorsements.Route(h)
+	c.exports.Route(h)
 	c.favourites.Route(h)
 	c.featuredTags.Route(h)
 	c.filters.Route(h)
+	c.followedTags.Route(h)
 	c.followRequests.Route(h)
+	c.imports.Route(h)
 	c.instance.Route(h)
 	c.lists.Route(h)
 	c.markers.Route(h)
@@ -114,10 +130,12 @@ func (c *Client) Route(r *router.Router, m ...gin.HandlerFunc) {
 	c.notifications.Route(h)
 	c.polls.Route(h)
 	c.preferences.Route(h)
+	c.push.Route(h)
 	c.reports.Route(h)
 	c.search.Route(h)
 	c.statuses.Route(h)
 	c.streaming.Route(h)
+	c.tags.Route(h)
 	c.timelines.Route(h)
 	c.user.Route(h)
 }
@@ -133,10 +151,14 @@ func NewClient(db db.DB, p *processing.Processor, app *weaver.AppContext) *Clien
 		blocks:         blocks.New(p),
 		bookmarks:      bookmarks.New(p),
 		customEmojis:   customemojis.New(p),
+		endorsements:   endorsements.New(p),
+		exports:        exports.New(p),
 		favourites:     favourites.New(p),
 		featuredTags:   featuredtags.New(p),
 		filters:        filter.New(p),
+		followedTags:   followedtags.New(p),
 		followRequests: followrequests.New(p),
+		imports:        imports.New(p),
 		instance:       instance.New(p),
 		lists:          lists.New(p),
 		markers:        markers.New(p),
@@ -144,10 +166,12 @@ func NewClient(db db.DB, p *processing.Processor, app *weaver.AppContext) *Clien
 		notifications:  notifications.New(p),
 		polls:          polls.New(p),
 		preferences:    preferences.New(p),
+		push:           push.New(p),
 		reports:        reports.New(p),
 		search:         search.New(p),
 		statuses:       statuses.New(p, app),
 		streaming:      streaming.New(p, time.Second*30, 4096),
+		tags:           tags.New(p),
 		timelines:      timelines.New(p),
 		user:           user.New(p),
 	}
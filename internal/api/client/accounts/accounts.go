@@ -40,17 +40,21 @@ const (
 
 	BlockPath         = BasePathWithID + "/block"
 	DeletePath        = BasePath + "/delete"
+	EndorsementsPath  = BasePathWithID + "/endorsements"
+	FeaturedTagsPath  = BasePathWithID + "/featured_tags"
 	FollowersPath     = BasePathWithID + "/followers"
 	FollowingPath     = BasePathWithID + "/following"
 	FollowPath        = BasePathWithID + "/follow"
 	ListsPath         = BasePathWithID + "/lists"
 	LookupPath        = BasePath + "/lookup"
 	NotePath          = BasePathWithID + "/note"
+	PinPath           = BasePathWithID + "/pin"
 	RelationshipsPath = BasePath + "/relationships"
 	SearchPath        = BasePath + "/search"
 	StatusesPath      = BasePathWithID + "/statuses"
 	UnblockPath       = BasePathWithID + "/unblock"
 	UnfollowPath      = BasePathWithID + "/unfollow"
+	UnpinPath         = BasePathWithID + "/unpin"
 	UpdatePath        = BasePath + "/update_credentials"
 	VerifyPath        = BasePath + "/verify_credentials"
 	MovePath          = BasePath + "/move"
@@ -101,6 +105,14 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodPost, BlockPath, m.AccountBlockPOSTHandler)
 	attachHandler(http.MethodPost, UnblockPath, m.AccountUnblockPOSTHandler)
 
+	// endorse or unendorse account, and get endorsed accounts
+	attachHandler(http.MethodPost, PinPath, m.AccountEndorsePOSTHandler)
+	attachHandler(http.MethodPost, UnpinPath, m.AccountUnendorsePOSTHandler)
+	attachHandler(http.MethodGet, EndorsementsPath, m.AccountEndorsementsGETHandler)
+
+	// account featured hashtags
+	attachHandler(http.MethodGet, FeaturedTagsPath, m.AccountFeaturedTagsGETHandler)
+
 	// account lists
 	attachHandler(http.MethodGet, ListsPath, m.AccountListsGETHandler)
 
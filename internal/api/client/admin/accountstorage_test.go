@@ -0,0 +1,111 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type AccountStorageTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *AccountStorageTestSuite) getUsage(recorder *httptest.ResponseRecorder) *apimodel.AdminAccountStorageUsage {
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	usage := new(apimodel.AdminAccountStorageUsage)
+	if err := json.Unmarshal(b, usage); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return usage
+}
+
+func (suite *AccountStorageTestSuite) TestAccountStorageGet() {
+	recorder := httptest.NewRecorder()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	path := admin.AccountsPath + "/" + testAccount.ID + "/storage"
+	ctx := suite.newContext(recorder, http.MethodGet, nil, path, "application/json")
+	ctx.AddParam(admin.IDKey, testAccount.ID)
+
+	suite.adminModule.AccountStorageGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	usage := suite.getUsage(recorder)
+	suite.Equal(testAccount.ID, usage.AccountID)
+	suite.NotZero(usage.Media)
+	suite.Equal(usage.Media+usage.Avatar+usage.Header+usage.Emoji, usage.Total)
+	suite.False(usage.CustomQuota)
+}
+
+func (suite *AccountStorageTestSuite) TestAccountStorageGetRemote() {
+	recorder := httptest.NewRecorder()
+	testAccount := suite.testAccounts["remote_account_1"]
+
+	path := admin.AccountsPath + "/" + testAccount.ID + "/storage"
+	ctx := suite.newContext(recorder, http.MethodGet, nil, path, "application/json")
+	ctx.AddParam(admin.IDKey, testAccount.ID)
+
+	suite.adminModule.AccountStorageGETHandler(ctx)
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func (suite *AccountStorageTestSuite) TestAccountStoragePatch() {
+	recorder := httptest.NewRecorder()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	path := admin.AccountsPath + "/" + testAccount.ID + "/storage"
+	ctx := suite.newContext(recorder, http.MethodPatch, []byte("quota=1024"), path, "application/x-www-form-urlencoded")
+	ctx.AddParam(admin.IDKey, testAccount.ID)
+
+	suite.adminModule.AccountStoragePATCHHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	usage := suite.getUsage(recorder)
+	suite.Equal(1024, usage.Quota)
+	suite.True(usage.CustomQuota)
+	suite.NotZero(usage.Media)
+}
+
+func (suite *AccountStorageTestSuite) TestAccountStoragePatchNegative() {
+	recorder := httptest.NewRecorder()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	path := admin.AccountsPath + "/" + testAccount.ID + "/storage"
+	ctx := suite.newContext(recorder, http.MethodPatch, []byte("quota=-1"), path, "application/x-www-form-urlencoded")
+	ctx.AddParam(admin.IDKey, testAccount.ID)
+
+	suite.adminModule.AccountStoragePATCHHandler(ctx)
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func TestAccountStorageTestSuite(t *testing.T) {
+	suite.Run(t, &AccountStorageTestSuite{})
+}
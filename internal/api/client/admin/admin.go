@@ -30,6 +30,8 @@ const (
 	EmojiPath               = BasePath + "/custom_emojis"
 	EmojiPathWithID         = EmojiPath + "/:" + IDKey
 	EmojiCategoriesPath     = EmojiPath + "/categories"
+	EmojiPackImportPath     = EmojiPath + "/import"
+	EmojiPackExportPath     = EmojiPath + "/export"
 	DomainBlocksPath        = BasePath + "/domain_blocks"
 	DomainBlocksPathWithID  = DomainBlocksPath + "/:" + IDKey
 	DomainAllowsPath        = BasePath + "/domain_allows"
@@ -42,8 +44,16 @@ const (
 	AccountsPath            = BasePath + "/accounts"
 	AccountsPathWithID      = AccountsPath + "/:" + IDKey
 	AccountsActionPath      = AccountsPathWithID + "/action"
+	AccountsStoragePath     = AccountsPathWithID + "/storage"
 	MediaCleanupPath        = BasePath + "/media_cleanup"
 	MediaRefetchPath        = BasePath + "/media_refetch"
+	MediaHashesPath         = BasePath + "/media_hashes"
+	MediaHashesPathWithID   = MediaHashesPath + "/:" + IDKey
+	MediaHashesImportPath   = MediaHashesPath + "/import"
+	MediaPoliciesPath       = BasePath + "/media_policies"
+	MediaPoliciesPathWithID = MediaPoliciesPath + "/:" + IDKey
+	MediaPurgePath          = BasePath + "/media_purge"
+	StoragePath             = BasePath + "/storage"
 	ReportsPath             = BasePath + "/reports"
 	ReportsPathWithID       = ReportsPath + "/:" + IDKey
 	ReportsResolvePath      = ReportsPathWithID + "/resolve"
@@ -66,6 +76,7 @@ const (
 	MaxIDKey              = "max_id"
 	SinceIDKey            = "since_id"
 	MinIDKey              = "min_id"
+	FormatKey             = "format"
 )
 
 type Module struct {
@@ -86,6 +97,8 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, EmojiPathWithID, m.EmojiGETHandler)
 	attachHandler(http.MethodPatch, EmojiPathWithID, m.EmojiPATCHHandler)
 	attachHandler(http.MethodGet, EmojiCategoriesPath, m.EmojiCategoriesGETHandler)
+	attachHandler(http.MethodPost, EmojiPackImportPath, m.EmojiPackImportPOSTHandler)
+	attachHandler(http.MethodGet, EmojiPackExportPath, m.EmojiPackExportGETHandler)
 
 	// domain block stuff
 	attachHandler(http.MethodPost, DomainBlocksPath, m.DomainBlocksPOSTHandler)
@@ -114,10 +127,28 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 
 	// accounts stuff
 	attachHandler(http.MethodPost, AccountsActionPath, m.AccountActionPOSTHandler)
+	attachHandler(http.MethodGet, AccountsStoragePath, m.AccountStorageGETHandler)
+	attachHandler(http.MethodPatch, AccountsStoragePath, m.AccountStoragePATCHHandler)
 
 	// media stuff
 	attachHandler(http.MethodPost, MediaCleanupPath, m.MediaCleanupPOSTHandler)
 	attachHandler(http.MethodPost, MediaRefetchPath, m.MediaRefetchPOSTHandler)
+	attachHandler(http.MethodGet, StoragePath, m.StorageGETHandler)
+
+	// media hash blocklist stuff
+	attachHandler(http.MethodGet, MediaHashesPath, m.MediaHashesGETHandler)
+	attachHandler(http.MethodPost, MediaHashesPath, m.MediaHashPOSTHandler)
+	attachHandler(http.MethodPost, MediaHashesImportPath, m.MediaHashesImportPOSTHandler)
+	attachHandler(http.MethodGet, MediaHashesPathWithID, m.MediaHashGETHandler)
+	attachHandler(http.MethodDelete, MediaHashesPathWithID, m.MediaHashDELETEHandler)
+
+	// remote media policy stuff
+	attachHandler(http.MethodGet, MediaPoliciesPath, m.MediaPoliciesGETHandler)
+	attachHandler(http.MethodPost, MediaPoliciesPath, m.MediaPolicyPOSTHandler)
+	attachHandler(http.MethodGet, MediaPoliciesPathWithID, m.MediaPolicyGETHandler)
+	attachHandler(http.MethodPatch, MediaPoliciesPathWithID, m.MediaPolicyPATCHHandler)
+	attachHandler(http.MethodDelete, MediaPoliciesPathWithID, m.MediaPolicyDELETEHandler)
+	attachHandler(http.MethodPost, MediaPurgePath, m.MediaPurgePOSTHandler)
 
 	// reports stuff
 	attachHandler(http.MethodGet, ReportsPath, m.ReportsGETHandler)
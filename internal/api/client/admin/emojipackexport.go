@@ -0,0 +1,112 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// EmojiPackExportGETHandler swagger:operation GET /api/v1/admin/custom_emojis/export emojiPackExport
+//
+// Export all local custom emojis as an emoji pack.
+//
+// The pack is a zip or tar.gz archive of emoji images, named by shortcode, along with
+// both a Misskey (`meta.json`) and a Pleroma (`pack.json`) manifest, so that it can be
+// imported by GoToSocial, Misskey or Pleroma / Akkoma.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/zip
+//	- application/gzip
+//
+//	parameters:
+//	-
+//		name: format
+//		in: query
+//		description: Archive format of the pack.
+//		type: string
+//		enum:
+//			- zip
+//			- tar.gz
+//		default: zip
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: The emoji pack archive.
+//			schema:
+//				type: file
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'500':
+//			description: internal server error
+func (m *Module) EmojiPackExportGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	content, errWithCode := m.processor.Admin().EmojiPackExport(ctx, c.Query(FormatKey))
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	defer func() {
+		// Close content when we're done,
+		// this also stops the pack writer.
+		if err := content.Content.Close(); err != nil {
+			log.Errorf(ctx, "error closing emoji pack: %v", err)
+		}
+	}()
+
+	filename := "emojis.zip"
+	if content.ContentType == "application/gzip" {
+		filename = "emojis.tar.gz"
+	}
+
+	c.DataFromReader(http.StatusOK, content.ContentLength, content.ContentType, content.Content, map[string]string{
+		"Content-Disposition": `attachment; filename="` + filename + `"`,
+	})
+}
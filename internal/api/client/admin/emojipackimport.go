@@ -0,0 +1,133 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// EmojiPackImportPOSTHandler swagger:operation POST /api/v1/admin/custom_emojis/import emojiPackImport
+//
+// Import a pack of custom emojis.
+//
+// The pack should be a zip or tar.gz archive of emoji images. If the archive contains
+// a Misskey (`meta.json`) or Pleroma (`pack.json` or `emoji.txt`) manifest, shortcodes
+// and categories are taken from it. Otherwise, each image is imported with its file name
+// (minus extension) as shortcode, and the name of the directory containing it as category.
+//
+// Each emoji is imported separately, so some may fail (eg., if too large)
+// while the rest succeed. The result of each is returned in a multi-status response.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	consumes:
+//	- multipart/form-data
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: pack
+//		in: formData
+//		description: Zip or tar.gz archive of emojis to import.
+//		type: file
+//		required: true
+//	-
+//		name: conflict
+//		in: formData
+//		description: >-
+//			What to do with emojis whose shortcode already exists on this instance.
+//			`skip` leaves the existing emoji alone. `overwrite` replaces its image and
+//			category. `rename` imports the emoji with a numbered suffix, eg. `blobcat_2`.
+//		type: string
+//		enum:
+//			- skip
+//			- overwrite
+//			- rename
+//		default: skip
+//	-
+//		name: category
+//		in: formData
+//		description: Category in which to place imported emojis that have no category in the pack.
+//		type: string
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'207':
+//			description: >-
+//				The result of importing each emoji. The resource of successful entries
+//				is the imported admin emoji, with message `created`, `overwritten` or
+//				`renamed`. The resource of failed or skipped entries is the shortcode
+//				given for the emoji in the pack.
+//			schema:
+//				"$ref": "#/definitions/multiStatus"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) EmojiPackImportPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := new(apimodel.EmojiPackImportRequest)
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	multiStatus, errWithCode := m.processor.Admin().EmojiPackImport(c.Request.Context(), form)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusMultiStatus, multiStatus)
+}
@@ -0,0 +1,80 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type StorageGetTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *StorageGetTestSuite) TestStorageGet() {
+	recorder := httptest.NewRecorder()
+
+	path := admin.StoragePath
+	ctx := suite.newContext(recorder, http.MethodGet, nil, path, "application/json")
+
+	suite.adminModule.StorageGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	usage := new(apimodel.AdminStorageUsage)
+	if err := json.Unmarshal(b, usage); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.NotZero(usage.LocalMedia)
+	suite.NotZero(usage.LocalEmoji)
+	suite.NotZero(usage.RemoteMedia)
+	suite.Equal(
+		usage.LocalMedia+usage.LocalAvatars+usage.LocalHeaders+
+			usage.LocalEmoji+usage.RemoteMedia+usage.RemoteEmoji,
+		usage.Total,
+	)
+}
+
+func (suite *StorageGetTestSuite) TestStorageGetNotAdmin() {
+	recorder := httptest.NewRecorder()
+
+	path := admin.StoragePath
+	ctx := suite.newContext(recorder, http.MethodGet, nil, path, "application/json")
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+
+	suite.adminModule.StorageGETHandler(ctx)
+	suite.Equal(http.StatusForbidden, recorder.Code)
+}
+
+func TestStorageGetTestSuite(t *testing.T) {
+	suite.Run(t, &StorageGetTestSuite{})
+}
@@ -0,0 +1,138 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bookmarks
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+const (
+	// FormatKey is the query key for the bookmark collection export format.
+	FormatKey = "format"
+
+	exportFormatJSON = "json"
+	exportFormatRSS  = "rss"
+
+	appRSSUTF8 = string(apiutil.AppRSSXML) + "; charset=utf-8"
+)
+
+// BookmarkCollectionExportGETHandler swagger:operation GET /api/v1/bookmark_collections/{id}/export bookmarkCollectionExport
+//
+// Export all statuses in a bookmark collection, either as a JSON array of statuses or as an RSS feed.
+//
+//	---
+//	tags:
+//	- bookmarks
+//
+//	produces:
+//	- application/json
+//	- application/rss+xml
+//
+//	parameters:
+//	-
+//		name: id
+//		type: string
+//		description: ID of the bookmark collection
+//		in: path
+//		required: true
+//	-
+//		name: format
+//		type: string
+//		description: Export format, one of `json` or `rss`.
+//		default: json
+//		in: query
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:bookmarks
+//
+//	responses:
+//		'200':
+//			description: Exported statuses in the collection, newest bookmarks first.
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/status"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) BookmarkCollectionExportGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	targetCollectionID := c.Param(IDKey)
+	if targetCollectionID == "" {
+		err := errors.New("no bookmark collection id specified")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	format := strings.ToLower(c.DefaultQuery(FormatKey, exportFormatJSON))
+	switch format {
+
+	case exportFormatJSON:
+		if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+			apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+			return
+		}
+
+		statuses, errWithCode := m.processor.Account().BookmarkCollectionExportJSON(c.Request.Context(), authed.Account, targetCollectionID)
+		if errWithCode != nil {
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		apiutil.JSON(c, http.StatusOK, statuses)
+
+	case exportFormatRSS:
+		if _, err := apiutil.NegotiateAccept(c, apiutil.AppRSSXML); err != nil {
+			apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+			return
+		}
+
+		feed, errWithCode := m.processor.Account().BookmarkCollectionExportRSS(c.Request.Context(), authed.Account, targetCollectionID)
+		if errWithCode != nil {
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		apiutil.Data(c, http.StatusOK, appRSSUTF8, []byte(feed))
+
+	default:
+		err := fmt.Errorf("unrecognized export format %s, valid formats are %s and %s", format, exportFormatJSON, exportFormatRSS)
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+	}
+}
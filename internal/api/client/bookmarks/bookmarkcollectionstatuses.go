@@ -0,0 +1,202 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bookmarks
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// BookmarkCollectionStatusesPOSTHandler swagger:operation POST /api/v1/bookmark_collections/{id}/statuses addBookmarkCollectionStatuses
+//
+// Add bookmarked statuses to a bookmark collection.
+//
+//	---
+//	tags:
+//	- bookmarks
+//
+//	consumes:
+//	- application/json
+//	- application/xml
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: id
+//		type: string
+//		description: ID of the bookmark collection
+//		in: path
+//		required: true
+//	-
+//		name: status_ids
+//		type: array
+//		items:
+//			type: string
+//		description: >-
+//			Array of statusIDs to add.
+//			Each statusID must correspond to a status
+//			that the requesting account has bookmarked.
+//		in: formData
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:bookmarks
+//
+//	responses:
+//		'200':
+//			description: bookmark collection statuses updated
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: unprocessable (one or more statuses not bookmarked)
+//		'500':
+//			description: internal server error
+func (m *Module) BookmarkCollectionStatusesPOSTHandler(c *gin.Context) {
+	authed, targetCollectionID, form, errWithCode := m.parseCollectionStatusesRequest(c)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if errWithCode := m.processor.Account().BookmarkCollectionAdd(c.Request.Context(), authed.Account, targetCollectionID, form.StatusIDs); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.Data(c, http.StatusOK, apiutil.AppJSON, apiutil.EmptyJSONObject)
+}
+
+// BookmarkCollectionStatusesDELETEHandler swagger:operation DELETE /api/v1/bookmark_collections/{id}/statuses removeBookmarkCollectionStatuses
+//
+// Remove statuses from a bookmark collection.
+// The statuses remain bookmarked.
+//
+//	---
+//	tags:
+//	- bookmarks
+//
+//	consumes:
+//	- application/json
+//	- application/xml
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: id
+//		type: string
+//		description: ID of the bookmark collection
+//		in: path
+//		required: true
+//	-
+//		name: status_ids
+//		type: array
+//		items:
+//			type: string
+//		description: Array of statusIDs to remove.
+//		in: formData
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:bookmarks
+//
+//	responses:
+//		'200':
+//			description: bookmark collection statuses updated
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: unprocessable (one or more statuses not bookmarked)
+//		'500':
+//			description: internal server error
+func (m *Module) BookmarkCollectionStatusesDELETEHandler(c *gin.Context) {
+	authed, targetCollectionID, form, errWithCode := m.parseCollectionStatusesRequest(c)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if errWithCode := m.processor.Account().BookmarkCollectionRemove(c.Request.Context(), authed.Account, targetCollectionID, form.StatusIDs); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.Data(c, http.StatusOK, apiutil.AppJSON, apiutil.EmptyJSONObject)
+}
+
+// parseCollectionStatusesRequest performs the authorization,
+// content negotiation, and form parsing shared by the add and
+// remove bookmark collection statuses handlers.
+func (m *Module) parseCollectionStatusesRequest(c *gin.Context) (
+	*oauth.Auth,
+	string,
+	*apimodel.BookmarkCollectionStatusesChangeRequest,
+	gtserror.WithCode,
+) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		return nil, "", nil, gtserror.NewErrorUnauthorized(err, err.Error())
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		return nil, "", nil, gtserror.NewErrorNotAcceptable(err, err.Error())
+	}
+
+	targetCollectionID := c.Param(IDKey)
+	if targetCollectionID == "" {
+		err := errors.New("no bookmark collection id specified")
+		return nil, "", nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	form := &apimodel.BookmarkCollectionStatusesChangeRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		return nil, "", nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if len(form.StatusIDs) == 0 {
+		err := errors.New("no status IDs given")
+		return nil, "", nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	return authed, targetCollectionID, form, nil
+}
@@ -27,6 +27,13 @@ import (
 const (
 	// BasePath is the base path for serving the bookmarks API, minus the 'api' prefix
 	BasePath = "/v1/bookmarks"
+
+	IDKey = "id"
+	// CollectionsPath is the base path for serving the bookmark collections API, minus the 'api' prefix
+	CollectionsPath        = "/v1/bookmark_collections"
+	CollectionsPathWithID  = CollectionsPath + "/:" + IDKey
+	CollectionStatusesPath = CollectionsPathWithID + "/statuses"
+	CollectionExportPath   = CollectionsPathWithID + "/export"
 )
 
 type Module struct {
@@ -41,4 +48,16 @@ func New(processor *processing.Processor) *Module {
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodGet, BasePath, m.BookmarksGETHandler)
+
+	// create / get / update / delete bookmark collections
+	attachHandler(http.MethodPost, CollectionsPath, m.BookmarkCollectionCreatePOSTHandler)
+	attachHandler(http.MethodGet, CollectionsPath, m.BookmarkCollectionsGETHandler)
+	attachHandler(http.MethodGet, CollectionsPathWithID, m.BookmarkCollectionGETHandler)
+	attachHandler(http.MethodPut, CollectionsPathWithID, m.BookmarkCollectionUpdatePUTHandler)
+	attachHandler(http.MethodDelete, CollectionsPathWithID, m.BookmarkCollectionDELETEHandler)
+
+	// add / remove bookmarked statuses, export collection
+	attachHandler(http.MethodPost, CollectionStatusesPath, m.BookmarkCollectionStatusesPOSTHandler)
+	attachHandler(http.MethodDelete, CollectionStatusesPath, m.BookmarkCollectionStatusesDELETEHandler)
+	attachHandler(http.MethodGet, CollectionExportPath, m.BookmarkCollectionExportGETHandler)
 }
@@ -36,6 +36,8 @@ const (
 	MaxIDKey = "max_id"
 	// MinIDKey is for specifying the minimum ID of the bookmark to retrieve.
 	MinIDKey = "min_id"
+	// CollectionIDKey is for only retrieving bookmarks in the given collection.
+	CollectionIDKey = "collection_id"
 )
 
 // BookmarksGETHandler swagger:operation GET /api/v1/bookmarks bookmarksGet
@@ -49,6 +51,29 @@ const (
 //	produces:
 //	- application/json
 //
+//	parameters:
+//	-
+//		name: collection_id
+//		type: string
+//		description: Only return bookmarks in the bookmark collection with this ID.
+//		in: query
+//	-
+//		name: limit
+//		type: integer
+//		description: Number of bookmarks to return.
+//		default: 30
+//		in: query
+//	-
+//		name: max_id
+//		type: string
+//		description: Return only bookmarks *OLDER* than the given bookmark ID.
+//		in: query
+//	-
+//		name: min_id
+//		type: string
+//		description: Return only bookmarks *NEWER* than the given bookmark ID.
+//		in: query
+//
 //	security:
 //	- OAuth2 Bearer:
 //		- read:bookmarks
@@ -66,6 +91,8 @@ const (
 //					description: Links to the next and previous queries.
 //		'401':
 //			description: unauthorized
+//		'404':
+//			description: not found
 //		'406':
 //			description: not acceptable
 //		'500':
@@ -106,7 +133,9 @@ func (m *Module) BookmarksGETHandler(c *gin.Context) {
 		minID = minIDString
 	}
 
-	resp, errWithCode := m.processor.Account().BookmarksGet(c.Request.Context(), authed.Account, limit, maxID, minID)
+	collectionID := c.Query(CollectionIDKey)
+
+	resp, errWithCode := m.processor.Account().BookmarksGet(c.Request.Context(), authed.Account, collectionID, limit, maxID, minID)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
@@ -0,0 +1,91 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package exports
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+)
+
+const (
+	// BasePath is the base path for serving the exports API, minus the 'api' prefix
+	BasePath      = "/v1/exports"
+	FollowingPath = BasePath + "/following.csv"
+	FollowersPath = BasePath + "/followers.csv"
+	BlocksPath    = BasePath + "/blocks.csv"
+	ListsPath     = BasePath + "/lists.csv"
+	BookmarksPath = BasePath + "/bookmarks.csv"
+	TagsPath      = BasePath + "/followed_tags.csv"
+)
+
+type Module struct {
+	processor *processing.Processor
+}
+
+func New(processor *processing.Processor) *Module {
+	return &Module{
+		processor: processor,
+	}
+}
+
+func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
+	attachHandler(http.MethodGet, FollowingPath, m.ExportFollowingGETHandler)
+	attachHandler(http.MethodGet, FollowersPath, m.ExportFollowersGETHandler)
+	attachHandler(http.MethodGet, BlocksPath, m.ExportBlocksGETHandler)
+	attachHandler(http.MethodGet, ListsPath, m.ExportListsGETHandler)
+	attachHandler(http.MethodGet, BookmarksPath, m.ExportBookmarksGETHandler)
+	attachHandler(http.MethodGet, TagsPath, m.ExportFollowedTagsGETHandler)
+}
+
+// exportFunc is the signature of
+// a processor CSV export function.
+type exportFunc func(
+	context.Context,
+	*gtsmodel.Account,
+) ([][]string, gtserror.WithCode)
+
+// exportCSV handles authentication and content negotiation
+// for a CSV export request, and writes the records
+// returned by the given export function as a response.
+func (m *Module) exportCSV(c *gin.Context, export exportFunc) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.CSVHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	records, errWithCode := export(c.Request.Context(), authed.Account)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.EncodeCSVResponse(c.Writer, c.Request, http.StatusOK, records)
+}
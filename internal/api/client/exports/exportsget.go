@@ -0,0 +1,212 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package exports
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// ExportFollowingGETHandler swagger:operation GET /api/v1/exports/following.csv exportFollowing
+//
+// Export a CSV file of accounts that the requesting account follows.
+//
+// The format is compatible with Mastodon's following_accounts.csv.
+//
+//	---
+//	tags:
+//	- import-export
+//
+//	produces:
+//	- text/csv
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:follows
+//
+//	responses:
+//		'200':
+//			description: CSV file of followed accounts.
+//			schema:
+//				type: string
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) ExportFollowingGETHandler(c *gin.Context) {
+	m.exportCSV(c, m.processor.Account().ExportFollowing)
+}
+
+// ExportFollowersGETHandler swagger:operation GET /api/v1/exports/followers.csv exportFollowers
+//
+// Export a CSV file of accounts that follow the requesting account.
+//
+//	---
+//	tags:
+//	- import-export
+//
+//	produces:
+//	- text/csv
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:follows
+//
+//	responses:
+//		'200':
+//			description: CSV file of following accounts.
+//			schema:
+//				type: string
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) ExportFollowersGETHandler(c *gin.Context) {
+	m.exportCSV(c, m.processor.Account().ExportFollowers)
+}
+
+// ExportBlocksGETHandler swagger:operation GET /api/v1/exports/blocks.csv exportBlocks
+//
+// Export a CSV file of accounts that the requesting account blocks.
+//
+// The format is compatible with Mastodon's blocked_accounts.csv.
+//
+//	---
+//	tags:
+//	- import-export
+//
+//	produces:
+//	- text/csv
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:blocks
+//
+//	responses:
+//		'200':
+//			description: CSV file of blocked accounts.
+//			schema:
+//				type: string
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) ExportBlocksGETHandler(c *gin.Context) {
+	m.exportCSV(c, m.processor.Account().ExportBlocks)
+}
+
+// ExportListsGETHandler swagger:operation GET /api/v1/exports/lists.csv exportLists
+//
+// Export a CSV file of the requesting account's lists, and the accounts they contain.
+//
+// The format is compatible with Mastodon's lists.csv.
+//
+//	---
+//	tags:
+//	- import-export
+//
+//	produces:
+//	- text/csv
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:lists
+//
+//	responses:
+//		'200':
+//			description: CSV file of list titles and accounts.
+//			schema:
+//				type: string
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) ExportListsGETHandler(c *gin.Context) {
+	m.exportCSV(c, m.processor.Account().ExportLists)
+}
+
+// ExportBookmarksGETHandler swagger:operation GET /api/v1/exports/bookmarks.csv exportBookmarks
+//
+// Export a CSV file of URIs of statuses that the requesting account has bookmarked.
+//
+// The format is compatible with Mastodon's bookmarks.csv.
+//
+//	---
+//	tags:
+//	- import-export
+//
+//	produces:
+//	- text/csv
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:bookmarks
+//
+//	responses:
+//		'200':
+//			description: CSV file of bookmarked status URIs.
+//			schema:
+//				type: string
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) ExportBookmarksGETHandler(c *gin.Context) {
+	m.exportCSV(c, m.processor.Account().ExportBookmarks)
+}
+
+// ExportFollowedTagsGETHandler swagger:operation GET /api/v1/exports/followed_tags.csv exportFollowedTags
+//
+// Export a CSV file of hashtags that the requesting account follows.
+//
+// The file has no header row, and contains one hashtag per line, including the leading #.
+//
+//	---
+//	tags:
+//	- import-export
+//
+//	produces:
+//	- text/csv
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:follows
+//
+//	responses:
+//		'200':
+//			description: CSV file of followed hashtags.
+//			schema:
+//				type: string
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) ExportFollowedTagsGETHandler(c *gin.Context) {
+	m.exportCSV(c, m.processor.Account().ExportFollowedTags)
+}
@@ -25,7 +25,10 @@ import (
 )
 
 const (
-	BasePath = "/v1/featured_tags"
+	IDKey = "id"
+	// BasePath is the base path for serving the featured tags API, minus the 'api' prefix
+	BasePath       = "/v1/featured_tags"
+	BasePathWithID = BasePath + "/:" + IDKey
 )
 
 type Module struct {
@@ -40,4 +43,6 @@ func New(processor *processing.Processor) *Module {
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodGet, BasePath, m.FeaturedTagsGETHandler)
+	attachHandler(http.MethodPost, BasePath, m.FeaturedTagCreatePOSTHandler)
+	attachHandler(http.MethodDelete, BasePathWithID, m.FeaturedTagDELETEHandler)
 }
@@ -0,0 +1,121 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package imports
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// ImportPOSTHandler swagger:operation POST /api/v1/import importCreate
+//
+// Upload a CSV file of follows, blocks, lists, or bookmarks to import.
+//
+// The CSV format is compatible with the exports produced by Mastodon and GoToSocial.
+//
+// The import is processed asynchronously: the returned import can be polled
+// at /api/v1/import/{id} to check its progress and any errors encountered.
+//
+//	---
+//	tags:
+//	- import-export
+//
+//	consumes:
+//	- multipart/form-data
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: data
+//		in: formData
+//		description: CSV file to import.
+//		type: file
+//		required: true
+//	-
+//		name: type
+//		in: formData
+//		description: >-
+//			Type of data contained in the CSV file.
+//			One of: following, blocks, mutes, lists, bookmarks.
+//		type: string
+//		required: true
+//	-
+//		name: mode
+//		in: formData
+//		description: >-
+//			Mode of reconciliation with existing data.
+//			`merge` adds imported entries to existing data,
+//			`overwrite` also removes existing data not present in the import.
+//		type: string
+//		default: merge
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:follows
+//		- write:blocks
+//		- write:lists
+//		- write:bookmarks
+//
+//	responses:
+//		'202':
+//			description: The newly created import, which will be processed asynchronously.
+//			schema:
+//				"$ref": "#/definitions/import"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: unprocessable
+//		'500':
+//			description: internal server error
+func (m *Module) ImportPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.ImportRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	apiImport, errWithCode := m.processor.Account().Import(c.Request.Context(), authed.Account, form)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusAccepted, apiImport)
+}
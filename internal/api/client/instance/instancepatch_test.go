@@ -107,7 +107,14 @@ func (suite *InstancePatchTestSuite) TestInstancePatch1() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "image/heic",
+        "image/avif",
+        "video/mp4",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/flac",
+        "audio/wav",
+        "audio/mp4"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -228,7 +235,14 @@ func (suite *InstancePatchTestSuite) TestInstancePatch2() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "image/heic",
+        "image/avif",
+        "video/mp4",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/flac",
+        "audio/wav",
+        "audio/mp4"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -349,7 +363,14 @@ func (suite *InstancePatchTestSuite) TestInstancePatch3() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "image/heic",
+        "image/avif",
+        "video/mp4",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/flac",
+        "audio/wav",
+        "audio/mp4"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -521,7 +542,14 @@ func (suite *InstancePatchTestSuite) TestInstancePatch6() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "image/heic",
+        "image/avif",
+        "video/mp4",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/flac",
+        "audio/wav",
+        "audio/mp4"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -664,7 +692,14 @@ func (suite *InstancePatchTestSuite) TestInstancePatch8() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "image/heic",
+        "image/avif",
+        "video/mp4",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/flac",
+        "audio/wav",
+        "audio/mp4"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -822,7 +857,14 @@ func (suite *InstancePatchTestSuite) TestInstancePatch9() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "image/heic",
+        "image/avif",
+        "video/mp4",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/flac",
+        "audio/wav",
+        "audio/mp4"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -0,0 +1,127 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// NotificationPolicyPUTHandler swagger:operation PUT /api/v1/notifications/policy notificationPolicyUpdate
+//
+// Update the notification policy of the currently authorized user.
+//
+// Only the provided fields will be updated. Notifications caught by the
+// policy are filtered into notification requests, which can be reviewed
+// and then accepted or dismissed.
+//
+//	---
+//	tags:
+//	- notifications
+//
+//	consumes:
+//	- application/json
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: filter_not_following
+//		type: boolean
+//		description: Filter notifications from accounts you don't follow.
+//		in: formData
+//	-
+//		name: filter_not_followers
+//		type: boolean
+//		description: Filter notifications from accounts that don't follow you.
+//		in: formData
+//	-
+//		name: filter_new_accounts
+//		type: boolean
+//		description: Filter notifications from accounts created less than new_accounts_days ago.
+//		in: formData
+//	-
+//		name: new_accounts_days
+//		type: integer
+//		description: Number of days after creation that an account is considered new.
+//		minimum: 1
+//		maximum: 365
+//		in: formData
+//	-
+//		name: filter_private_mentions
+//		type: boolean
+//		description: >-
+//			Filter private mentions from accounts you don't follow,
+//			unless they're in reply to one of your own statuses.
+//		in: formData
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:notifications
+//
+//	responses:
+//		'200':
+//			name: policy
+//			description: The updated notification policy.
+//			schema:
+//				"$ref": "#/definitions/notificationPolicy"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) NotificationPolicyPUTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.NotificationPolicyUpdateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	policy, errWithCode := m.processor.Timeline().NotificationPolicyUpdate(
+		c.Request.Context(),
+		authed.Account,
+		form,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, policy)
+}
@@ -33,6 +33,18 @@ const (
 	// Use this anywhere you need to know the ID of the notification being queried.
 	BasePathWithID    = BasePath + "/:" + IDKey
 	BasePathWithClear = BasePath + "/clear"
+	// BasePathWithPolicy is the path for getting and updating the notification policy.
+	BasePathWithPolicy = BasePath + "/policy"
+	// BasePathWithRequests is the path for listing notification requests.
+	BasePathWithRequests = BasePath + "/requests"
+	// BasePathWithRequestID is the path for one notification request.
+	BasePathWithRequestID      = BasePathWithRequests + "/:" + IDKey
+	BasePathWithRequestAccept  = BasePathWithRequestID + "/accept"
+	BasePathWithRequestDismiss = BasePathWithRequestID + "/dismiss"
+	// BasePathV2 is the base path for serving grouped notifications, minus the 'api' prefix.
+	BasePathV2 = "/v2/notifications"
+	// BasePathV2WithUnreadCount is the path for getting a count of unread notification groups.
+	BasePathV2WithUnreadCount = BasePathV2 + "/unread_count"
 
 	// ExcludeTypes is an array specifying notification types to exclude
 	ExcludeTypesKey = "exclude_types[]"
@@ -40,6 +52,9 @@ const (
 	LimitKey        = "limit"
 	SinceIDKey      = "since_id"
 	MinIDKey        = "min_id"
+
+	// GroupedTypesKey is an array specifying notification types to group
+	GroupedTypesKey = "grouped_types[]"
 )
 
 type Module struct {
@@ -56,4 +71,12 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, BasePath, m.NotificationsGETHandler)
 	attachHandler(http.MethodGet, BasePathWithID, m.NotificationGETHandler)
 	attachHandler(http.MethodPost, BasePathWithClear, m.NotificationsClearPOSTHandler)
+	attachHandler(http.MethodGet, BasePathWithPolicy, m.NotificationPolicyGETHandler)
+	attachHandler(http.MethodPut, BasePathWithPolicy, m.NotificationPolicyPUTHandler)
+	attachHandler(http.MethodGet, BasePathWithRequests, m.NotificationRequestsGETHandler)
+	attachHandler(http.MethodGet, BasePathWithRequestID, m.NotificationRequestGETHandler)
+	attachHandler(http.MethodPost, BasePathWithRequestAccept, m.NotificationRequestAcceptPOSTHandler)
+	attachHandler(http.MethodPost, BasePathWithRequestDismiss, m.NotificationRequestDismissPOSTHandler)
+	attachHandler(http.MethodGet, BasePathV2, m.NotificationsV2GETHandler)
+	attachHandler(http.MethodGet, BasePathV2WithUnreadCount, m.NotificationsUnreadCountGETHandler)
 }
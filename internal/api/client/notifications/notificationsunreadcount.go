@@ -0,0 +1,122 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// NotificationsUnreadCountGETHandler swagger:operation GET /api/v2/notifications/unread_count notificationsUnreadCount
+//
+// Get the number of unread notification groups for currently authorized user.
+//
+// Notifications are considered unread if they're newer than the
+// last read ID of the user's notifications marker. If no marker
+// has been set, all notifications are considered unread.
+//
+//	---
+//	tags:
+//	- notifications
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: limit
+//		type: integer
+//		description: Maximum number of notification groups to count.
+//		default: 100
+//		maximum: 1000
+//		minimum: 1
+//		in: query
+//		required: false
+//	-
+//		name: exclude_types
+//		type: array
+//		items:
+//			type: string
+//			description: Array of types of notifications to exclude (follow, favourite, reblog, mention, poll, follow_request)
+//		in: query
+//		required: false
+//	-
+//		name: grouped_types
+//		type: array
+//		items:
+//			type: string
+//			description: >-
+//				Array of types of notifications to group (favourite, reblog).
+//				Defaults to all groupable types.
+//		in: query
+//		required: false
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:notifications
+//
+//	responses:
+//		'200':
+//			name: count
+//			description: Count of unread notification groups.
+//			schema:
+//				"$ref": "#/definitions/notificationsUnreadCount"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) NotificationsUnreadCountGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	limit, errWithCode := apiutil.ParseLimit(c.Query(LimitKey), 100, 1000, 1)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	count, errWithCode := m.processor.Timeline().NotificationsUnreadCountGet(
+		c.Request.Context(),
+		authed,
+		limit,
+		c.QueryArray(ExcludeTypesKey),
+		c.QueryArray(GroupedTypesKey),
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, count)
+}
@@ -0,0 +1,161 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package push
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// PushSubscriptionPOSTHandler swagger:operation POST /api/v1/push/subscription pushSubscriptionPost
+//
+// Create a Web Push subscription for the current access token, replacing any existing one.
+//
+// Alerts are encrypted using the given keys, as described in RFC 8291, and
+// signed with the instance's VAPID key (see `server_key` in the response).
+//
+//	---
+//	tags:
+//	- push
+//
+//	consumes:
+//	- application/json
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: subscription[endpoint]
+//		type: string
+//		description: The https URL of the push endpoint to send alerts to.
+//		in: formData
+//		required: true
+//	-
+//		name: subscription[keys][p256dh]
+//		type: string
+//		description: Base64url-encoded public key of the client's P-256 ECDH key pair.
+//		in: formData
+//		required: true
+//	-
+//		name: subscription[keys][auth]
+//		type: string
+//		description: Base64url-encoded 16 byte auth secret.
+//		in: formData
+//		required: true
+//	-
+//		name: data[alerts][follow]
+//		type: boolean
+//		description: Receive alerts for new follows.
+//		in: formData
+//	-
+//		name: data[alerts][follow_request]
+//		type: boolean
+//		description: Receive alerts for new follow requests.
+//		in: formData
+//	-
+//		name: data[alerts][favourite]
+//		type: boolean
+//		description: Receive alerts for new favourites.
+//		in: formData
+//	-
+//		name: data[alerts][mention]
+//		type: boolean
+//		description: Receive alerts for new mentions.
+//		in: formData
+//	-
+//		name: data[alerts][reblog]
+//		type: boolean
+//		description: Receive alerts for new boosts.
+//		in: formData
+//	-
+//		name: data[alerts][poll]
+//		type: boolean
+//		description: Receive alerts for ended polls.
+//		in: formData
+//	-
+//		name: data[alerts][status]
+//		type: boolean
+//		description: Receive alerts for new statuses from accounts you've enabled notifications for.
+//		in: formData
+//	-
+//		name: data[policy]
+//		type: string
+//		description: Whose actions should trigger alerts.
+//		enum:
+//			- all
+//			- followed
+//			- follower
+//			- none
+//		default: all
+//		in: formData
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- push
+//
+//	responses:
+//		'200':
+//			description: The newly created push subscription.
+//			schema:
+//				"$ref": "#/definitions/pushSubscription"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) PushSubscriptionPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.PushSubscriptionCreateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	subscription, errWithCode := m.processor.Push().Create(
+		c.Request.Context(),
+		authed.Account,
+		authed.Token.GetAccess(),
+		form,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, subscription)
+}
@@ -0,0 +1,142 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package push
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// PushSubscriptionPUTHandler swagger:operation PUT /api/v1/push/subscription pushSubscriptionPut
+//
+// Update the alerts and policy of the Web Push subscription of the current access token.
+//
+// Alerts not included in the request are switched off.
+//
+//	---
+//	tags:
+//	- push
+//
+//	consumes:
+//	- application/json
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: data[alerts][follow]
+//		type: boolean
+//		description: Receive alerts for new follows.
+//		in: formData
+//	-
+//		name: data[alerts][follow_request]
+//		type: boolean
+//		description: Receive alerts for new follow requests.
+//		in: formData
+//	-
+//		name: data[alerts][favourite]
+//		type: boolean
+//		description: Receive alerts for new favourites.
+//		in: formData
+//	-
+//		name: data[alerts][mention]
+//		type: boolean
+//		description: Receive alerts for new mentions.
+//		in: formData
+//	-
+//		name: data[alerts][reblog]
+//		type: boolean
+//		description: Receive alerts for new boosts.
+//		in: formData
+//	-
+//		name: data[alerts][poll]
+//		type: boolean
+//		description: Receive alerts for ended polls.
+//		in: formData
+//	-
+//		name: data[alerts][status]
+//		type: boolean
+//		description: Receive alerts for new statuses from accounts you've enabled notifications for.
+//		in: formData
+//	-
+//		name: data[policy]
+//		type: string
+//		description: Whose actions should trigger alerts. If not set, the policy is unchanged.
+//		enum:
+//			- all
+//			- followed
+//			- follower
+//			- none
+//		in: formData
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- push
+//
+//	responses:
+//		'200':
+//			description: The updated push subscription.
+//			schema:
+//				"$ref": "#/definitions/pushSubscription"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found (no subscription for this token)
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) PushSubscriptionPUTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.PushSubscriptionUpdateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	subscription, errWithCode := m.processor.Push().Update(
+		c.Request.Context(),
+		authed.Token.GetAccess(),
+		form,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, subscription)
+}
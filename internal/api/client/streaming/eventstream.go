@@ -0,0 +1,510 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package streaming
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	streampkg "github.com/superseriousbusiness/gotosocial/internal/stream"
+)
+
+// UserEventStreamGETHandler swagger:operation GET /api/v1/streaming/user streamUser
+//
+// Stream updates to the requesting account's home timeline, and notifications, as server-sent events.
+//
+// See eventStream for details of the event stream format.
+//
+//	---
+//	tags:
+//	- streaming
+//
+//	produces:
+//	- text/event-stream
+//
+//	parameters:
+//	-
+//		name: access_token
+//		type: string
+//		description: Access token for the requesting account. Can be provided as an Authorization header instead.
+//		in: query
+//	-
+//		name: Last-Event-ID
+//		type: string
+//		description: ID of the last event received, to resume the stream from (if still possible).
+//		in: header
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:statuses
+//
+//	responses:
+//		'200':
+//			description: Stream of server-sent events.
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+func (m *Module) UserEventStreamGETHandler(c *gin.Context) {
+	m.eventStream(c, streampkg.TimelineHome)
+}
+
+// UserNotificationEventStreamGETHandler swagger:operation GET /api/v1/streaming/user/notification streamUserNotification
+//
+// Stream notifications for the requesting account as server-sent events.
+//
+// See eventStream for details of the event stream format.
+//
+//	---
+//	tags:
+//	- streaming
+//
+//	produces:
+//	- text/event-stream
+//
+//	parameters:
+//	-
+//		name: access_token
+//		type: string
+//		description: Access token for the requesting account. Can be provided as an Authorization header instead.
+//		in: query
+//	-
+//		name: Last-Event-ID
+//		type: string
+//		description: ID of the last event received, to resume the stream from (if still possible).
+//		in: header
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:statuses
+//
+//	responses:
+//		'200':
+//			description: Stream of server-sent events.
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+func (m *Module) UserNotificationEventStreamGETHandler(c *gin.Context) {
+	m.eventStream(c, streampkg.TimelineNotifications)
+}
+
+// PublicEventStreamGETHandler swagger:operation GET /api/v1/streaming/public streamPublic
+//
+// Stream updates to the public (federated) timeline as server-sent events.
+//
+// See eventStream for details of the event stream format.
+//
+//	---
+//	tags:
+//	- streaming
+//
+//	produces:
+//	- text/event-stream
+//
+//	parameters:
+//	-
+//		name: access_token
+//		type: string
+//		description: Access token for the requesting account. Can be provided as an Authorization header instead.
+//		in: query
+//	-
+//		name: Last-Event-ID
+//		type: string
+//		description: ID of the last event received, to resume the stream from (if still possible).
+//		in: header
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:statuses
+//
+//	responses:
+//		'200':
+//			description: Stream of server-sent events.
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+func (m *Module) PublicEventStreamGETHandler(c *gin.Context) {
+	m.eventStream(c, streampkg.TimelinePublic)
+}
+
+// PublicLocalEventStreamGETHandler swagger:operation GET /api/v1/streaming/public/local streamPublicLocal
+//
+// Stream updates to the local timeline as server-sent events.
+//
+// See eventStream for details of the event stream format.
+//
+//	---
+//	tags:
+//	- streaming
+//
+//	produces:
+//	- text/event-stream
+//
+//	parameters:
+//	-
+//		name: access_token
+//		type: string
+//		description: Access token for the requesting account. Can be provided as an Authorization header instead.
+//		in: query
+//	-
+//		name: Last-Event-ID
+//		type: string
+//		description: ID of the last event received, to resume the stream from (if still possible).
+//		in: header
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:statuses
+//
+//	responses:
+//		'200':
+//			description: Stream of server-sent events.
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+func (m *Module) PublicLocalEventStreamGETHandler(c *gin.Context) {
+	m.eventStream(c, streampkg.TimelineLocal)
+}
+
+// HashtagEventStreamGETHandler swagger:operation GET /api/v1/streaming/hashtag streamHashtag
+//
+// Stream public statuses using the given hashtag(s) as server-sent events.
+//
+// See eventStream for details of the event stream format.
+//
+//	---
+//	tags:
+//	- streaming
+//
+//	produces:
+//	- text/event-stream
+//
+//	parameters:
+//	-
+//		name: access_token
+//		type: string
+//		description: Access token for the requesting account. Can be provided as an Authorization header instead.
+//		in: query
+//	-
+//		name: tag
+//		type: string
+//		description: |-
+//			Name of the hashtag to stream.
+//			May be repeated to stream multiple hashtags at once.
+//		in: query
+//		required: true
+//	-
+//		name: Last-Event-ID
+//		type: string
+//		description: ID of the last event received, to resume the stream from (if still possible).
+//		in: header
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:statuses
+//
+//	responses:
+//		'200':
+//			description: Stream of server-sent events.
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+func (m *Module) HashtagEventStreamGETHandler(c *gin.Context) {
+	m.eventStream(c, streampkg.TimelineHashtag)
+}
+
+// HashtagLocalEventStreamGETHandler swagger:operation GET /api/v1/streaming/hashtag/local streamHashtagLocal
+//
+// Stream local public statuses using the given hashtag(s) as server-sent events.
+//
+// See eventStream for details of the event stream format.
+//
+//	---
+//	tags:
+//	- streaming
+//
+//	produces:
+//	- text/event-stream
+//
+//	parameters:
+//	-
+//		name: access_token
+//		type: string
+//		description: Access token for the requesting account. Can be provided as an Authorization header instead.
+//		in: query
+//	-
+//		name: tag
+//		type: string
+//		description: |-
+//			Name of the hashtag to stream.
+//			May be repeated to stream multiple hashtags at once.
+//		in: query
+//		required: true
+//	-
+//		name: Last-Event-ID
+//		type: string
+//		description: ID of the last event received, to resume the stream from (if still possible).
+//		in: header
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:statuses
+//
+//	responses:
+//		'200':
+//			description: Stream of server-sent events.
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+func (m *Module) HashtagLocalEventStreamGETHandler(c *gin.Context) {
+	m.eventStream(c, streampkg.TimelineHashtagLocal)
+}
+
+// ListEventStreamGETHandler swagger:operation GET /api/v1/streaming/list streamList
+//
+// Stream updates to the given list as server-sent events.
+//
+// See eventStream for details of the event stream format.
+//
+//	---
+//	tags:
+//	- streaming
+//
+//	produces:
+//	- text/event-stream
+//
+//	parameters:
+//	-
+//		name: access_token
+//		type: string
+//		description: Access token for the requesting account. Can be provided as an Authorization header instead.
+//		in: query
+//	-
+//		name: list
+//		type: string
+//		description: ID of the list to stream.
+//		in: query
+//		required: true
+//	-
+//		name: Last-Event-ID
+//		type: string
+//		description: ID of the last event received, to resume the stream from (if still possible).
+//		in: header
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:statuses
+//
+//	responses:
+//		'200':
+//			description: Stream of server-sent events.
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+func (m *Module) ListEventStreamGETHandler(c *gin.Context) {
+	m.eventStream(c, streampkg.TimelineList)
+}
+
+// DirectEventStreamGETHandler swagger:operation GET /api/v1/streaming/direct streamDirect
+//
+// Stream direct messages of the requesting account as server-sent events.
+//
+// See eventStream for details of the event stream format.
+//
+//	---
+//	tags:
+//	- streaming
+//
+//	produces:
+//	- text/event-stream
+//
+//	parameters:
+//	-
+//		name: access_token
+//		type: string
+//		description: Access token for the requesting account. Can be provided as an Authorization header instead.
+//		in: query
+//	-
+//		name: Last-Event-ID
+//		type: string
+//		description: ID of the last event received, to resume the stream from (if still possible).
+//		in: header
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:statuses
+//
+//	responses:
+//		'200':
+//			description: Stream of server-sent events.
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+func (m *Module) DirectEventStreamGETHandler(c *gin.Context) {
+	m.eventStream(c, streampkg.TimelineDirect)
+}
+
+// eventStream serves a stream of the given type to the requesting account as
+// server-sent events (https://html.spec.whatwg.org/multipage/server-sent-events.html),
+// for clients that can't use websockets. Each event has the ID of the underlying
+// stream message, the message event type (update, notification, etc) as its event
+// name, and the message payload as its data. Heartbeat comments are sent while
+// the stream is idle, and clients can resume a dropped stream using the
+// Last-Event-ID header, provided the missed events are still buffered.
+func (m *Module) eventStream(c *gin.Context, streamType string) {
+	account, errWithCode := m.streamAccount(c)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// List and hashtag streams
+	// must specify what to stream.
+	switch {
+	case streamType == streampkg.TimelineList && c.Query(StreamListKey) == "":
+		const text = "no list specified"
+		errWithCode := gtserror.NewErrorBadRequest(errors.New(text), text)
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+
+	case (streamType == streampkg.TimelineHashtag || streamType == streampkg.TimelineHashtagLocal) &&
+		c.Query(StreamTagKey) == "":
+		const text = "no tag specified"
+		errWithCode := gtserror.NewErrorBadRequest(errors.New(text), text)
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Open stream with the processor, resuming
+	// from the last event seen by the client.
+	stream, errWithCode := m.processor.Stream().Resume(
+		c.Request.Context(),
+		account,
+		c.GetHeader(LastEventIDHeader),
+		getStreamTypes(c, streamType)...,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+	defer stream.Close()
+
+	// This request will stay open for as long as
+	// the client is connected; don't let it take
+	// up a throttling slot for all of that time.
+	middleware.ReleaseThrottle(c)
+
+	l := log.
+		WithContext(c.Request.Context()).
+		WithField("streamID", id.NewULID()).
+		WithField("username", account.Username)
+	l.Info("opened event stream")
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("X-Accel-Buffering", "no") // tell nginx not to buffer
+	c.Status(http.StatusOK)
+
+	// Write an initial heartbeat, to
+	// flush headers to the client now.
+	if !writeEvent(c, ":)\n\n") {
+		return
+	}
+
+	ctx := c.Request.Context()
+	for {
+		// Wrap context with timeout to send a heartbeat.
+		pingctx, cncl := context.WithTimeout(ctx, m.dTicker)
+
+		// Block on receipt of msg.
+		msg, ok := stream.Recv(pingctx)
+
+		// Check if cancel because ping.
+		pinged := (pingctx.Err() != nil) && (ctx.Err() == nil)
+		cncl()
+
+		var event string
+		switch {
+		case !ok && pinged:
+			// Idle; send a heartbeat comment
+			// to keep the connection alive.
+			event = ":thump\n\n"
+
+		case !ok:
+			// Stream or
+			// client closed.
+			l.Info("closed event stream")
+			return
+
+		default:
+			event = formatEvent(msg)
+		}
+
+		if !writeEvent(c, event) {
+			l.Info("closed event stream")
+			return
+		}
+	}
+}
+
+// formatEvent formats the given stream
+// message as a server-sent event.
+func formatEvent(msg streampkg.Message) string {
+	var b strings.Builder
+	b.WriteString("id: ")
+	b.WriteString(msg.ID)
+	b.WriteString("\nevent: ")
+	b.WriteString(msg.Event)
+	b.WriteString("\n")
+
+	// Data fields can't contain newlines,
+	// so split payload over multiple data
+	// lines; clients join them back up.
+	for _, line := range strings.Split(msg.Payload, "\n") {
+		b.WriteString("data: ")
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	return b.String()
+}
+
+// writeEvent writes the given event to the client and flushes
+// it, returning false if the client has gone away.
+func writeEvent(c *gin.Context, event string) bool {
+	if _, err := c.Writer.WriteString(event); err != nil {
+		return false
+	}
+	c.Writer.Flush()
+	return true
+}
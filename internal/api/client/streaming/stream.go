@@ -94,7 +94,8 @@ import (
 //		type: string
 //		description: |-
 //			Name of the tag to subscribe to.
-//			Only used if stream type is 'hashtag' or 'hashtag:local'.
+//			Only used, and required, if stream type is 'hashtag' or 'hashtag:local'.
+//			May be repeated to subscribe to multiple tags at once.
 //		in: query
 //
 //	security:
@@ -149,54 +150,15 @@ import (
 //		'400':
 //			description: bad request
 func (m *Module) StreamGETHandler(c *gin.Context) {
-	var (
-		account     *gtsmodel.Account
-		errWithCode gtserror.WithCode
-	)
-
-	// Try query param access token.
-	token := c.Query(AccessTokenQueryKey)
-	if token == "" {
-		// Try fallback HTTP header provided token.
-		token = c.GetHeader(AccessTokenHeader)
-	}
-
-	if token != "" {
-
-		// Token was provided, use it to authorize stream.
-		account, errWithCode = m.processor.Stream().Authorize(c.Request.Context(), token)
-		if errWithCode != nil {
-			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
-			return
-		}
-
-	} else {
-
-		// No explicit token was provided:
-		// try regular oauth as a last resort.
-		authed, err := oauth.Authed(c, true, true, true, true)
-		if err != nil {
-			errWithCode := gtserror.NewErrorUnauthorized(err, err.Error())
-			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
-			return
-		}
-
-		// Set the auth'ed account.
-		account = authed.Account
+	account, errWithCode := m.streamAccount(c)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
 	}
 
-	// Get the initial requested stream type, if there is one.
-	streamType := c.Query(StreamQueryKey)
-
-	// By appending other query params to the streamType, we
-	// can allow streaming for specific list IDs or hashtags.
-	// The streamType in this case will end up looking like
-	// `hashtag:example` or `list:01H3YF48G8B7KTPQFS8D2QBVG8`.
-	if list := c.Query(StreamListKey); list != "" {
-		streamType += ":" + list
-	} else if tag := c.Query(StreamTagKey); tag != "" {
-		streamType += ":" + tag
-	}
+	// Get the initial requested stream
+	// type(s), if there are any.
+	streamTypes := getStreamTypes(c, c.Query(StreamQueryKey))
 
 	// Open a stream with the processor; this lets processor
 	// functions pass messages into a channel, which we can
@@ -204,7 +166,7 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 	stream, errWithCode := m.processor.Stream().Open(
 		c.Request.Context(), // this ctx is only used for logging
 		account,
-		streamType,
+		streamTypes...,
 	)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
@@ -237,6 +199,65 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 	go m.handleWSConn(&l, wsConn, stream)
 }
 
+// streamAccount returns the account authorized for the
+// given streaming request, by access token query param,
+// websocket protocol header, or regular oauth as fallback.
+func (m *Module) streamAccount(c *gin.Context) (*gtsmodel.Account, gtserror.WithCode) {
+	// Try query param access token.
+	token := c.Query(AccessTokenQueryKey)
+	if token == "" {
+		// Try fallback HTTP header provided token.
+		token = c.GetHeader(AccessTokenHeader)
+	}
+
+	if token != "" {
+		// Token was provided, use it to authorize stream.
+		return m.processor.Stream().Authorize(c.Request.Context(), token)
+	}
+
+	// No explicit token was provided:
+	// try regular oauth as a last resort.
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		return nil, gtserror.NewErrorUnauthorized(err, err.Error())
+	}
+
+	return authed.Account, nil
+}
+
+// getStreamTypes returns the stream type(s) to open
+// for the given requested stream type, qualified by
+// list ID or hashtag(s) from request query, if set.
+func getStreamTypes(c *gin.Context, streamType string) []string {
+	streamTypes := []string{streamType}
+
+	// By appending other query params to the streamType, we
+	// can allow streaming for specific list IDs or hashtags.
+	// The streamType in this case will end up looking like
+	// `hashtag#example` or `list:01H3YF48G8B7KTPQFS8D2QBVG8`.
+	switch streamType {
+	case streampkg.TimelineList:
+		if list := c.Query(StreamListKey); list != "" {
+			streamTypes[0] += ":" + list
+		}
+
+	case streampkg.TimelineHashtag, streampkg.TimelineHashtagLocal:
+		// Multiple tags may be given,
+		// in which case we subscribe
+		// the stream to all of them.
+		if tags := c.QueryArray(StreamTagKey); len(tags) > 0 {
+			streamTypes = make([]string, 0, len(tags))
+			for _, tag := range tags {
+				streamTypes = append(streamTypes,
+					streampkg.HashtagStreamType(streamType, tag),
+				)
+			}
+		}
+	}
+
+	return streamTypes
+}
+
 // handleWSConn handles a two-way websocket streaming connection.
 // It will both read messages from the connection, and push messages
 // into the connection. If any errors are encountered while reading
@@ -296,6 +317,7 @@ func (m *Module) readFromWSConn(
 			Type   string `json:"type"`
 			Stream string `json:"stream"`
 			List   string `json:"list,omitempty"`
+			Tag    string `json:"tag,omitempty"`
 		}
 
 		// Read JSON objects from the client and act on them.
@@ -326,11 +348,26 @@ func (m *Module) readFromWSConn(
 			continue
 		}
 
-		if msg.List != "" {
-			// If a list is given, add this to
-			// the stream name as this is how we
-			// we track stream types internally.
-			msg.Stream += ":" + msg.List
+		switch msg.Stream {
+		case streampkg.TimelineList:
+			if msg.List != "" {
+				// If a list is given, add this to
+				// the stream name as this is how we
+				// we track stream types internally.
+				msg.Stream += ":" + msg.List
+			}
+
+		case streampkg.TimelineHashtag, streampkg.TimelineHashtagLocal:
+			if msg.Tag == "" {
+				// Hashtag streams are
+				// meaningless without a tag.
+				l.Warnf("missing 'tag' field: %v", msg)
+				continue
+			}
+
+			// Qualify the stream name
+			// with the (normalized) tag.
+			msg.Stream = streampkg.HashtagStreamType(msg.Stream, msg.Tag)
 		}
 
 		switch msg.Type {
@@ -388,6 +425,12 @@ func (m *Module) writeToWSConn(
 			return
 		}
 
+		// Stream types are tracked internally in a
+		// different format to what clients expect.
+		if len(msg.Stream) == 1 {
+			msg.Stream = streampkg.ClientStream(msg.Stream[0])
+		}
+
 		l.Trace("writing websocket message: %+v", msg)
 
 		// Received a new message from the processor.
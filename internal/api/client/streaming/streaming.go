@@ -33,6 +33,16 @@ const (
 	StreamTagKey        = "tag"                    // name of tag being requested
 	AccessTokenQueryKey = "access_token"           // oauth access token
 	AccessTokenHeader   = "Sec-Websocket-Protocol" //nolint:gosec
+	LastEventIDHeader   = "Last-Event-ID"          // id of last event received by event stream client
+
+	UserPath             = BasePath + "/user"              // event stream for home timeline + notifications
+	UserNotificationPath = BasePath + "/user/notification" // event stream for notifications
+	PublicPath           = BasePath + "/public"            // event stream for public timeline
+	PublicLocalPath      = BasePath + "/public/local"      // event stream for local timeline
+	HashtagPath          = BasePath + "/hashtag"           // event stream for hashtag
+	HashtagLocalPath     = BasePath + "/hashtag/local"     // event stream for local hashtag
+	ListPath             = BasePath + "/list"              // event stream for list
+	DirectPath           = BasePath + "/direct"            // event stream for direct messages
 )
 
 type Module struct {
@@ -60,4 +70,12 @@ func New(processor *processing.Processor, dTicker time.Duration, wsBuf int) *Mod
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodGet, BasePath, m.StreamGETHandler)
+	attachHandler(http.MethodGet, UserPath, m.UserEventStreamGETHandler)
+	attachHandler(http.MethodGet, UserNotificationPath, m.UserNotificationEventStreamGETHandler)
+	attachHandler(http.MethodGet, PublicPath, m.PublicEventStreamGETHandler)
+	attachHandler(http.MethodGet, PublicLocalPath, m.PublicLocalEventStreamGETHandler)
+	attachHandler(http.MethodGet, HashtagPath, m.HashtagEventStreamGETHandler)
+	attachHandler(http.MethodGet, HashtagLocalPath, m.HashtagLocalEventStreamGETHandler)
+	attachHandler(http.MethodGet, ListPath, m.ListEventStreamGETHandler)
+	attachHandler(http.MethodGet, DirectPath, m.DirectEventStreamGETHandler)
 }
@@ -0,0 +1,54 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tags
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+)
+
+const (
+	// NameKey is the key for the hashtag name in the path.
+	NameKey = "name"
+	// BasePath is the base URI path for serving tags, minus the api prefix.
+	BasePath = "/v1/tags"
+	// BasePathWithName is the base path with the name key in it.
+	BasePathWithName = BasePath + "/:" + NameKey
+	// FollowPath is used for following a hashtag.
+	FollowPath = BasePathWithName + "/follow"
+	// UnfollowPath is used for unfollowing a hashtag.
+	UnfollowPath = BasePathWithName + "/unfollow"
+)
+
+type Module struct {
+	processor *processing.Processor
+}
+
+func New(processor *processing.Processor) *Module {
+	return &Module{
+		processor: processor,
+	}
+}
+
+func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
+	attachHandler(http.MethodGet, BasePathWithName, m.TagGETHandler)
+	attachHandler(http.MethodPost, FollowPath, m.FollowTagPOSTHandler)
+	attachHandler(http.MethodPost, UnfollowPath, m.UnfollowTagPOSTHandler)
+}
@@ -0,0 +1,123 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package fileserver
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+const (
+	assetsPathPrefix = "/assets"
+	distPathPrefix   = assetsPathPrefix + "/dist"
+
+	cssFA      = assetsPathPrefix + "/Fork-Awesome/css/fork-awesome.min.css"
+	cssStatus  = distPathPrefix + "/status.css"
+	cssThread  = distPathPrefix + "/thread.css"
+	jsFrontend = distPathPrefix + "/frontend.js"
+)
+
+// wantsPreview returns whether the requester only accepts
+// text/html, and none of the types media may be served as.
+//
+// This is mostly the case when a link to a gts-hosted file
+// is shared on something like mastodon, as the masto servers
+// will attempt to look up the link to provide a preview of it.
+// Browsers accept */*, so will still be served the file itself.
+func wantsPreview(c *gin.Context) bool {
+	if len(c.Request.Header.Values("Accept")) == 0 {
+		// No Accept header, they'll take anything.
+		return false
+	}
+
+	if apiutil.NegotiateFormat(c, apiutil.TextHTML) == "" {
+		// Doesn't want HTML.
+		return false
+	}
+
+	return apiutil.NegotiateFormat(c,
+		"image/*",
+		"video/*",
+		"audio/*",
+	) == ""
+}
+
+// serveFilePreview renders an HTML page embedding the requested
+// media, with OpenGraph meta tags describing it for unfurlers.
+func (m *Module) serveFilePreview(
+	c *gin.Context,
+	requester *gtsmodel.Account,
+	form *apimodel.GetContentRequestForm,
+) {
+	ctx := c.Request.Context()
+
+	instance, errWithCode := m.processor.InstanceGetV1(ctx)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Return instance we already got from the db,
+	// don't try to fetch it again when erroring.
+	instanceGet := func(ctx context.Context) (*apimodel.InstanceV1, gtserror.WithCode) {
+		return instance, nil
+	}
+
+	attachment, errWithCode := m.processor.Media().GetFilePreview(ctx, requester, form)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	// A HEAD request only needs to
+	// know that HTML would be served.
+	if c.Request.Method == http.MethodHead {
+		c.Header("Content-Type", apiutil.TextHTML)
+		c.Status(http.StatusOK)
+		return
+	}
+
+	page := apiutil.WebPage{
+		Template: "media.tmpl",
+		Instance: instance,
+		OGMeta:   apiutil.OGBase(instance).WithAttachment(attachment),
+		Stylesheets: []string{
+			cssFA, cssStatus, cssThread,
+		},
+		Javascript: []string{jsFrontend},
+		Extra: map[string]any{
+			"attachment": attachment,
+			// Status-like object for rendering
+			// the media gallery template with
+			// just this one attachment in it.
+			"media": map[string]any{
+				"MediaAttachments": []*apimodel.Attachment{
+					attachment.Attachment,
+				},
+			},
+		},
+	}
+
+	apiutil.TemplateWebPage(c, page)
+}
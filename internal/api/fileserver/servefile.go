@@ -31,6 +31,7 @@ import (
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
@@ -79,12 +80,25 @@ func (m *Module) ServeFile(c *gin.Context) {
 	// Acquire context from gin request.
 	ctx := c.Request.Context()
 
-	content, errWithCode := m.processor.Media().GetFile(ctx, authed.Account, &apimodel.GetContentRequestForm{
+	form := &apimodel.GetContentRequestForm{
 		AccountID: accountID,
 		MediaType: mediaType,
 		MediaSize: mediaSize,
 		FileName:  fileName,
-	})
+	}
+
+	// Response depends on whether
+	// HTML or media was requested.
+	c.Writer.Header().Add("Vary", "Accept")
+
+	if wantsPreview(c) && mediaType != string(media.TypeEmoji) {
+		// Requester only accepts text/html,
+		// serve them a preview page instead.
+		m.serveFilePreview(c, authed.Account, form)
+		return
+	}
+
+	content, errWithCode := m.processor.Media().GetFile(ctx, authed.Account, form)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
@@ -106,9 +120,6 @@ func (m *Module) ServeFile(c *gin.Context) {
 		}
 	}()
 
-	// TODO: if the requester only accepts text/html we should try to serve them *something*.
-	// This is mostly needed because when sharing a link to a gts-hosted file on something like mastodon, the masto servers will
-	// attempt to look up the content to provide a preview of the link, and they ask for text/html.
 	contentType, err := apiutil.NegotiateAccept(c, content.ContentType)
 	if err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
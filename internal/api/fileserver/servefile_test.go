@@ -43,12 +43,23 @@ func (suite *ServeFileTestSuite) GetFile(
 	mediaType media.Type,
 	mediaSize media.Size,
 	filename string,
+) (code int, headers http.Header, body []byte) {
+	return suite.GetFileAccept("*/*", accountID, mediaType, mediaSize, filename)
+}
+
+// GetFileAccept is like GetFile, but requests the file with the given Accept header.
+func (suite *ServeFileTestSuite) GetFileAccept(
+	accept string,
+	accountID string,
+	mediaType media.Type,
+	mediaSize media.Size,
+	filename string,
 ) (code int, headers http.Header, body []byte) {
 	recorder := httptest.NewRecorder()
 
 	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
 	ctx.Request = httptest.NewRequest(http.MethodGet, "http://localhost:8080/whatever", nil)
-	ctx.Request.Header.Set("accept", "*/*")
+	ctx.Request.Header.Set("accept", accept)
 	ctx.AddParam(fileserver.AccountIDKey, accountID)
 	ctx.AddParam(fileserver.MediaTypeKey, string(mediaType))
 	ctx.AddParam(fileserver.MediaSizeKey, string(mediaSize))
@@ -266,6 +277,57 @@ func (suite *ServeFileTestSuite) TestServeFileNotFound() {
 	suite.Equal(http.StatusNotFound, code)
 }
 
+func (suite *ServeFileTestSuite) TestServePreviewOK() {
+	targetAttachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+
+	code, headers, body := suite.GetFileAccept(
+		"text/html",
+		targetAttachment.AccountID,
+		media.TypeAttachment,
+		media.SizeOriginal,
+		targetAttachment.ID+".jpg",
+	)
+
+	suite.Equal(http.StatusOK, code)
+	suite.Equal("text/html; charset=utf-8", headers.Get("content-type"))
+	suite.Contains(string(body), `<meta property="og:url" content="`+targetAttachment.URL+`">`)
+	suite.Contains(string(body), `<meta property="og:image" content="`+targetAttachment.Thumbnail.URL+`">`)
+	suite.Contains(string(body), `<meta property="og:image:alt" content="Black and white image of some 50&#39;s style text saying: Welcome On Board">`)
+	suite.Contains(string(body), `<meta name="twitter:card" content="summary_large_image">`)
+	suite.Contains(string(body), `<figure class="media-preview">`)
+}
+
+func (suite *ServeFileTestSuite) TestServePreviewBrowser() {
+	targetAttachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+
+	// Browsers accept html first, but also
+	// everything else, so get the file itself.
+	code, headers, _ := suite.GetFileAccept(
+		"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8",
+		targetAttachment.AccountID,
+		media.TypeAttachment,
+		media.SizeOriginal,
+		targetAttachment.ID+".jpg",
+	)
+
+	suite.Equal(http.StatusOK, code)
+	suite.Equal("image/jpeg", headers.Get("content-type"))
+}
+
+func (suite *ServeFileTestSuite) TestServePreviewUnattachedNotFound() {
+	targetAttachment := suite.testAttachments["local_account_1_unattached_1"]
+
+	code, _, _ := suite.GetFileAccept(
+		"text/html",
+		targetAttachment.AccountID,
+		media.TypeAttachment,
+		media.SizeOriginal,
+		targetAttachment.ID+".jpg",
+	)
+
+	suite.Equal(http.StatusNotFound, code)
+}
+
 func TestServeFileTestSuite(t *testing.T) {
 	suite.Run(t, new(ServeFileTestSuite))
 }
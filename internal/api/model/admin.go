@@ -229,3 +229,76 @@ type DebugAPUrlResponse struct {
 	// may be an error, may be both!
 	ResponseBody string `json:"response_body"`
 }
+
+// AdminStorageUsage models the admin view of media storage used by this instance.
+//
+// swagger:model adminStorageUsage
+type AdminStorageUsage struct {
+	// Size in bytes of all media stored by this instance.
+	// example: 1048576
+	Total int `json:"total"`
+	// Size in bytes of local media attachments (and their thumbnails), excluding avatars and headers.
+	// example: 524288
+	LocalMedia int `json:"local_media"`
+	// Size in bytes of local avatars (and their thumbnails).
+	// example: 65536
+	LocalAvatars int `json:"local_avatars"`
+	// Size in bytes of local headers (and their thumbnails).
+	// example: 131072
+	LocalHeaders int `json:"local_headers"`
+	// Size in bytes of local custom emoji (and their static versions).
+	// example: 16384
+	LocalEmoji int `json:"local_emoji"`
+	// Size in bytes of cached remote media attachments (and their thumbnails), including avatars and headers.
+	// example: 294912
+	RemoteMedia int `json:"remote_media"`
+	// Size in bytes of cached remote custom emoji (and their static versions).
+	// example: 16384
+	RemoteEmoji int `json:"remote_emoji"`
+	// Default storage quota in bytes of each local account. 0 means no limit.
+	// example: 104857600
+	DefaultAccountQuota int `json:"default_account_quota"`
+}
+
+// AdminAccountStorageUsage models the admin view of media storage used by one local account.
+//
+// swagger:model adminAccountStorageUsage
+type AdminAccountStorageUsage struct {
+	// The ID of the account.
+	// example: 01GQ4PHNT622DQ9X95XQX4KKNR
+	AccountID string `json:"account_id"`
+	// Size in bytes of all media stored for this account.
+	// example: 720896
+	Total int `json:"total"`
+	// Size in bytes of media attachments (and their thumbnails), excluding avatar and header.
+	// example: 524288
+	Media int `json:"media"`
+	// Size in bytes of avatars (and their thumbnails).
+	// example: 65536
+	Avatar int `json:"avatar"`
+	// Size in bytes of headers (and their thumbnails).
+	// example: 131072
+	Header int `json:"header"`
+	// Size in bytes of local custom emoji. Only set for the instance account, which owns them.
+	// example: 0
+	Emoji int `json:"emoji"`
+	// Storage quota in bytes of this account. 0 means no limit.
+	// example: 104857600
+	Quota int `json:"quota"`
+	// Whether this account has its own quota set by an admin,
+	// rather than using the instance default quota.
+	// example: false
+	CustomQuota bool `json:"custom_quota"`
+	// When usage was last counted. (ISO 8601 Datetime)
+	// example: 2021-07-30T09:20:25+00:00
+	UpdatedAt string `json:"updated_at"`
+}
+
+// AdminAccountStorageQuotaRequest models a request to set the storage quota of a local account.
+//
+// swagger:ignore
+type AdminAccountStorageQuotaRequest struct {
+	// Storage quota in bytes. 0 means no limit.
+	// If not set, the account will use the instance default quota.
+	Quota *int `form:"quota" json:"quota"`
+}
@@ -296,6 +296,22 @@ type Attachment struct {
 	Sensitive bool `json:"-"`
 }
 
+// WebAttachment models an attachment along with the account
+// and status it belongs to, for rendering an HTML preview of the
+// attachment when its URL is opened by a link unfurler.
+//
+// swagger:ignore
+type WebAttachment struct {
+	*Attachment
+	// Content type of the original file.
+	ContentType string
+	// Account that owns the attachment.
+	Account *Account
+	// Status the attachment is attached to,
+	// or nil if it's an avatar or header.
+	Status *Status
+}
+
 // MediaMeta models media metadata.
 // This can be metadata about an image, an audio file, video, etc.
 //
@@ -0,0 +1,62 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// BookmarkCollection represents a named collection
+// into which a user can sort their bookmarks.
+//
+// swagger:model bookmarkCollection
+type BookmarkCollection struct {
+	// The ID of the collection.
+	ID string `json:"id"`
+	// The user-defined title of the collection.
+	Title string `json:"title"`
+	// When the collection was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// When the collection was last updated (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	UpdatedAt string `json:"updated_at"`
+}
+
+// BookmarkCollectionCreateRequest models bookmark collection creation parameters.
+//
+// swagger:parameters bookmarkCollectionCreate
+type BookmarkCollectionCreateRequest struct {
+	// Title of this collection.
+	// example: Research
+	// in: formData
+	// required: true
+	Title string `form:"title" json:"title" xml:"title"`
+}
+
+// BookmarkCollectionUpdateRequest models bookmark collection update parameters.
+//
+// swagger:parameters bookmarkCollectionUpdate
+type BookmarkCollectionUpdateRequest struct {
+	// New title of this collection.
+	// example: Research
+	// in: formData
+	// required: true
+	Title string `form:"title" json:"title" xml:"title"`
+}
+
+// swagger:ignore
+type BookmarkCollectionStatusesChangeRequest struct {
+	StatusIDs []string `form:"status_ids[]" json:"status_ids" xml:"status_ids"`
+}
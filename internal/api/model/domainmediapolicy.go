@@ -0,0 +1,88 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// DomainMediaPolicy represents how media from a remote domain (and its subdomains) is handled.
+//
+// swagger:model domainMediaPolicy
+type DomainMediaPolicy struct {
+	// The ID of the media policy.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+
+	// The domain this policy applies to, including its subdomains.
+	// example: example.org
+	Domain string `json:"domain"`
+
+	// How to handle media from the domain.
+	// `cache` fetches and caches media as usual.
+	// `proxy` doesn't keep media cached, but streams it from the remote when requested.
+	// `remote` doesn't keep media cached, but redirects requests to the remote URL.
+	// `reject` never fetches media from the domain at all.
+	// enum:
+	// - cache
+	// - proxy
+	// - remote
+	// - reject
+	// example: proxy
+	Policy string `json:"policy"`
+
+	// For the `cache` policy, the number of days to keep remote media
+	// from the domain cached for. Null if the instance default is used.
+	// example: 7
+	CacheDays *int `json:"cache_days"`
+
+	// Private comment on this policy.
+	// example: big instance, don't keep their media for long
+	Comment string `json:"comment"`
+
+	// The ID of the admin account that created this media policy.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	// readonly: true
+	CreatedBy string `json:"created_by"`
+
+	// Time at which the media policy was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	CreatedAt string `json:"created_at"`
+
+	// Time at which the media policy was last updated (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	UpdatedAt string `json:"updated_at"`
+}
+
+// DomainMediaPolicyRequest is the form submitted as a POST to create
+// a new media policy, or as a PATCH to update an existing one.
+//
+// swagger:ignore
+type DomainMediaPolicyRequest struct {
+	// The domain to create a policy for. Ignored on update.
+	Domain *string `form:"domain" json:"domain" xml:"domain"`
+
+	// One of `cache`, `proxy`, `remote` or `reject`.
+	Policy *string `form:"policy" json:"policy" xml:"policy"`
+
+	// Days to keep media cached for, for the `cache`
+	// policy. 0 means use the instance default.
+	CacheDays *int `form:"cache_days" json:"cache_days" xml:"cache_days"`
+
+	// Private comment on this policy.
+	Comment *string `form:"comment" json:"comment" xml:"comment"`
+}
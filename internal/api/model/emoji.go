@@ -82,3 +82,18 @@ const (
 	EmojiUpdateDisable EmojiUpdateType = "disable" // disable remote emoji
 	EmojiUpdateCopy    EmojiUpdateType = "copy"    // copy remote emoji -> local
 )
+
+// EmojiPackImportRequest represents a request to import a pack of custom emojis, made through the admin API.
+//
+// swagger:ignore
+type EmojiPackImportRequest struct {
+	// Zip or tar.gz archive of emoji images, optionally with
+	// a Misskey (meta.json) or Pleroma (pack.json) manifest.
+	Pack *multipart.FileHeader `form:"pack" binding:"required"`
+	// What to do with emojis whose shortcode already exists
+	// on the instance. One of skip (default), overwrite, rename.
+	Conflict string `form:"conflict"`
+	// Category in which to place imported emojis
+	// that don't have a category in the pack.
+	CategoryName string `form:"category"`
+}
@@ -18,15 +18,30 @@
 package model
 
 // FeaturedTag represents a hashtag that is featured on a profile.
+//
+// swagger:model featuredTag
 type FeaturedTag struct {
 	// The internal ID of the featured tag in the database.
+	// example: 01FBW9XGEP7G6K88VY4S9MPE1R
 	ID string `json:"id"`
-	// The name of the hashtag being featured.
+	// The name of the hashtag being featured, without the # sign.
+	// example: helloworld
 	Name string `json:"name"`
 	// A link to all statuses by a user that contain this hashtag.
+	// example: https://example.org/tags/helloworld
 	URL string `json:"url"`
 	// The number of authored statuses containing this hashtag.
 	StatusesCount int `json:"statuses_count"`
 	// The timestamp of the last authored status containing this hashtag. (ISO 8601 Datetime)
-	LastStatusAt string `json:"last_status_at"`
+	// Will be null if no statuses with this hashtag have been authored.
+	// example: 2021-07-30T09:20:25+00:00
+	LastStatusAt *string `json:"last_status_at"`
+}
+
+// FeaturedTagCreateRequest models a request to feature a hashtag on a profile.
+//
+// swagger:ignore
+type FeaturedTagCreateRequest struct {
+	// The hashtag to be featured, with or without the # sign.
+	Name string `form:"name" json:"name"`
 }
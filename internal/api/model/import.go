@@ -0,0 +1,77 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import "mime/multipart"
+
+// Import represents one CSV import of follows,
+// blocks, lists, or bookmarks, along with the
+// progress made towards processing it.
+//
+// swagger:model import
+type Import struct {
+	// The ID of the import.
+	// example: 01FBW9XGEP7G6K88VY4S9MPE1R
+	ID string `json:"id"`
+	// Type of data being imported.
+	// example: following
+	Type string `json:"type"`
+	// Mode of reconciliation with existing data.
+	//	merge = add imported entries to existing data
+	//	overwrite = replace existing data with imported entries
+	// example: merge
+	Mode string `json:"mode"`
+	// Current state of the import.
+	//	in_progress = entries are still being processed
+	//	finished = all entries have been processed
+	// example: in_progress
+	State string `json:"state"`
+	// Total number of entries parsed from the import file.
+	TotalItems int `json:"total_items"`
+	// Number of entries processed so far, successfully or not.
+	ProcessedItems int `json:"processed_items"`
+	// Number of processed entries that could not be imported.
+	FailedItems int `json:"failed_items"`
+	// Errors encountered while processing entries, if any.
+	Errors []string `json:"errors"`
+	// Time when the import was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Time when the import finished processing (ISO 8601 Datetime), if it has.
+	// example: 2021-07-30T09:20:25+00:00
+	CompletedAt string `json:"completed_at,omitempty"`
+}
+
+// ImportRequest models a request to import a CSV file.
+//
+// swagger:ignore
+type ImportRequest struct {
+	// CSV file to import, in the format exported by Mastodon or GoToSocial.
+	Data *multipart.FileHeader `form:"data" binding:"required"`
+	// Type of data contained in the CSV file.
+	//	following = accounts to follow
+	//	blocks = accounts to block
+	//	mutes = accounts to mute
+	//	lists = list titles and the accounts they contain
+	//	bookmarks = URIs of statuses to bookmark
+	Type string `form:"type" binding:"required"`
+	// Mode of reconciliation with existing data.
+	//	merge = add imported entries to existing data
+	//	overwrite = replace existing data with imported entries
+	Mode string `form:"mode"`
+}
@@ -83,9 +83,8 @@ type InstanceV2Usage struct {
 //
 // swagger:model instanceV2Users
 type InstanceV2Users struct {
-	// The number of active users in the past 4 weeks.
-	// Currently not implemented: will always be 0.
-	// example: 0
+	// The number of local users who have signed in during the past 4 weeks.
+	// example: 5
 	ActiveMonth int `json:"active_month"`
 }
 
@@ -161,6 +160,17 @@ type InstanceV2Configuration struct {
 	Translation InstanceV2ConfigurationTranslation `json:"translation"`
 	// Instance configuration pertaining to emojis.
 	Emojis InstanceConfigurationEmojis `json:"emojis"`
+	// Instance configuration pertaining to Web Push.
+	VAPID InstanceV2ConfigurationVAPID `json:"vapid"`
+}
+
+// Instance configuration pertaining to Web Push.
+//
+// swagger:model instanceV2ConfigurationVAPID
+type InstanceV2ConfigurationVAPID struct {
+	// Base64url-encoded VAPID public key of this instance,
+	// used by push services to authenticate push alerts.
+	PublicKey string `json:"public_key"`
 }
 
 // Information about registering for this instance.
@@ -30,6 +30,9 @@ type List struct {
 	//	list = Show replies to members of the list
 	//	none = Show replies to no one
 	RepliesPolicy string `json:"replies_policy"`
+	// Exclusive is true if posts from members of this
+	// list are hidden from the owner's home timeline.
+	Exclusive bool `json:"exclusive"`
 }
 
 // ListCreateRequest models list creation parameters.
@@ -49,6 +52,10 @@ type ListCreateRequest struct {
 	// default: list
 	// in: formData
 	RepliesPolicy string `form:"replies_policy" json:"replies_policy" xml:"replies_policy"`
+	// Hide posts from members of this list from your home timeline.
+	// default: false
+	// in: formData
+	Exclusive bool `form:"exclusive" json:"exclusive" xml:"exclusive"`
 }
 
 // ListUpdateRequest models list update parameters.
@@ -65,6 +72,9 @@ type ListUpdateRequest struct {
 	//	none = Show replies to no one
 	// in: formData
 	RepliesPolicy *string `form:"replies_policy" json:"replies_policy" xml:"replies_policy"`
+	// Hide posts from members of this list from your home timeline.
+	// in: formData
+	Exclusive *bool `form:"exclusive" json:"exclusive" xml:"exclusive"`
 }
 
 // swagger:ignore
@@ -0,0 +1,80 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import "mime/multipart"
+
+// MediaHash represents one entry in the media hash blocklist.
+//
+// swagger:model mediaHash
+type MediaHash struct {
+	// The ID of the media hash.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+
+	// The type of hash, ie., the algorithm used to calculate it.
+	// `sha256` is the SHA-256 hash of the exact file contents.
+	// `phash` is a 64-bit perceptual difference hash (dHash) of
+	// the image, which also matches near-identical copies of it.
+	// enum:
+	// - sha256
+	// - phash
+	// example: phash
+	Type string `json:"type"`
+
+	// The hash value, hex-encoded.
+	// example: 8f373714acfcf4d0
+	Hash string `json:"hash"`
+
+	// Private comment on this hash, eg., where it came from.
+	// example: from the shared moderation list
+	Comment string `json:"comment"`
+
+	// The ID of the admin account that created this media hash.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	// readonly: true
+	CreatedBy string `json:"created_by"`
+
+	// Time at which the media hash was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	CreatedAt string `json:"created_at"`
+}
+
+// MediaHashRequest is the form submitted as a POST to create a new media hash entry.
+//
+// swagger:model mediaHashCreateRequest
+type MediaHashRequest struct {
+	// The type of hash, either `sha256` or `phash`.
+	Type string `form:"type" json:"type" xml:"type"`
+
+	// The hex-encoded hash value.
+	Hash string `form:"hash" json:"hash" xml:"hash"`
+
+	// Private comment on this hash.
+	Comment string `form:"comment" json:"comment" xml:"comment"`
+}
+
+// MediaHashImportRequest is the form submitted as a POST to import a list of media hashes.
+//
+// swagger:ignore
+type MediaHashImportRequest struct {
+	// Plain text file with one hash per line, as `<type> <hash> [comment]`.
+	Hashes *multipart.FileHeader `form:"hashes" binding:"required"`
+}
@@ -45,6 +45,68 @@ type Notification struct {
 	Status *Status `json:"status,omitempty"`
 }
 
+// NotificationGroup represents a group of notifications of
+// the same type about the same status, eg., favourites of
+// one of the requester's statuses. Notifications that can't
+// be grouped are returned as a group of one notification.
+//
+// swagger:model notificationGroup
+type NotificationGroup struct {
+	// Opaque key identifying this group. Groups are keyed by
+	// notification type and status, so the same key may appear
+	// again on subsequent pages.
+	GroupKey string `json:"group_key"`
+	// Number of notifications in this group on the current page.
+	NotificationsCount int `json:"notifications_count"`
+	// The type of event that resulted in the notifications in this group.
+	Type string `json:"type"`
+	// ID of the most recent notification in this group.
+	MostRecentNotificationID string `json:"most_recent_notification_id"`
+	// ID of the oldest notification in this group on the current page.
+	PageMinID string `json:"page_min_id"`
+	// ID of the newest notification in this group on the current page.
+	PageMaxID string `json:"page_max_id"`
+	// Timestamp of the newest notification in this group on the current page (ISO 8601 Datetime).
+	LatestPageNotificationAt string `json:"latest_page_notification_at"`
+	// IDs of some of the accounts that performed the
+	// actions in this group, most recent first.
+	SampleAccountIDs []string `json:"sample_account_ids"`
+	// ID of the status that was the object of the
+	// notifications in this group, if applicable.
+	StatusID string `json:"status_id,omitempty"`
+}
+
+// GroupedNotificationsResults represents one page of
+// notification groups, along with the accounts and
+// statuses referenced by those groups.
+//
+// swagger:model groupedNotificationsResults
+type GroupedNotificationsResults struct {
+	// Accounts referenced by notification groups.
+	Accounts []*Account `json:"accounts"`
+	// Statuses referenced by notification groups.
+	Statuses []*Status `json:"statuses"`
+	// The notification groups on this page.
+	NotificationGroups []*NotificationGroup `json:"notification_groups"`
+}
+
+// GroupedNotificationsResponse wraps one page of grouped
+// notifications, ready to be serialized, along with the
+// Link header for the previous and next pages.
+type GroupedNotificationsResponse struct {
+	Results    *GroupedNotificationsResults
+	LinkHeader string
+}
+
+// NotificationsUnreadCount represents a
+// count of unread notifications (or groups).
+//
+// swagger:model notificationsUnreadCount
+type NotificationsUnreadCount struct {
+	// Number of unread notifications or notification groups.
+	Count int `json:"count"`
+}
+
 /*
 	The below functions are added onto the apimodel notification so that it satisfies
 	the Timelineable interface in internal/timeline.
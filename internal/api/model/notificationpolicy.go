@@ -0,0 +1,87 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// NotificationPolicy represents the requesting account's policy
+// for filtering notifications from accounts it may not want to
+// hear from. Filtered notifications are put into notification
+// requests, which can be reviewed, then accepted or dismissed.
+//
+// swagger:model notificationPolicy
+type NotificationPolicy struct {
+	// Filter notifications from accounts you don't follow.
+	FilterNotFollowing bool `json:"filter_not_following"`
+	// Filter notifications from accounts that don't follow you.
+	FilterNotFollowers bool `json:"filter_not_followers"`
+	// Filter notifications from accounts created less than new_accounts_days ago.
+	FilterNewAccounts bool `json:"filter_new_accounts"`
+	// Number of days after creation that an account is considered new.
+	NewAccountsDays int `json:"new_accounts_days"`
+	// Filter private mentions from accounts you don't follow,
+	// unless they're in reply to one of your own statuses.
+	FilterPrivateMentions bool `json:"filter_private_mentions"`
+	// Summary of pending notification requests.
+	Summary NotificationPolicySummary `json:"summary"`
+}
+
+// NotificationPolicySummary summarizes the
+// requesting account's pending notification requests.
+//
+// swagger:model notificationPolicySummary
+type NotificationPolicySummary struct {
+	// Number of pending notification requests.
+	PendingRequestsCount int `json:"pending_requests_count"`
+	// Total number of filtered notifications in pending notification requests.
+	PendingNotificationsCount int `json:"pending_notifications_count"`
+}
+
+// NotificationPolicyUpdateRequest models a request
+// to update the requesting account's notification policy.
+//
+// swagger:ignore
+type NotificationPolicyUpdateRequest struct {
+	// Filter notifications from accounts you don't follow.
+	FilterNotFollowing *bool `form:"filter_not_following" json:"filter_not_following"`
+	// Filter notifications from accounts that don't follow you.
+	FilterNotFollowers *bool `form:"filter_not_followers" json:"filter_not_followers"`
+	// Filter notifications from accounts created less than new_accounts_days ago.
+	FilterNewAccounts *bool `form:"filter_new_accounts" json:"filter_new_accounts"`
+	// Number of days after creation that an account is considered new.
+	NewAccountsDays *int `form:"new_accounts_days" json:"new_accounts_days"`
+	// Filter private mentions from accounts you don't follow.
+	FilterPrivateMentions *bool `form:"filter_private_mentions" json:"filter_private_mentions"`
+}
+
+// NotificationRequest represents a group of notifications from one
+// account that were filtered by the requesting account's policy.
+//
+// swagger:model notificationRequest
+type NotificationRequest struct {
+	// The id of the notification request in the database.
+	ID string `json:"id"`
+	// When the first filtered notification of the request was created (ISO 8601 Datetime).
+	CreatedAt string `json:"created_at"`
+	// When the most recent filtered notification of the request was created (ISO 8601 Datetime).
+	UpdatedAt string `json:"updated_at"`
+	// The account that performed the actions that generated the filtered notifications.
+	Account *Account `json:"account"`
+	// Number of filtered notifications in this request.
+	NotificationsCount int `json:"notifications_count"`
+	// Most recent status associated with a filtered notification in this request, if any.
+	LastStatus *Status `json:"last_status,omitempty"`
+}
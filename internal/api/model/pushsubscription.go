@@ -18,6 +18,8 @@
 package model
 
 // PushSubscription represents a subscription to the push streaming server.
+//
+// swagger:model pushSubscription
 type PushSubscription struct {
 	// The id of the push subscription in the database.
 	ID string `json:"id"`
@@ -27,12 +29,19 @@ type PushSubscription struct {
 	ServerKey string `json:"server_key"`
 	// Which alerts should be delivered to the endpoint.
 	Alerts *PushSubscriptionAlerts `json:"alerts"`
+	// Whose actions should trigger alerts: all, followed, follower, or none.
+	// example: all
+	Policy string `json:"policy"`
 }
 
 // PushSubscriptionAlerts represents the specific alerts that this push subscription will give.
+//
+// swagger:model pushSubscriptionAlerts
 type PushSubscriptionAlerts struct {
 	// Receive a push notification when someone has followed you?
 	Follow bool `json:"follow"`
+	// Receive a push notification when someone has requested to follow you?
+	FollowRequest bool `json:"follow_request"`
 	// Receive a push notification when a status you created has been favourited by someone else?
 	Favourite bool `json:"favourite"`
 	// Receive a push notification when someone else has mentioned you in a status?
@@ -41,4 +50,109 @@ type PushSubscriptionAlerts struct {
 	Reblog bool `json:"reblog"`
 	// Receive a push notification when a poll you voted in or created has ended?
 	Poll bool `json:"poll"`
+	// Receive a push notification when someone you enabled notifications for has posted a status?
+	Status bool `json:"status"`
+}
+
+// PushSubscriptionCreateRequest models a request to create a push subscription.
+// Like PushSubscriptionUpdateRequest, this has two sets of fields to support
+// nested structures in both form data and JSON bodies.
+//
+// swagger:ignore
+type PushSubscriptionCreateRequest struct {
+	Subscription *PushSubscriptionRequestSubscription `json:"subscription"`
+	FormEndpoint string                               `form:"subscription[endpoint]"`
+	FormP256dh   string                               `form:"subscription[keys][p256dh]"`
+	FormAuth     string                               `form:"subscription[keys][auth]"`
+
+	PushSubscriptionUpdateRequest
+}
+
+type PushSubscriptionRequestSubscription struct {
+	// Where push alerts will be sent to.
+	Endpoint string `json:"endpoint"`
+	// Keys used to encrypt push alerts.
+	Keys PushSubscriptionRequestKeys `json:"keys"`
+}
+
+type PushSubscriptionRequestKeys struct {
+	// Base64url-encoded public key of the client's P-256 ECDH key pair.
+	P256dh string `json:"p256dh"`
+	// Base64url-encoded auth secret.
+	Auth string `json:"auth"`
+}
+
+// Endpoint should be used instead of Subscription or FormEndpoint.
+func (r *PushSubscriptionCreateRequest) Endpoint() string {
+	if r.Subscription != nil {
+		return r.Subscription.Endpoint
+	}
+	return r.FormEndpoint
+}
+
+// P256dh should be used instead of Subscription or FormP256dh.
+func (r *PushSubscriptionCreateRequest) P256dh() string {
+	if r.Subscription != nil {
+		return r.Subscription.Keys.P256dh
+	}
+	return r.FormP256dh
+}
+
+// Auth should be used instead of Subscription or FormAuth.
+func (r *PushSubscriptionCreateRequest) Auth() string {
+	if r.Subscription != nil {
+		return r.Subscription.Keys.Auth
+	}
+	return r.FormAuth
+}
+
+// PushSubscriptionUpdateRequest models a request to update the alerts
+// and policy of a push subscription. This has two sets of fields to
+// support nested structures in both form data and JSON bodies.
+//
+// swagger:ignore
+type PushSubscriptionUpdateRequest struct {
+	Data                   *PushSubscriptionRequestData `json:"data"`
+	FormAlertFollow        bool                         `form:"data[alerts][follow]"`
+	FormAlertFollowRequest bool                         `form:"data[alerts][follow_request]"`
+	FormAlertFavourite     bool                         `form:"data[alerts][favourite]"`
+	FormAlertMention       bool                         `form:"data[alerts][mention]"`
+	FormAlertReblog        bool                         `form:"data[alerts][reblog]"`
+	FormAlertPoll          bool                         `form:"data[alerts][poll]"`
+	FormAlertStatus        bool                         `form:"data[alerts][status]"`
+	FormPolicy             string                       `form:"data[policy]"`
+}
+
+type PushSubscriptionRequestData struct {
+	// Which alerts should be delivered to the endpoint.
+	Alerts *PushSubscriptionAlerts `json:"alerts"`
+	// Whose actions should trigger alerts.
+	Policy string `json:"policy"`
+}
+
+// Alerts should be used instead of Data or the FormAlert fields.
+func (r *PushSubscriptionUpdateRequest) Alerts() PushSubscriptionAlerts {
+	if r.Data != nil {
+		if r.Data.Alerts != nil {
+			return *r.Data.Alerts
+		}
+		return PushSubscriptionAlerts{}
+	}
+	return PushSubscriptionAlerts{
+		Follow:        r.FormAlertFollow,
+		FollowRequest: r.FormAlertFollowRequest,
+		Favourite:     r.FormAlertFavourite,
+		Mention:       r.FormAlertMention,
+		Reblog:        r.FormAlertReblog,
+		Poll:          r.FormAlertPoll,
+		Status:        r.FormAlertStatus,
+	}
+}
+
+// Policy should be used instead of Data or FormPolicy.
+func (r *PushSubscriptionUpdateRequest) Policy() string {
+	if r.Data != nil {
+		return r.Data.Policy
+	}
+	return r.FormPolicy
 }
@@ -100,6 +100,11 @@ type Status struct {
 	// The poll attached to the status.
 	// nullable: true
 	Poll *Poll `json:"poll"`
+	// The status that this status quotes, if any. Only the ID of the
+	// quoted status is included, as with Mastodon's "ShallowQuote";
+	// the quoted status itself can be fetched separately.
+	// Omitted if this status is not a quote.
+	Quote *StatusQuote `json:"quote,omitempty"`
 	// Plain-text source of a status. Returned instead of content when status is deleted,
 	// so the user may redraft from the source text without the client having to reverse-engineer
 	// the original text from the HTML content.
@@ -165,6 +170,31 @@ type StatusReblogged struct {
 	*Status
 }
 
+// StatusQuote models the status quoted by a status.
+//
+// swagger:model statusQuote
+type StatusQuote struct {
+	weaver.AutoMarshal
+	// State of the quote.
+	// One of "accepted" (quoted status is visible to the
+	// requester), "unauthorized" (quoted status exists but
+	// is not visible to the requester), or "deleted" (quoted
+	// status is not known to this instance, or is gone).
+	// example: accepted
+	State string `json:"state"`
+	// ID of the quoted status, if state is "accepted".
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	// nullable: true
+	QuotedStatusID *string `json:"quoted_status_id"`
+}
+
+// Possible states of a StatusQuote.
+const (
+	StatusQuoteStateAccepted     = "accepted"
+	StatusQuoteStateUnauthorized = "unauthorized"
+	StatusQuoteStateDeleted      = "deleted"
+)
+
 // StatusCreateRequest models status creation parameters.
 //
 // swagger:model statusCreateRequest
@@ -189,6 +219,9 @@ type StatusCreateRequest struct {
 	// ID of the status being replied to, if status is a reply.
 	// in: formData
 	InReplyToID string `form:"in_reply_to_id" json:"in_reply_to_id" xml:"in_reply_to_id"`
+	// ID of a public or unlisted status to quote.
+	// in: formData
+	QuoteID string `form:"quote_id" json:"quote_id" xml:"quote_id"`
 	// Status and attached media should be marked as sensitive.
 	// in: formData
 	Sensitive bool `form:"sensitive" json:"sensitive" xml:"sensitive"`
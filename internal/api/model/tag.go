@@ -34,4 +34,7 @@ type Tag struct {
 	// Currently just a stub, if provided will always be an empty array.
 	// example: []
 	//History *[]any `json:"history,omitempty"`
+	// Whether the requesting account follows this hashtag.
+	// Only set when the tag is returned from a tags or followed_tags endpoint.
+	Following *bool `json:"following,omitempty"`
 }
@@ -1065,6 +1065,7 @@ type __is_Status[T ~struct {
 	Emojis             []Emoji            "json:\"emojis\""
 	Card               *Card              "json:\"card\""
 	Poll               *Poll              "json:\"poll\""
+	Quote              *StatusQuote       "json:\"quote,omitempty\""
 	Text               string             "json:\"text,omitempty\""
 	LanguageTag        *language.Language "json:\"-\""
 	WebPollOptions     []WebPollOption    "json:\"-\""
@@ -1104,6 +1105,7 @@ func (x *Status) WeaverMarshal(enc *codegen.Encoder) {
 	serviceweaver_enc_slice_Emoji_ecf5fef1(enc, x.Emojis)
 	serviceweaver_enc_ptr_Card_4b08a3fe(enc, x.Card)
 	serviceweaver_enc_ptr_Poll_4b9a13e1(enc, x.Poll)
+	serviceweaver_enc_ptr_StatusQuote_c9fcf668(enc, x.Quote)
 	enc.String(x.Text)
 	serviceweaver_enc_ptr_Language_32b7d5e1(enc, x.LanguageTag)
 	serviceweaver_enc_slice_WebPollOption_ccc49646(enc, x.WebPollOptions)
@@ -1141,6 +1143,7 @@ func (x *Status) WeaverUnmarshal(dec *codegen.Decoder) {
 	x.Emojis = serviceweaver_dec_slice_Emoji_ecf5fef1(dec)
 	x.Card = serviceweaver_dec_ptr_Card_4b08a3fe(dec)
 	x.Poll = serviceweaver_dec_ptr_Poll_4b9a13e1(dec)
+	x.Quote = serviceweaver_dec_ptr_StatusQuote_c9fcf668(dec)
 	x.Text = dec.String()
 	x.LanguageTag = serviceweaver_dec_ptr_Language_32b7d5e1(dec)
 	x.WebPollOptions = serviceweaver_dec_slice_WebPollOption_ccc49646(dec)
@@ -1306,6 +1309,24 @@ func serviceweaver_dec_ptr_Poll_4b9a13e1(dec *codegen.Decoder) *Poll {
 	return &res
 }
 
+func serviceweaver_enc_ptr_StatusQuote_c9fcf668(enc *codegen.Encoder, arg *StatusQuote) {
+	if arg == nil {
+		enc.Bool(false)
+	} else {
+		enc.Bool(true)
+		(*arg).WeaverMarshal(enc)
+	}
+}
+
+func serviceweaver_dec_ptr_StatusQuote_c9fcf668(dec *codegen.Decoder) *StatusQuote {
+	if !dec.Bool() {
+		return nil
+	}
+	var res StatusQuote
+	(&res).WeaverUnmarshal(dec)
+	return &res
+}
+
 func serviceweaver_enc_ptr_Language_32b7d5e1(enc *codegen.Encoder, arg *language.Language) {
 	if arg == nil {
 		enc.Bool(false)
@@ -1355,6 +1376,7 @@ type __is_StatusCreateRequest[T ~struct {
 	MediaIDs    []string          "form:\"media_ids[]\" json:\"media_ids\" xml:\"media_ids\""
 	Poll        *PollRequest      "form:\"poll\" json:\"poll\" xml:\"poll\""
 	InReplyToID string            "form:\"in_reply_to_id\" json:\"in_reply_to_id\" xml:\"in_reply_to_id\""
+	QuoteID     string            "form:\"quote_id\" json:\"quote_id\" xml:\"quote_id\""
 	Sensitive   bool              "form:\"sensitive\" json:\"sensitive\" xml:\"sensitive\""
 	SpoilerText string            "form:\"spoiler_text\" json:\"spoiler_text\" xml:\"spoiler_text\""
 	Visibility  Visibility        "form:\"visibility\" json:\"visibility\" xml:\"visibility\""
@@ -1373,6 +1395,7 @@ func (x *StatusCreateRequest) WeaverMarshal(enc *codegen.Encoder) {
 	serviceweaver_enc_slice_string_4af10117(enc, x.MediaIDs)
 	serviceweaver_enc_ptr_PollRequest_294fb03d(enc, x.Poll)
 	enc.String(x.InReplyToID)
+	enc.String(x.QuoteID)
 	enc.Bool(x.Sensitive)
 	enc.String(x.SpoilerText)
 	enc.String((string)(x.Visibility))
@@ -1389,6 +1412,7 @@ func (x *StatusCreateRequest) WeaverUnmarshal(dec *codegen.Decoder) {
 	x.MediaIDs = serviceweaver_dec_slice_string_4af10117(dec)
 	x.Poll = serviceweaver_dec_ptr_PollRequest_294fb03d(dec)
 	x.InReplyToID = dec.String()
+	x.QuoteID = dec.String()
 	x.Sensitive = dec.Bool()
 	x.SpoilerText = dec.String()
 	*(*string)(&x.Visibility) = dec.String()
@@ -1415,12 +1439,39 @@ func serviceweaver_dec_ptr_PollRequest_294fb03d(dec *codegen.Decoder) *PollReque
 	return &res
 }
 
+var _ codegen.AutoMarshal = (*StatusQuote)(nil)
+
+type __is_StatusQuote[T ~struct {
+	weaver.AutoMarshal
+	State          string  "json:\"state\""
+	QuotedStatusID *string "json:\"quoted_status_id\""
+}] struct{}
+
+var _ __is_StatusQuote[StatusQuote]
+
+func (x *StatusQuote) WeaverMarshal(enc *codegen.Encoder) {
+	if x == nil {
+		panic(fmt.Errorf("StatusQuote.WeaverMarshal: nil receiver"))
+	}
+	enc.String(x.State)
+	serviceweaver_enc_ptr_string_3e89801b(enc, x.QuotedStatusID)
+}
+
+func (x *StatusQuote) WeaverUnmarshal(dec *codegen.Decoder) {
+	if x == nil {
+		panic(fmt.Errorf("StatusQuote.WeaverUnmarshal: nil receiver"))
+	}
+	x.State = dec.String()
+	x.QuotedStatusID = serviceweaver_dec_ptr_string_3e89801b(dec)
+}
+
 var _ codegen.AutoMarshal = (*Tag)(nil)
 
 type __is_Tag[T ~struct {
 	weaver.AutoMarshal
-	Name string "json:\"name\""
-	URL  string "json:\"url\""
+	Name      string "json:\"name\""
+	URL       string "json:\"url\""
+	Following *bool  "json:\"following,omitempty\""
 }] struct{}
 
 var _ __is_Tag[Tag]
@@ -1431,6 +1482,7 @@ func (x *Tag) WeaverMarshal(enc *codegen.Encoder) {
 	}
 	enc.String(x.Name)
 	enc.String(x.URL)
+	serviceweaver_enc_ptr_bool_31f02903(enc, x.Following)
 }
 
 func (x *Tag) WeaverUnmarshal(dec *codegen.Decoder) {
@@ -1439,6 +1491,7 @@ func (x *Tag) WeaverUnmarshal(dec *codegen.Decoder) {
 	}
 	x.Name = dec.String()
 	x.URL = dec.String()
+	x.Following = serviceweaver_dec_ptr_bool_31f02903(dec)
 }
 
 var _ codegen.AutoMarshal = (*WebPollOption)(nil)
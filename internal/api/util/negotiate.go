@@ -83,6 +83,12 @@ var ActivityPubHeaders = []string{
 	AppActivityJSON,
 }
 
+// CSVHeaders just contains the text/csv
+// MIME type, used for import and export.
+var CSVHeaders = []string{
+	TextCSV,
+}
+
 var HostMetaHeaders = []string{
 	AppXMLXRD,
 	AppXML,
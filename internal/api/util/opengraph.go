@@ -46,6 +46,14 @@ type OGMeta struct {
 	ImageHeight string // og:image:height
 	ImageAlt    string // og:image:alt
 
+	// video + audio tags
+	Video       string // og:video
+	VideoType   string // og:video:type
+	VideoWidth  string // og:video:width
+	VideoHeight string // og:video:height
+	Audio       string // og:audio
+	AudioType   string // og:audio:type
+
 	// article tags
 	ArticlePublisher     string // article:publisher
 	ArticleAuthor        string // article:author
@@ -54,6 +62,11 @@ type OGMeta struct {
 
 	// profile tags
 	ProfileUsername string // profile:username
+
+	// twitter tags, for unfurlers that don't
+	// fall back to og tags. The rest of the
+	// twitter card is filled from og tags.
+	TwitterCard string // twitter:card
 }
 
 // OGBase returns an *ogMeta suitable for serving at
@@ -147,6 +160,63 @@ func (og *OGMeta) WithStatus(status *apimodel.Status) *OGMeta {
 	return og
 }
 
+// WithAttachment uses the given attachment to build an ogMeta
+// struct specific to that attachment. It's suitable for serving
+// at attachment preview pages.
+func (og *OGMeta) WithAttachment(attachment *apimodel.WebAttachment) *OGMeta {
+	if attachment.Status != nil {
+		// Start from the status,
+		// then specialize to this
+		// particular attachment.
+		og = og.WithStatus(attachment.Status)
+		og.Title = "Media from post by " + AccountTitle(attachment.Account, og.SiteName)
+	} else {
+		og = og.WithAccount(attachment.Account)
+		og.Title = "Media from " + AccountTitle(attachment.Account, og.SiteName)
+	}
+
+	og.URL = *attachment.URL
+
+	if attachment.Description != nil && *attachment.Description != "" {
+		og.Description = ParseDescription(*attachment.Description)
+	}
+
+	if attachment.Sensitive {
+		// Don't embed sensitive media,
+		// stick with the author avatar.
+		og.TwitterCard = "summary"
+		return og
+	}
+
+	og.Image = *attachment.PreviewURL
+	og.ImageAlt = ""
+	if attachment.Description != nil {
+		og.ImageAlt = *attachment.Description
+	}
+	og.TwitterCard = "summary_large_image"
+
+	if meta := attachment.Meta; meta != nil {
+		og.ImageWidth = strconv.Itoa(meta.Small.Width)
+		og.ImageHeight = strconv.Itoa(meta.Small.Height)
+	}
+
+	switch attachment.Type {
+	case "video", "gifv":
+		og.Video = *attachment.URL
+		og.VideoType = attachment.ContentType
+		if meta := attachment.Meta; meta != nil {
+			og.VideoWidth = strconv.Itoa(meta.Original.Width)
+			og.VideoHeight = strconv.Itoa(meta.Original.Height)
+		}
+
+	case "audio":
+		og.Audio = *attachment.URL
+		og.AudioType = attachment.ContentType
+	}
+
+	return og
+}
+
 // AccountTitle parses a page title from account and accountDomain
 func AccountTitle(account *apimodel.Account, accountDomain string) string {
 	user := "@" + account.Acct + "@" + accountDomain
@@ -21,6 +21,8 @@ import (
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/cache/headerfilter"
+	"github.com/superseriousbusiness/gotosocial/internal/cache/mediahash"
+	"github.com/superseriousbusiness/gotosocial/internal/cache/mediapolicy"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
@@ -37,6 +39,14 @@ type Caches struct {
 	// the block []headerfilter.Filter cache.
 	BlockHeaderFilters headerfilter.Cache
 
+	// MediaHashes provides access to
+	// the media hash blocklist cache.
+	MediaHashes mediahash.Cache
+
+	// MediaPolicies provides access to
+	// the per-domain media policy cache.
+	MediaPolicies mediapolicy.Cache
+
 	// Visibility provides access to the item visibility
 	// cache. (used by the visibility filter).
 	Visibility VisibilityCache
@@ -86,6 +96,11 @@ func (c *Caches) Init() {
 	c.initUser()
 	c.initWebfinger()
 	c.initVisibility()
+
+	// Drop any previously loaded
+	// media hashes and policies.
+	c.MediaHashes.Clear()
+	c.MediaPolicies.Clear()
 }
 
 // Start will start any caches that require a background
@@ -76,6 +76,7 @@ type GTSCaches struct {
 	// - 'l>' for local following IDs
 	// - '<'  for follower IDs
 	// - 'l<' for local follower IDs
+	// - 'x>' for following IDs in exclusive lists
 	FollowIDs *SliceCache[string]
 
 	// FollowRequest provides access to the gtsmodel FollowRequest database cache.
@@ -884,6 +885,7 @@ func (c *Caches) initStatus() {
 		s2.InReplyTo = nil
 		s2.InReplyToAccount = nil
 		s2.BoostOf = nil
+		s2.QuoteOf = nil
 		s2.BoostOfAccount = nil
 		s2.Poll = nil
 		s2.Attachments = nil
@@ -0,0 +1,143 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mediahash
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Cache provides a means of caching the media hash blocklist
+// in memory to reduce load on an underlying storage mechanism.
+type Cache struct {
+	// current cached hashes.
+	ptr atomic.Pointer[hashes]
+}
+
+// hashes is a loaded media hash
+// blocklist, indexed for matching.
+// A nil sha256 map marks it unloaded.
+type hashes struct {
+	sha256 map[string]*gtsmodel.MediaHash
+	phash  []phash
+}
+
+// phash is a parsed perceptual media hash.
+type phash struct {
+	value uint64
+	hash  *gtsmodel.MediaHash
+}
+
+// MatchSHA256 returns the cached SHA-256 media hash equal to given hex-encoded sum, loading using callback if necessary.
+func (c *Cache) MatchSHA256(sum string, load func() ([]*gtsmodel.MediaHash, error)) (*gtsmodel.MediaHash, error) {
+	ptr, err := c.load(load)
+	if err != nil {
+		return nil, err
+	}
+	return ptr.sha256[sum], nil
+}
+
+// MatchPHash returns the cached perceptual media hash nearest to given hash within maxDistance bits, loading using callback if necessary.
+func (c *Cache) MatchPHash(value uint64, maxDistance int, load func() ([]*gtsmodel.MediaHash, error)) (*gtsmodel.MediaHash, error) {
+	ptr, err := c.load(load)
+	if err != nil {
+		return nil, err
+	}
+
+	var match *gtsmodel.MediaHash
+
+	for _, p := range ptr.phash {
+		// Hamming distance, ie.,
+		// number of differing bits.
+		dist := bits.OnesCount64(value ^ p.value)
+
+		if dist <= maxDistance {
+			// Nearest so far, only
+			// look for nearer now.
+			match = p.hash
+			maxDistance = dist - 1
+		}
+	}
+
+	return match, nil
+}
+
+// Clear will drop the currently loaded hashes,
+// triggering a reload on next call to .Match_().
+func (c *Cache) Clear() {
+	// Store a new unloaded value rather than nil, so
+	// any load already in progress fails to swap in
+	// the (now stale) hashes it loaded, see .load().
+	c.ptr.Store(new(hashes))
+}
+
+// load returns the currently loaded hashes,
+// hydrating the cache from callback if needed.
+func (c *Cache) load(load func() ([]*gtsmodel.MediaHash, error)) (*hashes, error) {
+	// Load ptr value.
+	old := c.ptr.Load()
+
+	if old != nil && old.sha256 != nil {
+		// Cache is hydrated.
+		return old, nil
+	}
+
+	// Load hashes from callback.
+	ptr, err := loadHashes(load)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only store the new media hashes if the cache
+	// wasn't cleared while loading, else they may be
+	// missing changes made since we began the load.
+	c.ptr.CompareAndSwap(old, ptr)
+
+	return ptr, nil
+}
+
+// loadHashes will load media hashes from given load callback, indexing them by type.
+func loadHashes(load func() ([]*gtsmodel.MediaHash, error)) (*hashes, error) {
+	// Load hashes from callback.
+	mediaHashes, err := load()
+	if err != nil {
+		return nil, fmt.Errorf("error reloading cache: %w", err)
+	}
+
+	ptr := &hashes{sha256: make(map[string]*gtsmodel.MediaHash)}
+
+	for _, hash := range mediaHashes {
+		switch hash.Type {
+		case gtsmodel.MediaHashTypeSHA256:
+			ptr.sha256[hash.Hash] = hash
+
+		case gtsmodel.MediaHashTypePHash:
+			value, err := strconv.ParseUint(hash.Hash, 16, 64)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing perceptual hash %s: %w", hash.ID, err)
+			}
+			ptr.phash = append(ptr.phash, phash{value, hash})
+		}
+	}
+
+	return ptr, nil
+}
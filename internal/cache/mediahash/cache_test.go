@@ -0,0 +1,79 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mediahash_test
+
+import (
+	"testing"
+
+	"github.com/superseriousbusiness/gotosocial/internal/cache/mediahash"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func TestCacheClearDuringLoad(t *testing.T) {
+	c := new(mediahash.Cache)
+
+	hash := &gtsmodel.MediaHash{
+		ID:   "01HT0Q7ZC5J6D8N4Q1VZ4K0GQ3",
+		Type: gtsmodel.MediaHashTypeSHA256,
+		Hash: "5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03",
+	}
+
+	// Loader that returns a stale, empty hash list,
+	// with the list being changed (and so the cache
+	// cleared) while the load is still in progress.
+	stale := func() ([]*gtsmodel.MediaHash, error) {
+		t.Log("load: returning stale hashes")
+		c.Clear()
+		return nil, nil
+	}
+
+	if match, err := c.MatchSHA256(hash.Hash, stale); err != nil {
+		t.Fatalf("error matching: %v", err)
+	} else if match != nil {
+		t.Fatalf("unexpected match with stale hashes: %+v", match)
+	}
+
+	// The stale hashes shouldn't have been
+	// stored, so the next match should load.
+	loaded := false
+	current := func() ([]*gtsmodel.MediaHash, error) {
+		t.Log("load: returning current hashes")
+		loaded = true
+		return []*gtsmodel.MediaHash{hash}, nil
+	}
+
+	if match, err := c.MatchSHA256(hash.Hash, current); err != nil {
+		t.Fatalf("error matching: %v", err)
+	} else if !loaded {
+		t.Fatal("expected cache to reload after clear during load")
+	} else if match != hash {
+		t.Fatalf("expected match %+v, got %+v", hash, match)
+	}
+
+	// Now loaded, so shouldn't load again.
+	failing := func() ([]*gtsmodel.MediaHash, error) {
+		t.Fatal("unexpected reload of hydrated cache")
+		return nil, nil
+	}
+
+	if match, err := c.MatchSHA256(hash.Hash, failing); err != nil {
+		t.Fatalf("error matching: %v", err)
+	} else if match != hash {
+		t.Fatalf("expected match %+v, got %+v", hash, match)
+	}
+}
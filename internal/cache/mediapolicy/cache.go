@@ -0,0 +1,87 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mediapolicy
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Cache provides a means of caching per-domain media
+// policies in memory to reduce load on an underlying
+// storage mechanism, eg. a database.
+type Cache struct {
+	// current cached policies,
+	// indexed by domain.
+	ptr atomic.Pointer[map[string]*gtsmodel.DomainMediaPolicy]
+}
+
+// Match returns the cached media policy for given domain, ie. the policy
+// for the domain itself or else for its nearest parent domain, or nil if
+// there is none. The cache is loaded from callback if necessary.
+func (c *Cache) Match(domain string, load func() ([]*gtsmodel.DomainMediaPolicy, error)) (*gtsmodel.DomainMediaPolicy, error) {
+	// Load ptr value.
+	ptr := c.ptr.Load()
+
+	if ptr == nil || *ptr == nil {
+		// Cache is not hydrated.
+		// Load policies from callback.
+		policies, err := load()
+		if err != nil {
+			return nil, fmt.Errorf("error reloading cache: %w", err)
+		}
+
+		// Index policies by domain.
+		m := make(map[string]*gtsmodel.DomainMediaPolicy, len(policies))
+		for _, policy := range policies {
+			m[policy.Domain] = policy
+		}
+
+		// Only store the new loaded policies if the
+		// cache wasn't cleared while loading, else
+		// they may be missing changes made since.
+		old := ptr
+		ptr = &m
+		c.ptr.CompareAndSwap(old, ptr)
+	}
+
+	for domain != "" {
+		if policy, ok := (*ptr)[domain]; ok {
+			return policy, nil
+		}
+
+		// Move on to the parent domain,
+		// eg. a.example.org -> example.org.
+		_, domain, _ = strings.Cut(domain, ".")
+	}
+
+	return nil, nil
+}
+
+// Clear will drop the currently loaded policies,
+// triggering a reload on next call to .Match().
+func (c *Cache) Clear() {
+	// Store a new (nil) unloaded map rather than
+	// nil, so any load already in progress fails
+	// to swap in the policies it loaded.
+	var m map[string]*gtsmodel.DomainMediaPolicy
+	c.ptr.Store(&m)
+}
@@ -228,6 +228,7 @@ func sizeofAccount() uintptr {
 		FollowersURI:            exampleURI,
 		FollowingURI:            exampleURI,
 		FeaturedCollectionURI:   exampleURI,
+		FeaturedTagsURI:         exampleURI,
 		ActorType:               ap.ActorPerson,
 		PrivateKey:              gtsmodel.PrivateKey{},
 		PublicKey:               gtsmodel.PublicKey{},
@@ -358,6 +359,7 @@ func sizeofList() uintptr {
 		Title:         exampleTextSmall,
 		AccountID:     exampleID,
 		RepliesPolicy: gtsmodel.RepliesPolicyFollowed,
+		Exclusive:     func() *bool { ok := false; return &ok }(),
 	}))
 }
 
@@ -406,9 +408,10 @@ func sizeofMedia() uintptr {
 			URL:         exampleURI,
 			RemoteURL:   exampleURI,
 		},
-		Avatar: func() *bool { ok := false; return &ok }(),
-		Header: func() *bool { ok := false; return &ok }(),
-		Cached: func() *bool { ok := true; return &ok }(),
+		Avatar:      func() *bool { ok := false; return &ok }(),
+		Header:      func() *bool { ok := false; return &ok }(),
+		Cached:      func() *bool { ok := true; return &ok }(),
+		Quarantined: func() *bool { ok := false; return &ok }(),
 	}))
 }
 
@@ -499,6 +502,8 @@ func sizeofStatus() uintptr {
 		InReplyToAccountID:       exampleID,
 		BoostOfID:                exampleID,
 		BoostOfAccountID:         exampleID,
+		QuoteOfID:                exampleID,
+		QuoteOfURI:               exampleURI,
 		ContentWarning:           exampleUsername, // similar length
 		Visibility:               gtsmodel.VisibilityPublic,
 		Sensitive:                func() *bool { ok := false; return &ok }(),
@@ -573,6 +578,7 @@ func sizeofUser() uintptr {
 		EncryptedPassword:      exampleTextSmall,
 		CurrentSignInAt:        exampleTime,
 		LastSignInAt:           exampleTime,
+		LastActiveAt:           exampleTime,
 		InviteID:               exampleID,
 		ChosenLanguages:        []string{"en", "fr", "jp"},
 		FilteredLanguages:      []string{"en", "fr", "jp"},
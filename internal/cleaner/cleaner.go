@@ -24,9 +24,12 @@ import (
 
 	"codeberg.org/gruf/go-store/v2/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 )
 
@@ -36,6 +39,7 @@ const (
 
 type Cleaner struct {
 	state *state.State
+	mgr   *media.Manager
 	emoji Emoji
 	media Media
 }
@@ -43,6 +47,7 @@ type Cleaner struct {
 func New(state *state.State) *Cleaner {
 	c := new(Cleaner)
 	c.state = state
+	c.mgr = media.NewManager(state)
 	c.emoji.Cleaner = c
 	c.media.Cleaner = c
 	return c
@@ -106,6 +111,74 @@ func (c *Cleaner) removeFiles(ctx context.Context, files ...string) (int, error)
 	return diff, nil
 }
 
+// domainCutoff returns the time before which cached remote media from domain
+// should be uncached, according to the cache days of any matching media policy
+// for the domain, else falling back to the given default cutoff olderThan.
+func (c *Cleaner) domainCutoff(ctx context.Context, domain string, olderThan time.Time) (time.Time, error) {
+	policy, err := c.state.DB.MatchDomainMediaPolicy(ctx, domain)
+	if err != nil {
+		return time.Time{}, gtserror.Newf("error matching media policy for %s: %w", domain, err)
+	}
+
+	if policy == nil ||
+		policy.Policy != gtsmodel.MediaPolicyCache ||
+		policy.CacheDays == nil {
+		// No retention
+		// override set.
+		return olderThan, nil
+	}
+
+	return cacheDaysCutoff(*policy.CacheDays), nil
+}
+
+// latestCutoff returns the latest of the given default cutoff olderThan,
+// and the cutoffs of all media policies setting their own cache days.
+func (c *Cleaner) latestCutoff(ctx context.Context, olderThan time.Time) (time.Time, error) {
+	policies, err := c.state.DB.GetDomainMediaPolicies(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return time.Time{}, gtserror.Newf("error getting media policies: %w", err)
+	}
+
+	for _, policy := range policies {
+		if policy.Policy != gtsmodel.MediaPolicyCache ||
+			policy.CacheDays == nil {
+			continue
+		}
+
+		cutoff := cacheDaysCutoff(*policy.CacheDays)
+		if cutoff.After(olderThan) {
+			olderThan = cutoff
+		}
+	}
+
+	return olderThan, nil
+}
+
+// uncachedDomains returns the domains of all media
+// policies for which remote media shouldn't be cached.
+func (c *Cleaner) uncachedDomains(ctx context.Context) ([]string, error) {
+	policies, err := c.state.DB.GetDomainMediaPolicies(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error getting media policies: %w", err)
+	}
+
+	var domains []string
+	for _, policy := range policies {
+		if !policy.Caches() {
+			domains = append(domains, policy.Domain)
+		}
+	}
+
+	return domains, nil
+}
+
+// cacheDaysCutoff converts media policy cache days to a cutoff
+// time, dropping it by a minute as in UncacheRemote functions.
+func cacheDaysCutoff(days int) time.Time {
+	t := time.Now().Add(-24 * time.Hour * time.Duration(days))
+	return t.Add(-time.Minute)
+}
+
 // ScheduleJobs schedules cleaning
 // jobs using configured parameters.
 //
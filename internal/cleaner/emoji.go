@@ -41,6 +41,7 @@ type Emoji struct {
 func (e *Emoji) All(ctx context.Context, maxRemoteDays int) {
 	t := time.Now().Add(-24 * time.Hour * time.Duration(maxRemoteDays))
 	e.LogUncacheRemote(ctx, t)
+	e.LogUncachePolicies(ctx)
 	e.LogFixBroken(ctx)
 	e.LogPruneUnused(ctx)
 	e.LogFixCacheStates(ctx)
@@ -57,6 +58,26 @@ func (e *Emoji) LogUncacheRemote(ctx context.Context, olderThan time.Time) {
 	}
 }
 
+// LogUncachePolicies performs Emoji.UncachePolicies(...), logging the start and outcome.
+func (e *Emoji) LogUncachePolicies(ctx context.Context) {
+	log.Info(ctx, "start")
+	if n, err := e.UncachePolicies(ctx); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "uncached: %d", n)
+	}
+}
+
+// LogUncacheDomain performs Emoji.UncacheDomain(...), logging the start and outcome.
+func (e *Emoji) LogUncacheDomain(ctx context.Context, domain string) {
+	log.Infof(ctx, "start domain: %s", domain)
+	if n, err := e.UncacheDomain(ctx, domain); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "uncached: %d", n)
+	}
+}
+
 // LogFixBroken performs Emoji.FixBroken(...), logging the start and outcome.
 func (e *Emoji) LogFixBroken(ctx context.Context) {
 	log.Info(ctx, "start")
@@ -87,7 +108,8 @@ func (e *Emoji) LogFixCacheStates(ctx context.Context) {
 	}
 }
 
-// UncacheRemote will uncache all remote emoji older than given input time. Context
+// UncacheRemote will uncache all remote emoji older than given input time, or older
+// than the cache days of the media policy for their domain, if one is set. Context
 // will be checked for `gtscontext.DryRun()` in order to actually perform the action.
 func (e *Emoji) UncacheRemote(ctx context.Context, olderThan time.Time) (int, error) {
 	var total int
@@ -99,6 +121,13 @@ func (e *Emoji) UncacheRemote(ctx context.Context, olderThan time.Time) (int, er
 	// Store recent time.
 	mostRecent := olderThan
 
+	// Media policies may keep emoji from some domains
+	// for less time, so search from the latest cutoff.
+	olderThan, err := e.latestCutoff(ctx, olderThan)
+	if err != nil {
+		return total, err
+	}
+
 	for {
 		// Fetch the next batch of cached emojis older than last-set time.
 		emojis, err := e.state.DB.GetCachedEmojisOlderThan(ctx, olderThan, selectLimit)
@@ -116,9 +145,24 @@ func (e *Emoji) UncacheRemote(ctx context.Context, olderThan time.Time) (int, er
 		olderThan = emojis[len(emojis)-1].CreatedAt
 
 		for _, emoji := range emojis {
+			// Get the cutoff for this emoji's domain.
+			after, err := e.domainCutoff(ctx,
+				emoji.Domain,
+				mostRecent,
+			)
+			if err != nil {
+				return total, err
+			}
+
+			if !emoji.CreatedAt.Before(after) {
+				// Still within
+				// cache period.
+				continue
+			}
+
 			// Check / uncache each remote emoji.
 			uncached, err := e.uncacheRemote(ctx,
-				mostRecent,
+				after,
 				emoji,
 			)
 			if err != nil {
@@ -136,6 +180,67 @@ func (e *Emoji) UncacheRemote(ctx context.Context, olderThan time.Time) (int, er
 	return total, nil
 }
 
+// UncacheDomain will uncache all remote emoji from the given domain (or its subdomains),
+// regardless of age. Context will be checked for `gtscontext.DryRun()` in order to
+// actually perform the action.
+func (e *Emoji) UncacheDomain(ctx context.Context, domain string) (int, error) {
+	var (
+		total int
+		maxID string
+	)
+
+	for {
+		// Fetch the next batch of cached emojis from domain, below last-set max ID.
+		emojis, err := e.state.DB.GetCachedEmojisByDomain(ctx, domain, maxID, selectLimit)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return total, gtserror.Newf("error getting remote emoji: %w", err)
+		}
+
+		// If no emojis are returned, we reached the end.
+		if len(emojis) == 0 {
+			break
+		}
+
+		// Use last ID as the next 'maxID' value.
+		maxID = emojis[len(emojis)-1].ID
+
+		for _, emoji := range emojis {
+			// Uncache each remote emoji.
+			if err := e.uncache(ctx, emoji); err != nil {
+				return total, err
+			}
+
+			// Update
+			// count.
+			total++
+		}
+	}
+
+	return total, nil
+}
+
+// UncachePolicies will uncache all remote emoji from domains whose media policy
+// says not to cache their media, eg. emoji cached before the policy was set. Context
+// will be checked for `gtscontext.DryRun()` in order to actually perform the action.
+func (e *Emoji) UncachePolicies(ctx context.Context) (int, error) {
+	var total int
+
+	domains, err := e.uncachedDomains(ctx)
+	if err != nil {
+		return total, err
+	}
+
+	for _, domain := range domains {
+		n, err := e.UncacheDomain(ctx, domain)
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+
+	return total, nil
+}
+
 // FixBroken will check all emojis for valid related models (e.g. category).
 // Broken media will be automatically updated to remove now-missing models.
 // Context will be checked for `gtscontext.DryRun()` to perform the action.
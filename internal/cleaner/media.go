@@ -20,6 +20,9 @@ package cleaner
 import (
 	"context"
 	"errors"
+	"path"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -44,6 +47,7 @@ type Media struct {
 func (m *Media) All(ctx context.Context, maxRemoteDays int) {
 	t := time.Now().Add(-24 * time.Hour * time.Duration(maxRemoteDays))
 	m.LogUncacheRemote(ctx, t)
+	m.LogUncachePolicies(ctx)
 	m.LogPruneOrphaned(ctx)
 	m.LogPruneUnused(ctx)
 	m.LogFixCacheStates(ctx)
@@ -60,6 +64,26 @@ func (m *Media) LogUncacheRemote(ctx context.Context, olderThan time.Time) {
 	}
 }
 
+// LogUncachePolicies performs Media.UncachePolicies(...), logging the start and outcome.
+func (m *Media) LogUncachePolicies(ctx context.Context) {
+	log.Info(ctx, "start")
+	if n, err := m.UncachePolicies(ctx); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "uncached: %d", n)
+	}
+}
+
+// LogUncacheDomain performs Media.UncacheDomain(...), logging the start and outcome.
+func (m *Media) LogUncacheDomain(ctx context.Context, domain string) {
+	log.Infof(ctx, "start domain: %s", domain)
+	if n, err := m.UncacheDomain(ctx, domain); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "uncached: %d", n)
+	}
+}
+
 // LogPruneOrphaned performs Media.PruneOrphaned(...), logging the start and outcome.
 func (m *Media) LogPruneOrphaned(ctx context.Context) {
 	log.Info(ctx, "start")
@@ -80,6 +104,16 @@ func (m *Media) LogPruneUnused(ctx context.Context) {
 	}
 }
 
+// LogDedupe performs Media.Dedupe(...), logging the start and outcome.
+func (m *Media) LogDedupe(ctx context.Context) {
+	log.Info(ctx, "start")
+	if n, err := m.Dedupe(ctx); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "deduped: %d", n)
+	}
+}
+
 // LogFixCacheStates performs Media.FixCacheStates(...), logging the start and outcome.
 func (m *Media) LogFixCacheStates(ctx context.Context) {
 	log.Info(ctx, "start")
@@ -95,16 +129,28 @@ func (m *Media) LogFixCacheStates(ctx context.Context) {
 func (m *Media) PruneOrphaned(ctx context.Context) (int, error) {
 	var files []string
 
-	// All media files in storage will have path fitting: {$account}/{$type}/{$size}/{$id}.{$ext}
+	// All media files in storage will have path fitting: {$account}/{$type}/{$size}/{$id}.{$ext},
+	// except for content-addressed media blobs which will have path fitting: blobs/{$prefix}/{$hash}.{$ext}
 	if err := m.state.Storage.WalkKeys(ctx, func(ctx context.Context, path string) error {
-		// Check for our expected fileserver path format.
-		if !regexes.FilePath.MatchString(path) {
+		var (
+			orphaned bool
+			err      error
+		)
+
+		// Check for our expected fileserver path formats.
+		switch {
+		case regexes.FilePath.MatchString(path):
+			orphaned, err = m.isOrphaned(ctx, path)
+
+		case regexes.BlobPath.MatchString(path):
+			orphaned, err = m.isOrphanedBlob(ctx, path)
+
+		default:
 			log.Warn(ctx, "unexpected storage item: %s", path)
 			return nil
 		}
 
 		// Check whether this entry is orphaned.
-		orphaned, err := m.isOrphaned(ctx, path)
 		if err != nil {
 			return gtserror.Newf("error checking orphaned status: %w", err)
 		}
@@ -172,7 +218,8 @@ func (m *Media) PruneUnused(ctx context.Context) (int, error) {
 	return total, nil
 }
 
-// UncacheRemote will uncache all remote media attachments older than given input time.
+// UncacheRemote will uncache all remote media attachments older than given input time,
+// or older than the cache days of the media policy for their domain, if one is set.
 // Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
 func (m *Media) UncacheRemote(ctx context.Context, olderThan time.Time) (int, error) {
 	var total int
@@ -184,6 +231,13 @@ func (m *Media) UncacheRemote(ctx context.Context, olderThan time.Time) (int, er
 	// Store recent time.
 	mostRecent := olderThan
 
+	// Media policies may keep media from some domains
+	// for less time, so search from the latest cutoff.
+	olderThan, err := m.latestCutoff(ctx, olderThan)
+	if err != nil {
+		return total, err
+	}
+
 	for {
 		// Fetch the next batch of cached attachments older than last-set time.
 		attachments, err := m.state.DB.GetCachedAttachmentsOlderThan(ctx, olderThan, selectLimit)
@@ -201,8 +255,20 @@ func (m *Media) UncacheRemote(ctx context.Context, olderThan time.Time) (int, er
 		olderThan = attachments[len(attachments)-1].CreatedAt
 
 		for _, media := range attachments {
+			// Get the cutoff for this media's domain.
+			after, err := m.mediaCutoff(ctx, mostRecent, media)
+			if err != nil {
+				return total, err
+			}
+
+			if !media.CreatedAt.Before(after) {
+				// Still within
+				// cache period.
+				continue
+			}
+
 			// Check / uncache each remote media attachment.
-			uncached, err := m.uncacheRemote(ctx, mostRecent, media)
+			uncached, err := m.uncacheRemote(ctx, after, media)
 			if err != nil {
 				return total, err
 			}
@@ -218,6 +284,117 @@ func (m *Media) UncacheRemote(ctx context.Context, olderThan time.Time) (int, er
 	return total, nil
 }
 
+// UncacheDomain will uncache all remote media attachments owned by accounts on the given
+// domain (or its subdomains), regardless of age. Context will be checked for
+// `gtscontext.DryRun()` in order to actually perform the action.
+func (m *Media) UncacheDomain(ctx context.Context, domain string) (int, error) {
+	var (
+		total int
+		maxID string
+	)
+
+	for {
+		// Fetch the next batch of cached attachments from domain, below last-set max ID.
+		attachments, err := m.state.DB.GetCachedAttachmentsByDomain(ctx, domain, maxID, selectLimit)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return total, gtserror.Newf("error getting remote attachments: %w", err)
+		}
+
+		// If no attachments are returned, we reached the end.
+		if len(attachments) == 0 {
+			break
+		}
+
+		// Use last ID as the next 'maxID' value.
+		maxID = attachments[len(attachments)-1].ID
+
+		for _, media := range attachments {
+			// Uncache each remote media attachment.
+			if err := m.uncache(ctx, media); err != nil {
+				return total, err
+			}
+
+			// Update
+			// count.
+			total++
+		}
+	}
+
+	return total, nil
+}
+
+// UncachePolicies will uncache all remote media attachments from domains whose media
+// policy says not to cache their media, eg. media cached before the policy was set.
+// Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
+func (m *Media) UncachePolicies(ctx context.Context) (int, error) {
+	var total int
+
+	domains, err := m.uncachedDomains(ctx)
+	if err != nil {
+		return total, err
+	}
+
+	for _, domain := range domains {
+		n, err := m.UncacheDomain(ctx, domain)
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+
+	return total, nil
+}
+
+// Dedupe will move all cached media files not yet stored as content-addressed blobs into
+// blob storage, so that identical files are only stored once. This only needs to be run
+// once, to migrate media that was stored before deduplication was introduced.
+// Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
+func (m *Media) Dedupe(ctx context.Context) (int, error) {
+	var (
+		total int
+		page  paging.Page
+	)
+
+	// Set page select limit.
+	page.Limit = selectLimit
+
+	for {
+		// Fetch the next batch of media attachments to next maxID.
+		attachments, err := m.state.DB.GetAttachments(ctx, &page)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return total, gtserror.Newf("error getting attachments: %w", err)
+		}
+
+		// Get current max ID.
+		maxID := page.Max.Value
+
+		// If no attachments or the same group is returned, we reached the end.
+		if len(attachments) == 0 || maxID == attachments[len(attachments)-1].ID {
+			break
+		}
+
+		// Use last ID as the next 'maxID' value.
+		maxID = attachments[len(attachments)-1].ID
+		page.Max = paging.MaxID(maxID)
+
+		for _, media := range attachments {
+			// Check / dedupe media attachment files.
+			deduped, err := m.dedupe(ctx, media)
+			if err != nil {
+				return total, err
+			}
+
+			if deduped {
+				// Update
+				// count.
+				total++
+			}
+		}
+	}
+
+	return total, nil
+}
+
 // FixCacheStatus will check all media for up-to-date cache status (i.e. in storage driver).
 // Media marked as cached, with any required files missing, will be automatically uncached.
 // Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
@@ -333,6 +510,21 @@ func (m *Media) isOrphaned(ctx context.Context, path string) (bool, error) {
 	return false, nil
 }
 
+func (m *Media) isOrphanedBlob(ctx context.Context, path string) (bool, error) {
+	// Look for blob in database stored by path.
+	blob, err := m.state.DB.GetMediaBlobByPath(ctx, path)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, gtserror.Newf("error fetching media blob %s: %w", path, err)
+	}
+
+	if blob == nil {
+		log.Debugf(ctx, "missing db entry for media blob %s", path)
+		return true, nil
+	}
+
+	return false, nil
+}
+
 func (m *Media) pruneUnused(ctx context.Context, media *gtsmodel.MediaAttachment) (bool, error) {
 	// Start a log entry for media.
 	l := log.WithContext(ctx).
@@ -404,13 +596,31 @@ func (m *Media) fixCacheState(ctx context.Context, media *gtsmodel.MediaAttachme
 		return false, nil
 	}
 
-	// Check whether files exist.
-	exist, err := m.haveFiles(ctx,
+	files := []string{
 		media.Thumbnail.Path,
 		media.File.Path,
-	)
-	if err != nil {
-		return false, err
+	}
+
+	if media.File.OriginalPath != "" {
+		// Kept original of converted media.
+		files = append(files, media.File.OriginalPath)
+	}
+
+	if !*media.Cached {
+		// Uncached media holds no references to content-addressed
+		// blobs, which may still exist as they're in use by other
+		// media. So only check for leftover non-blob files.
+		files = slices.DeleteFunc(files, regexes.BlobPath.MatchString)
+	}
+
+	// Check whether files exist.
+	exist := false
+	if len(files) > 0 {
+		var err error
+		exist, err = m.haveFiles(ctx, files...)
+		if err != nil {
+			return false, err
+		}
 	}
 
 	switch {
@@ -422,10 +632,7 @@ func (m *Media) fixCacheState(ctx context.Context, media *gtsmodel.MediaAttachme
 	case !*media.Cached && exist:
 		// Remove files if we don't expect them to exist.
 		l.Debug("cached=false exists=true => deleting")
-		_, err := m.removeFiles(ctx,
-			media.Thumbnail.Path,
-			media.File.Path,
-		)
+		_, err := m.removeFiles(ctx, files...)
 		return true, err
 
 	default:
@@ -433,6 +640,119 @@ func (m *Media) fixCacheState(ctx context.Context, media *gtsmodel.MediaAttachme
 	}
 }
 
+func (m *Media) dedupe(ctx context.Context, media *gtsmodel.MediaAttachment) (bool, error) {
+	if !*media.Cached {
+		// Nothing stored.
+		return false, nil
+	}
+
+	// Start a log entry for media.
+	l := log.WithContext(ctx).
+		WithField("media", media.ID)
+
+	// Gather pointers to any file
+	// paths not yet stored as blobs.
+	var paths []*string
+	for _, path := range []*string{
+		&media.File.Path,
+		&media.File.OriginalPath,
+		&media.Thumbnail.Path,
+	} {
+		if *path != "" && !regexes.BlobPath.MatchString(*path) {
+			paths = append(paths, path)
+		}
+	}
+
+	if len(paths) == 0 {
+		// Already deduped.
+		return false, nil
+	}
+
+	// Check whether files exist.
+	for _, path := range paths {
+		have, err := m.state.Storage.Has(ctx, *path)
+		if err != nil {
+			return false, gtserror.Newf("error checking storage for %s: %w", *path, err)
+		} else if !have {
+			// FixCacheStates will take care of this case.
+			l.Debug("skipping due to missing files")
+			return false, nil
+		}
+	}
+
+	if gtscontext.DryRun(ctx) {
+		// Dry run, do nothing.
+		return true, nil
+	}
+
+	var (
+		oldPaths []string
+		blobs    []string
+	)
+
+	// releaseBlobs drops references to
+	// blobs acquired on a failed dedupe.
+	releaseBlobs := func() {
+		for _, blob := range blobs {
+			if err := m.mgr.ReleaseFile(ctx, blob); err != nil {
+				l.Errorf("error releasing blob: %v", err)
+			}
+		}
+	}
+
+	for _, path := range paths {
+		// Copy each file into blob storage.
+		blob, err := m.storeBlob(ctx, *path)
+		if err != nil {
+			releaseBlobs()
+			return false, err
+		}
+
+		oldPaths = append(oldPaths, *path)
+		blobs = append(blobs, blob)
+	}
+
+	// Point attachment at its new blobs.
+	for i, path := range paths {
+		*path = blobs[i]
+	}
+
+	l.Debug("moving media files to blob storage")
+	if err := m.state.DB.UpdateAttachment(ctx, media,
+		"file_path",
+		"file_original_path",
+		"thumbnail_path",
+	); err != nil {
+		releaseBlobs()
+		return false, gtserror.Newf("error updating media: %w", err)
+	}
+
+	// Files have now been moved,
+	// so remove the old copies.
+	_, err := m.removeFiles(ctx, oldPaths...)
+	return true, err
+}
+
+// storeBlob copies the file at given storage path into
+// content-addressed blob storage, returning blob path.
+func (m *Media) storeBlob(ctx context.Context, file string) (string, error) {
+	rc, err := m.state.Storage.GetStream(ctx, file)
+	if err != nil {
+		return "", gtserror.Newf("error opening %s: %w", file, err)
+	}
+	defer rc.Close()
+
+	// Keep the original file extension.
+	ext := strings.TrimPrefix(path.Ext(file), ".")
+
+	blob, _, err := m.mgr.StoreBlob(ctx, rc, ext)
+	if err != nil {
+		return "", gtserror.Newf("error storing %s as blob: %w", file, err)
+	}
+
+	return blob, nil
+}
+
 func (m *Media) uncacheRemote(ctx context.Context, after time.Time, media *gtsmodel.MediaAttachment) (bool, error) {
 	if !*media.Cached {
 		// Already uncached.
@@ -486,6 +806,21 @@ func (m *Media) uncacheRemote(ctx context.Context, after time.Time, media *gtsmo
 	return true, m.uncache(ctx, media)
 }
 
+// mediaCutoff returns the time before which the given remote media should
+// be uncached, according to any media policy for the owning account's domain.
+func (m *Media) mediaCutoff(ctx context.Context, olderThan time.Time, media *gtsmodel.MediaAttachment) (time.Time, error) {
+	account, missing, err := m.getOwningAccount(ctx, media)
+	if err != nil {
+		return time.Time{}, err
+	} else if missing || account == nil {
+		// Nothing to match
+		// a policy against.
+		return olderThan, nil
+	}
+
+	return m.domainCutoff(ctx, account.Domain, olderThan)
+}
+
 func (m *Media) getOwningAccount(ctx context.Context, media *gtsmodel.MediaAttachment) (*gtsmodel.Account, bool, error) {
 	if media.AccountID == "" {
 		// no related account.
@@ -538,13 +873,9 @@ func (m *Media) uncache(ctx context.Context, media *gtsmodel.MediaAttachment) er
 		return nil
 	}
 
-	// Remove media and thumbnail.
-	_, err := m.removeFiles(ctx,
-		media.File.Path,
-		media.Thumbnail.Path,
-	)
-	if err != nil {
-		return gtserror.Newf("error removing media files: %w", err)
+	// Release media and thumbnail.
+	if err := m.mgr.ReleaseAttachment(ctx, media); err != nil {
+		return gtserror.Newf("error releasing media files: %w", err)
 	}
 
 	// Update attachment to reflect that we no longer have it cached.
@@ -563,13 +894,9 @@ func (m *Media) delete(ctx context.Context, media *gtsmodel.MediaAttachment) err
 		return nil
 	}
 
-	// Remove media and thumbnail.
-	_, err := m.removeFiles(ctx,
-		media.File.Path,
-		media.Thumbnail.Path,
-	)
-	if err != nil {
-		return gtserror.Newf("error removing media files: %w", err)
+	// Release media and thumbnail.
+	if err := m.mgr.ReleaseAttachment(ctx, media); err != nil {
+		return gtserror.Newf("error releasing media files: %w", err)
 	}
 
 	// Delete media attachment entirely from the database.
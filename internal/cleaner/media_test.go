@@ -22,6 +22,8 @@ import (
 	"context"
 	"io"
 	"os"
+	"path"
+	"strings"
 	"testing"
 	"time"
 
@@ -32,6 +34,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/regexes"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
@@ -352,6 +355,60 @@ func (suite *MediaTestSuite) TestUncacheRemoteTwice() {
 	suite.Equal(0, totalUncachedAgain)
 }
 
+func (suite *MediaTestSuite) TestUncacheDomain() {
+	ctx := context.Background()
+
+	testStatusAttachment := suite.testAttachments["remote_account_1_status_1_attachment_1"]
+	suite.True(*testStatusAttachment.Cached)
+
+	testHeader := suite.testAttachments["remote_account_3_header"]
+	suite.True(*testHeader.Cached)
+
+	// Only media owned by accounts
+	// on this domain is uncached.
+	totalUncached, err := suite.cleaner.Media().UncacheDomain(ctx, "fossbros-anonymous.io")
+	suite.NoError(err)
+	suite.Positive(totalUncached)
+
+	uncachedAttachment, err := suite.db.GetAttachmentByID(ctx, testStatusAttachment.ID)
+	suite.NoError(err)
+	suite.False(*uncachedAttachment.Cached)
+
+	cachedAttachment, err := suite.db.GetAttachmentByID(ctx, testHeader.ID)
+	suite.NoError(err)
+	suite.True(*cachedAttachment.Cached)
+
+	// Nothing left to uncache second time around.
+	totalUncached, err = suite.cleaner.Media().UncacheDomain(ctx, "fossbros-anonymous.io")
+	suite.NoError(err)
+	suite.Zero(totalUncached)
+}
+
+func (suite *MediaTestSuite) TestUncachePolicies() {
+	ctx := context.Background()
+
+	testHeader := suite.testAttachments["remote_account_3_header"]
+	suite.True(*testHeader.Cached)
+
+	// Stop caching media from remote_account_3's domain.
+	if err := suite.db.PutDomainMediaPolicy(ctx, &gtsmodel.DomainMediaPolicy{
+		ID:                 "01HTBG3MJ0SWX4M4T0R4D6XK2B",
+		Domain:             "thequeenisstillalive.technology",
+		Policy:             gtsmodel.MediaPolicyRemote,
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	totalUncached, err := suite.cleaner.Media().UncachePolicies(ctx)
+	suite.NoError(err)
+	suite.Positive(totalUncached)
+
+	uncachedAttachment, err := suite.db.GetAttachmentByID(ctx, testHeader.ID)
+	suite.NoError(err)
+	suite.False(*uncachedAttachment.Cached)
+}
+
 func (suite *MediaTestSuite) TestUncacheAndRecache() {
 	ctx := context.Background()
 	testStatusAttachment := suite.testAttachments["remote_account_1_status_1_attachment_1"]
@@ -397,9 +454,9 @@ func (suite *MediaTestSuite) TestUncacheAndRecache() {
 		// recachedAttachment should be basically the same as the old attachment
 		suite.True(*recachedAttachment.Cached)
 		suite.Equal(original.ID, recachedAttachment.ID)
-		suite.Equal(original.File.Path, recachedAttachment.File.Path)           // file should be stored in the same place
-		suite.Equal(original.Thumbnail.Path, recachedAttachment.Thumbnail.Path) // as should the thumbnail
-		suite.EqualValues(original.FileMeta, recachedAttachment.FileMeta)       // and the filemeta should be the same
+		suite.Regexp(regexes.BlobPath, recachedAttachment.File.Path)      // file should be stored as a deduplicated blob
+		suite.Regexp(regexes.BlobPath, recachedAttachment.Thumbnail.Path) // as should the thumbnail
+		suite.EqualValues(original.FileMeta, recachedAttachment.FileMeta) // and the filemeta should be the same
 
 		// recached files should be back in storage
 		_, err = suite.storage.Get(ctx, recachedAttachment.File.Path)
@@ -409,6 +466,95 @@ func (suite *MediaTestSuite) TestUncacheAndRecache() {
 	}
 }
 
+func (suite *MediaTestSuite) TestDedupeDry() {
+	ctx := context.Background()
+	testStatusAttachment := suite.testAttachments["remote_account_1_status_1_attachment_1"]
+
+	// dry run should show up 8 attachments to dedupe
+	totalDeduped, err := suite.cleaner.Media().Dedupe(gtscontext.SetDryRun(ctx))
+	suite.NoError(err)
+	suite.Equal(8, totalDeduped)
+
+	// media should still be stored in the same place
+	media, err := suite.db.GetAttachmentByID(ctx, testStatusAttachment.ID)
+	suite.NoError(err)
+	suite.Equal(testStatusAttachment.File.Path, media.File.Path)
+	suite.Equal(testStatusAttachment.Thumbnail.Path, media.Thumbnail.Path)
+
+	_, err = suite.storage.Get(ctx, testStatusAttachment.File.Path)
+	suite.NoError(err)
+}
+
+func (suite *MediaTestSuite) TestDedupe() {
+	ctx := context.Background()
+	testStatusAttachment := suite.testAttachments["remote_account_1_status_1_attachment_1"]
+
+	totalDeduped, err := suite.cleaner.Media().Dedupe(ctx)
+	suite.NoError(err)
+	suite.Equal(8, totalDeduped)
+
+	// media should now be stored as blobs
+	media, err := suite.db.GetAttachmentByID(ctx, testStatusAttachment.ID)
+	suite.NoError(err)
+	suite.True(*media.Cached)
+	suite.Regexp(regexes.BlobPath, media.File.Path)
+	suite.Regexp(regexes.BlobPath, media.Thumbnail.Path)
+
+	for _, path := range []string{
+		media.File.Path,
+		media.Thumbnail.Path,
+	} {
+		blob, err := suite.db.GetMediaBlobByPath(ctx, path)
+		suite.NoError(err)
+		suite.Positive(blob.RefCount)
+
+		_, err = suite.storage.Get(ctx, path)
+		suite.NoError(err)
+	}
+
+	// old files should no longer be stored
+	_, err = suite.storage.Get(ctx, testStatusAttachment.File.Path)
+	suite.ErrorIs(err, storage.ErrNotFound)
+	_, err = suite.storage.Get(ctx, testStatusAttachment.Thumbnail.Path)
+	suite.ErrorIs(err, storage.ErrNotFound)
+
+	// deduping again should do nothing
+	totalDeduped, err = suite.cleaner.Media().Dedupe(ctx)
+	suite.NoError(err)
+	suite.Equal(0, totalDeduped)
+}
+
+func (suite *MediaTestSuite) TestDedupeOriginal() {
+	ctx := context.Background()
+	testStatusAttachment := suite.testAttachments["local_account_1_status_4_attachment_1"]
+
+	// Give the attachment a kept original,
+	// as if it had been converted on upload.
+	media, err := suite.db.GetAttachmentByID(ctx, testStatusAttachment.ID)
+	suite.NoError(err)
+	originalPath := strings.TrimSuffix(media.File.Path, path.Ext(media.File.Path)) + "-original.gif"
+	_, err = suite.storage.Put(ctx, originalPath, []byte("GIF89a original"))
+	suite.NoError(err)
+	media.File.OriginalPath = originalPath
+	suite.NoError(suite.db.UpdateAttachment(ctx, media, "file_original_path"))
+
+	_, err = suite.cleaner.Media().Dedupe(ctx)
+	suite.NoError(err)
+
+	// original should now be stored as a blob too
+	media, err = suite.db.GetAttachmentByID(ctx, testStatusAttachment.ID)
+	suite.NoError(err)
+	suite.Regexp(regexes.BlobPath, media.File.OriginalPath)
+
+	b, err := suite.storage.Get(ctx, media.File.OriginalPath)
+	suite.NoError(err)
+	suite.Equal("GIF89a original", string(b))
+
+	// old original should no longer be stored
+	_, err = suite.storage.Get(ctx, originalPath)
+	suite.ErrorIs(err, storage.ErrNotFound)
+}
+
 func (suite *MediaTestSuite) TestUncacheOneNonExistent() {
 	ctx := context.Background()
 	testStatusAttachment := suite.testAttachments["remote_account_1_status_1_attachment_1"]
@@ -102,15 +102,21 @@ type Configuration struct {
 	MediaEmojiRemoteMaxSize  bytesize.Size `name:"media-emoji-remote-max-size" usage:"Max size in bytes of emojis to download from other instances."`
 	MediaCleanupFrom         string        `name:"media-cleanup-from" usage:"Time of day from which to start running media cleanup/prune jobs. Should be in the format 'hh:mm:ss', eg., '15:04:05'."`
 	MediaCleanupEvery        time.Duration `name:"media-cleanup-every" usage:"Period to elapse between cleanups, starting from media-cleanup-at."`
+	MediaHEIFTranscode       bool          `name:"media-heif-transcode" usage:"Convert HEIC and AVIF images to JPEG (or PNG, if they have transparency) for better client compatibility."`
+	MediaAccountQuota        bytesize.Size `name:"media-account-quota" usage:"Default max total size in bytes of media (attachments, avatar and header) that each local account may store. 0 means no limit."`
+	MediaGIFTranscode        string        `name:"media-gif-transcode" usage:"Convert animated GIFs to a more compact format: mp4 (served as gifv), webp (served as an animated image), or off."`
+	MediaGIFKeepOriginal     bool          `name:"media-gif-keep-original" usage:"Keep the original file of animated GIFs converted by media-gif-transcode in storage."`
+	MediaFFmpegPath          string        `name:"media-ffmpeg-path" usage:"Path to the ffmpeg executable used to convert media, or just its name to look it up in PATH."`
 
-	StorageBackend       string `name:"storage-backend" usage:"Storage backend to use for media attachments"`
-	StorageLocalBasePath string `name:"storage-local-base-path" usage:"Full path to an already-created directory where gts should store/retrieve media files. Subfolders will be created within this dir."`
-	StorageS3Endpoint    string `name:"storage-s3-endpoint" usage:"S3 Endpoint URL (e.g 'minio.example.org:9000')"`
-	StorageS3AccessKey   string `name:"storage-s3-access-key" usage:"S3 Access Key"`
-	StorageS3SecretKey   string `name:"storage-s3-secret-key" usage:"S3 Secret Key"`
-	StorageS3UseSSL      bool   `name:"storage-s3-use-ssl" usage:"Use SSL for S3 connections. Only set this to 'false' when testing locally"`
-	StorageS3BucketName  string `name:"storage-s3-bucket" usage:"Place blobs in this bucket"`
-	StorageS3Proxy       bool   `name:"storage-s3-proxy" usage:"Proxy S3 contents through GoToSocial instead of redirecting to a presigned URL"`
+	StorageBackend         string `name:"storage-backend" usage:"Storage backend to use for media attachments"`
+	StorageLocalBasePath   string `name:"storage-local-base-path" usage:"Full path to an already-created directory where gts should store/retrieve media files. Subfolders will be created within this dir."`
+	StorageS3Endpoint      string `name:"storage-s3-endpoint" usage:"S3 Endpoint URL (e.g 'minio.example.org:9000')"`
+	StorageS3AccessKey     string `name:"storage-s3-access-key" usage:"S3 Access Key"`
+	StorageS3SecretKey     string `name:"storage-s3-secret-key" usage:"S3 Secret Key"`
+	StorageS3UseSSL        bool   `name:"storage-s3-use-ssl" usage:"Use SSL for S3 connections. Only set this to 'false' when testing locally"`
+	StorageS3BucketName    string `name:"storage-s3-bucket" usage:"Place blobs in this bucket"`
+	StorageS3Proxy         bool   `name:"storage-s3-proxy" usage:"Proxy S3 contents through GoToSocial instead of redirecting to a presigned URL"`
+	StorageFallbackBackend string `name:"storage-fallback-backend" usage:"Storage backend to read media from when it's not found in storage-backend, eg. while migrating between backends. Leave empty to disable."`
 
 	StatusesMaxChars           int `name:"statuses-max-chars" usage:"Max permitted characters for posted statuses, including content warning"`
 	StatusesPollMaxOptions     int `name:"statuses-poll-max-options" usage:"Max amount of options permitted on a poll"`
@@ -157,6 +163,11 @@ type Configuration struct {
 	SyslogProtocol string `name:"syslog-protocol" usage:"Protocol to use when directing logs to syslog. Leave empty to connect to local syslog."`
 	SyslogAddress  string `name:"syslog-address" usage:"Address:port to send syslog logs to. Leave empty to connect to local syslog."`
 
+	StreamingBackend string `name:"streaming-backend" usage:"Backend to use for relaying streaming API messages between GoToSocial nodes sharing one database: 'local' (single node only), or 'postgres' (relay via Postgres LISTEN/NOTIFY, requires db-type postgres)"`
+
+	TimelinesPersistEnabled   bool `name:"timelines-persist-enabled" usage:"Persist the index of home and list timelines in the database, so that timelines don't have to be rebuilt from scratch after a restart."`
+	TimelinesPersistMaxLength int  `name:"timelines-persist-max-length" usage:"Maximum number of items to persist per timeline. Older items are trimmed hourly."`
+
 	AdvancedCookiesSamesite      string        `name:"advanced-cookies-samesite" usage:"'strict' or 'lax', see https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Set-Cookie/SameSite"`
 	AdvancedRateLimitRequests    int           `name:"advanced-rate-limit-requests" usage:"Amount of HTTP requests to permit within a 5 minute window. 0 or less turns rate limiting off."`
 	AdvancedRateLimitExceptions  []string      `name:"advanced-rate-limit-exceptions" usage:"Slice of CIDRs to exclude from rate limit restrictions."`
@@ -173,13 +184,18 @@ type Configuration struct {
 	Cache CacheConfiguration `name:"cache"`
 
 	// TODO: move these elsewhere, these are more ephemeral vs long-running flags like above
-	AdminAccountUsername     string `name:"username" usage:"the username to create/delete/etc"`
-	AdminAccountEmail        string `name:"email" usage:"the email address of this account"`
-	AdminAccountPassword     string `name:"password" usage:"the password to set for this account"`
-	AdminTransPath           string `name:"path" usage:"the path of the file to import from/export to"`
-	AdminMediaPruneDryRun    bool   `name:"dry-run" usage:"perform a dry run and only log number of items eligible for pruning"`
-	AdminMediaListLocalOnly  bool   `name:"local-only" usage:"list only local attachments/emojis; if specified then remote-only cannot also be true"`
-	AdminMediaListRemoteOnly bool   `name:"remote-only" usage:"list only remote attachments/emojis; if specified then local-only cannot also be true"`
+	AdminAccountUsername      string        `name:"username" usage:"the username to create/delete/etc"`
+	AdminAccountEmail         string        `name:"email" usage:"the email address of this account"`
+	AdminAccountPassword      string        `name:"password" usage:"the password to set for this account"`
+	AdminTransPath            string        `name:"path" usage:"the path of the file to import from/export to"`
+	AdminMediaPruneDryRun     bool          `name:"dry-run" usage:"perform a dry run and only log number of items eligible for pruning"`
+	AdminMediaListLocalOnly   bool          `name:"local-only" usage:"list only local attachments/emojis; if specified then remote-only cannot also be true"`
+	AdminMediaListRemoteOnly  bool          `name:"remote-only" usage:"list only remote attachments/emojis; if specified then local-only cannot also be true"`
+	AdminMediaMigrateFrom     string        `name:"from" usage:"storage backend to migrate media from"`
+	AdminMediaMigrateTo       string        `name:"to" usage:"storage backend to migrate media to"`
+	AdminMediaMigrateThrottle time.Duration `name:"throttle" usage:"duration to wait between copying each file, to limit load on storage backends"`
+	AdminEmojiConflict        string        `name:"conflict" usage:"what to do when an imported emoji shortcode already exists on this instance: skip, overwrite or rename"`
+	AdminEmojiCategory        string        `name:"category" usage:"category to put imported emojis in when the pack does not specify one"`
 
 	RequestIDHeader string `name:"request-id-header" usage:"Header to extract the Request ID from. Eg.,'X-Request-Id'."`
 }
@@ -29,4 +29,15 @@ const (
 	RequestHeaderFilterModeAllow    = "allow"
 	RequestHeaderFilterModeBlock    = "block"
 	RequestHeaderFilterModeDisabled = ""
+
+	// Streaming backend determines how streaming
+	// API messages are relayed between nodes.
+	StreamingBackendLocal    = "local"
+	StreamingBackendPostgres = "postgres"
+
+	// GIF transcode format determines what
+	// animated GIFs are converted to, if any.
+	MediaGIFTranscodeOff  = "off"
+	MediaGIFTranscodeMP4  = "mp4"
+	MediaGIFTranscodeWebP = "webp"
 )
@@ -81,6 +81,11 @@ var Defaults = Configuration{
 	MediaEmojiRemoteMaxSize:  100 * bytesize.KiB,
 	MediaCleanupFrom:         "00:00",        // Midnight.
 	MediaCleanupEvery:        24 * time.Hour, // 1/day.
+	MediaHEIFTranscode:       false,
+	MediaAccountQuota:        0, // no limit
+	MediaGIFTranscode:        "off",
+	MediaGIFKeepOriginal:     false,
+	MediaFFmpegPath:          "ffmpeg",
 
 	StorageBackend:       "local",
 	StorageLocalBasePath: "./gotosocial/storage",
@@ -128,6 +133,11 @@ var Defaults = Configuration{
 	SyslogProtocol: "udp",
 	SyslogAddress:  "localhost:514",
 
+	StreamingBackend: "local",
+
+	TimelinesPersistEnabled:   false,
+	TimelinesPersistMaxLength: 400,
+
 	AdvancedCookiesSamesite:      "lax",
 	AdvancedRateLimitRequests:    300, // 1 per second per 5 minutes
 	AdvancedRateLimitExceptions:  []string{},
@@ -107,10 +107,16 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		cmd.Flags().Uint64(MediaEmojiRemoteMaxSizeFlag(), uint64(cfg.MediaEmojiRemoteMaxSize), fieldtag("MediaEmojiRemoteMaxSize", "usage"))
 		cmd.Flags().String(MediaCleanupFromFlag(), cfg.MediaCleanupFrom, fieldtag("MediaCleanupFrom", "usage"))
 		cmd.Flags().Duration(MediaCleanupEveryFlag(), cfg.MediaCleanupEvery, fieldtag("MediaCleanupEvery", "usage"))
+		cmd.Flags().Bool(MediaHEIFTranscodeFlag(), cfg.MediaHEIFTranscode, fieldtag("MediaHEIFTranscode", "usage"))
+		cmd.Flags().Uint64(MediaAccountQuotaFlag(), uint64(cfg.MediaAccountQuota), fieldtag("MediaAccountQuota", "usage"))
+		cmd.Flags().String(MediaGIFTranscodeFlag(), cfg.MediaGIFTranscode, fieldtag("MediaGIFTranscode", "usage"))
+		cmd.Flags().Bool(MediaGIFKeepOriginalFlag(), cfg.MediaGIFKeepOriginal, fieldtag("MediaGIFKeepOriginal", "usage"))
+		cmd.Flags().String(MediaFFmpegPathFlag(), cfg.MediaFFmpegPath, fieldtag("MediaFFmpegPath", "usage"))
 
 		// Storage
 		cmd.Flags().String(StorageBackendFlag(), cfg.StorageBackend, fieldtag("StorageBackend", "usage"))
 		cmd.Flags().String(StorageLocalBasePathFlag(), cfg.StorageLocalBasePath, fieldtag("StorageLocalBasePath", "usage"))
+		cmd.Flags().String(StorageFallbackBackendFlag(), cfg.StorageFallbackBackend, fieldtag("StorageFallbackBackend", "usage"))
 
 		// Statuses
 		cmd.Flags().Int(StatusesMaxCharsFlag(), cfg.StatusesMaxChars, fieldtag("StatusesMaxChars", "usage"))
@@ -150,6 +156,13 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		cmd.Flags().String(SyslogProtocolFlag(), cfg.SyslogProtocol, fieldtag("SyslogProtocol", "usage"))
 		cmd.Flags().String(SyslogAddressFlag(), cfg.SyslogAddress, fieldtag("SyslogAddress", "usage"))
 
+		// Streaming
+		cmd.Flags().String(StreamingBackendFlag(), cfg.StreamingBackend, fieldtag("StreamingBackend", "usage"))
+
+		// Timelines
+		cmd.Flags().Bool(TimelinesPersistEnabledFlag(), cfg.TimelinesPersistEnabled, fieldtag("TimelinesPersistEnabled", "usage"))
+		cmd.Flags().Int(TimelinesPersistMaxLengthFlag(), cfg.TimelinesPersistMaxLength, fieldtag("TimelinesPersistMaxLength", "usage"))
+
 		// Advanced flags
 		cmd.Flags().String(AdvancedCookiesSamesiteFlag(), cfg.AdvancedCookiesSamesite, fieldtag("AdvancedCookiesSamesite", "usage"))
 		cmd.Flags().Int(AdvancedRateLimitRequestsFlag(), cfg.AdvancedRateLimitRequests, fieldtag("AdvancedRateLimitRequests", "usage"))
@@ -225,3 +238,41 @@ func AddAdminMediaPrune(cmd *cobra.Command) {
 	usage := fieldtag("AdminMediaPruneDryRun", "usage")
 	cmd.Flags().Bool(name, true, usage)
 }
+
+// AddAdminMediaMigrateStorage attaches flags pertaining to media storage migration commands.
+func AddAdminMediaMigrateStorage(cmd *cobra.Command) {
+	// Migration is dry-run by default, like pruning.
+	AddAdminMediaPrune(cmd)
+
+	from := AdminMediaMigrateFromFlag()
+	fromUsage := fieldtag("AdminMediaMigrateFrom", "usage")
+	cmd.Flags().String(from, "", fromUsage) // REQUIRED
+	if err := cmd.MarkFlagRequired(from); err != nil {
+		panic(err)
+	}
+
+	to := AdminMediaMigrateToFlag()
+	toUsage := fieldtag("AdminMediaMigrateTo", "usage")
+	cmd.Flags().String(to, "", toUsage) // REQUIRED
+	if err := cmd.MarkFlagRequired(to); err != nil {
+		panic(err)
+	}
+
+	throttle := AdminMediaMigrateThrottleFlag()
+	throttleUsage := fieldtag("AdminMediaMigrateThrottle", "usage")
+	cmd.Flags().Duration(throttle, 0, throttleUsage)
+}
+
+// AddAdminEmojiImport attaches flags pertaining to emoji pack import commands.
+func AddAdminEmojiImport(cmd *cobra.Command) {
+	// Pack path is required.
+	AddAdminTrans(cmd)
+
+	conflict := AdminEmojiConflictFlag()
+	conflictUsage := fieldtag("AdminEmojiConflict", "usage")
+	cmd.Flags().String(conflict, "skip", conflictUsage)
+
+	category := AdminEmojiCategoryFlag()
+	categoryUsage := fieldtag("AdminEmojiCategory", "usage")
+	cmd.Flags().String(category, "", categoryUsage)
+}
@@ -1325,6 +1325,131 @@ func GetMediaCleanupEvery() time.Duration { return global.GetMediaCleanupEvery()
 // SetMediaCleanupEvery safely sets the value for global configuration 'MediaCleanupEvery' field
 func SetMediaCleanupEvery(v time.Duration) { global.SetMediaCleanupEvery(v) }
 
+// GetMediaHEIFTranscode safely fetches the Configuration value for state's 'MediaHEIFTranscode' field
+func (st *ConfigState) GetMediaHEIFTranscode() (v bool) {
+	st.mutex.RLock()
+	v = st.config.MediaHEIFTranscode
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaHEIFTranscode safely sets the Configuration value for state's 'MediaHEIFTranscode' field
+func (st *ConfigState) SetMediaHEIFTranscode(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaHEIFTranscode = v
+	st.reloadToViper()
+}
+
+// MediaHEIFTranscodeFlag returns the flag name for the 'MediaHEIFTranscode' field
+func MediaHEIFTranscodeFlag() string { return "media-heif-transcode" }
+
+// GetMediaHEIFTranscode safely fetches the value for global configuration 'MediaHEIFTranscode' field
+func GetMediaHEIFTranscode() bool { return global.GetMediaHEIFTranscode() }
+
+// SetMediaHEIFTranscode safely sets the value for global configuration 'MediaHEIFTranscode' field
+func SetMediaHEIFTranscode(v bool) { global.SetMediaHEIFTranscode(v) }
+
+// GetMediaAccountQuota safely fetches the Configuration value for state's 'MediaAccountQuota' field
+func (st *ConfigState) GetMediaAccountQuota() (v bytesize.Size) {
+	st.mutex.RLock()
+	v = st.config.MediaAccountQuota
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaAccountQuota safely sets the Configuration value for state's 'MediaAccountQuota' field
+func (st *ConfigState) SetMediaAccountQuota(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaAccountQuota = v
+	st.reloadToViper()
+}
+
+// MediaAccountQuotaFlag returns the flag name for the 'MediaAccountQuota' field
+func MediaAccountQuotaFlag() string { return "media-account-quota" }
+
+// GetMediaAccountQuota safely fetches the value for global configuration 'MediaAccountQuota' field
+func GetMediaAccountQuota() bytesize.Size { return global.GetMediaAccountQuota() }
+
+// SetMediaAccountQuota safely sets the value for global configuration 'MediaAccountQuota' field
+func SetMediaAccountQuota(v bytesize.Size) { global.SetMediaAccountQuota(v) }
+
+// GetMediaGIFTranscode safely fetches the Configuration value for state's 'MediaGIFTranscode' field
+func (st *ConfigState) GetMediaGIFTranscode() (v string) {
+	st.mutex.RLock()
+	v = st.config.MediaGIFTranscode
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaGIFTranscode safely sets the Configuration value for state's 'MediaGIFTranscode' field
+func (st *ConfigState) SetMediaGIFTranscode(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaGIFTranscode = v
+	st.reloadToViper()
+}
+
+// MediaGIFTranscodeFlag returns the flag name for the 'MediaGIFTranscode' field
+func MediaGIFTranscodeFlag() string { return "media-gif-transcode" }
+
+// GetMediaGIFTranscode safely fetches the value for global configuration 'MediaGIFTranscode' field
+func GetMediaGIFTranscode() string { return global.GetMediaGIFTranscode() }
+
+// SetMediaGIFTranscode safely sets the value for global configuration 'MediaGIFTranscode' field
+func SetMediaGIFTranscode(v string) { global.SetMediaGIFTranscode(v) }
+
+// GetMediaGIFKeepOriginal safely fetches the Configuration value for state's 'MediaGIFKeepOriginal' field
+func (st *ConfigState) GetMediaGIFKeepOriginal() (v bool) {
+	st.mutex.RLock()
+	v = st.config.MediaGIFKeepOriginal
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaGIFKeepOriginal safely sets the Configuration value for state's 'MediaGIFKeepOriginal' field
+func (st *ConfigState) SetMediaGIFKeepOriginal(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaGIFKeepOriginal = v
+	st.reloadToViper()
+}
+
+// MediaGIFKeepOriginalFlag returns the flag name for the 'MediaGIFKeepOriginal' field
+func MediaGIFKeepOriginalFlag() string { return "media-gif-keep-original" }
+
+// GetMediaGIFKeepOriginal safely fetches the value for global configuration 'MediaGIFKeepOriginal' field
+func GetMediaGIFKeepOriginal() bool { return global.GetMediaGIFKeepOriginal() }
+
+// SetMediaGIFKeepOriginal safely sets the value for global configuration 'MediaGIFKeepOriginal' field
+func SetMediaGIFKeepOriginal(v bool) { global.SetMediaGIFKeepOriginal(v) }
+
+// GetMediaFFmpegPath safely fetches the Configuration value for state's 'MediaFFmpegPath' field
+func (st *ConfigState) GetMediaFFmpegPath() (v string) {
+	st.mutex.RLock()
+	v = st.config.MediaFFmpegPath
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaFFmpegPath safely sets the Configuration value for state's 'MediaFFmpegPath' field
+func (st *ConfigState) SetMediaFFmpegPath(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaFFmpegPath = v
+	st.reloadToViper()
+}
+
+// MediaFFmpegPathFlag returns the flag name for the 'MediaFFmpegPath' field
+func MediaFFmpegPathFlag() string { return "media-ffmpeg-path" }
+
+// GetMediaFFmpegPath safely fetches the value for global configuration 'MediaFFmpegPath' field
+func GetMediaFFmpegPath() string { return global.GetMediaFFmpegPath() }
+
+// SetMediaFFmpegPath safely sets the value for global configuration 'MediaFFmpegPath' field
+func SetMediaFFmpegPath(v string) { global.SetMediaFFmpegPath(v) }
+
 // GetStorageBackend safely fetches the Configuration value for state's 'StorageBackend' field
 func (st *ConfigState) GetStorageBackend() (v string) {
 	st.mutex.RLock()
@@ -1525,6 +1650,31 @@ func GetStorageS3Proxy() bool { return global.GetStorageS3Proxy() }
 // SetStorageS3Proxy safely sets the value for global configuration 'StorageS3Proxy' field
 func SetStorageS3Proxy(v bool) { global.SetStorageS3Proxy(v) }
 
+// GetStorageFallbackBackend safely fetches the Configuration value for state's 'StorageFallbackBackend' field
+func (st *ConfigState) GetStorageFallbackBackend() (v string) {
+	st.mutex.RLock()
+	v = st.config.StorageFallbackBackend
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStorageFallbackBackend safely sets the Configuration value for state's 'StorageFallbackBackend' field
+func (st *ConfigState) SetStorageFallbackBackend(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StorageFallbackBackend = v
+	st.reloadToViper()
+}
+
+// StorageFallbackBackendFlag returns the flag name for the 'StorageFallbackBackend' field
+func StorageFallbackBackendFlag() string { return "storage-fallback-backend" }
+
+// GetStorageFallbackBackend safely fetches the value for global configuration 'StorageFallbackBackend' field
+func GetStorageFallbackBackend() string { return global.GetStorageFallbackBackend() }
+
+// SetStorageFallbackBackend safely sets the value for global configuration 'StorageFallbackBackend' field
+func SetStorageFallbackBackend(v string) { global.SetStorageFallbackBackend(v) }
+
 // GetStatusesMaxChars safely fetches the Configuration value for state's 'StatusesMaxChars' field
 func (st *ConfigState) GetStatusesMaxChars() (v int) {
 	st.mutex.RLock()
@@ -2450,6 +2600,81 @@ func GetSyslogAddress() string { return global.GetSyslogAddress() }
 // SetSyslogAddress safely sets the value for global configuration 'SyslogAddress' field
 func SetSyslogAddress(v string) { global.SetSyslogAddress(v) }
 
+// GetStreamingBackend safely fetches the Configuration value for state's 'StreamingBackend' field
+func (st *ConfigState) GetStreamingBackend() (v string) {
+	st.mutex.RLock()
+	v = st.config.StreamingBackend
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStreamingBackend safely sets the Configuration value for state's 'StreamingBackend' field
+func (st *ConfigState) SetStreamingBackend(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StreamingBackend = v
+	st.reloadToViper()
+}
+
+// StreamingBackendFlag returns the flag name for the 'StreamingBackend' field
+func StreamingBackendFlag() string { return "streaming-backend" }
+
+// GetStreamingBackend safely fetches the value for global configuration 'StreamingBackend' field
+func GetStreamingBackend() string { return global.GetStreamingBackend() }
+
+// SetStreamingBackend safely sets the value for global configuration 'StreamingBackend' field
+func SetStreamingBackend(v string) { global.SetStreamingBackend(v) }
+
+// GetTimelinesPersistEnabled safely fetches the Configuration value for state's 'TimelinesPersistEnabled' field
+func (st *ConfigState) GetTimelinesPersistEnabled() (v bool) {
+	st.mutex.RLock()
+	v = st.config.TimelinesPersistEnabled
+	st.mutex.RUnlock()
+	return
+}
+
+// SetTimelinesPersistEnabled safely sets the Configuration value for state's 'TimelinesPersistEnabled' field
+func (st *ConfigState) SetTimelinesPersistEnabled(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.TimelinesPersistEnabled = v
+	st.reloadToViper()
+}
+
+// TimelinesPersistEnabledFlag returns the flag name for the 'TimelinesPersistEnabled' field
+func TimelinesPersistEnabledFlag() string { return "timelines-persist-enabled" }
+
+// GetTimelinesPersistEnabled safely fetches the value for global configuration 'TimelinesPersistEnabled' field
+func GetTimelinesPersistEnabled() bool { return global.GetTimelinesPersistEnabled() }
+
+// SetTimelinesPersistEnabled safely sets the value for global configuration 'TimelinesPersistEnabled' field
+func SetTimelinesPersistEnabled(v bool) { global.SetTimelinesPersistEnabled(v) }
+
+// GetTimelinesPersistMaxLength safely fetches the Configuration value for state's 'TimelinesPersistMaxLength' field
+func (st *ConfigState) GetTimelinesPersistMaxLength() (v int) {
+	st.mutex.RLock()
+	v = st.config.TimelinesPersistMaxLength
+	st.mutex.RUnlock()
+	return
+}
+
+// SetTimelinesPersistMaxLength safely sets the Configuration value for state's 'TimelinesPersistMaxLength' field
+func (st *ConfigState) SetTimelinesPersistMaxLength(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.TimelinesPersistMaxLength = v
+	st.reloadToViper()
+}
+
+// TimelinesPersistMaxLengthFlag returns the flag name for the 'TimelinesPersistMaxLength' field
+func TimelinesPersistMaxLengthFlag() string { return "timelines-persist-max-length" }
+
+// GetTimelinesPersistMaxLength safely fetches the value for global configuration 'TimelinesPersistMaxLength' field
+func GetTimelinesPersistMaxLength() int { return global.GetTimelinesPersistMaxLength() }
+
+// SetTimelinesPersistMaxLength safely sets the value for global configuration 'TimelinesPersistMaxLength' field
+func SetTimelinesPersistMaxLength(v int) { global.SetTimelinesPersistMaxLength(v) }
+
 // GetAdvancedCookiesSamesite safely fetches the Configuration value for state's 'AdvancedCookiesSamesite' field
 func (st *ConfigState) GetAdvancedCookiesSamesite() (v string) {
 	st.mutex.RLock()
@@ -3775,6 +4000,131 @@ func GetAdminMediaListRemoteOnly() bool { return global.GetAdminMediaListRemoteO
 // SetAdminMediaListRemoteOnly safely sets the value for global configuration 'AdminMediaListRemoteOnly' field
 func SetAdminMediaListRemoteOnly(v bool) { global.SetAdminMediaListRemoteOnly(v) }
 
+// GetAdminMediaMigrateFrom safely fetches the Configuration value for state's 'AdminMediaMigrateFrom' field
+func (st *ConfigState) GetAdminMediaMigrateFrom() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdminMediaMigrateFrom
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminMediaMigrateFrom safely sets the Configuration value for state's 'AdminMediaMigrateFrom' field
+func (st *ConfigState) SetAdminMediaMigrateFrom(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminMediaMigrateFrom = v
+	st.reloadToViper()
+}
+
+// AdminMediaMigrateFromFlag returns the flag name for the 'AdminMediaMigrateFrom' field
+func AdminMediaMigrateFromFlag() string { return "from" }
+
+// GetAdminMediaMigrateFrom safely fetches the value for global configuration 'AdminMediaMigrateFrom' field
+func GetAdminMediaMigrateFrom() string { return global.GetAdminMediaMigrateFrom() }
+
+// SetAdminMediaMigrateFrom safely sets the value for global configuration 'AdminMediaMigrateFrom' field
+func SetAdminMediaMigrateFrom(v string) { global.SetAdminMediaMigrateFrom(v) }
+
+// GetAdminMediaMigrateTo safely fetches the Configuration value for state's 'AdminMediaMigrateTo' field
+func (st *ConfigState) GetAdminMediaMigrateTo() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdminMediaMigrateTo
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminMediaMigrateTo safely sets the Configuration value for state's 'AdminMediaMigrateTo' field
+func (st *ConfigState) SetAdminMediaMigrateTo(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminMediaMigrateTo = v
+	st.reloadToViper()
+}
+
+// AdminMediaMigrateToFlag returns the flag name for the 'AdminMediaMigrateTo' field
+func AdminMediaMigrateToFlag() string { return "to" }
+
+// GetAdminMediaMigrateTo safely fetches the value for global configuration 'AdminMediaMigrateTo' field
+func GetAdminMediaMigrateTo() string { return global.GetAdminMediaMigrateTo() }
+
+// SetAdminMediaMigrateTo safely sets the value for global configuration 'AdminMediaMigrateTo' field
+func SetAdminMediaMigrateTo(v string) { global.SetAdminMediaMigrateTo(v) }
+
+// GetAdminMediaMigrateThrottle safely fetches the Configuration value for state's 'AdminMediaMigrateThrottle' field
+func (st *ConfigState) GetAdminMediaMigrateThrottle() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.AdminMediaMigrateThrottle
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminMediaMigrateThrottle safely sets the Configuration value for state's 'AdminMediaMigrateThrottle' field
+func (st *ConfigState) SetAdminMediaMigrateThrottle(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminMediaMigrateThrottle = v
+	st.reloadToViper()
+}
+
+// AdminMediaMigrateThrottleFlag returns the flag name for the 'AdminMediaMigrateThrottle' field
+func AdminMediaMigrateThrottleFlag() string { return "throttle" }
+
+// GetAdminMediaMigrateThrottle safely fetches the value for global configuration 'AdminMediaMigrateThrottle' field
+func GetAdminMediaMigrateThrottle() time.Duration { return global.GetAdminMediaMigrateThrottle() }
+
+// SetAdminMediaMigrateThrottle safely sets the value for global configuration 'AdminMediaMigrateThrottle' field
+func SetAdminMediaMigrateThrottle(v time.Duration) { global.SetAdminMediaMigrateThrottle(v) }
+
+// GetAdminEmojiConflict safely fetches the Configuration value for state's 'AdminEmojiConflict' field
+func (st *ConfigState) GetAdminEmojiConflict() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdminEmojiConflict
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminEmojiConflict safely sets the Configuration value for state's 'AdminEmojiConflict' field
+func (st *ConfigState) SetAdminEmojiConflict(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminEmojiConflict = v
+	st.reloadToViper()
+}
+
+// AdminEmojiConflictFlag returns the flag name for the 'AdminEmojiConflict' field
+func AdminEmojiConflictFlag() string { return "conflict" }
+
+// GetAdminEmojiConflict safely fetches the value for global configuration 'AdminEmojiConflict' field
+func GetAdminEmojiConflict() string { return global.GetAdminEmojiConflict() }
+
+// SetAdminEmojiConflict safely sets the value for global configuration 'AdminEmojiConflict' field
+func SetAdminEmojiConflict(v string) { global.SetAdminEmojiConflict(v) }
+
+// GetAdminEmojiCategory safely fetches the Configuration value for state's 'AdminEmojiCategory' field
+func (st *ConfigState) GetAdminEmojiCategory() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdminEmojiCategory
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminEmojiCategory safely sets the Configuration value for state's 'AdminEmojiCategory' field
+func (st *ConfigState) SetAdminEmojiCategory(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminEmojiCategory = v
+	st.reloadToViper()
+}
+
+// AdminEmojiCategoryFlag returns the flag name for the 'AdminEmojiCategory' field
+func AdminEmojiCategoryFlag() string { return "category" }
+
+// GetAdminEmojiCategory safely fetches the value for global configuration 'AdminEmojiCategory' field
+func GetAdminEmojiCategory() string { return global.GetAdminEmojiCategory() }
+
+// SetAdminEmojiCategory safely sets the value for global configuration 'AdminEmojiCategory' field
+func SetAdminEmojiCategory(v string) { global.SetAdminEmojiCategory(v) }
+
 // GetRequestIDHeader safely fetches the Configuration value for state's 'RequestIDHeader' field
 func (st *ConfigState) GetRequestIDHeader() (v string) {
 	st.mutex.RLock()
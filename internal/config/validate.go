@@ -19,6 +19,8 @@ package config
 
 import (
 	"fmt"
+	"os/exec"
+	"strings"
 
 	"github.com/miekg/dns"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -144,5 +146,61 @@ func Validate() error {
 		)
 	}
 
+	// `streaming-backend` should be "local" or
+	// "postgres", the latter only if the db is.
+	switch backend := GetStreamingBackend(); backend {
+	case StreamingBackendLocal:
+		// No problem.
+
+	case StreamingBackendPostgres:
+		if dbType := GetDbType(); !strings.EqualFold(dbType, "postgres") {
+			errf(
+				"%s %s requires %s postgres, provided value was %s",
+				StreamingBackendFlag(), backend, DbTypeFlag(), dbType,
+			)
+		}
+
+	default:
+		errf(
+			"%s must be set to either local or postgres, provided value was %s",
+			StreamingBackendFlag(), backend,
+		)
+	}
+
+	// `media-gif-transcode` should be
+	// "mp4", "webp", or "off".
+	switch format := GetMediaGIFTranscode(); format {
+	case MediaGIFTranscodeMP4, MediaGIFTranscodeWebP, MediaGIFTranscodeOff:
+		// No problem.
+
+	default:
+		errf(
+			"%s must be set to either mp4, webp, or off, provided value was %s",
+			MediaGIFTranscodeFlag(), format,
+		)
+	}
+
+	// Converting GIFs requires ffmpeg,
+	// turn it off if it's not available.
+	if GetMediaGIFTranscode() != MediaGIFTranscodeOff {
+		if _, err := exec.LookPath(GetMediaFFmpegPath()); err != nil {
+			log.Warnf(
+				nil,
+				"%s was set to %s but ffmpeg could not be found (%v); animated GIFs will be stored as-is",
+				MediaGIFTranscodeFlag(), GetMediaGIFTranscode(), err,
+			)
+			SetMediaGIFTranscode(MediaGIFTranscodeOff)
+		}
+	}
+
+	// `timelines-persist-max-length` must be
+	// positive when persisting timelines.
+	if GetTimelinesPersistEnabled() && GetTimelinesPersistMaxLength() < 1 {
+		errf(
+			"%s must be 1 or more when %s is true, provided value was %d",
+			TimelinesPersistMaxLengthFlag(), TimelinesPersistEnabledFlag(), GetTimelinesPersistMaxLength(),
+		)
+	}
+
 	return errs.Combine()
 }
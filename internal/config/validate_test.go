@@ -140,6 +140,18 @@ func (suite *ConfigValidateTestSuite) TestValidateConfigBadProtocolNoHost() {
 	suite.EqualError(err, "host must be set\nprotocol must be set to either http or https, provided value was foo")
 }
 
+func (suite *ConfigValidateTestSuite) TestValidateConfigGIFTranscodeNoFFmpeg() {
+	testrig.InitTestConfig()
+
+	config.SetMediaGIFTranscode(config.MediaGIFTranscodeMP4)
+	config.SetMediaFFmpegPath("/does/not/exist/ffmpeg")
+
+	// Converting should be turned off, not fail validation.
+	err := config.Validate()
+	suite.NoError(err)
+	suite.Equal(config.MediaGIFTranscodeOff, config.GetMediaGIFTranscode())
+}
+
 func TestConfigValidateTestSuite(t *testing.T) {
 	suite.Run(t, &ConfigValidateTestSuite{})
 }
@@ -0,0 +1,69 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type BookmarkCollection interface {
+	// GetBookmarkCollectionByID gets one bookmark collection with the given ID.
+	GetBookmarkCollectionByID(ctx context.Context, id string) (*gtsmodel.BookmarkCollection, error)
+
+	// GetBookmarkCollectionsByAccountID gets all bookmark collections
+	// owned by the given accountID, sorted by title.
+	GetBookmarkCollectionsByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.BookmarkCollection, error)
+
+	// GetBookmarkCollectionsForBookmark gets all bookmark
+	// collections that contain the given bookmarkID.
+	GetBookmarkCollectionsForBookmark(ctx context.Context, bookmarkID string) ([]*gtsmodel.BookmarkCollection, error)
+
+	// PutBookmarkCollection inserts a new bookmark collection into the database.
+	PutBookmarkCollection(ctx context.Context, collection *gtsmodel.BookmarkCollection) error
+
+	// UpdateBookmarkCollection updates the given bookmark collection.
+	// Columns is optional, if not specified all will be updated.
+	UpdateBookmarkCollection(ctx context.Context, collection *gtsmodel.BookmarkCollection, columns ...string) error
+
+	// DeleteBookmarkCollectionByID deletes one bookmark collection
+	// with the given ID, and all entries in it. The bookmarks
+	// themselves are left alone.
+	DeleteBookmarkCollectionByID(ctx context.Context, id string) error
+
+	// DeleteBookmarkCollectionsByAccountID deletes all bookmark collections
+	// (and their entries) owned by the given accountID.
+	DeleteBookmarkCollectionsByAccountID(ctx context.Context, accountID string) error
+
+	// GetBookmarkCollectionBookmarkIDs retrieves the IDs of status bookmarks contained in
+	// the given collection, paged by bookmark ID. If limit is 0 then no limit will be set.
+	GetBookmarkCollectionBookmarkIDs(ctx context.Context, collectionID string, limit int, maxID string, minID string) ([]string, error)
+
+	// GetBookmarkCollectionBookmarks retrieves status bookmarks contained in the given
+	// collection, paged by bookmark ID. If limit is 0 then no limit will be set.
+	GetBookmarkCollectionBookmarks(ctx context.Context, collectionID string, limit int, maxID string, minID string) ([]*gtsmodel.StatusBookmark, error)
+
+	// PutBookmarkCollectionEntries inserts the given entries into the database.
+	// Entries for bookmarks already in the collection are ignored.
+	PutBookmarkCollectionEntries(ctx context.Context, entries []*gtsmodel.BookmarkCollectionEntry) error
+
+	// DeleteBookmarkCollectionEntry removes the given
+	// bookmark from the given collection, if present.
+	DeleteBookmarkCollectionEntry(ctx context.Context, collectionID string, bookmarkID string) error
+}
@@ -19,8 +19,10 @@ package bundb
 
 import (
 	"context"
+	"crypto/ecdh"
 	"crypto/rand"
 	"crypto/rsa"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"net/mail"
@@ -132,6 +134,7 @@ func (a *adminDB) NewSignup(ctx context.Context, newSignup gtsmodel.NewSignup) (
 			FollowingURI:          uris.FollowingURI,
 			FollowersURI:          uris.FollowersURI,
 			FeaturedCollectionURI: uris.FeaturedCollectionURI,
+			FeaturedTagsURI:       uris.FeaturedTagsURI,
 			ActorType:             ap.ActorPerson,
 			PrivateKey:            gtsmodel.PrivateKey{Key: privKey},
 			PublicKey:             gtsmodel.PublicKey{Key: &privKey.PublicKey},
@@ -265,6 +268,7 @@ func (a *adminDB) CreateInstanceAccount(ctx context.Context) error {
 		FollowersURI:          newAccountURIs.FollowersURI,
 		FollowingURI:          newAccountURIs.FollowingURI,
 		FeaturedCollectionURI: newAccountURIs.FeaturedCollectionURI,
+		FeaturedTagsURI:       newAccountURIs.FeaturedTagsURI,
 	}
 
 	// insert the new account!
@@ -293,7 +297,12 @@ func (a *adminDB) CreateInstanceInstance(ctx context.Context) error {
 	}
 	if exists {
 		log.Infof(ctx, "instance entry already exists")
-		return nil
+		return a.ensureInstanceVAPIDKeys(ctx, host)
+	}
+
+	vapidPublicKey, vapidPrivateKey, err := newVAPIDKeyPair()
+	if err != nil {
+		return err
 	}
 
 	iID, err := id.NewRandomULID()
@@ -302,10 +311,12 @@ func (a *adminDB) CreateInstanceInstance(ctx context.Context) error {
 	}
 
 	i := &gtsmodel.Instance{
-		ID:     iID,
-		Domain: host,
-		Title:  host,
-		URI:    fmt.Sprintf("%s://%s", protocol, host),
+		ID:              iID,
+		Domain:          host,
+		Title:           host,
+		URI:             fmt.Sprintf("%s://%s", protocol, host),
+		VAPIDPublicKey:  vapidPublicKey,
+		VAPIDPrivateKey: vapidPrivateKey,
 	}
 
 	insertQ := a.db.
@@ -321,6 +332,52 @@ func (a *adminDB) CreateInstanceInstance(ctx context.Context) error {
 	return nil
 }
 
+// ensureInstanceVAPIDKeys generates a VAPID key pair for
+// the existing instance entry with the given domain, if it
+// doesn't have one yet (eg., it was created before Web Push
+// support was added).
+func (a *adminDB) ensureInstanceVAPIDKeys(ctx context.Context, host string) error {
+	instance, err := a.state.DB.GetInstance(ctx, host)
+	if err != nil {
+		return err
+	}
+
+	if instance.VAPIDPublicKey != "" && instance.VAPIDPrivateKey != "" {
+		// Already set.
+		return nil
+	}
+
+	instance.VAPIDPublicKey, instance.VAPIDPrivateKey, err = newVAPIDKeyPair()
+	if err != nil {
+		return err
+	}
+
+	if err := a.state.DB.UpdateInstance(ctx, instance,
+		"vapid_public_key",
+		"vapid_private_key",
+	); err != nil {
+		return err
+	}
+
+	log.Infof(ctx, "generated VAPID key pair for instance %s", host)
+	return nil
+}
+
+// newVAPIDKeyPair generates a new P-256 key pair
+// for signing Web Push requests, and returns the
+// (uncompressed) public key and private key as
+// unpadded base64url strings, as used by clients.
+func newVAPIDKeyPair() (string, string, error) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", gtserror.Newf("error generating VAPID key pair: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+		base64.RawURLEncoding.EncodeToString(key.Bytes()),
+		nil
+}
+
 /*
 	ACTION FUNCS
 */
@@ -0,0 +1,247 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type bookmarkCollectionDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (b *bookmarkCollectionDB) GetBookmarkCollectionByID(ctx context.Context, id string) (*gtsmodel.BookmarkCollection, error) {
+	collection := new(gtsmodel.BookmarkCollection)
+
+	if err := b.db.
+		NewSelect().
+		Model(collection).
+		Where("? = ?", bun.Ident("bookmark_collection.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+func (b *bookmarkCollectionDB) GetBookmarkCollectionsByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.BookmarkCollection, error) {
+	var collections []*gtsmodel.BookmarkCollection
+
+	if err := b.db.
+		NewSelect().
+		Model(&collections).
+		Where("? = ?", bun.Ident("bookmark_collection.account_id"), accountID).
+		Order("bookmark_collection.title ASC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return collections, nil
+}
+
+func (b *bookmarkCollectionDB) GetBookmarkCollectionsForBookmark(ctx context.Context, bookmarkID string) ([]*gtsmodel.BookmarkCollection, error) {
+	var collections []*gtsmodel.BookmarkCollection
+
+	if err := b.db.
+		NewSelect().
+		Model(&collections).
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("bookmark_collection_entries"), bun.Ident("entry"),
+			bun.Ident("entry.collection_id"), bun.Ident("bookmark_collection.id"),
+		).
+		Where("? = ?", bun.Ident("entry.bookmark_id"), bookmarkID).
+		Order("bookmark_collection.title ASC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return collections, nil
+}
+
+func (b *bookmarkCollectionDB) PutBookmarkCollection(ctx context.Context, collection *gtsmodel.BookmarkCollection) error {
+	_, err := b.db.
+		NewInsert().
+		Model(collection).
+		Exec(ctx)
+	return err
+}
+
+func (b *bookmarkCollectionDB) UpdateBookmarkCollection(ctx context.Context, collection *gtsmodel.BookmarkCollection, columns ...string) error {
+	collection.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := b.db.
+		NewUpdate().
+		Model(collection).
+		Where("? = ?", bun.Ident("bookmark_collection.id"), collection.ID).
+		Column(columns...).
+		Exec(ctx)
+	return err
+}
+
+func (b *bookmarkCollectionDB) DeleteBookmarkCollectionByID(ctx context.Context, id string) error {
+	return b.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Delete all entries in the collection.
+		if _, err := tx.
+			NewDelete().
+			Table("bookmark_collection_entries").
+			Where("? = ?", bun.Ident("collection_id"), id).
+			Exec(ctx); err != nil {
+			return gtserror.Newf("error deleting collection entries: %w", err)
+		}
+
+		// Delete the collection itself.
+		if _, err := tx.
+			NewDelete().
+			Table("bookmark_collections").
+			Where("? = ?", bun.Ident("id"), id).
+			Exec(ctx); err != nil {
+			return gtserror.Newf("error deleting collection: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (b *bookmarkCollectionDB) DeleteBookmarkCollectionsByAccountID(ctx context.Context, accountID string) error {
+	var collectionIDs []string
+
+	if err := b.db.
+		NewSelect().
+		Table("bookmark_collections").
+		Column("id").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Scan(ctx, &collectionIDs); err != nil {
+		return err
+	}
+
+	var errs gtserror.MultiError
+
+	for _, id := range collectionIDs {
+		if err := b.DeleteBookmarkCollectionByID(ctx, id); err != nil {
+			errs.Appendf("error deleting bookmark collection %s: %w", id, err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (b *bookmarkCollectionDB) GetBookmarkCollectionBookmarkIDs(ctx context.Context, collectionID string, limit int, maxID string, minID string) ([]string, error) {
+	if collectionID == "" {
+		return nil, errors.New("must provide a collection")
+	}
+
+	// Ensure reasonable
+	if limit < 0 {
+		limit = 0
+	}
+
+	// Guess size of IDs based on limit.
+	ids := make([]string, 0, limit)
+
+	q := b.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("bookmark_collection_entries"), bun.Ident("entry")).
+		Column("entry.bookmark_id").
+		Where("? = ?", bun.Ident("entry.collection_id"), collectionID).
+		Order("entry.bookmark_id DESC")
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("entry.bookmark_id"), maxID)
+	}
+
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("entry.bookmark_id"), minID)
+	}
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (b *bookmarkCollectionDB) GetBookmarkCollectionBookmarks(ctx context.Context, collectionID string, limit int, maxID string, minID string) ([]*gtsmodel.StatusBookmark, error) {
+	ids, err := b.GetBookmarkCollectionBookmarkIDs(ctx, collectionID, limit, maxID, minID)
+	if err != nil {
+		return nil, err
+	}
+
+	bookmarks := make([]*gtsmodel.StatusBookmark, 0, len(ids))
+
+	for _, id := range ids {
+		bookmark, err := b.state.DB.GetStatusBookmark(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting bookmark %q: %v", id, err)
+			continue
+		}
+
+		bookmarks = append(bookmarks, bookmark)
+	}
+
+	return bookmarks, nil
+}
+
+func (b *bookmarkCollectionDB) PutBookmarkCollectionEntries(ctx context.Context, entries []*gtsmodel.BookmarkCollectionEntry) error {
+	return b.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		for _, entry := range entries {
+			if _, err := tx.
+				NewInsert().
+				Model(entry).
+				On("CONFLICT (?, ?) DO NOTHING", bun.Ident("collection_id"), bun.Ident("bookmark_id")).
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (b *bookmarkCollectionDB) DeleteBookmarkCollectionEntry(ctx context.Context, collectionID string, bookmarkID string) error {
+	_, err := b.db.
+		NewDelete().
+		Table("bookmark_collection_entries").
+		Where("? = ?", bun.Ident("collection_id"), collectionID).
+		Where("? = ?", bun.Ident("bookmark_id"), bookmarkID).
+		Exec(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	return nil
+}
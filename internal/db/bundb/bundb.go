@@ -58,15 +58,21 @@ type DBService struct {
 	db.Admin
 	db.Application
 	db.Basic
+	db.BookmarkCollection
 	db.Domain
+	db.DomainMediaPolicy
 	db.Emoji
+	db.FeaturedTag
 	db.HeaderFilter
+	db.Import
 	db.Instance
 	db.List
 	db.Marker
 	db.Media
+	db.MediaHash
 	db.Mention
 	db.Notification
+	db.NotificationPolicy
 	db.Poll
 	db.Relationship
 	db.Report
@@ -76,11 +82,13 @@ type DBService struct {
 	db.Status
 	db.StatusBookmark
 	db.StatusFave
+	db.StorageUsage
 	db.Tag
 	db.Thread
 	db.Timeline
 	db.User
 	db.Tombstone
+	db.WebPush
 	db *bun.DB
 }
 
@@ -184,18 +192,34 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 		Basic: &basicDB{
 			db: db,
 		},
+		BookmarkCollection: &bookmarkCollectionDB{
+			db:    db,
+			state: state,
+		},
 		Domain: &domainDB{
 			db:    db,
 			state: state,
 		},
+		DomainMediaPolicy: &domainMediaPolicyDB{
+			db:    db,
+			state: state,
+		},
 		Emoji: &emojiDB{
 			db:    db,
 			state: state,
 		},
+		FeaturedTag: &featuredTagDB{
+			db:    db,
+			state: state,
+		},
 		HeaderFilter: &headerFilterDB{
 			db:    db,
 			state: state,
 		},
+		Import: &importDB{
+			db:    db,
+			state: state,
+		},
 		Instance: &instanceDB{
 			db:    db,
 			state: state,
@@ -212,6 +236,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		MediaHash: &mediaHashDB{
+			db:    db,
+			state: state,
+		},
 		Mention: &mentionDB{
 			db:    db,
 			state: state,
@@ -220,6 +248,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		NotificationPolicy: &notificationPolicyDB{
+			db:    db,
+			state: state,
+		},
 		Poll: &pollDB{
 			db:    db,
 			state: state,
@@ -255,6 +287,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		StorageUsage: &storageUsageDB{
+			db:    db,
+			state: state,
+		},
 		Tag: &tagDB{
 			db:    db,
 			state: state,
@@ -275,6 +311,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		WebPush: &webPushDB{
+			db:    db,
+			state: state,
+		},
 		db: db,
 	}
 
@@ -367,6 +407,13 @@ func maxOpenConns() int {
 	return multiplier * runtime.GOMAXPROCS(0)
 }
 
+// PostgresConnConfig returns connection config for the configured
+// Postgres database, for components that need their own dedicated
+// connections outside of the main database connection pool.
+func PostgresConnConfig() (*pgx.ConnConfig, error) {
+	return deriveBunDBPGOptions()
+}
+
 // deriveBunDBPGOptions takes an application config and returns either a ready-to-use set of options
 // with sensible defaults, or an error if it's not satisfied by the provided config.
 func deriveBunDBPGOptions() (*pgx.ConnConfig, error) {
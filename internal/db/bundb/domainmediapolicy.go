@@ -0,0 +1,136 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+type domainMediaPolicyDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (d *domainMediaPolicyDB) MatchDomainMediaPolicy(ctx context.Context, domain string) (*gtsmodel.DomainMediaPolicy, error) {
+	// Normalize the domain as punycode
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	// Media from *us* is always cached.
+	if domain == "" || domain == config.GetAccountDomain() ||
+		domain == config.GetHost() {
+		return nil, nil
+	}
+
+	return d.state.Caches.MediaPolicies.Match(domain, func() ([]*gtsmodel.DomainMediaPolicy, error) {
+		return d.GetDomainMediaPolicies(ctx)
+	})
+}
+
+func (d *domainMediaPolicyDB) GetDomainMediaPolicyByID(ctx context.Context, id string) (*gtsmodel.DomainMediaPolicy, error) {
+	policy := new(gtsmodel.DomainMediaPolicy)
+	if err := d.db.NewSelect().
+		Model(policy).
+		Where("? = ?", bun.Ident("id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (d *domainMediaPolicyDB) GetDomainMediaPolicyByDomain(ctx context.Context, domain string) (*gtsmodel.DomainMediaPolicy, error) {
+	// Normalize the domain as punycode
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := new(gtsmodel.DomainMediaPolicy)
+	if err := d.db.NewSelect().
+		Model(policy).
+		Where("? = ?", bun.Ident("domain"), domain).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (d *domainMediaPolicyDB) GetDomainMediaPolicies(ctx context.Context) ([]*gtsmodel.DomainMediaPolicy, error) {
+	var policies []*gtsmodel.DomainMediaPolicy
+	err := d.db.NewSelect().
+		Model(&policies).
+		Order("domain ASC").
+		Scan(ctx, &policies)
+	return policies, err
+}
+
+func (d *domainMediaPolicyDB) PutDomainMediaPolicy(ctx context.Context, policy *gtsmodel.DomainMediaPolicy) error {
+	// Normalize the domain as punycode
+	var err error
+	policy.Domain, err = util.Punify(policy.Domain)
+	if err != nil {
+		return err
+	}
+
+	if _, err := d.db.NewInsert().
+		Model(policy).
+		Exec(ctx); err != nil {
+		return err
+	}
+	d.state.Caches.MediaPolicies.Clear()
+	return nil
+}
+
+func (d *domainMediaPolicyDB) UpdateDomainMediaPolicy(ctx context.Context, policy *gtsmodel.DomainMediaPolicy, columns ...string) error {
+	policy.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	if _, err := d.db.NewUpdate().
+		Model(policy).
+		Column(columns...).
+		Where("? = ?", bun.Ident("id"), policy.ID).
+		Exec(ctx); err != nil {
+		return err
+	}
+	d.state.Caches.MediaPolicies.Clear()
+	return nil
+}
+
+func (d *domainMediaPolicyDB) DeleteDomainMediaPolicyByID(ctx context.Context, id string) error {
+	if _, err := d.db.NewDelete().
+		Table("domain_media_policies").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx); err != nil {
+		return err
+	}
+	d.state.Caches.MediaPolicies.Clear()
+	return nil
+}
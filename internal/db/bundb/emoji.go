@@ -43,10 +43,16 @@ type emojiDB struct {
 }
 
 func (e *emojiDB) PutEmoji(ctx context.Context, emoji *gtsmodel.Emoji) error {
-	return e.state.Caches.GTS.Emoji.Store(emoji, func() error {
+	if err := e.state.Caches.GTS.Emoji.Store(emoji, func() error {
 		_, err := e.db.NewInsert().Model(emoji).Exec(ctx)
 		return err
-	})
+	}); err != nil {
+		return err
+	}
+
+	// Count new emoji towards storage usage.
+	e.updateStorageUsage(ctx, emoji, emojiSize(emoji))
+	return nil
 }
 
 func (e *emojiDB) UpdateEmoji(ctx context.Context, emoji *gtsmodel.Emoji, columns ...string) error {
@@ -56,16 +62,48 @@ func (e *emojiDB) UpdateEmoji(ctx context.Context, emoji *gtsmodel.Emoji, column
 		columns = append(columns, "updated_at")
 	}
 
+	// Image changes are only made
+	// by updating all columns.
+	var oldSize int
+	sizeChanged := emoji.IsLocal() && len(columns) == 0
+
 	// Update the emoji model in the database.
-	return e.state.Caches.GTS.Emoji.Store(emoji, func() error {
-		_, err := e.db.
-			NewUpdate().
-			Model(emoji).
-			Where("? = ?", bun.Ident("emoji.id"), emoji.ID).
-			Column(columns...).
-			Exec(ctx)
+	if err := e.state.Caches.GTS.Emoji.Store(emoji, func() error {
+		return e.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			if sizeChanged {
+				// Get the old image size
+				// to account for the change.
+				if err := tx.
+					NewSelect().
+					Table("emojis").
+					ColumnExpr("? + ?",
+						bun.Ident("image_file_size"),
+						bun.Ident("image_static_file_size"),
+					).
+					Where("? = ?", bun.Ident("id"), emoji.ID).
+					Scan(ctx, &oldSize); err != nil {
+					return err
+				}
+			}
+
+			_, err := tx.
+				NewUpdate().
+				Model(emoji).
+				Where("? = ?", bun.Ident("emoji.id"), emoji.ID).
+				Column(columns...).
+				Exec(ctx)
+			return err
+		})
+	}); err != nil {
 		return err
-	})
+	}
+
+	if sizeChanged {
+		// Image may have changed size.
+		e.updateStorageUsage(ctx, emoji, emojiSize(emoji)-oldSize)
+	}
+
+	return nil
 }
 
 func (e *emojiDB) DeleteEmojiByID(ctx context.Context, id string) error {
@@ -98,7 +136,7 @@ func (e *emojiDB) DeleteEmojiByID(ctx context.Context, id string) error {
 	// Load emoji into cache before attempting a delete,
 	// as we need it cached in order to trigger the invalidate
 	// callback. This in turn invalidates others.
-	_, err := e.GetEmojiByID(
+	emoji, err := e.GetEmojiByID(
 		gtscontext.SetBarebones(ctx),
 		id,
 	)
@@ -109,6 +147,12 @@ func (e *emojiDB) DeleteEmojiByID(ctx context.Context, id string) error {
 		return err
 	}
 
+	if emoji != nil {
+		// On return, drop deleted
+		// emoji from storage usage.
+		defer e.updateStorageUsage(ctx, emoji, -emojiSize(emoji))
+	}
+
 	return e.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
 		// Delete relational links between this emoji
 		// and any statuses using it, returning the
@@ -210,6 +254,34 @@ func (e *emojiDB) DeleteEmojiByID(ctx context.Context, id string) error {
 	})
 }
 
+// updateStorageUsage adds size to the storage usage of the
+// instance account, which owns all local emoji. Remote emoji
+// are not counted, so this is a no-op for remote emoji.
+func (e *emojiDB) updateStorageUsage(ctx context.Context, emoji *gtsmodel.Emoji, size int) {
+	if !emoji.IsLocal() || size == 0 {
+		return
+	}
+
+	instanceAcct, err := e.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		log.Errorf(ctx, "error getting instance account: %v", err)
+		return
+	}
+
+	if err := e.state.DB.AddAccountStorageUsage(ctx, &gtsmodel.AccountStorageUsage{
+		AccountID: instanceAcct.ID,
+		EmojiSize: size,
+	}); err != nil {
+		log.Errorf(ctx, "error updating storage usage for instance account: %v", err)
+	}
+}
+
+// emojiSize returns the stored size of
+// an emoji image and its static image.
+func emojiSize(emoji *gtsmodel.Emoji) int {
+	return emoji.ImageFileSize + emoji.ImageStaticFileSize
+}
+
 func (e *emojiDB) GetEmojisBy(ctx context.Context, domain string, includeDisabled bool, includeEnabled bool, shortcode string, maxShortcodeDomain string, minShortcodeDomain string, limit int) ([]*gtsmodel.Emoji, error) {
 	emojiIDs := []string{}
 
@@ -402,6 +474,36 @@ func (e *emojiDB) GetCachedEmojisOlderThan(ctx context.Context, olderThan time.T
 	return e.GetEmojisByIDs(ctx, emojiIDs)
 }
 
+func (e *emojiDB) GetCachedEmojisByDomain(ctx context.Context, domain string, maxID string, limit int) ([]*gtsmodel.Emoji, error) {
+	var emojiIDs []string
+
+	q := e.db.NewSelect().
+		Table("emojis").
+		Column("id").
+		Where("cached = true").
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			// Include subdomains of domain.
+			return q.
+				Where("? = ?", bun.Ident("domain"), domain).
+				WhereOr("? LIKE ?", bun.Ident("domain"), "%."+domain)
+		}).
+		Order("id DESC")
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("id"), maxID)
+	}
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &emojiIDs); err != nil {
+		return nil, err
+	}
+
+	return e.GetEmojisByIDs(ctx, emojiIDs)
+}
+
 func (e *emojiDB) GetUseableEmojis(ctx context.Context) ([]*gtsmodel.Emoji, error) {
 	emojiIDs := []string{}
 
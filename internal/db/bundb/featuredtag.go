@@ -0,0 +1,151 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type featuredTagDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (f *featuredTagDB) GetFeaturedTagByID(ctx context.Context, id string) (*gtsmodel.FeaturedTag, error) {
+	featuredTag := new(gtsmodel.FeaturedTag)
+
+	if err := f.db.
+		NewSelect().
+		Model(featuredTag).
+		Where("? = ?", bun.Ident("featured_tag.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := f.populateFeaturedTag(ctx, featuredTag); err != nil {
+		return nil, err
+	}
+
+	return featuredTag, nil
+}
+
+func (f *featuredTagDB) GetAccountFeaturedTags(ctx context.Context, accountID string) ([]*gtsmodel.FeaturedTag, error) {
+	featuredTags := []*gtsmodel.FeaturedTag{}
+
+	if err := f.db.
+		NewSelect().
+		Model(&featuredTags).
+		Where("? = ?", bun.Ident("featured_tag.account_id"), accountID).
+		Order("featured_tag.id ASC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	for _, featuredTag := range featuredTags {
+		if err := f.populateFeaturedTag(ctx, featuredTag); err != nil {
+			return nil, err
+		}
+	}
+
+	return featuredTags, nil
+}
+
+func (f *featuredTagDB) populateFeaturedTag(ctx context.Context, featuredTag *gtsmodel.FeaturedTag) error {
+	if featuredTag.Tag != nil {
+		return nil
+	}
+
+	var err error
+	featuredTag.Tag, err = f.state.DB.GetTag(
+		gtscontext.SetBarebones(ctx),
+		featuredTag.TagID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error populating featured tag tag: %w", err)
+	}
+
+	return nil
+}
+
+func (f *featuredTagDB) PutFeaturedTag(ctx context.Context, featuredTag *gtsmodel.FeaturedTag) error {
+	_, err := f.db.
+		NewInsert().
+		Model(featuredTag).
+		Exec(ctx)
+	return err
+}
+
+func (f *featuredTagDB) DeleteFeaturedTagByID(ctx context.Context, id string) error {
+	_, err := f.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("featured_tags"), bun.Ident("featured_tag")).
+		Where("? = ?", bun.Ident("featured_tag.id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (f *featuredTagDB) DeleteAccountFeaturedTags(ctx context.Context, accountID string) error {
+	_, err := f.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("featured_tags"), bun.Ident("featured_tag")).
+		Where("? = ?", bun.Ident("featured_tag.account_id"), accountID).
+		Exec(ctx)
+	return err
+}
+
+func (f *featuredTagDB) GetAccountTagStats(ctx context.Context, accountID string, tagID string) (int, time.Time, error) {
+	var stats []struct {
+		Count        int
+		LastStatusAt time.Time
+	}
+
+	if err := f.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("status_to_tag")).
+		Join(
+			"INNER JOIN ? AS ? ON ? = ?",
+			bun.Ident("statuses"), bun.Ident("status"),
+			bun.Ident("status.id"), bun.Ident("status_to_tag.status_id"),
+		).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		ColumnExpr("MAX(?) AS ?", bun.Ident("status.created_at"), bun.Ident("last_status_at")).
+		Where("? = ?", bun.Ident("status_to_tag.tag_id"), tagID).
+		Where("? = ?", bun.Ident("status.account_id"), accountID).
+		Where("? IN (?)", bun.Ident("status.visibility"), bun.In([]gtsmodel.Visibility{
+			gtsmodel.VisibilityPublic,
+			gtsmodel.VisibilityUnlocked,
+		})).
+		Scan(ctx, &stats); err != nil {
+		return 0, time.Time{}, err
+	}
+
+	if len(stats) == 0 {
+		return 0, time.Time{}, nil
+	}
+
+	return stats[0].Count, stats[0].LastStatusAt, nil
+}
@@ -0,0 +1,107 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type importDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (i *importDB) GetImportByID(ctx context.Context, id string) (*gtsmodel.Import, error) {
+	imp := new(gtsmodel.Import)
+
+	if err := i.db.
+		NewSelect().
+		Model(imp).
+		Where("? = ?", bun.Ident("import.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return imp, nil
+}
+
+func (i *importDB) GetAccountImports(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.Import, error) {
+	var (
+		maxID = page.GetMax()
+		minID = page.GetMin()
+		limit = page.GetLimit()
+	)
+
+	imports := make([]*gtsmodel.Import, 0, limit)
+
+	q := i.db.
+		NewSelect().
+		Model(&imports).
+		Where("? = ?", bun.Ident("import.account_id"), accountID).
+		Order("import.id DESC")
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("import.id"), maxID)
+	}
+
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("import.id"), minID)
+	}
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return imports, nil
+}
+
+func (i *importDB) PutImport(ctx context.Context, imp *gtsmodel.Import) error {
+	_, err := i.db.
+		NewInsert().
+		Model(imp).
+		Exec(ctx)
+
+	return err
+}
+
+func (i *importDB) UpdateImport(ctx context.Context, imp *gtsmodel.Import, columns ...string) error {
+	// Update the import's last-updated
+	imp.UpdatedAt = time.Now()
+	if len(columns) != 0 {
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := i.db.
+		NewUpdate().
+		Model(imp).
+		Where("? = ?", bun.Ident("import.id"), imp.ID).
+		Column(columns...).
+		Exec(ctx)
+
+	return err
+}
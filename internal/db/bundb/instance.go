@@ -61,6 +61,19 @@ func (i *instanceDB) CountInstanceUsers(ctx context.Context, domain string) (int
 	return count, nil
 }
 
+func (i *instanceDB) CountInstanceActiveUsers(ctx context.Context, since time.Time) (int, error) {
+	return i.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("users"), bun.Ident("user")).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				WhereOr("? >= ?", bun.Ident("user.current_sign_in_at"), since).
+				WhereOr("? >= ?", bun.Ident("user.last_active_at"), since)
+		}).
+		Where("? = ?", bun.Ident("user.disabled"), false).
+		Count(ctx)
+}
+
 func (i *instanceDB) CountInstanceStatuses(ctx context.Context, domain string) (int, error) {
 	q := i.db.
 		NewSelect().
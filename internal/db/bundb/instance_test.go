@@ -26,6 +26,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 type InstanceTestSuite struct {
@@ -44,6 +45,40 @@ func (suite *InstanceTestSuite) TestCountInstanceUsersRemote() {
 	suite.Equal(1, count)
 }
 
+func (suite *InstanceTestSuite) TestCountInstanceActiveUsers() {
+	for since, expect := range map[string]int{
+		"2022-06-01T00:00:00Z": 3,
+		"2022-06-05T00:00:00Z": 1,
+		"2022-06-06T00:00:00Z": 0,
+	} {
+		count, err := suite.db.CountInstanceActiveUsers(context.Background(), testrig.TimeMustParse(since))
+		suite.NoError(err)
+		suite.Equal(expect, count, "since "+since)
+	}
+}
+
+func (suite *InstanceTestSuite) TestCountInstanceActiveUsersLastActive() {
+	ctx := context.Background()
+
+	// Mark a user who last signed in on
+	// 2022-06-04 as active more recently.
+	testUser := &gtsmodel.User{}
+	*testUser = *suite.testUsers["local_account_1"]
+	testUser.LastActiveAt = testrig.TimeMustParse("2022-06-05T12:00:00Z")
+	if err := suite.db.UpdateUser(ctx, testUser, "last_active_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	count, err := suite.db.CountInstanceActiveUsers(ctx, testrig.TimeMustParse("2022-06-05T00:00:00Z"))
+	suite.NoError(err)
+	suite.Equal(2, count)
+
+	// Sign in times should be left alone.
+	dbUser, err := suite.db.GetUserByID(ctx, testUser.ID)
+	suite.NoError(err)
+	suite.Equal(suite.testUsers["local_account_1"].CurrentSignInAt, dbUser.CurrentSignInAt)
+}
+
 func (suite *InstanceTestSuite) TestCountInstanceStatuses() {
 	count, err := suite.db.CountInstanceStatuses(context.Background(), config.GetHost())
 	suite.NoError(err)
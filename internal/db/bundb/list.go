@@ -160,6 +160,17 @@ func (l *listDB) UpdateList(ctx context.Context, list *gtsmodel.List, columns ..
 		if err := l.state.Timelines.List.RemoveTimeline(ctx, list.ID); err != nil {
 			log.Errorf(ctx, "error invalidating list timeline: %q", err)
 		}
+
+		// If the list is (or was) exclusive, the owner's
+		// home timeline needs to be rebuilt to reflect it.
+		if len(columns) == 0 ||
+			slices.Contains(columns, "exclusive") ||
+			util.PtrValueOr(list.Exclusive, false) {
+			l.state.Caches.GTS.FollowIDs.Invalidate("x>" + list.AccountID)
+			if err := l.state.Timelines.Home.RemoveTimeline(ctx, list.AccountID); err != nil {
+				log.Errorf(ctx, "error invalidating home timeline: %q", err)
+			}
+		}
 	}()
 
 	return l.state.Caches.GTS.List.Store(list, func() error {
@@ -175,7 +186,7 @@ func (l *listDB) UpdateList(ctx context.Context, list *gtsmodel.List, columns ..
 func (l *listDB) DeleteListByID(ctx context.Context, id string) error {
 	// Load list by ID into cache to ensure we can perform
 	// all necessary cache invalidation hooks on removal.
-	_, err := l.GetListByID(
+	list, err := l.GetListByID(
 		// Don't populate the entry;
 		// we only want the list ID.
 		gtscontext.SetBarebones(ctx),
@@ -196,6 +207,12 @@ func (l *listDB) DeleteListByID(ctx context.Context, id string) error {
 		if err := l.state.Timelines.List.RemoveTimeline(ctx, id); err != nil {
 			log.Errorf(ctx, "error invalidating list timeline: %q", err)
 		}
+
+		if list != nil {
+			// Members of an exclusive list need
+			// to reappear in the home timeline.
+			l.invalidateHomeTimelineForList(ctx, list)
+		}
 	}()
 
 	return l.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
@@ -511,6 +528,16 @@ func (l *listDB) PutListEntries(ctx context.Context, entries []*gtsmodel.ListEnt
 			if err := l.state.Timelines.List.RemoveTimeline(ctx, id); err != nil {
 				log.Errorf(ctx, "error invalidating list timeline: %q", err)
 			}
+
+			list, err := l.GetListByID(gtscontext.SetBarebones(ctx), id)
+			if err != nil {
+				log.Errorf(ctx, "error getting list %s: %v", id, err)
+				continue
+			}
+
+			// New members of an exclusive list need
+			// to disappear from the home timeline.
+			l.invalidateHomeTimelineForList(ctx, list)
 		}
 	}()
 
@@ -557,6 +584,16 @@ func (l *listDB) DeleteListEntry(ctx context.Context, id string) error {
 		if err := l.state.Timelines.List.RemoveTimeline(ctx, entry.ListID); err != nil {
 			log.Errorf(ctx, "error invalidating list timeline: %q", err)
 		}
+
+		list, err := l.GetListByID(gtscontext.SetBarebones(ctx), entry.ListID)
+		if err != nil {
+			log.Errorf(ctx, "error getting list %s: %v", entry.ListID, err)
+			return
+		}
+
+		// Removed members of an exclusive list
+		// need to reappear in the home timeline.
+		l.invalidateHomeTimelineForList(ctx, list)
 	}()
 
 	// Finally delete the list entry.
@@ -591,6 +628,32 @@ func (l *listDB) DeleteListEntriesForFollowID(ctx context.Context, followID stri
 	return nil
 }
 
+func (l *listDB) GetFollowIDsInExclusiveLists(ctx context.Context, accountID string) ([]string, error) {
+	return l.state.Caches.GTS.FollowIDs.Load("x>"+accountID, func() ([]string, error) {
+		var followIDs []string
+
+		// Follow IDs not in cache, perform DB query!
+		if err := l.db.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("list_entries"), bun.Ident("list_entry")).
+			Join(
+				"JOIN ? AS ? ON ? = ?",
+				bun.Ident("lists"), bun.Ident("list"),
+				bun.Ident("list_entry.list_id"), bun.Ident("list.id"),
+			).
+			// Select only follow IDs from table.
+			Column("list_entry.follow_id").
+			Distinct().
+			Where("? = ?", bun.Ident("list.account_id"), accountID).
+			Where("? = ?", bun.Ident("list.exclusive"), true).
+			Scan(ctx, &followIDs); err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, err
+		}
+
+		return followIDs, nil
+	})
+}
+
 func (l *listDB) ListIncludesAccount(ctx context.Context, listID string, accountID string) (bool, error) {
 	exists, err := l.db.
 		NewSelect().
@@ -606,3 +669,21 @@ func (l *listDB) ListIncludesAccount(ctx context.Context, listID string, account
 
 	return exists, err
 }
+
+// invalidateHomeTimelineForList removes the home timeline
+// (and cached exclusive list follow IDs) of the owner of the
+// given list if the list is exclusive, so that it gets
+// rebuilt to reflect the list's members.
+func (l *listDB) invalidateHomeTimelineForList(ctx context.Context, list *gtsmodel.List) {
+	if !util.PtrValueOr(list.Exclusive, false) {
+		// Home timeline
+		// not affected.
+		return
+	}
+
+	l.state.Caches.GTS.FollowIDs.Invalidate("x>" + list.AccountID)
+
+	if err := l.state.Timelines.Home.RemoveTimeline(ctx, list.AccountID); err != nil {
+		log.Errorf(ctx, "error invalidating home timeline: %q", err)
+	}
+}
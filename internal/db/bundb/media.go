@@ -27,6 +27,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
@@ -111,10 +112,16 @@ func (m *mediaDB) getAttachment(ctx context.Context, lookup string, dbQuery func
 }
 
 func (m *mediaDB) PutAttachment(ctx context.Context, media *gtsmodel.MediaAttachment) error {
-	return m.state.Caches.GTS.Media.Store(media, func() error {
+	if err := m.state.Caches.GTS.Media.Store(media, func() error {
 		_, err := m.db.NewInsert().Model(media).Exec(ctx)
 		return err
-	})
+	}); err != nil {
+		return err
+	}
+
+	// Count new media towards storage usage.
+	m.updateStorageUsage(ctx, media, 1)
+	return nil
 }
 
 func (m *mediaDB) UpdateAttachment(ctx context.Context, media *gtsmodel.MediaAttachment, columns ...string) error {
@@ -229,8 +236,13 @@ func (m *mediaDB) DeleteAttachment(ctx context.Context, id string) error {
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	// Drop deleted media from storage usage.
+	m.updateStorageUsage(ctx, media, -1)
+	return nil
 }
 
 func (m *mediaDB) GetAttachments(ctx context.Context, page *paging.Page) ([]*gtsmodel.MediaAttachment, error) {
@@ -308,3 +320,170 @@ func (m *mediaDB) GetCachedAttachmentsOlderThan(ctx context.Context, olderThan t
 
 	return m.GetAttachmentsByIDs(ctx, attachmentIDs)
 }
+
+func (m *mediaDB) GetCachedAttachmentsByDomain(ctx context.Context, domain string, maxID string, limit int) ([]*gtsmodel.MediaAttachment, error) {
+	attachmentIDs := make([]string, 0, limit)
+
+	q := m.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("media_attachments"), bun.Ident("media_attachment")).
+		Column("media_attachment.id").
+		Join("JOIN ? AS ? ON ? = ?",
+			bun.Ident("accounts"), bun.Ident("account"),
+			bun.Ident("media_attachment.account_id"), bun.Ident("account.id"),
+		).
+		Where("? = true", bun.Ident("media_attachment.cached")).
+		Where("? IS NOT NULL", bun.Ident("media_attachment.remote_url")).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			// Include subdomains of domain.
+			return q.
+				Where("? = ?", bun.Ident("account.domain"), domain).
+				WhereOr("? LIKE ?", bun.Ident("account.domain"), "%."+domain)
+		}).
+		Order("media_attachment.id DESC")
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("media_attachment.id"), maxID)
+	}
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &attachmentIDs); err != nil {
+		return nil, err
+	}
+
+	return m.GetAttachmentsByIDs(ctx, attachmentIDs)
+}
+
+func (m *mediaDB) GetMediaBlobByPath(ctx context.Context, path string) (*gtsmodel.MediaBlob, error) {
+	var blob gtsmodel.MediaBlob
+
+	if err := m.db.
+		NewSelect().
+		Model(&blob).
+		Where("? = ?", bun.Ident("media_blob.path"), path).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &blob, nil
+}
+
+func (m *mediaDB) AcquireMediaBlob(ctx context.Context, blob *gtsmodel.MediaBlob, storeFn func() error) error {
+	blob.UpdatedAt = time.Now()
+	blob.RefCount = 1
+
+	return m.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Insert the blob with this as the one reference,
+		// or add a reference if it exists. This locks the
+		// row until the transaction is done, so it can't
+		// be deleted by a concurrent release meanwhile.
+		if err := tx.
+			NewInsert().
+			Model(blob).
+			On("CONFLICT (?) DO UPDATE", bun.Ident("path")).
+			Set("? = ? + 1", bun.Ident("ref_count"), bun.Ident("media_blob.ref_count")).
+			Set("? = ?", bun.Ident("updated_at"), blob.UpdatedAt).
+			Returning("?", bun.Ident("ref_count")).
+			Scan(ctx, &blob.RefCount); err != nil {
+			return gtserror.Newf("error upserting media blob: %w", err)
+		}
+
+		if blob.RefCount > 1 || storeFn == nil {
+			// Already referenced, so
+			// it's already in storage.
+			return nil
+		}
+
+		// We hold the only reference, and the blob
+		// may have been removed from storage by the
+		// release of a previous last reference.
+		return storeFn()
+	})
+}
+
+func (m *mediaDB) ReleaseMediaBlob(ctx context.Context, path string, deleteFn func() error) (int, error) {
+	var refs int
+
+	// Drop this reference, returning the number remaining.
+	if err := m.db.
+		NewUpdate().
+		Table("media_blobs").
+		Set("? = ? - 1", bun.Ident("ref_count"), bun.Ident("ref_count")).
+		Set("? = ?", bun.Ident("updated_at"), time.Now()).
+		Where("? = ?", bun.Ident("path"), path).
+		Where("? > 0", bun.Ident("ref_count")).
+		Returning("?", bun.Ident("ref_count")).
+		Scan(ctx, &refs); err != nil {
+		return 0, err
+	}
+
+	if refs > 0 {
+		// Still referenced.
+		return refs, nil
+	}
+
+	// That was the last reference, delete the blob.
+	var deleteErr error
+	err := m.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Lock the blob, only if it's still unreferenced. Until
+		// the transaction is done, it can't be acquired again.
+		if err := tx.
+			NewUpdate().
+			Table("media_blobs").
+			Set("? = ?", bun.Ident("updated_at"), time.Now()).
+			Where("? = ?", bun.Ident("path"), path).
+			Where("? <= 0", bun.Ident("ref_count")).
+			Returning("?", bun.Ident("ref_count")).
+			Scan(ctx, &refs); err != nil {
+			return err
+		}
+
+		if deleteFn != nil {
+			// On error the file is left
+			// orphaned, to be pruned later.
+			deleteErr = deleteFn()
+		}
+
+		_, err := tx.
+			NewDelete().
+			Table("media_blobs").
+			Where("? = ?", bun.Ident("path"), path).
+			Exec(ctx)
+		return err
+	})
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return 0, err
+	}
+
+	// ErrNoEntries means it was acquired
+	// again (or deleted) in the meantime.
+	return 0, deleteErr
+}
+
+// updateStorageUsage adds the size of given media, multiplied by
+// sign, to the storage usage of the local account owning it. Remote
+// media is not counted, so this is a no-op for remote media.
+func (m *mediaDB) updateStorageUsage(ctx context.Context, media *gtsmodel.MediaAttachment, sign int) {
+	if media.RemoteURL != "" || media.AccountID == "" {
+		return
+	}
+
+	size := sign * (media.File.FileSize + media.Thumbnail.FileSize)
+	delta := &gtsmodel.AccountStorageUsage{AccountID: media.AccountID}
+
+	switch {
+	case util.PtrValueOr(media.Avatar, false):
+		delta.AvatarSize = size
+	case util.PtrValueOr(media.Header, false):
+		delta.HeaderSize = size
+	default:
+		delta.MediaSize = size
+	}
+
+	if err := m.state.DB.AddAccountStorageUsage(ctx, delta); err != nil {
+		log.Errorf(ctx, "error updating storage usage for account %s: %v", media.AccountID, err)
+	}
+}
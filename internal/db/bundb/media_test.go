@@ -19,10 +19,13 @@ package bundb_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
 type MediaTestSuite struct {
@@ -50,6 +53,75 @@ func (suite *MediaTestSuite) TestGetCachedAttachmentsOlderThan() {
 	suite.Len(attachments, 3)
 }
 
+func (suite *MediaTestSuite) TestAcquireReleaseMediaBlob() {
+	ctx := context.Background()
+	path := "blobs/ab/abcdef.png"
+
+	var stored int
+	storeFn := func() error {
+		stored++
+		return nil
+	}
+
+	// Acquire the same blob twice, only
+	// storing it for the first reference.
+	for i := 1; i <= 2; i++ {
+		blob := &gtsmodel.MediaBlob{
+			Path:     path,
+			Hash:     "abcdef",
+			FileSize: 1024,
+		}
+		suite.NoError(suite.db.AcquireMediaBlob(ctx, blob, storeFn))
+		suite.Equal(i, blob.RefCount)
+		suite.Equal(1, stored)
+	}
+
+	var deleted int
+	deleteFn := func() error {
+		deleted++
+		return nil
+	}
+
+	// First release leaves it referenced.
+	refs, err := suite.db.ReleaseMediaBlob(ctx, path, deleteFn)
+	suite.NoError(err)
+	suite.Equal(1, refs)
+	suite.Zero(deleted)
+
+	// Last release deletes it.
+	refs, err = suite.db.ReleaseMediaBlob(ctx, path, deleteFn)
+	suite.NoError(err)
+	suite.Zero(refs)
+	suite.Equal(1, deleted)
+
+	_, err = suite.db.GetMediaBlobByPath(ctx, path)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Releasing it again finds nothing.
+	_, err = suite.db.ReleaseMediaBlob(ctx, path, deleteFn)
+	suite.ErrorIs(err, db.ErrNoEntries)
+	suite.Equal(1, deleted)
+}
+
+func (suite *MediaTestSuite) TestAcquireMediaBlobStoreFails() {
+	ctx := context.Background()
+	path := "blobs/ab/abcdef.png"
+
+	// A failure to store the blob
+	// shouldn't leave it referenced.
+	err := suite.db.AcquireMediaBlob(ctx, &gtsmodel.MediaBlob{
+		Path:     path,
+		Hash:     "abcdef",
+		FileSize: 1024,
+	}, func() error {
+		return errors.New("oh no")
+	})
+	suite.ErrorContains(err, "oh no")
+
+	_, err = suite.db.GetMediaBlobByPath(ctx, path)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
 func TestMediaTestSuite(t *testing.T) {
 	suite.Run(t, new(MediaTestSuite))
 }
@@ -0,0 +1,84 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type mediaHashDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (m *mediaHashDB) MatchMediaSHA256(ctx context.Context, sum string) (*gtsmodel.MediaHash, error) {
+	return m.state.Caches.MediaHashes.MatchSHA256(sum, func() ([]*gtsmodel.MediaHash, error) {
+		return m.GetMediaHashes(ctx)
+	})
+}
+
+func (m *mediaHashDB) MatchMediaPHash(ctx context.Context, phash uint64, maxDistance int) (*gtsmodel.MediaHash, error) {
+	return m.state.Caches.MediaHashes.MatchPHash(phash, maxDistance, func() ([]*gtsmodel.MediaHash, error) {
+		return m.GetMediaHashes(ctx)
+	})
+}
+
+func (m *mediaHashDB) GetMediaHashByID(ctx context.Context, id string) (*gtsmodel.MediaHash, error) {
+	hash := new(gtsmodel.MediaHash)
+	if err := m.db.NewSelect().
+		Model(hash).
+		Where("? = ?", bun.Ident("id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return hash, nil
+}
+
+func (m *mediaHashDB) GetMediaHashes(ctx context.Context) ([]*gtsmodel.MediaHash, error) {
+	var hashes []*gtsmodel.MediaHash
+	err := m.db.NewSelect().
+		Model(&hashes).
+		Order("id DESC").
+		Scan(ctx, &hashes)
+	return hashes, err
+}
+
+func (m *mediaHashDB) PutMediaHash(ctx context.Context, hash *gtsmodel.MediaHash) error {
+	if _, err := m.db.NewInsert().
+		Model(hash).
+		Exec(ctx); err != nil {
+		return err
+	}
+	m.state.Caches.MediaHashes.Clear()
+	return nil
+}
+
+func (m *mediaHashDB) DeleteMediaHashByID(ctx context.Context, id string) error {
+	if _, err := m.db.NewDelete().
+		Table("media_hashes").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx); err != nil {
+		return err
+	}
+	m.state.Caches.MediaHashes.Clear()
+	return nil
+}
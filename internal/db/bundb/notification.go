@@ -254,6 +254,10 @@ func (n *notificationDB) GetAccountNotifications(
 	// Return only notifs for this account.
 	q = q.Where("? = ?", bun.Ident("notification.target_account_id"), accountID)
 
+	// Return only notifs that weren't filtered
+	// by the account's notification policy.
+	q = q.Where("? = ?", bun.Ident("notification.filtered"), false)
+
 	if limit > 0 {
 		q = q.Limit(limit)
 	}
@@ -407,3 +411,84 @@ func (n *notificationDB) DeleteNotificationsForStatus(ctx context.Context, statu
 		Exec(ctx)
 	return err
 }
+
+func (n *notificationDB) UnfilterNotifications(ctx context.Context, targetAccountID string, originAccountID string) error {
+	notifIDs, err := n.getFilteredNotificationIDs(ctx, targetAccountID, originAccountID)
+	if err != nil {
+		return err
+	}
+
+	if len(notifIDs) == 0 {
+		// Nothing to do.
+		return nil
+	}
+
+	defer func() {
+		// Invalidate all IDs on return.
+		for _, id := range notifIDs {
+			n.state.Caches.GTS.Notification.Invalidate("ID", id)
+		}
+	}()
+
+	_, err = n.db.NewUpdate().
+		Table("notifications").
+		Set("? = ?", bun.Ident("filtered"), false).
+		Where("? IN (?)", bun.Ident("id"), bun.In(notifIDs)).
+		Exec(ctx)
+	return err
+}
+
+func (n *notificationDB) DeleteFilteredNotifications(ctx context.Context, targetAccountID string, originAccountID string) error {
+	notifIDs, err := n.getFilteredNotificationIDs(ctx, targetAccountID, originAccountID)
+	if err != nil {
+		return err
+	}
+
+	if len(notifIDs) == 0 {
+		// Nothing to do.
+		return nil
+	}
+
+	defer func() {
+		// Invalidate all IDs on return.
+		for _, id := range notifIDs {
+			n.state.Caches.GTS.Notification.Invalidate("ID", id)
+		}
+	}()
+
+	// Load all notif into cache, this *really* isn't great
+	// but it is the only way we can ensure we invalidate all
+	// related caches correctly (e.g. visibility).
+	for _, id := range notifIDs {
+		_, err := n.GetNotificationByID(ctx, id)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return err
+		}
+	}
+
+	// Finally delete all from DB.
+	_, err = n.db.NewDelete().
+		Table("notifications").
+		Where("? IN (?)", bun.Ident("id"), bun.In(notifIDs)).
+		Exec(ctx)
+	return err
+}
+
+// getFilteredNotificationIDs returns the IDs of all filtered
+// notifications targeting targetAccountID from originAccountID.
+func (n *notificationDB) getFilteredNotificationIDs(ctx context.Context, targetAccountID string, originAccountID string) ([]string, error) {
+	var notifIDs []string
+
+	if _, err := n.db.
+		NewSelect().
+		Column("id").
+		Table("notifications").
+		Where("? = ?", bun.Ident("target_account_id"), targetAccountID).
+		Where("? = ?", bun.Ident("origin_account_id"), originAccountID).
+		Where("? = ?", bun.Ident("filtered"), true).
+		Exec(ctx, &notifIDs); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, err
+	}
+
+	return notifIDs, nil
+}
@@ -0,0 +1,318 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type notificationPolicyDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (n *notificationPolicyDB) GetNotificationPolicy(ctx context.Context, accountID string) (*gtsmodel.NotificationPolicy, error) {
+	policy := new(gtsmodel.NotificationPolicy)
+
+	if err := n.db.
+		NewSelect().
+		Model(policy).
+		Where("? = ?", bun.Ident("notification_policy.account_id"), accountID).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (n *notificationPolicyDB) PutNotificationPolicy(ctx context.Context, policy *gtsmodel.NotificationPolicy) error {
+	_, err := n.db.
+		NewInsert().
+		Model(policy).
+		Exec(ctx)
+	return err
+}
+
+func (n *notificationPolicyDB) UpdateNotificationPolicy(ctx context.Context, policy *gtsmodel.NotificationPolicy, columns ...string) error {
+	policy.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := n.db.
+		NewUpdate().
+		Model(policy).
+		Where("? = ?", bun.Ident("notification_policy.id"), policy.ID).
+		Column(columns...).
+		Exec(ctx)
+	return err
+}
+
+func (n *notificationPolicyDB) GetNotificationRequestByID(ctx context.Context, id string) (*gtsmodel.NotificationRequest, error) {
+	return n.getNotificationRequest(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("? = ?", bun.Ident("notification_request.id"), id)
+	})
+}
+
+func (n *notificationPolicyDB) GetNotificationRequest(ctx context.Context, accountID string, fromAccountID string) (*gtsmodel.NotificationRequest, error) {
+	return n.getNotificationRequest(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.
+			Where("? = ?", bun.Ident("notification_request.account_id"), accountID).
+			Where("? = ?", bun.Ident("notification_request.from_account_id"), fromAccountID)
+	})
+}
+
+func (n *notificationPolicyDB) getNotificationRequest(ctx context.Context, where func(*bun.SelectQuery) *bun.SelectQuery) (*gtsmodel.NotificationRequest, error) {
+	request := new(gtsmodel.NotificationRequest)
+
+	q := n.db.
+		NewSelect().
+		Model(request)
+
+	if err := where(q).Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// Only a barebones model was requested.
+		return request, nil
+	}
+
+	if err := n.PopulateNotificationRequest(ctx, request); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+func (n *notificationPolicyDB) GetAccountNotificationRequests(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.NotificationRequest, error) {
+	var (
+		maxID = page.GetMax()
+		minID = page.GetMin()
+		limit = page.GetLimit()
+	)
+
+	requests := make([]*gtsmodel.NotificationRequest, 0, limit)
+
+	q := n.db.
+		NewSelect().
+		Model(&requests).
+		Where("? = ?", bun.Ident("notification_request.account_id"), accountID).
+		Order("notification_request.id DESC")
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("notification_request.id"), maxID)
+	}
+
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("notification_request.id"), minID)
+	}
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	for _, request := range requests {
+		if err := n.PopulateNotificationRequest(ctx, request); err != nil {
+			return nil, gtserror.Newf("error populating notification request %s: %w", request.ID, err)
+		}
+	}
+
+	return requests, nil
+}
+
+func (n *notificationPolicyDB) CountAccountNotificationRequests(ctx context.Context, accountID string) (int, int, error) {
+	var counts []int
+
+	if err := n.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("notification_requests"), bun.Ident("notification_request")).
+		Column("notification_request.notifications_count").
+		Where("? = ?", bun.Ident("notification_request.account_id"), accountID).
+		Scan(ctx, &counts); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return 0, 0, err
+	}
+
+	var notifications int
+	for _, count := range counts {
+		notifications += count
+	}
+
+	return len(counts), notifications, nil
+}
+
+func (n *notificationPolicyDB) PopulateNotificationRequest(ctx context.Context, request *gtsmodel.NotificationRequest) error {
+	var (
+		errs gtserror.MultiError
+		err  error
+	)
+
+	if request.Account == nil {
+		request.Account, err = n.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			request.AccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating notification request account: %w", err)
+		}
+	}
+
+	if request.FromAccount == nil {
+		request.FromAccount, err = n.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			request.FromAccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating notification request from account: %w", err)
+		}
+	}
+
+	if request.LastStatusID != "" && request.LastStatus == nil {
+		request.LastStatus, err = n.state.DB.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			request.LastStatusID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			// Status may have been deleted
+			// since, that's not an error.
+			errs.Appendf("error populating notification request last status: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (n *notificationPolicyDB) PutNotificationRequest(ctx context.Context, request *gtsmodel.NotificationRequest) error {
+	_, err := n.db.
+		NewInsert().
+		Model(request).
+		Exec(ctx)
+	return err
+}
+
+func (n *notificationPolicyDB) UpdateNotificationRequest(ctx context.Context, request *gtsmodel.NotificationRequest, columns ...string) error {
+	request.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := n.db.
+		NewUpdate().
+		Model(request).
+		Where("? = ?", bun.Ident("notification_request.id"), request.ID).
+		Column(columns...).
+		Exec(ctx)
+	return err
+}
+
+func (n *notificationPolicyDB) IncrementNotificationRequest(ctx context.Context, request *gtsmodel.NotificationRequest) error {
+	request.UpdatedAt = time.Now()
+	request.NotificationsCount = 1
+
+	// Insert the request with this as the one notification,
+	// or bump the count on the existing request. Done in one
+	// statement so concurrent notifications can't race it.
+	_, err := n.db.
+		NewInsert().
+		Model(request).
+		On("CONFLICT (?, ?) DO UPDATE", bun.Ident("account_id"), bun.Ident("from_account_id")).
+		Set("? = ? + 1", bun.Ident("notifications_count"), bun.Ident("notification_request.notifications_count")).
+		Set("? = COALESCE(?, ?)", bun.Ident("last_status_id"), bun.Ident("excluded.last_status_id"), bun.Ident("notification_request.last_status_id")).
+		Set("? = ?", bun.Ident("updated_at"), request.UpdatedAt).
+		Exec(ctx)
+	return err
+}
+
+func (n *notificationPolicyDB) DeleteNotificationRequestByID(ctx context.Context, id string) error {
+	_, err := n.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("notification_requests"), bun.Ident("notification_request")).
+		Where("? = ?", bun.Ident("notification_request.id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (n *notificationPolicyDB) IsNotificationPermitted(ctx context.Context, accountID string, fromAccountID string) (bool, error) {
+	exists, err := n.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("notification_permissions"), bun.Ident("notification_permission")).
+		Column("notification_permission.id").
+		Where("? = ?", bun.Ident("notification_permission.account_id"), accountID).
+		Where("? = ?", bun.Ident("notification_permission.from_account_id"), fromAccountID).
+		Exists(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, err
+	}
+	return exists, nil
+}
+
+func (n *notificationPolicyDB) PutNotificationPermission(ctx context.Context, permission *gtsmodel.NotificationPermission) error {
+	_, err := n.db.
+		NewInsert().
+		Model(permission).
+		Exec(ctx)
+	return err
+}
+
+func (n *notificationPolicyDB) DeleteAccountNotificationPolicyData(ctx context.Context, accountID string) error {
+	return n.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.
+			NewDelete().
+			TableExpr("? AS ?", bun.Ident("notification_policies"), bun.Ident("notification_policy")).
+			Where("? = ?", bun.Ident("notification_policy.account_id"), accountID).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		// Delete requests + permissions
+		// both to and from this account.
+		for _, table := range []string{
+			"notification_requests",
+			"notification_permissions",
+		} {
+			if _, err := tx.
+				NewDelete().
+				Table(table).
+				WhereOr("? = ?", bun.Ident("account_id"), accountID).
+				WhereOr("? = ?", bun.Ident("from_account_id"), accountID).
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
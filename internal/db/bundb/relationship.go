@@ -108,6 +108,12 @@ func (r *relationshipDB) GetRelationship(ctx context.Context, requestingAccount
 		rel.Note = note.Comment
 	}
 
+	// check if the requesting account is endorsing the target account
+	rel.Endorsed, err = r.IsEndorsed(ctx, requestingAccount, targetAccount)
+	if err != nil {
+		return nil, gtserror.Newf("error checking endorsed: %w", err)
+	}
+
 	return &rel, nil
 }
 
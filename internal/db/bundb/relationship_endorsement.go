@@ -0,0 +1,165 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/uptrace/bun"
+)
+
+func (r *relationshipDB) IsEndorsed(ctx context.Context, sourceAccountID string, targetAccountID string) (bool, error) {
+	endorsement, err := r.GetEndorsement(
+		gtscontext.SetBarebones(ctx),
+		sourceAccountID,
+		targetAccountID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, err
+	}
+	return (endorsement != nil), nil
+}
+
+func (r *relationshipDB) GetEndorsement(ctx context.Context, sourceAccountID string, targetAccountID string) (*gtsmodel.Endorsement, error) {
+	endorsement := new(gtsmodel.Endorsement)
+
+	if err := r.db.
+		NewSelect().
+		Model(endorsement).
+		Where("? = ?", bun.Ident("endorsement.account_id"), sourceAccountID).
+		Where("? = ?", bun.Ident("endorsement.target_account_id"), targetAccountID).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// Only a barebones model was requested.
+		return endorsement, nil
+	}
+
+	if err := r.populateEndorsement(ctx, endorsement); err != nil {
+		return nil, err
+	}
+
+	return endorsement, nil
+}
+
+func (r *relationshipDB) GetAccountEndorsements(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.Endorsement, error) {
+	var (
+		maxID = page.GetMax()
+		minID = page.GetMin()
+		limit = page.GetLimit()
+	)
+
+	endorsements := make([]*gtsmodel.Endorsement, 0, limit)
+
+	q := r.db.
+		NewSelect().
+		Model(&endorsements).
+		Where("? = ?", bun.Ident("endorsement.account_id"), accountID).
+		Order("endorsement.id DESC")
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("endorsement.id"), maxID)
+	}
+
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("endorsement.id"), minID)
+	}
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	for _, endorsement := range endorsements {
+		if err := r.populateEndorsement(ctx, endorsement); err != nil {
+			return nil, err
+		}
+	}
+
+	return endorsements, nil
+}
+
+func (r *relationshipDB) populateEndorsement(ctx context.Context, endorsement *gtsmodel.Endorsement) error {
+	var (
+		errs = gtserror.NewMultiError(2)
+		err  error
+	)
+
+	// Ensure endorsement source account set.
+	if endorsement.Account == nil {
+		endorsement.Account, err = r.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			endorsement.AccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating endorsement source account: %w", err)
+		}
+	}
+
+	// Ensure endorsement target account set.
+	if endorsement.TargetAccount == nil {
+		endorsement.TargetAccount, err = r.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			endorsement.TargetAccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating endorsement target account: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (r *relationshipDB) PutEndorsement(ctx context.Context, endorsement *gtsmodel.Endorsement) error {
+	_, err := r.db.
+		NewInsert().
+		Model(endorsement).
+		Exec(ctx)
+	return err
+}
+
+func (r *relationshipDB) DeleteEndorsement(ctx context.Context, sourceAccountID string, targetAccountID string) error {
+	_, err := r.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("endorsements"), bun.Ident("endorsement")).
+		Where("? = ?", bun.Ident("endorsement.account_id"), sourceAccountID).
+		Where("? = ?", bun.Ident("endorsement.target_account_id"), targetAccountID).
+		Exec(ctx)
+	return err
+}
+
+func (r *relationshipDB) DeleteAccountEndorsements(ctx context.Context, accountID string) error {
+	_, err := r.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("endorsements"), bun.Ident("endorsement")).
+		WhereOr("? = ?", bun.Ident("endorsement.account_id"), accountID).
+		WhereOr("? = ?", bun.Ident("endorsement.target_account_id"), accountID).
+		Exec(ctx)
+	return err
+}
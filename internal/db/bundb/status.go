@@ -197,7 +197,7 @@ func (s *statusDB) getStatus(ctx context.Context, lookup string, dbQuery func(*g
 func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status) error {
 	var (
 		err  error
-		errs = gtserror.NewMultiError(9)
+		errs = gtserror.NewMultiError(10)
 	)
 
 	if status.Account == nil {
@@ -235,6 +235,19 @@ func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status)
 		}
 	}
 
+	if status.QuoteOfID != "" && status.QuoteOf == nil {
+		// Quoted status is not set, fetch from database.
+		status.QuoteOf, err = s.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			status.QuoteOfID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			// The quoted status may have been
+			// deleted since, leave QuoteOf nil.
+			errs.Appendf("error populating quoted status: %w", err)
+		}
+	}
+
 	if status.BoostOfID != "" {
 		if status.BoostOf == nil {
 			// Status boost is not set, fetch from database.
@@ -207,6 +207,37 @@ func (suite *StatusTestSuite) TestDeleteStatus() {
 	suite.ErrorIs(err, db.ErrNoEntries)
 }
 
+func (suite *StatusTestSuite) TestGetStatusQuoteDeleted() {
+	ctx := context.Background()
+
+	quotedStatus := suite.testStatuses["admin_account_status_1"]
+
+	// Take a copy of a status
+	// and make it quote another.
+	quotingStatus := &gtsmodel.Status{}
+	*quotingStatus = *suite.testStatuses["local_account_1_status_1"]
+	quotingStatus.QuoteOfID = quotedStatus.ID
+	quotingStatus.QuoteOfURI = quotedStatus.URI
+
+	if err := suite.db.UpdateStatus(ctx, quotingStatus, "quote_of_id", "quote_of_uri"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Delete the quoted status.
+	if err := suite.db.DeleteStatusByID(ctx, quotedStatus.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// The quoting status should still
+	// load, just without the quote set.
+	dbStatus, err := suite.db.GetStatusByID(ctx, quotingStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(quotedStatus.ID, dbStatus.QuoteOfID)
+	suite.Nil(dbStatus.QuoteOf)
+}
+
 // This test was added specifically to ensure that Postgres wasn't getting upset
 // about trying to use a transaction in which an error has already occurred, which
 // was previously leading to errors like 'current transaction is aborted, commands
@@ -142,13 +142,23 @@ func (s *statusBookmarkDB) PutStatusBookmark(ctx context.Context, statusBookmark
 }
 
 func (s *statusBookmarkDB) DeleteStatusBookmark(ctx context.Context, id string) error {
-	_, err := s.db.
-		NewDelete().
-		TableExpr("? AS ?", bun.Ident("status_bookmarks"), bun.Ident("status_bookmark")).
-		Where("? = ?", bun.Ident("status_bookmark.id"), id).
-		Exec(ctx)
+	return s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Remove the bookmark from any collections.
+		if _, err := tx.
+			NewDelete().
+			Table("bookmark_collection_entries").
+			Where("? = ?", bun.Ident("bookmark_id"), id).
+			Exec(ctx); err != nil {
+			return err
+		}
 
-	return err
+		_, err := tx.
+			NewDelete().
+			TableExpr("? AS ?", bun.Ident("status_bookmarks"), bun.Ident("status_bookmark")).
+			Where("? = ?", bun.Ident("status_bookmark.id"), id).
+			Exec(ctx)
+		return err
+	})
 }
 
 func (s *statusBookmarkDB) DeleteStatusBookmarks(ctx context.Context, targetAccountID string, originAccountID string) error {
@@ -160,23 +170,21 @@ func (s *statusBookmarkDB) DeleteStatusBookmarks(ctx context.Context, targetAcco
 	// statement (when bookmarks have a cache),
 	// + use the IDs to invalidate cache entries.
 
-	q := s.db.
-		NewDelete().
-		TableExpr("? AS ?", bun.Ident("status_bookmarks"), bun.Ident("status_bookmark"))
+	// Select IDs of the bookmarks to delete.
+	subQ := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_bookmarks"), bun.Ident("status_bookmark")).
+		Column("status_bookmark.id")
 
 	if targetAccountID != "" {
-		q = q.Where("? = ?", bun.Ident("status_bookmark.target_account_id"), targetAccountID)
+		subQ = subQ.Where("? = ?", bun.Ident("status_bookmark.target_account_id"), targetAccountID)
 	}
 
 	if originAccountID != "" {
-		q = q.Where("? = ?", bun.Ident("status_bookmark.account_id"), originAccountID)
+		subQ = subQ.Where("? = ?", bun.Ident("status_bookmark.account_id"), originAccountID)
 	}
 
-	if _, err := q.Exec(ctx); err != nil {
-		return err
-	}
-
-	return nil
+	return s.deleteStatusBookmarksIn(ctx, subQ)
 }
 
 func (s *statusBookmarkDB) DeleteStatusBookmarksForStatus(ctx context.Context, statusID string) error {
@@ -184,14 +192,36 @@ func (s *statusBookmarkDB) DeleteStatusBookmarksForStatus(ctx context.Context, s
 	// statement (when bookmarks have a cache),
 	// + use the IDs to invalidate cache entries.
 
-	q := s.db.
-		NewDelete().
+	// Select IDs of the bookmarks to delete.
+	subQ := s.db.
+		NewSelect().
 		TableExpr("? AS ?", bun.Ident("status_bookmarks"), bun.Ident("status_bookmark")).
+		Column("status_bookmark.id").
 		Where("? = ?", bun.Ident("status_bookmark.status_id"), statusID)
 
-	if _, err := q.Exec(ctx); err != nil {
-		return err
-	}
+	return s.deleteStatusBookmarksIn(ctx, subQ)
+}
+
+// deleteStatusBookmarksIn deletes all status bookmarks with IDs selected by
+// the given subquery, first removing them from any bookmark collections.
+func (s *statusBookmarkDB) deleteStatusBookmarksIn(ctx context.Context, subQ *bun.SelectQuery) error {
+	return s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.
+			NewDelete().
+			Table("bookmark_collection_entries").
+			Where("? IN (?)", bun.Ident("bookmark_id"), subQ).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		if _, err := tx.
+			NewDelete().
+			Table("status_bookmarks").
+			Where("? IN (?)", bun.Ident("id"), subQ).
+			Exec(ctx); err != nil {
+			return err
+		}
 
-	return nil
+		return nil
+	})
 }
@@ -0,0 +1,243 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type storageUsageDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (s *storageUsageDB) GetAccountStorageUsage(ctx context.Context, accountID string) (*gtsmodel.AccountStorageUsage, error) {
+	usage := new(gtsmodel.AccountStorageUsage)
+
+	if err := s.db.
+		NewSelect().
+		Model(usage).
+		Where("? = ?", bun.Ident("account_storage_usage.account_id"), accountID).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return usage, nil
+}
+
+func (s *storageUsageDB) UpdateAccountStorageUsage(ctx context.Context, accountID string) (*gtsmodel.AccountStorageUsage, error) {
+	var err error
+
+	usage := &gtsmodel.AccountStorageUsage{
+		AccountID: accountID,
+		UpdatedAt: time.Now(),
+	}
+
+	// Count media attachments, avatars and headers separately.
+	usage.MediaSize, err = s.sumLocalAttachments(ctx, accountID, false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	usage.AvatarSize, err = s.sumLocalAttachments(ctx, accountID, true, false)
+	if err != nil {
+		return nil, err
+	}
+
+	usage.HeaderSize, err = s.sumLocalAttachments(ctx, accountID, false, true)
+	if err != nil {
+		return nil, err
+	}
+
+	instanceAcct, err := s.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return nil, gtserror.Newf("error getting instance account: %w", err)
+	}
+
+	if accountID == instanceAcct.ID {
+		// Local emoji are owned by
+		// the instance account.
+		usage.EmojiSize, err = s.sumEmojis(ctx, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Insert the usage, or update the sizes on the
+	// existing entry, leaving any quota in place.
+	if _, err := s.db.
+		NewInsert().
+		Model(usage).
+		On("CONFLICT (?) DO UPDATE", bun.Ident("account_id")).
+		Set("? = ?", bun.Ident("updated_at"), usage.UpdatedAt).
+		Set("? = ?", bun.Ident("media_size"), usage.MediaSize).
+		Set("? = ?", bun.Ident("avatar_size"), usage.AvatarSize).
+		Set("? = ?", bun.Ident("header_size"), usage.HeaderSize).
+		Set("? = ?", bun.Ident("emoji_size"), usage.EmojiSize).
+		Exec(ctx); err != nil {
+		return nil, gtserror.Newf("error storing account storage usage: %w", err)
+	}
+
+	// Reload to get the quota.
+	return s.GetAccountStorageUsage(ctx, accountID)
+}
+
+func (s *storageUsageDB) AddAccountStorageUsage(ctx context.Context, delta *gtsmodel.AccountStorageUsage) error {
+	// Only update an existing entry. With no entry
+	// yet, the full usage is counted on first get.
+	_, err := s.db.
+		NewUpdate().
+		Table("account_storage_usages").
+		Set("? = ?", bun.Ident("updated_at"), time.Now()).
+		Set("? = ? + ?", bun.Ident("media_size"), bun.Ident("media_size"), delta.MediaSize).
+		Set("? = ? + ?", bun.Ident("avatar_size"), bun.Ident("avatar_size"), delta.AvatarSize).
+		Set("? = ? + ?", bun.Ident("header_size"), bun.Ident("header_size"), delta.HeaderSize).
+		Set("? = ? + ?", bun.Ident("emoji_size"), bun.Ident("emoji_size"), delta.EmojiSize).
+		Where("? = ?", bun.Ident("account_id"), delta.AccountID).
+		Exec(ctx)
+	return err
+}
+
+func (s *storageUsageDB) UpdateAccountStorageQuota(ctx context.Context, accountID string, quota *int) error {
+	usage := &gtsmodel.AccountStorageUsage{
+		AccountID: accountID,
+		UpdatedAt: time.Now(),
+		Quota:     quota,
+	}
+
+	// Insert the quota, or update the quota on the
+	// existing entry, leaving counted sizes in place.
+	_, err := s.db.
+		NewInsert().
+		Model(usage).
+		On("CONFLICT (?) DO UPDATE", bun.Ident("account_id")).
+		Set("? = ?", bun.Ident("updated_at"), usage.UpdatedAt).
+		Set("? = ?", bun.Ident("quota"), usage.Quota).
+		Exec(ctx)
+	return err
+}
+
+func (s *storageUsageDB) GetInstanceStorageUsage(ctx context.Context) (*gtsmodel.InstanceStorageUsage, error) {
+	var (
+		usage gtsmodel.InstanceStorageUsage
+		err   error
+	)
+
+	usage.LocalMediaSize, err = s.sumLocalAttachments(ctx, "", false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	usage.LocalAvatarSize, err = s.sumLocalAttachments(ctx, "", true, false)
+	if err != nil {
+		return nil, err
+	}
+
+	usage.LocalHeaderSize, err = s.sumLocalAttachments(ctx, "", false, true)
+	if err != nil {
+		return nil, err
+	}
+
+	usage.LocalEmojiSize, err = s.sumEmojis(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Remote media only counts while it's cached.
+	if err := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("media_attachments"), bun.Ident("media_attachment")).
+		ColumnExpr("COALESCE(SUM(? + ?), 0)",
+			bun.Ident("media_attachment.file_file_size"),
+			bun.Ident("media_attachment.thumbnail_file_size"),
+		).
+		Where("? IS NOT NULL", bun.Ident("media_attachment.remote_url")).
+		Where("? = ?", bun.Ident("media_attachment.cached"), true).
+		Scan(ctx, &usage.RemoteMediaSize); err != nil {
+		return nil, gtserror.Newf("error counting remote media: %w", err)
+	}
+
+	usage.RemoteEmojiSize, err = s.sumEmojis(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &usage, nil
+}
+
+// sumLocalAttachments returns the total size of local media attachments
+// (and their thumbnails) with the given avatar / header flags. If accountID
+// is set, only attachments belonging to that account are counted.
+func (s *storageUsageDB) sumLocalAttachments(ctx context.Context, accountID string, avatar bool, header bool) (int, error) {
+	var size int
+
+	q := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("media_attachments"), bun.Ident("media_attachment")).
+		ColumnExpr("COALESCE(SUM(? + ?), 0)",
+			bun.Ident("media_attachment.file_file_size"),
+			bun.Ident("media_attachment.thumbnail_file_size"),
+		).
+		Where("? IS NULL", bun.Ident("media_attachment.remote_url")).
+		Where("? = ?", bun.Ident("media_attachment.avatar"), avatar).
+		Where("? = ?", bun.Ident("media_attachment.header"), header)
+
+	if accountID != "" {
+		q = q.Where("? = ?", bun.Ident("media_attachment.account_id"), accountID)
+	}
+
+	if err := q.Scan(ctx, &size); err != nil {
+		return 0, gtserror.Newf("error counting local media: %w", err)
+	}
+
+	return size, nil
+}
+
+// sumEmojis returns the total size of either local custom
+// emoji, or cached remote custom emoji (and their statics).
+func (s *storageUsageDB) sumEmojis(ctx context.Context, local bool) (int, error) {
+	var size int
+
+	q := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("emojis"), bun.Ident("emoji")).
+		ColumnExpr("COALESCE(SUM(? + ?), 0)",
+			bun.Ident("emoji.image_file_size"),
+			bun.Ident("emoji.image_static_file_size"),
+		)
+
+	if local {
+		q = q.Where("? IS NULL", bun.Ident("emoji.domain"))
+	} else {
+		q = q.
+			Where("? IS NOT NULL", bun.Ident("emoji.domain")).
+			Where("? = ?", bun.Ident("emoji.cached"), true)
+	}
+
+	if err := q.Scan(ctx, &size); err != nil {
+		return 0, gtserror.Newf("error counting emoji: %w", err)
+	}
+
+	return size, nil
+}
@@ -0,0 +1,193 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type StorageUsageTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *StorageUsageTestSuite) TestAccountStorageUsage() {
+	ctx := context.Background()
+	testAttachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+	accountID := testAttachment.AccountID
+
+	// Not counted until first updated.
+	_, err := suite.db.GetAccountStorageUsage(ctx, accountID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	usage, err := suite.db.UpdateAccountStorageUsage(ctx, accountID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	before := usage.MediaSize
+	suite.NotZero(before)
+
+	// Store a copy of the attachment.
+	attachment := new(gtsmodel.MediaAttachment)
+	*attachment = *testAttachment
+	attachment.ID = id.NewULID()
+	attachment.StatusID = ""
+	if err := suite.db.PutAttachment(ctx, attachment); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	size := attachment.File.FileSize + attachment.Thumbnail.FileSize
+	usage, err = suite.db.GetAccountStorageUsage(ctx, accountID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(before+size, usage.MediaSize)
+
+	// Incremental count should match a full recount.
+	recounted, err := suite.db.UpdateAccountStorageUsage(ctx, accountID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(usage.Total(), recounted.Total())
+
+	// Deleting the copy drops it again.
+	if err := suite.db.DeleteAttachment(ctx, attachment.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	usage, err = suite.db.GetAccountStorageUsage(ctx, accountID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(before, usage.MediaSize)
+}
+
+func (suite *StorageUsageTestSuite) TestAccountStorageUsageAvatar() {
+	ctx := context.Background()
+	testAttachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+	accountID := testAttachment.AccountID
+
+	usage, err := suite.db.UpdateAccountStorageUsage(ctx, accountID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	before := *usage
+
+	// Store a copy of the attachment as an avatar.
+	attachment := new(gtsmodel.MediaAttachment)
+	*attachment = *testAttachment
+	attachment.ID = id.NewULID()
+	attachment.StatusID = ""
+	attachment.Avatar = util.Ptr(true)
+	if err := suite.db.PutAttachment(ctx, attachment); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	usage, err = suite.db.GetAccountStorageUsage(ctx, accountID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(before.MediaSize, usage.MediaSize)
+	suite.Equal(before.AvatarSize+attachment.File.FileSize+attachment.Thumbnail.FileSize, usage.AvatarSize)
+}
+
+func (suite *StorageUsageTestSuite) TestAccountStorageUsageNotCounted() {
+	ctx := context.Background()
+	testAttachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+
+	// Store a copy of the attachment
+	// before usage has been counted.
+	attachment := new(gtsmodel.MediaAttachment)
+	*attachment = *testAttachment
+	attachment.ID = id.NewULID()
+	attachment.StatusID = ""
+	if err := suite.db.PutAttachment(ctx, attachment); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Usage should still not be stored,
+	// rather than stored with only the copy.
+	_, err := suite.db.GetAccountStorageUsage(ctx, attachment.AccountID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *StorageUsageTestSuite) TestEmojiStorageUsage() {
+	ctx := context.Background()
+
+	instanceAcct, err := suite.db.GetInstanceAccount(ctx, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	usage, err := suite.db.UpdateAccountStorageUsage(ctx, instanceAcct.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	before := usage.EmojiSize
+	suite.NotZero(before)
+
+	// Store a copy of a local emoji.
+	emoji := new(gtsmodel.Emoji)
+	*emoji = *suite.testEmojis["rainbow"]
+	emoji.ID = id.NewULID()
+	emoji.Shortcode = "rainbow2"
+	emoji.URI = "http://localhost:8080/emoji/" + emoji.ID
+	if err := suite.db.PutEmoji(ctx, emoji); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	usage, err = suite.db.GetAccountStorageUsage(ctx, instanceAcct.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(before+emoji.ImageFileSize+emoji.ImageStaticFileSize, usage.EmojiSize)
+
+	// Replace the image with a smaller one.
+	emoji.ImageFileSize = 100
+	emoji.ImageStaticFileSize = 10
+	if err := suite.db.UpdateEmoji(ctx, emoji); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	usage, err = suite.db.GetAccountStorageUsage(ctx, instanceAcct.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(before+110, usage.EmojiSize)
+
+	// Deleting the copy drops it again.
+	if err := suite.db.DeleteEmojiByID(ctx, emoji.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	usage, err = suite.db.GetAccountStorageUsage(ctx, instanceAcct.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(before, usage.EmojiSize)
+}
+
+func TestStorageUsageTestSuite(t *testing.T) {
+	suite.Run(t, new(StorageUsageTestSuite))
+}
@@ -0,0 +1,133 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/uptrace/bun"
+)
+
+func (t *tagDB) IsAccountFollowingTag(ctx context.Context, accountID string, tagID string) (bool, error) {
+	exists, err := t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("followed_tags"), bun.Ident("followed_tag")).
+		Column("followed_tag.id").
+		Where("? = ?", bun.Ident("followed_tag.account_id"), accountID).
+		Where("? = ?", bun.Ident("followed_tag.tag_id"), tagID).
+		Exists(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, err
+	}
+	return exists, nil
+}
+
+func (t *tagDB) GetAccountFollowedTags(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.FollowedTag, error) {
+	var (
+		maxID = page.GetMax()
+		minID = page.GetMin()
+		limit = page.GetLimit()
+	)
+
+	followedTags := make([]*gtsmodel.FollowedTag, 0, limit)
+
+	q := t.db.
+		NewSelect().
+		Model(&followedTags).
+		Where("? = ?", bun.Ident("followed_tag.account_id"), accountID).
+		Order("followed_tag.id DESC")
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("followed_tag.id"), maxID)
+	}
+
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("followed_tag.id"), minID)
+	}
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	for _, followedTag := range followedTags {
+		var err error
+
+		// Ensure followed tag's tag is set.
+		followedTag.Tag, err = t.GetTag(ctx, followedTag.TagID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.Newf("error populating followed tag tag: %w", err)
+		}
+	}
+
+	return followedTags, nil
+}
+
+func (t *tagDB) GetAccountIDsFollowingTags(ctx context.Context, tagIDs []string) ([]string, error) {
+	if len(tagIDs) == 0 {
+		return nil, nil
+	}
+
+	var accountIDs []string
+
+	if err := t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("followed_tags"), bun.Ident("followed_tag")).
+		ColumnExpr("DISTINCT ?", bun.Ident("followed_tag.account_id")).
+		Where("? IN (?)", bun.Ident("followed_tag.tag_id"), bun.In(tagIDs)).
+		Scan(ctx, &accountIDs); err != nil {
+		return nil, err
+	}
+
+	return accountIDs, nil
+}
+
+func (t *tagDB) PutFollowedTag(ctx context.Context, followedTag *gtsmodel.FollowedTag) error {
+	_, err := t.db.
+		NewInsert().
+		Model(followedTag).
+		Exec(ctx)
+	return err
+}
+
+func (t *tagDB) DeleteFollowedTag(ctx context.Context, accountID string, tagID string) error {
+	_, err := t.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("followed_tags"), bun.Ident("followed_tag")).
+		Where("? = ?", bun.Ident("followed_tag.account_id"), accountID).
+		Where("? = ?", bun.Ident("followed_tag.tag_id"), tagID).
+		Exec(ctx)
+	return err
+}
+
+func (t *tagDB) DeleteAccountFollowedTags(ctx context.Context, accountID string) error {
+	_, err := t.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("followed_tags"), bun.Ident("followed_tag")).
+		Where("? = ?", bun.Ident("followed_tag.account_id"), accountID).
+		Exec(ctx)
+	return err
+}
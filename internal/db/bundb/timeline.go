@@ -119,15 +119,32 @@ func (t *timelineDB) GetHomeTimeline(ctx context.Context, accountID string, maxI
 		return nil, gtserror.Newf("db error getting follows for account %s: %w", accountID, err)
 	}
 
-	// Extract just the accountID from each follow.
-	targetAccountIDs := make([]string, len(follows)+1)
-	for i, f := range follows {
-		targetAccountIDs[i] = f.TargetAccountID
+	// Posts from accounts whose follows are entries
+	// in an exclusive list of accountID should only
+	// show in that list, not in the home timeline.
+	exclusiveFollowIDs, err := t.state.DB.GetFollowIDsInExclusiveLists(ctx, accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting exclusive list follows for account %s: %w", accountID, err)
+	}
+
+	exclusive := make(map[string]struct{}, len(exclusiveFollowIDs))
+	for _, id := range exclusiveFollowIDs {
+		exclusive[id] = struct{}{}
+	}
+
+	// Extract just the accountID from each follow,
+	// skipping follows included in exclusive lists.
+	targetAccountIDs := make([]string, 0, len(follows)+1)
+	for _, f := range follows {
+		if _, ok := exclusive[f.ID]; ok {
+			continue
+		}
+		targetAccountIDs = append(targetAccountIDs, f.TargetAccountID)
 	}
 
 	// Add accountID itself as a pseudo follow so that
 	// accountID can see its own posts in the timeline.
-	targetAccountIDs[len(targetAccountIDs)-1] = accountID
+	targetAccountIDs = append(targetAccountIDs, accountID)
 
 	// Select only statuses authored by
 	// accounts with IDs in the slice.
@@ -524,3 +541,134 @@ func (t *timelineDB) GetTagTimeline(
 	// Return status IDs loaded from cache + db.
 	return t.state.DB.GetStatusesByIDs(ctx, statusIDs)
 }
+
+func (t *timelineDB) GetTimelineEntries(ctx context.Context, timelineType gtsmodel.TimelineType, timelineID string, limit int) ([]*gtsmodel.TimelineEntry, error) {
+	// Ensure reasonable
+	if limit < 0 {
+		limit = 0
+	}
+
+	entries := make([]*gtsmodel.TimelineEntry, 0, limit)
+
+	q := t.db.
+		NewSelect().
+		Model(&entries).
+		Where("? = ?", bun.Ident("timeline_entry.timeline_type"), timelineType).
+		Where("? = ?", bun.Ident("timeline_entry.timeline_id"), timelineID).
+		Order("timeline_entry.item_id DESC")
+
+	if limit > 0 {
+		// limit amount of entries returned
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (t *timelineDB) PutTimelineEntries(ctx context.Context, entries []*gtsmodel.TimelineEntry) error {
+	if len(entries) == 0 {
+		// Nothing to do.
+		return nil
+	}
+
+	_, err := t.db.
+		NewInsert().
+		Model(&entries).
+		On("CONFLICT (?, ?, ?) DO NOTHING",
+			bun.Ident("timeline_type"),
+			bun.Ident("timeline_id"),
+			bun.Ident("item_id"),
+		).
+		Exec(ctx)
+	return err
+}
+
+func (t *timelineDB) DeleteTimelineEntry(ctx context.Context, timelineType gtsmodel.TimelineType, timelineID string, itemID string) error {
+	_, err := t.db.
+		NewDelete().
+		Table("timeline_entries").
+		Where("? = ?", bun.Ident("timeline_type"), timelineType).
+		Where("? = ?", bun.Ident("timeline_id"), timelineID).
+		Where("? = ?", bun.Ident("item_id"), itemID).
+		Exec(ctx)
+	return err
+}
+
+func (t *timelineDB) DeleteTimelineEntriesByItemID(ctx context.Context, timelineType gtsmodel.TimelineType, itemID string) error {
+	_, err := t.db.
+		NewDelete().
+		Table("timeline_entries").
+		Where("? = ?", bun.Ident("timeline_type"), timelineType).
+		Where("? = ?", bun.Ident("item_id"), itemID).
+		Exec(ctx)
+	return err
+}
+
+func (t *timelineDB) DeleteTimelineEntriesByOrBoosting(ctx context.Context, timelineType gtsmodel.TimelineType, timelineID string, accountID string) error {
+	_, err := t.db.
+		NewDelete().
+		Table("timeline_entries").
+		Where("? = ?", bun.Ident("timeline_type"), timelineType).
+		Where("? = ?", bun.Ident("timeline_id"), timelineID).
+		WhereGroup(" AND ", func(q *bun.DeleteQuery) *bun.DeleteQuery {
+			return q.
+				Where("? = ?", bun.Ident("account_id"), accountID).
+				WhereOr("? = ?", bun.Ident("boost_of_account_id"), accountID)
+		}).
+		Exec(ctx)
+	return err
+}
+
+func (t *timelineDB) DeleteTimelineEntries(ctx context.Context, timelineType gtsmodel.TimelineType, timelineID string) error {
+	_, err := t.db.
+		NewDelete().
+		Table("timeline_entries").
+		Where("? = ?", bun.Ident("timeline_type"), timelineType).
+		Where("? = ?", bun.Ident("timeline_id"), timelineID).
+		Exec(ctx)
+	return err
+}
+
+func (t *timelineDB) TrimTimelineEntries(ctx context.Context, timelineType gtsmodel.TimelineType, maxLength int) (int, error) {
+	// Number each entry of each timeline
+	// from newest to oldest item ID.
+	ranked := t.db.
+		NewSelect().
+		Table("timeline_entries").
+		Column("id").
+		ColumnExpr(
+			"ROW_NUMBER() OVER (PARTITION BY ? ORDER BY ? DESC) AS ?",
+			bun.Ident("timeline_id"),
+			bun.Ident("item_id"),
+			bun.Ident("rank"),
+		).
+		Where("? = ?", bun.Ident("timeline_type"), timelineType)
+
+	// Select IDs of entries that
+	// fall beyond the max length.
+	excess := t.db.
+		NewSelect().
+		TableExpr("(?) AS ?", ranked, bun.Ident("ranked")).
+		Column("ranked.id").
+		Where("? > ?", bun.Ident("ranked.rank"), maxLength)
+
+	res, err := t.db.
+		NewDelete().
+		Table("timeline_entries").
+		Where("? IN (?)", bun.Ident("id"), excess).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(deleted), nil
+}
@@ -160,6 +160,65 @@ func (suite *TimelineTestSuite) TestGetHomeTimeline() {
 	suite.checkStatuses(s, id.Highest, id.Lowest, 19)
 }
 
+func (suite *TimelineTestSuite) TestGetHomeTimelineExclusiveList() {
+	var (
+		ctx            = context.Background()
+		viewingAccount = suite.testAccounts["local_account_1"]
+		list           = new(gtsmodel.List)
+	)
+	*list = *suite.testLists["local_account_1_list_1"]
+
+	// Gather the accounts whose posts
+	// belong in the list, not at home.
+	listed := make(map[string]bool)
+	for _, entry := range suite.testListEntries {
+		if entry.ListID != list.ID {
+			continue
+		}
+		follow, err := suite.db.GetFollowByID(ctx, entry.FollowID)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		listed[follow.TargetAccountID] = true
+	}
+
+	// countListed returns how many home timeline
+	// statuses were authored by listed accounts.
+	countListed := func() int {
+		s, err := suite.db.GetHomeTimeline(ctx, viewingAccount.ID, "", "", "", 20, false)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		var count int
+		for _, status := range s {
+			if listed[status.AccountID] {
+				count++
+			}
+		}
+		return count
+	}
+
+	// List isn't exclusive yet,
+	// so its posts show at home.
+	suite.NotZero(countListed())
+
+	// Once exclusive, they shouldn't, even
+	// with a previous result still cached.
+	list.Exclusive = util.Ptr(true)
+	if err := suite.db.UpdateList(ctx, list, "exclusive"); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(countListed())
+
+	// And back again.
+	list.Exclusive = util.Ptr(false)
+	if err := suite.db.UpdateList(ctx, list, "exclusive"); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotZero(countListed())
+}
+
 func (suite *TimelineTestSuite) TestGetHomeTimelineNoFollowing() {
 	var (
 		ctx            = context.Background()
@@ -0,0 +1,113 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type webPushDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (w *webPushDB) GetWebPushSubscriptionByTokenID(ctx context.Context, tokenID string) (*gtsmodel.WebPushSubscription, error) {
+	subscription := new(gtsmodel.WebPushSubscription)
+
+	if err := w.db.
+		NewSelect().
+		Model(subscription).
+		Where("? = ?", bun.Ident("web_push_subscription.token_id"), tokenID).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (w *webPushDB) GetWebPushSubscriptionsByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.WebPushSubscription, error) {
+	subscriptions := []*gtsmodel.WebPushSubscription{}
+
+	if err := w.db.
+		NewSelect().
+		Model(&subscriptions).
+		Where("? = ?", bun.Ident("web_push_subscription.account_id"), accountID).
+		Order("web_push_subscription.id ASC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (w *webPushDB) PutWebPushSubscription(ctx context.Context, subscription *gtsmodel.WebPushSubscription) error {
+	_, err := w.db.
+		NewInsert().
+		Model(subscription).
+		Exec(ctx)
+	return err
+}
+
+func (w *webPushDB) UpdateWebPushSubscription(ctx context.Context, subscription *gtsmodel.WebPushSubscription, columns ...string) error {
+	subscription.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := w.db.
+		NewUpdate().
+		Model(subscription).
+		Where("? = ?", bun.Ident("web_push_subscription.id"), subscription.ID).
+		Column(columns...).
+		Exec(ctx)
+	return err
+}
+
+func (w *webPushDB) DeleteWebPushSubscriptionByID(ctx context.Context, id string) error {
+	_, err := w.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("web_push_subscriptions"), bun.Ident("web_push_subscription")).
+		Where("? = ?", bun.Ident("web_push_subscription.id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (w *webPushDB) DeleteWebPushSubscriptionByTokenID(ctx context.Context, tokenID string) error {
+	_, err := w.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("web_push_subscriptions"), bun.Ident("web_push_subscription")).
+		Where("? = ?", bun.Ident("web_push_subscription.token_id"), tokenID).
+		Exec(ctx)
+	return err
+}
+
+func (w *webPushDB) DeleteWebPushSubscriptionsByAccountID(ctx context.Context, accountID string) error {
+	_, err := w.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("web_push_subscriptions"), bun.Ident("web_push_subscription")).
+		Where("? = ?", bun.Ident("web_push_subscription.account_id"), accountID).
+		Exec(ctx)
+	return err
+}
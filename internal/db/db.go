@@ -28,15 +28,21 @@ type DB interface {
 	Admin
 	Application
 	Basic
+	BookmarkCollection
 	Domain
+	DomainMediaPolicy
 	Emoji
+	FeaturedTag
 	HeaderFilter
+	Import
 	Instance
 	List
 	Marker
 	Media
+	MediaHash
 	Mention
 	Notification
+	NotificationPolicy
 	Poll
 	Relationship
 	Report
@@ -46,9 +52,11 @@ type DB interface {
 	Status
 	StatusBookmark
 	StatusFave
+	StorageUsage
 	Tag
 	Thread
 	Timeline
 	User
 	Tombstone
+	WebPush
 }
@@ -56,6 +56,10 @@ type Emoji interface {
 	// GetCachedEmojisOlderThan fetches all cached remote emojis with 'updated_at' greater than 'olderThan', up to a maximum of 'limit' emojis.
 	GetCachedEmojisOlderThan(ctx context.Context, olderThan time.Time, limit int) ([]*gtsmodel.Emoji, error)
 
+	// GetCachedEmojisByDomain gets limit n cached remote emojis from the given domain
+	// or its subdomains, with IDs less than maxID (if set), ordered newest to oldest.
+	GetCachedEmojisByDomain(ctx context.Context, domain string, maxID string, limit int) ([]*gtsmodel.Emoji, error)
+
 	// GetEmojisBy gets emojis based on given parameters. Useful for admin actions.
 	GetEmojisBy(ctx context.Context, domain string, includeDisabled bool, includeEnabled bool, shortcode string, maxShortcodeDomain string, minShortcodeDomain string, limit int) ([]*gtsmodel.Emoji, error)
 
@@ -0,0 +1,47 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// FeaturedTag handles getting/creation/deletion of hashtags featured on account profiles.
+type FeaturedTag interface {
+	// GetFeaturedTagByID gets one featured tag by its db id, with tag populated.
+	GetFeaturedTagByID(ctx context.Context, id string) (*gtsmodel.FeaturedTag, error)
+
+	// GetAccountFeaturedTags gets all tags featured by the given account id, oldest first, with tags populated.
+	GetAccountFeaturedTags(ctx context.Context, accountID string) ([]*gtsmodel.FeaturedTag, error)
+
+	// PutFeaturedTag puts the given featured tag in the database.
+	PutFeaturedTag(ctx context.Context, featuredTag *gtsmodel.FeaturedTag) error
+
+	// DeleteFeaturedTagByID deletes one featured tag by its db id.
+	DeleteFeaturedTagByID(ctx context.Context, id string) error
+
+	// DeleteAccountFeaturedTags deletes all tags featured by the given account id.
+	DeleteAccountFeaturedTags(ctx context.Context, accountID string) error
+
+	// GetAccountTagStats returns the number of public/unlisted statuses authored
+	// by the given account id which use the given tag, and when the latest was created.
+	GetAccountTagStats(ctx context.Context, accountID string, tagID string) (int, time.Time, error)
+}
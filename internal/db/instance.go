@@ -19,6 +19,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
@@ -28,6 +29,9 @@ type Instance interface {
 	// CountInstanceUsers returns the number of known accounts registered with the given domain.
 	CountInstanceUsers(ctx context.Context, domain string) (int, error)
 
+	// CountInstanceActiveUsers returns the number of local users who have signed in, or used an app, since the given time.
+	CountInstanceActiveUsers(ctx context.Context, since time.Time) (int, error)
+
 	// CountInstanceStatuses returns the number of known statuses posted from the given domain.
 	CountInstanceStatuses(ctx context.Context, domain string) (int, error)
 
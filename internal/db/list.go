@@ -71,6 +71,10 @@ type List interface {
 	// DeleteListEntryForFollowID deletes all list entries with the given followID.
 	DeleteListEntriesForFollowID(ctx context.Context, followID string) error
 
+	// GetFollowIDsInExclusiveLists returns the IDs of all follows
+	// that are entries in any exclusive list owned by accountID.
+	GetFollowIDsInExclusiveLists(ctx context.Context, accountID string) ([]string, error)
+
 	// ListIncludesAccount returns true if the given listID includes the given accountID.
 	ListIncludesAccount(ctx context.Context, listID string, accountID string) (bool, error)
 }
@@ -51,4 +51,25 @@ type Media interface {
 	// GetCachedAttachmentsOlderThan gets limit n remote attachments (including avatars and headers) older than
 	// the given time. These will be returned in order of attachment.created_at descending (i.e. newest to oldest).
 	GetCachedAttachmentsOlderThan(ctx context.Context, olderThan time.Time, limit int) ([]*gtsmodel.MediaAttachment, error)
+
+	// GetCachedAttachmentsByDomain gets limit n cached remote attachments (including avatars and headers) owned
+	// by accounts on the given domain or its subdomains, with IDs less than maxID (if set), newest to oldest.
+	GetCachedAttachmentsByDomain(ctx context.Context, domain string, maxID string, limit int) ([]*gtsmodel.MediaAttachment, error)
+
+	// GetMediaBlobByPath fetches the content-addressed media blob stored at the given path.
+	GetMediaBlobByPath(ctx context.Context, path string) (*gtsmodel.MediaBlob, error)
+
+	// AcquireMediaBlob atomically adds a reference to the given media blob, inserting it with
+	// a single reference if it doesn't exist yet, and sets blob.RefCount to the new count. If
+	// the blob had no other references, storeFn (if set) is called within the same transaction
+	// to ensure the blob is written to storage, and the reference is only added if it succeeds.
+	// The blob row stays locked meanwhile, so it can't be removed from under it by a release.
+	AcquireMediaBlob(ctx context.Context, blob *gtsmodel.MediaBlob, storeFn func() error) error
+
+	// ReleaseMediaBlob atomically drops a reference to the media blob stored at the given path.
+	// Once the release of the last reference is committed, the blob is locked, and if it still
+	// has no references deleteFn (if set) is called to remove it from storage before the blob is
+	// deleted. Returns the number of references remaining, or ErrNoEntries if no referenced blob
+	// is stored at path (ie., it isn't a content-addressed file).
+	ReleaseMediaBlob(ctx context.Context, path string, deleteFn func() error) (int, error)
 }
@@ -0,0 +1,50 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// MediaHash contains functions for managing the media hash blocklist.
+type MediaHash interface {
+	// MatchMediaSHA256 returns the blocklisted SHA-256 media hash
+	// equal to the given hex-encoded sum, or nil if there is none.
+	// (Note: matching is performed on cached media hashes).
+	MatchMediaSHA256(ctx context.Context, sum string) (*gtsmodel.MediaHash, error)
+
+	// MatchMediaPHash returns the blocklisted perceptual media hash
+	// nearest to given hash, within maxDistance differing bits, or
+	// nil if there is none. (Note: matching is performed on cached
+	// media hashes).
+	MatchMediaPHash(ctx context.Context, phash uint64, maxDistance int) (*gtsmodel.MediaHash, error)
+
+	// GetMediaHashByID fetches the media hash with ID from the database.
+	GetMediaHashByID(ctx context.Context, id string) (*gtsmodel.MediaHash, error)
+
+	// GetMediaHashes fetches all media hashes from the database.
+	GetMediaHashes(ctx context.Context) ([]*gtsmodel.MediaHash, error)
+
+	// PutMediaHash inserts the given media hash into the database.
+	PutMediaHash(ctx context.Context, hash *gtsmodel.MediaHash) error
+
+	// DeleteMediaHashByID deletes the media hash with ID from the database.
+	DeleteMediaHashByID(ctx context.Context, id string) error
+}
@@ -26,6 +26,7 @@ import (
 // Notification contains functions for creating and getting notifications.
 type Notification interface {
 	// GetNotifications returns a slice of notifications that pertain to the given accountID.
+	// Notifications that were filtered by the account's notification policy are not included.
 	//
 	// Returned notifications will be ordered ID descending (ie., highest/newest to lowest/oldest).
 	GetAccountNotifications(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int, excludeTypes []string) ([]*gtsmodel.Notification, error)
@@ -65,6 +66,14 @@ type Notification interface {
 	// At least one parameter must not be an empty string.
 	DeleteNotifications(ctx context.Context, types []string, targetAccountID string, originAccountID string) error
 
+	// UnfilterNotifications marks all notifications targeting targetAccountID from
+	// originAccountID, that were filtered by notification policy, as unfiltered.
+	UnfilterNotifications(ctx context.Context, targetAccountID string, originAccountID string) error
+
+	// DeleteFilteredNotifications deletes all notifications targeting targetAccountID
+	// from originAccountID that were filtered by notification policy.
+	DeleteFilteredNotifications(ctx context.Context, targetAccountID string, originAccountID string) error
+
 	// DeleteNotificationsForStatus deletes all notifications that relate to
 	// the given statusID. This function is useful when a status has been deleted,
 	// and so notifications relating to that status must also be deleted.
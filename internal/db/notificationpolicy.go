@@ -0,0 +1,85 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// NotificationPolicy handles getting/creation/deletion of
+// notification policies, and the requests + permissions
+// that result from filtering notifications by policy.
+type NotificationPolicy interface {
+	// GetNotificationPolicy gets the notification policy belonging to the given account id.
+	GetNotificationPolicy(ctx context.Context, accountID string) (*gtsmodel.NotificationPolicy, error)
+
+	// PutNotificationPolicy puts the given notification policy in the database.
+	PutNotificationPolicy(ctx context.Context, policy *gtsmodel.NotificationPolicy) error
+
+	// UpdateNotificationPolicy updates the given notification policy. If no columns
+	// are specified, every column is updated.
+	UpdateNotificationPolicy(ctx context.Context, policy *gtsmodel.NotificationPolicy, columns ...string) error
+
+	// GetNotificationRequestByID gets one notification request by its db id.
+	GetNotificationRequestByID(ctx context.Context, id string) (*gtsmodel.NotificationRequest, error)
+
+	// GetNotificationRequest gets the notification request targeting
+	// accountID from fromAccountID, if it exists.
+	GetNotificationRequest(ctx context.Context, accountID string, fromAccountID string) (*gtsmodel.NotificationRequest, error)
+
+	// GetAccountNotificationRequests gets a page of notification
+	// requests targeting the given account id, newest first.
+	GetAccountNotificationRequests(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.NotificationRequest, error)
+
+	// CountAccountNotificationRequests returns the number of notification requests
+	// targeting the given account id, and the total filtered notifications in them.
+	CountAccountNotificationRequests(ctx context.Context, accountID string) (requests int, notifications int, err error)
+
+	// PopulateNotificationRequest ensures that the notification request's struct fields are populated.
+	PopulateNotificationRequest(ctx context.Context, request *gtsmodel.NotificationRequest) error
+
+	// PutNotificationRequest puts the given notification request in the database.
+	PutNotificationRequest(ctx context.Context, request *gtsmodel.NotificationRequest) error
+
+	// UpdateNotificationRequest updates the given notification request. If no columns
+	// are specified, every column is updated.
+	UpdateNotificationRequest(ctx context.Context, request *gtsmodel.NotificationRequest, columns ...string) error
+
+	// IncrementNotificationRequest puts the given notification request in the database,
+	// or if a request between the same accounts already exists, atomically adds one to
+	// its notifications count and updates its last status id (if set) instead.
+	IncrementNotificationRequest(ctx context.Context, request *gtsmodel.NotificationRequest) error
+
+	// DeleteNotificationRequestByID deletes one notification request by its db id.
+	DeleteNotificationRequestByID(ctx context.Context, id string) error
+
+	// IsNotificationPermitted returns true if accountID has accepted a notification
+	// request from fromAccountID, so notifications from it should not be filtered.
+	IsNotificationPermitted(ctx context.Context, accountID string, fromAccountID string) (bool, error)
+
+	// PutNotificationPermission puts the given notification permission in the database.
+	PutNotificationPermission(ctx context.Context, permission *gtsmodel.NotificationPermission) error
+
+	// DeleteAccountNotificationPolicyData deletes the notification policy of
+	// the given account id, along with all notification requests and
+	// permissions both targeting and originating from that account.
+	DeleteAccountNotificationPolicyData(ctx context.Context, accountID string) error
+}
@@ -187,4 +187,22 @@ type Relationship interface {
 
 	// PopulateNote populates the struct pointers on the given note.
 	PopulateNote(ctx context.Context, note *gtsmodel.AccountNote) error
+
+	// IsEndorsed checks whether source account has endorsed (featured) target account on its profile.
+	IsEndorsed(ctx context.Context, sourceAccountID string, targetAccountID string) (bool, error)
+
+	// GetEndorsement gets an endorsement from a source account of a target account, if it exists.
+	GetEndorsement(ctx context.Context, sourceAccountID string, targetAccountID string) (*gtsmodel.Endorsement, error)
+
+	// GetAccountEndorsements gets a page of endorsements made by the given account id, newest first, with target accounts populated.
+	GetAccountEndorsements(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.Endorsement, error)
+
+	// PutEndorsement puts the given endorsement in the database.
+	PutEndorsement(ctx context.Context, endorsement *gtsmodel.Endorsement) error
+
+	// DeleteEndorsement deletes the endorsement from source account of target account, if it exists.
+	DeleteEndorsement(ctx context.Context, sourceAccountID string, targetAccountID string) error
+
+	// DeleteAccountEndorsements deletes all endorsements originating from OR targeting the given account id.
+	DeleteAccountEndorsements(ctx context.Context, accountID string) error
 }
@@ -0,0 +1,45 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type StorageUsage interface {
+	// GetAccountStorageUsage gets the storage usage of the given local accountID.
+	GetAccountStorageUsage(ctx context.Context, accountID string) (*gtsmodel.AccountStorageUsage, error)
+
+	// UpdateAccountStorageUsage recounts the size of all media stored for the given
+	// local accountID, storing and returning the updated storage usage of the account.
+	UpdateAccountStorageUsage(ctx context.Context, accountID string) (*gtsmodel.AccountStorageUsage, error)
+
+	// AddAccountStorageUsage adds the sizes in delta, which may be negative, to the stored
+	// storage usage of local account delta.AccountID. If no usage is stored for the account
+	// yet this does nothing, as the full usage is counted when it's first fetched.
+	AddAccountStorageUsage(ctx context.Context, delta *gtsmodel.AccountStorageUsage) error
+
+	// UpdateAccountStorageQuota sets the storage quota of the given local accountID.
+	// A nil quota means the instance default will be used, and 0 means unlimited.
+	UpdateAccountStorageQuota(ctx context.Context, accountID string, quota *int) error
+
+	// GetInstanceStorageUsage counts the size of all media stored by this instance.
+	GetInstanceStorageUsage(ctx context.Context) (*gtsmodel.InstanceStorageUsage, error)
+}
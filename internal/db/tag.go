@@ -21,6 +21,7 @@ import (
 	"context"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 // Tag contains functions for getting/creating tags in the database.
@@ -36,4 +37,23 @@ type Tag interface {
 
 	// GetTags gets multiple tags.
 	GetTags(ctx context.Context, ids []string) ([]*gtsmodel.Tag, error)
+
+	// IsAccountFollowingTag returns whether the given account follows the given tag.
+	IsAccountFollowingTag(ctx context.Context, accountID string, tagID string) (bool, error)
+
+	// GetAccountFollowedTags gets a page of tags followed by the given account id, newest first, with tags populated.
+	GetAccountFollowedTags(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.FollowedTag, error)
+
+	// GetAccountIDsFollowingTags returns the (deduplicated) IDs of all
+	// accounts following at least one of the given tag IDs.
+	GetAccountIDsFollowingTags(ctx context.Context, tagIDs []string) ([]string, error)
+
+	// PutFollowedTag puts the given followed tag in the database.
+	PutFollowedTag(ctx context.Context, followedTag *gtsmodel.FollowedTag) error
+
+	// DeleteFollowedTag deletes the follow of the given tag by the given account, if it exists.
+	DeleteFollowedTag(ctx context.Context, accountID string, tagID string) error
+
+	// DeleteAccountFollowedTags deletes all tag follows of the given account id.
+	DeleteAccountFollowedTags(ctx context.Context, accountID string) error
 }
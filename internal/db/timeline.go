@@ -52,4 +52,29 @@ type Timeline interface {
 	// GetTagTimeline returns a slice of public-visibility statuses that use the given tagID.
 	// Statuses should be returned in descending order of when they were created (newest first).
 	GetTagTimeline(ctx context.Context, tagID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.Status, error)
+
+	// GetTimelineEntries returns up to limit persisted entries of the
+	// timeline with the given type and ID, newest (highest item ID) first.
+	GetTimelineEntries(ctx context.Context, timelineType gtsmodel.TimelineType, timelineID string, limit int) ([]*gtsmodel.TimelineEntry, error)
+
+	// PutTimelineEntries inserts the given timeline entries, ignoring
+	// any entries whose item is already persisted in their timeline.
+	PutTimelineEntries(ctx context.Context, entries []*gtsmodel.TimelineEntry) error
+
+	// DeleteTimelineEntry deletes the entry for itemID from the timeline with the given type and ID.
+	DeleteTimelineEntry(ctx context.Context, timelineType gtsmodel.TimelineType, timelineID string, itemID string) error
+
+	// DeleteTimelineEntriesByItemID deletes entries for itemID from all timelines of the given type.
+	DeleteTimelineEntriesByItemID(ctx context.Context, timelineType gtsmodel.TimelineType, itemID string) error
+
+	// DeleteTimelineEntriesByOrBoosting deletes entries created by or boosting accountID
+	// from the timeline with the given type and ID.
+	DeleteTimelineEntriesByOrBoosting(ctx context.Context, timelineType gtsmodel.TimelineType, timelineID string, accountID string) error
+
+	// DeleteTimelineEntries deletes all entries of the timeline with the given type and ID.
+	DeleteTimelineEntries(ctx context.Context, timelineType gtsmodel.TimelineType, timelineID string) error
+
+	// TrimTimelineEntries deletes all but the newest maxLength entries of
+	// each timeline of the given type, returning the amount deleted.
+	TrimTimelineEntries(ctx context.Context, timelineType gtsmodel.TimelineType, maxLength int) (int, error)
 }
@@ -0,0 +1,49 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// WebPush handles getting/creation/deletion of Web Push subscriptions.
+type WebPush interface {
+	// GetWebPushSubscriptionByTokenID gets the push subscription belonging to the given access token id.
+	GetWebPushSubscriptionByTokenID(ctx context.Context, tokenID string) (*gtsmodel.WebPushSubscription, error)
+
+	// GetWebPushSubscriptionsByAccountID gets all push subscriptions belonging to the given account id.
+	GetWebPushSubscriptionsByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.WebPushSubscription, error)
+
+	// PutWebPushSubscription puts the given push subscription in the database.
+	PutWebPushSubscription(ctx context.Context, subscription *gtsmodel.WebPushSubscription) error
+
+	// UpdateWebPushSubscription updates the given push subscription. If no columns
+	// are specified, every column is updated.
+	UpdateWebPushSubscription(ctx context.Context, subscription *gtsmodel.WebPushSubscription, columns ...string) error
+
+	// DeleteWebPushSubscriptionByID deletes one push subscription by its db id.
+	DeleteWebPushSubscriptionByID(ctx context.Context, id string) error
+
+	// DeleteWebPushSubscriptionByTokenID deletes the push subscription belonging to the given access token id.
+	DeleteWebPushSubscriptionByTokenID(ctx context.Context, tokenID string) error
+
+	// DeleteWebPushSubscriptionsByAccountID deletes all push subscriptions belonging to the given account id.
+	DeleteWebPushSubscriptionsByAccountID(ctx context.Context, accountID string) error
+}
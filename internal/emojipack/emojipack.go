@@ -0,0 +1,348 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package emojipack
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// maxRenameAttempts is the number of numbered
+// suffixes tried when renaming an imported emoji
+// whose shortcode is already taken.
+const maxRenameAttempts = 100
+
+// Conflict determines what happens when an imported emoji
+// has the same shortcode as an existing local emoji.
+type Conflict string
+
+const (
+	ConflictSkip      Conflict = "skip"      // Keep the existing emoji.
+	ConflictOverwrite Conflict = "overwrite" // Replace the existing emoji's image and category.
+	ConflictRename    Conflict = "rename"    // Import under a new, numbered shortcode.
+)
+
+// ParseConflict parses the given string as a
+// conflict mode, defaulting to skip if empty.
+func ParseConflict(s string) (Conflict, error) {
+	switch c := Conflict(strings.ToLower(s)); c {
+	case "":
+		return ConflictSkip, nil
+	case ConflictSkip, ConflictOverwrite, ConflictRename:
+		return c, nil
+	default:
+		return "", fmt.Errorf("unrecognized emoji conflict mode %s, must be one of skip, overwrite, rename", s)
+	}
+}
+
+// Outcome describes what happened to one emoji in a pack on import.
+type Outcome string
+
+const (
+	OutcomeCreated     Outcome = "created"
+	OutcomeOverwritten Outcome = "overwritten"
+	OutcomeRenamed     Outcome = "renamed"
+	OutcomeSkipped     Outcome = "skipped"
+	OutcomeFailed      Outcome = "failed"
+)
+
+// Result is the result of importing one emoji from a pack.
+type Result struct {
+	// Shortcode of the emoji in the pack.
+	Shortcode string
+
+	// Emoji is the resulting local emoji;
+	// for skipped emojis this is the existing
+	// emoji, and for failures this is nil.
+	Emoji *gtsmodel.Emoji
+
+	Outcome Outcome
+
+	// Err is set on OutcomeFailed.
+	Err error
+}
+
+// Manager imports and exports
+// packs of local custom emojis.
+type Manager struct {
+	state        *state.State
+	mediaManager *media.Manager
+}
+
+// New returns a new emoji pack manager.
+func New(state *state.State, mediaManager *media.Manager) *Manager {
+	return &Manager{
+		state:        state,
+		mediaManager: mediaManager,
+	}
+}
+
+// Import imports each emoji in the given pack as a local emoji,
+// handling existing shortcodes according to conflict. Emojis
+// without a category in the pack are put in the given category,
+// if set. A failure to import one emoji does not stop the rest
+// being imported; the outcome for each emoji is returned.
+func (m *Manager) Import(
+	ctx context.Context,
+	pack *Pack,
+	conflict Conflict,
+	category string,
+) []Result {
+	results := make([]Result, 0, len(pack.Emojis))
+
+	for _, packEmoji := range pack.Emojis {
+		res := Result{Shortcode: packEmoji.Shortcode}
+
+		emoji, outcome, err := m.importEmoji(ctx, packEmoji, conflict, category)
+		if err != nil {
+			res.Outcome = OutcomeFailed
+			res.Err = err
+			log.Warnf(ctx, "error importing emoji %s: %v", packEmoji.Shortcode, err)
+		} else {
+			res.Outcome = outcome
+			res.Emoji = emoji
+		}
+
+		results = append(results, res)
+	}
+
+	return results
+}
+
+func (m *Manager) importEmoji(
+	ctx context.Context,
+	packEmoji *Emoji,
+	conflict Conflict,
+	category string,
+) (*gtsmodel.Emoji, Outcome, error) {
+	shortcode := packEmoji.Shortcode
+	if err := validate.EmojiShortcode(shortcode); err != nil {
+		return nil, "", err
+	}
+
+	if packEmoji.Category != "" {
+		category = packEmoji.Category
+	}
+
+	if err := validate.EmojiCategory(category); err != nil {
+		return nil, "", err
+	}
+
+	if packEmoji.Data == nil {
+		return nil, "", fmt.Errorf("image %s not found in emoji pack", packEmoji.Filename)
+	}
+
+	maxSize := config.GetMediaEmojiLocalMaxSize()
+	if size := len(packEmoji.Data); size > int(maxSize) {
+		return nil, "", fmt.Errorf("image %s is %d bytes, larger than the limit of %s", packEmoji.Filename, size, maxSize)
+	}
+
+	existing, err := m.state.DB.GetEmojiByShortcodeDomain(ctx, shortcode, "")
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, "", gtserror.Newf("db error checking for emoji with shortcode %s: %w", shortcode, err)
+	}
+
+	outcome := OutcomeCreated
+	if existing != nil {
+		switch conflict {
+		case ConflictOverwrite:
+			outcome = OutcomeOverwritten
+
+		case ConflictRename:
+			shortcode, err = m.freeShortcode(ctx, shortcode)
+			if err != nil {
+				return nil, "", err
+			}
+			existing = nil
+			outcome = OutcomeRenamed
+
+		default:
+			return existing, OutcomeSkipped, nil
+		}
+	}
+
+	var ai *media.AdditionalEmojiInfo
+	if category != "" {
+		category, err := m.getOrCreateEmojiCategory(ctx, category)
+		if err != nil {
+			return nil, "", err
+		}
+
+		ai = &media.AdditionalEmojiInfo{
+			CategoryID: &category.ID,
+		}
+	}
+
+	data := func(context.Context) (io.ReadCloser, int64, error) {
+		r := bytes.NewReader(packEmoji.Data)
+		return io.NopCloser(r), int64(len(packEmoji.Data)), nil
+	}
+
+	var processingEmoji *media.ProcessingEmoji
+	if existing != nil {
+		// Refresh the existing emoji with
+		// the new image, keeping its ID + URI.
+		processingEmoji, err = m.mediaManager.PreProcessEmoji(ctx,
+			data, existing.Shortcode, existing.ID, existing.URI, ai, true,
+		)
+	} else {
+		// Generate new emoji ID and URI.
+		var emojiID string
+		emojiID, err = id.NewRandomULID()
+		if err != nil {
+			return nil, "", gtserror.Newf("error creating id for new emoji: %w", err)
+		}
+
+		processingEmoji, err = m.mediaManager.PreProcessEmoji(ctx,
+			data, shortcode, emojiID, uris.URIForEmoji(emojiID), ai, false,
+		)
+	}
+
+	if err != nil {
+		return nil, "", gtserror.Newf("error processing emoji: %w", err)
+	}
+
+	// Complete processing immediately.
+	emoji, err := processingEmoji.LoadEmoji(ctx)
+	if err != nil {
+		return nil, "", gtserror.Newf("error loading emoji: %w", err)
+	}
+
+	return emoji, outcome, nil
+}
+
+// freeShortcode returns the first shortcode of the
+// form shortcode_2, shortcode_3 etc. not yet in use
+// by a local emoji, truncating shortcode if needed
+// to keep within the maximum shortcode length.
+func (m *Manager) freeShortcode(ctx context.Context, shortcode string) (string, error) {
+	for i := 2; i < maxRenameAttempts+2; i++ {
+		suffix := "_" + strconv.Itoa(i)
+
+		base := shortcode
+		if max := 30 - len(suffix); len(base) > max {
+			base = base[:max]
+		}
+
+		candidate := base + suffix
+		_, err := m.state.DB.GetEmojiByShortcodeDomain(ctx, candidate, "")
+		if errors.Is(err, db.ErrNoEntries) {
+			return candidate, nil
+		} else if err != nil {
+			return "", gtserror.Newf("db error checking for emoji with shortcode %s: %w", candidate, err)
+		}
+	}
+
+	return "", fmt.Errorf("could not find a free shortcode to rename %s to", shortcode)
+}
+
+// getOrCreateEmojiCategory either gets an existing
+// category with the given name from the database,
+// or, if the category doesn't yet exist, it creates
+// the category and then returns it.
+func (m *Manager) getOrCreateEmojiCategory(ctx context.Context, name string) (*gtsmodel.EmojiCategory, error) {
+	category, err := m.state.DB.GetEmojiCategoryByName(ctx, name)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting emoji category %s: %w", name, err)
+	}
+
+	if category != nil {
+		return category, nil
+	}
+
+	categoryID, err := id.NewRandomULID()
+	if err != nil {
+		return nil, gtserror.Newf("error generating id for new emoji category %s: %w", name, err)
+	}
+
+	category = &gtsmodel.EmojiCategory{
+		ID:   categoryID,
+		Name: name,
+	}
+
+	if err := m.state.DB.PutEmojiCategory(ctx, category); err != nil {
+		return nil, gtserror.Newf("db error putting new emoji category %s: %w", name, err)
+	}
+
+	return category, nil
+}
+
+// Export writes all local emojis to w as a pack
+// archive in the given format, returning the
+// number of emojis written.
+func (m *Manager) Export(ctx context.Context, w io.Writer, format Format) (int, error) {
+	emojis, err := m.state.DB.GetEmojisBy(ctx, "", true, true, "", "", "", 0)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return 0, gtserror.Newf("db error getting local emojis: %w", err)
+	}
+
+	pack := &Pack{
+		Emojis: make([]*Emoji, 0, len(emojis)),
+	}
+
+	for _, emoji := range emojis {
+		data, err := m.state.Storage.Get(ctx, emoji.ImagePath)
+		if err != nil {
+			// Don't fail the whole export
+			// for one missing / broken image.
+			log.Warnf(ctx, "error getting image for emoji %s, skipping: %v", emoji.Shortcode, err)
+			continue
+		}
+
+		var category string
+		if emoji.CategoryID != "" {
+			if emoji.Category == nil {
+				emoji.Category, err = m.state.DB.GetEmojiCategory(ctx, emoji.CategoryID)
+				if err != nil {
+					return 0, gtserror.Newf("db error getting category for emoji %s: %w", emoji.Shortcode, err)
+				}
+			}
+			category = emoji.Category.Name
+		}
+
+		pack.Emojis = append(pack.Emojis, &Emoji{
+			Shortcode: emoji.Shortcode,
+			Category:  category,
+			Filename:  emoji.Shortcode + strings.ToLower(path.Ext(emoji.ImagePath)),
+			Data:      data,
+		})
+	}
+
+	if err := Write(w, format, pack, config.GetHost()); err != nil {
+		return 0, err
+	}
+
+	return len(pack.Emojis), nil
+}
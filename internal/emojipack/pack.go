@@ -0,0 +1,497 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package emojipack
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+)
+
+const (
+	// maxFileSize is the upper limit on the size of any
+	// single file read from a pack archive. Emoji images
+	// are checked against the configured emoji size limit
+	// on import; this just guards against decompression bombs.
+	maxFileSize = 10 << 20 // 10MiB
+
+	// maxEntries and maxTotalSize are the upper limits on
+	// the number of entries in a pack archive, and on the
+	// total size of the files read from it, as these are
+	// all held in memory while the pack is imported.
+	maxEntries   = 10000
+	maxTotalSize = 256 << 20 // 256MiB
+
+	// Manifest file names.
+	misskeyManifest    = "meta.json"
+	pleromaManifest    = "pack.json"
+	pleromaLegacyIndex = "emoji.txt"
+)
+
+// Format is the archive format of an emoji pack.
+type Format string
+
+const (
+	FormatZip   Format = "zip"
+	FormatTarGz Format = "tar.gz"
+)
+
+// ParseFormat parses the given string as a pack
+// archive format, defaulting to zip if empty.
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(s) {
+	case "", "zip":
+		return FormatZip, nil
+	case "tar.gz", "tgz":
+		return FormatTarGz, nil
+	default:
+		return "", fmt.Errorf("unrecognized emoji pack format %s, must be one of zip, tar.gz", s)
+	}
+}
+
+// ContentType returns the MIME type of archives in this format.
+func (f Format) ContentType() string {
+	if f == FormatTarGz {
+		return "application/gzip"
+	}
+	return "application/zip"
+}
+
+// Emoji is a single emoji read from or written to a pack.
+type Emoji struct {
+	// Shortcode of the emoji, without colons.
+	Shortcode string
+
+	// Category of the emoji, if any.
+	Category string
+
+	// Filename is the path of
+	// the image within the archive.
+	Filename string
+
+	// Data is the image data. This is nil
+	// if the pack manifest referred to an
+	// image not present in the archive.
+	Data []byte
+}
+
+// Pack is a collection of emojis
+// read from or written to an archive.
+type Pack struct {
+	Emojis []*Emoji
+}
+
+// Read reads an emoji pack from the zip or tar.gz archive
+// in r, detecting the archive format from its contents.
+//
+// If the archive contains a Misskey (meta.json) or Pleroma
+// (pack.json, emoji.txt) manifest, shortcodes and categories
+// are taken from it. Otherwise every image in the archive is
+// read, with the shortcode taken from the file name and the
+// category from the name of the directory containing it.
+func Read(r io.ReaderAt, size int64) (*Pack, error) {
+	magic := make([]byte, 4)
+	if _, err := r.ReadAt(magic, 0); err != nil {
+		return nil, fmt.Errorf("error reading emoji pack: %w", err)
+	}
+
+	var (
+		files map[string][]byte
+		err   error
+	)
+
+	switch {
+	case bytes.HasPrefix(magic, []byte("PK\x03\x04")),
+		bytes.HasPrefix(magic, []byte("PK\x05\x06")):
+		files, err = readZip(r, size)
+
+	case bytes.HasPrefix(magic, []byte{0x1f, 0x8b}):
+		files, err = readTarGz(io.NewSectionReader(r, 0, size))
+
+	default:
+		return nil, errors.New("emoji pack is neither a zip nor a tar.gz archive")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parseFiles(files)
+}
+
+// readZip reads all regular image and
+// manifest files from the zip archive in r.
+func readZip(r io.ReaderAt, size int64) (map[string][]byte, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("error opening zip archive: %w", err)
+	}
+
+	if len(zr.File) > maxEntries {
+		return nil, fmt.Errorf("emoji pack has more than %d entries", maxEntries)
+	}
+
+	var (
+		files = make(map[string][]byte)
+		total int
+	)
+
+	for _, f := range zr.File {
+		name, ok := cleanName(f.Name)
+		if !ok || !f.Mode().IsRegular() || !wantFile(name) {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("error opening %s: %w", f.Name, err)
+		}
+
+		data, err := readFile(name, rc, &total)
+		_ = rc.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		files[name] = data
+	}
+
+	return files, nil
+}
+
+// readTarGz reads all regular image and
+// manifest files from the tar.gz archive in r.
+func readTarGz(r io.Reader) (map[string][]byte, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("error opening gzip stream: %w", err)
+	}
+	defer gz.Close()
+
+	var (
+		tr      = tar.NewReader(gz)
+		files   = make(map[string][]byte)
+		entries int
+		total   int
+	)
+
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return files, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("error reading tar archive: %w", err)
+		}
+
+		entries++
+		if entries > maxEntries {
+			return nil, fmt.Errorf("emoji pack has more than %d entries", maxEntries)
+		}
+
+		name, ok := cleanName(hdr.Name)
+		if !ok || hdr.Typeflag != tar.TypeReg || !wantFile(name) {
+			continue
+		}
+
+		data, err := readFile(name, tr, &total)
+		if err != nil {
+			return nil, err
+		}
+
+		files[name] = data
+	}
+}
+
+// readFile reads the archive file with name from r, adding its
+// size to total, and erroring if either it or total are larger
+// than permitted.
+func readFile(name string, r io.Reader, total *int) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", name, err)
+	}
+
+	if len(data) > maxFileSize {
+		return nil, fmt.Errorf("file %s in emoji pack is larger than %d bytes", name, maxFileSize)
+	}
+
+	*total += len(data)
+	if *total > maxTotalSize {
+		return nil, fmt.Errorf("files in emoji pack are larger than %d bytes in total", maxTotalSize)
+	}
+
+	return data, nil
+}
+
+// wantFile returns whether the archive file with
+// the given name may be part of the pack, ie., it's
+// either an image or a manifest.
+func wantFile(name string) bool {
+	switch path.Base(name) {
+	case misskeyManifest, pleromaManifest, pleromaLegacyIndex:
+		return true
+	default:
+		return isImageExt(path.Ext(name))
+	}
+}
+
+// cleanName cleans the given archive file
+// name, returning false if it should be
+// ignored (eg., hidden / OS metadata files).
+func cleanName(name string) (string, bool) {
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+	if name == "" || strings.HasPrefix(name, "__MACOSX/") {
+		return "", false
+	}
+
+	if strings.HasPrefix(path.Base(name), ".") {
+		return "", false
+	}
+
+	return name, true
+}
+
+// parseFiles builds a pack from the given archive files,
+// using the first manifest found (if any) to map images
+// to shortcodes, and falling back to file names otherwise.
+func parseFiles(files map[string][]byte) (*Pack, error) {
+	// Sort file names so that we
+	// find root-most manifests first.
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		di := strings.Count(names[i], "/")
+		dj := strings.Count(names[j], "/")
+		if di != dj {
+			return di < dj
+		}
+		return names[i] < names[j]
+	})
+
+	for _, manifest := range []string{
+		misskeyManifest,
+		pleromaManifest,
+		pleromaLegacyIndex,
+	} {
+		for _, name := range names {
+			if path.Base(name) != manifest {
+				continue
+			}
+
+			var (
+				dir   = path.Dir(name)
+				pack  *Pack
+				err   error
+				index = files[name]
+			)
+
+			switch manifest {
+			case misskeyManifest:
+				pack, err = parseMisskey(index, dir)
+			case pleromaManifest:
+				pack, err = parsePleroma(index, dir)
+			case pleromaLegacyIndex:
+				pack, err = parsePleromaLegacy(index, dir)
+			}
+
+			if err != nil {
+				return nil, fmt.Errorf("error parsing %s: %w", name, err)
+			}
+
+			// Fill in image data from archive.
+			for _, emoji := range pack.Emojis {
+				emoji.Data = files[emoji.Filename]
+			}
+
+			return pack, nil
+		}
+	}
+
+	// No manifest, just use images.
+	pack := new(Pack)
+	for _, name := range names {
+		ext := path.Ext(name)
+		if !isImageExt(ext) {
+			continue
+		}
+
+		var category string
+		if dir := path.Dir(name); dir != "." {
+			category = path.Base(dir)
+		}
+
+		pack.Emojis = append(pack.Emojis, &Emoji{
+			Shortcode: strings.TrimSuffix(path.Base(name), ext),
+			Category:  category,
+			Filename:  name,
+			Data:      files[name],
+		})
+	}
+
+	return pack, nil
+}
+
+// misskeyMeta models the meta.json file found
+// in emoji archives exported from Misskey.
+type misskeyMeta struct {
+	MetaVersion int            `json:"metaVersion"`
+	Host        string         `json:"host,omitempty"`
+	ExportedAt  string         `json:"exportedAt,omitempty"`
+	Emojis      []misskeyEmoji `json:"emojis"`
+}
+
+type misskeyEmoji struct {
+	Downloaded bool   `json:"downloaded"`
+	FileName   string `json:"fileName"`
+	Emoji      struct {
+		Name     string   `json:"name"`
+		Category string   `json:"category,omitempty"`
+		Aliases  []string `json:"aliases"`
+	} `json:"emoji"`
+}
+
+func parseMisskey(b []byte, dir string) (*Pack, error) {
+	var meta misskeyMeta
+	if err := json.Unmarshal(b, &meta); err != nil {
+		return nil, err
+	}
+
+	pack := &Pack{
+		Emojis: make([]*Emoji, 0, len(meta.Emojis)),
+	}
+
+	for _, e := range meta.Emojis {
+		if e.FileName == "" {
+			continue
+		}
+
+		pack.Emojis = append(pack.Emojis, &Emoji{
+			Shortcode: e.Emoji.Name,
+			Category:  e.Emoji.Category,
+			Filename:  path.Join(dir, e.FileName),
+		})
+	}
+
+	return pack, nil
+}
+
+// pleromaPack models the pack.json file
+// describing a Pleroma / Akkoma emoji pack.
+type pleromaPack struct {
+	Files map[string]string `json:"files"`
+	Pack  struct {
+		Description string `json:"description,omitempty"`
+		License     string `json:"license,omitempty"`
+		Homepage    string `json:"homepage,omitempty"`
+		ShareFiles  bool   `json:"share-files"`
+	} `json:"pack"`
+}
+
+func parsePleroma(b []byte, dir string) (*Pack, error) {
+	var p pleromaPack
+	if err := json.Unmarshal(b, &p); err != nil {
+		return nil, err
+	}
+
+	// Pleroma packs have no per-emoji
+	// categories, they're grouped by pack
+	// name, ie., the name of the directory.
+	category := pleromaCategory(dir)
+
+	shortcodes := make([]string, 0, len(p.Files))
+	for shortcode := range p.Files {
+		shortcodes = append(shortcodes, shortcode)
+	}
+	sort.Strings(shortcodes)
+
+	pack := &Pack{
+		Emojis: make([]*Emoji, 0, len(shortcodes)),
+	}
+
+	for _, shortcode := range shortcodes {
+		pack.Emojis = append(pack.Emojis, &Emoji{
+			Shortcode: shortcode,
+			Category:  category,
+			Filename:  path.Join(dir, p.Files[shortcode]),
+		})
+	}
+
+	return pack, nil
+}
+
+// parsePleromaLegacy parses the emoji.txt index used by
+// older Pleroma packs, formatted as lines of:
+//
+//	shortcode, path/to/image.png[, tag1, tag2]
+func parsePleromaLegacy(b []byte, dir string) (*Pack, error) {
+	category := pleromaCategory(dir)
+	pack := new(Pack)
+
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, ",")
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("malformed line %q", line)
+		}
+
+		pack.Emojis = append(pack.Emojis, &Emoji{
+			Shortcode: strings.TrimSpace(fields[0]),
+			Category:  category,
+			Filename:  path.Join(dir, strings.TrimPrefix(strings.TrimSpace(fields[1]), "/")),
+		})
+	}
+
+	return pack, scanner.Err()
+}
+
+// pleromaCategory returns the category
+// for emojis in a Pleroma pack manifest
+// located in the given archive directory.
+func pleromaCategory(dir string) string {
+	if dir == "." {
+		return ""
+	}
+	return path.Base(dir)
+}
+
+// isImageExt returns whether the given file
+// extension is that of a supported emoji image.
+func isImageExt(ext string) bool {
+	switch strings.ToLower(ext) {
+	case ".png", ".apng", ".gif", ".webp", ".jpg", ".jpeg":
+		return true
+	default:
+		return false
+	}
+}
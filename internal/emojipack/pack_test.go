@@ -0,0 +1,167 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package emojipack_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/emojipack"
+)
+
+type PackTestSuite struct {
+	suite.Suite
+}
+
+func (suite *PackTestSuite) zip(files map[string]string) *bytes.Reader {
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	for name, content := range files {
+		fw, err := zw.Create(name)
+		suite.NoError(err)
+		_, err = fw.Write([]byte(content))
+		suite.NoError(err)
+	}
+	suite.NoError(zw.Close())
+	return bytes.NewReader(buf.Bytes())
+}
+
+func (suite *PackTestSuite) read(r *bytes.Reader) map[string]*emojipack.Emoji {
+	pack, err := emojipack.Read(r, r.Size())
+	suite.NoError(err)
+
+	emojis := make(map[string]*emojipack.Emoji, len(pack.Emojis))
+	for _, emoji := range pack.Emojis {
+		emojis[emoji.Shortcode] = emoji
+	}
+	return emojis
+}
+
+func (suite *PackTestSuite) TestReadMisskey() {
+	emojis := suite.read(suite.zip(map[string]string{
+		"meta.json": `{"metaVersion":2,"emojis":[
+			{"downloaded":true,"fileName":"blob.png","emoji":{"name":"blob","category":"blobs","aliases":[]}},
+			{"downloaded":true,"fileName":"gone.png","emoji":{"name":"gone","aliases":[]}}
+		]}`,
+		"blob.png":  "blob",
+		"other.png": "not in manifest",
+	}))
+
+	suite.Len(emojis, 2)
+	suite.Equal("blobs", emojis["blob"].Category)
+	suite.Equal([]byte("blob"), emojis["blob"].Data)
+	suite.Nil(emojis["gone"].Data)
+}
+
+func (suite *PackTestSuite) TestReadPleroma() {
+	emojis := suite.read(suite.zip(map[string]string{
+		"blobcats/pack.json":      `{"files":{"blobcat":"blobcat.png","blobcat_heart":"img/heart.gif"},"pack":{"share-files":true}}`,
+		"blobcats/blobcat.png":    "cat",
+		"blobcats/img/heart.gif":  "heart",
+		"blobcats/._blobcat.png":  "resource fork",
+		"__MACOSX/blobcats/x.png": "junk",
+	}))
+
+	suite.Len(emojis, 2)
+	suite.Equal("blobcats", emojis["blobcat"].Category)
+	suite.Equal([]byte("heart"), emojis["blobcat_heart"].Data)
+}
+
+func (suite *PackTestSuite) TestReadPleromaLegacy() {
+	emojis := suite.read(suite.zip(map[string]string{
+		"emoji.txt":     "# comment\nparty, /party.gif, Custom\n",
+		"party.gif":     "party",
+		"something.png": "not indexed",
+	}))
+
+	suite.Len(emojis, 1)
+	suite.Equal("", emojis["party"].Category)
+	suite.Equal([]byte("party"), emojis["party"].Data)
+}
+
+func (suite *PackTestSuite) TestReadNoManifest() {
+	emojis := suite.read(suite.zip(map[string]string{
+		"rainbow.png":         "rainbow",
+		"animals/fox.webp":    "fox",
+		"README.md":           "not an image",
+		"animals/.hidden.gif": "hidden",
+	}))
+
+	suite.Len(emojis, 2)
+	suite.Equal("", emojis["rainbow"].Category)
+	suite.Equal("animals", emojis["fox"].Category)
+}
+
+func (suite *PackTestSuite) TestReadSkipsOtherFiles() {
+	// Too big to be read, but it's not
+	// an image so shouldn't be read at all.
+	emojis := suite.read(suite.zip(map[string]string{
+		"rainbow.png": "rainbow",
+		"video.mp4":   strings.Repeat("a", 11<<20),
+	}))
+
+	suite.Len(emojis, 1)
+	suite.Equal([]byte("rainbow"), emojis["rainbow"].Data)
+}
+
+func (suite *PackTestSuite) TestReadTooManyEntries() {
+	files := make(map[string]string, 10001)
+	for i := 0; i < 10001; i++ {
+		files[strconv.Itoa(i)+".png"] = ""
+	}
+
+	r := suite.zip(files)
+	_, err := emojipack.Read(r, r.Size())
+	suite.EqualError(err, "emoji pack has more than 10000 entries")
+}
+
+func (suite *PackTestSuite) TestReadNotArchive() {
+	r := bytes.NewReader([]byte("definitely not an archive"))
+	_, err := emojipack.Read(r, r.Size())
+	suite.EqualError(err, "emoji pack is neither a zip nor a tar.gz archive")
+}
+
+func (suite *PackTestSuite) TestWriteRead() {
+	pack := &emojipack.Pack{
+		Emojis: []*emojipack.Emoji{
+			{Shortcode: "rainbow", Filename: "rainbow.png", Data: []byte("rainbow")},
+			{Shortcode: "kip", Category: "cats", Filename: "kip.gif", Data: []byte("kip")},
+		},
+	}
+
+	for _, format := range []emojipack.Format{
+		emojipack.FormatZip,
+		emojipack.FormatTarGz,
+	} {
+		buf := new(bytes.Buffer)
+		suite.NoError(emojipack.Write(buf, format, pack, "example.org"))
+
+		emojis := suite.read(bytes.NewReader(buf.Bytes()))
+		suite.Len(emojis, 2)
+		suite.Equal("cats", emojis["kip"].Category)
+		suite.Equal([]byte("rainbow"), emojis["rainbow"].Data)
+	}
+}
+
+func TestPackTestSuite(t *testing.T) {
+	suite.Run(t, new(PackTestSuite))
+}
@@ -0,0 +1,143 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package emojipack
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Write writes the given pack to w as an archive in the given
+// format, with host recorded as the origin of the pack. Both a Misskey (meta.json) and a Pleroma (pack.json)
+// manifest are included, so the pack can be imported by either.
+func Write(w io.Writer, format Format, pack *Pack, host string) error {
+	var (
+		now  = time.Now()
+		meta = misskeyMeta{
+			MetaVersion: 2,
+			Host:        host,
+			ExportedAt:  now.UTC().Format(time.RFC3339),
+			Emojis:      make([]misskeyEmoji, 0, len(pack.Emojis)),
+		}
+		pleroma = pleromaPack{
+			Files: make(map[string]string, len(pack.Emojis)),
+		}
+	)
+
+	for _, emoji := range pack.Emojis {
+		entry := misskeyEmoji{
+			Downloaded: true,
+			FileName:   emoji.Filename,
+		}
+		entry.Emoji.Name = emoji.Shortcode
+		entry.Emoji.Category = emoji.Category
+		entry.Emoji.Aliases = []string{}
+		meta.Emojis = append(meta.Emojis, entry)
+
+		pleroma.Files[emoji.Shortcode] = emoji.Filename
+	}
+	pleroma.Pack.Description = "Custom emojis exported from " + host
+	pleroma.Pack.ShareFiles = true
+
+	metaB, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding %s: %w", misskeyManifest, err)
+	}
+
+	pleromaB, err := json.MarshalIndent(pleroma, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding %s: %w", pleromaManifest, err)
+	}
+
+	// Gather all the files to be written.
+	files := make([]file, 0, len(pack.Emojis)+2)
+	files = append(files,
+		file{name: misskeyManifest, data: metaB},
+		file{name: pleromaManifest, data: pleromaB},
+	)
+	for _, emoji := range pack.Emojis {
+		files = append(files, file{name: emoji.Filename, data: emoji.Data})
+	}
+
+	switch format {
+	case FormatZip:
+		return writeZip(w, files, now)
+	case FormatTarGz:
+		return writeTarGz(w, files, now)
+	default:
+		return fmt.Errorf("unrecognized emoji pack format %s", format)
+	}
+}
+
+type file struct {
+	name string
+	data []byte
+}
+
+func writeZip(w io.Writer, files []file, modified time.Time) error {
+	zw := zip.NewWriter(w)
+
+	for _, f := range files {
+		fw, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     f.name,
+			Method:   zip.Deflate,
+			Modified: modified,
+		})
+		if err != nil {
+			return fmt.Errorf("error creating %s: %w", f.name, err)
+		}
+
+		if _, err := fw.Write(f.data); err != nil {
+			return fmt.Errorf("error writing %s: %w", f.name, err)
+		}
+	}
+
+	return zw.Close()
+}
+
+func writeTarGz(w io.Writer, files []file, modified time.Time) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	for _, f := range files {
+		if err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     f.name,
+			Size:     int64(len(f.data)),
+			Mode:     0o644,
+			ModTime:  modified,
+		}); err != nil {
+			return fmt.Errorf("error writing header for %s: %w", f.name, err)
+		}
+
+		if _, err := tw.Write(f.data); err != nil {
+			return fmt.Errorf("error writing %s: %w", f.name, err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	return gz.Close()
+}
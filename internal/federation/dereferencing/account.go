@@ -34,6 +34,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
@@ -108,6 +109,10 @@ func (d *Dereferencer) GetAccountByURI(ctx context.Context, requestUser string,
 			if err := d.dereferenceAccountFeatured(ctx, requestUser, account); err != nil {
 				log.Errorf(ctx, "error fetching account featured collection: %v", err)
 			}
+
+			if err := d.dereferenceAccountFeaturedTags(ctx, requestUser, account); err != nil {
+				log.Errorf(ctx, "error fetching account featured tags: %v", err)
+			}
 		})
 	}
 
@@ -205,6 +210,10 @@ func (d *Dereferencer) GetAccountByUsernameDomain(ctx context.Context, requestUs
 			if err := d.dereferenceAccountFeatured(ctx, requestUser, account); err != nil {
 				log.Errorf(ctx, "error fetching account featured collection: %v", err)
 			}
+
+			if err := d.dereferenceAccountFeaturedTags(ctx, requestUser, account); err != nil {
+				log.Errorf(ctx, "error fetching account featured tags: %v", err)
+			}
 		})
 	}
 
@@ -326,6 +335,10 @@ func (d *Dereferencer) RefreshAccount(
 			if err := d.dereferenceAccountFeatured(ctx, requestUser, latest); err != nil {
 				log.Errorf(ctx, "error fetching account featured collection: %v", err)
 			}
+
+			if err := d.dereferenceAccountFeaturedTags(ctx, requestUser, latest); err != nil {
+				log.Errorf(ctx, "error fetching account featured tags: %v", err)
+			}
 		})
 	}
 
@@ -374,6 +387,10 @@ func (d *Dereferencer) RefreshAccountAsync(
 			if err := d.dereferenceAccountFeatured(ctx, requestUser, latest); err != nil {
 				log.Errorf(ctx, "error fetching account featured collection: %v", err)
 			}
+
+			if err := d.dereferenceAccountFeaturedTags(ctx, requestUser, latest); err != nil {
+				log.Errorf(ctx, "error fetching account featured tags: %v", err)
+			}
 		}
 	})
 }
@@ -813,6 +830,7 @@ func (d *Dereferencer) fetchRemoteAccountAvatar(ctx context.Context, tsport tran
 		processing = d.mediaManager.PreProcessMedia(data, latestAcc.ID, &media.AdditionalMediaInfo{
 			Avatar:    func() *bool { v := true; return &v }(),
 			RemoteURL: &latestAcc.AvatarRemoteURL,
+			Domain:    &latestAcc.Domain,
 		})
 
 		// Store media in map to mark as processing.
@@ -899,6 +917,7 @@ func (d *Dereferencer) fetchRemoteAccountHeader(ctx context.Context, tsport tran
 		processing = d.mediaManager.PreProcessMedia(data, latestAcc.ID, &media.AdditionalMediaInfo{
 			Header:    func() *bool { v := true; return &v }(),
 			RemoteURL: &latestAcc.HeaderRemoteURL,
+			Domain:    &latestAcc.Domain,
 		})
 
 		// Store media in map to mark as processing.
@@ -1149,3 +1168,97 @@ outerLoop:
 
 	return nil
 }
+
+// dereferenceAccountFeaturedTags dereferences an account's featuredTagsURI (if not empty). Each discovered hashtag will be
+// created in the database (if necessary), and the account's featured tags will be replaced with those found in the collection.
+func (d *Dereferencer) dereferenceAccountFeaturedTags(ctx context.Context, requestUser string, account *gtsmodel.Account) error {
+	if account.FeaturedTagsURI == "" {
+		// Nothing to do.
+		return nil
+	}
+
+	uri, err := url.Parse(account.FeaturedTagsURI)
+	if err != nil {
+		return err
+	}
+
+	collect, err := d.dereferenceCollection(ctx, requestUser, uri)
+	if err != nil {
+		return err
+	}
+
+	var (
+		tags []*gtsmodel.Tag
+		keys = make(map[string]struct{}) // Use map to dedupe items.
+	)
+
+	for {
+		// Get next collect item.
+		item := collect.NextItem()
+		if item == nil {
+			break
+		}
+
+		// Featured hashtags are always
+		// embedded, never just IRIs.
+		t := item.GetType()
+		if t == nil || t.GetTypeName() != ap.TagHashtag {
+			continue
+		}
+
+		hashtaggable, ok := t.(ap.Hashtaggable)
+		if !ok {
+			continue
+		}
+
+		name, ok := text.NormalizeHashtag(ap.ExtractName(hashtaggable))
+		if !ok {
+			continue
+		}
+
+		if _, set := keys[name]; set {
+			continue
+		}
+		keys[name] = struct{}{}
+
+		// Look for existing tag with name in the database.
+		tag, err := d.state.DB.GetTagByName(ctx, name)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting tag %s: %w", name, err)
+		}
+
+		if tag == nil {
+			// Create new tag with this name.
+			tag = &gtsmodel.Tag{
+				ID:   id.NewULID(),
+				Name: name,
+			}
+
+			if err := d.state.DB.PutTag(ctx, tag); err != nil {
+				log.Errorf(ctx, "db error putting tag %s: %v", name, err)
+				continue
+			}
+		}
+
+		tags = append(tags, tag)
+	}
+
+	// Replace previous featured tags
+	// with the most recent ones.
+	if err := d.state.DB.DeleteAccountFeaturedTags(ctx, account.ID); err != nil {
+		return gtserror.Newf("db error deleting featured tags: %w", err)
+	}
+
+	for _, tag := range tags {
+		if err := d.state.DB.PutFeaturedTag(ctx, &gtsmodel.FeaturedTag{
+			ID:        id.NewULID(),
+			AccountID: account.ID,
+			TagID:     tag.ID,
+		}); err != nil {
+			log.Errorf(ctx, "db error putting featured tag %s: %v", tag.Name, err)
+			continue
+		}
+	}
+
+	return nil
+}
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -230,6 +231,47 @@ func (suite *AccountTestSuite) TestDereferenceRemoteAccountWithNonMatchingURI()
 	suite.Nil(fetchedAccount)
 }
 
+func (suite *AccountTestSuite) TestDereferenceNewRemoteAccountWithAvatar() {
+	fetchingAccount := suite.testAccounts["local_account_1"]
+
+	const (
+		remoteURI = "https://unknown-instance.com/users/brand_new_person"
+		avatarURL = "https://turnip.farm/attachments/f17843c7-015e-4251-9b5a-91389c49ee57.jpg"
+	)
+
+	// Give the (not yet stored)
+	// remote account an avatar.
+	icon := streams.NewActivityStreamsImage()
+	iconURL := streams.NewActivityStreamsUrlProperty()
+	iconURL.AppendIRI(testrig.URLMustParse(avatarURL))
+	icon.SetActivityStreamsUrl(iconURL)
+	iconProp := streams.NewActivityStreamsIconProperty()
+	iconProp.AppendActivityStreamsImage(icon)
+	suite.client.TestRemotePeople[remoteURI].SetActivityStreamsIcon(iconProp)
+
+	fetchedAccount, _, err := suite.dereferencer.GetAccountByURI(
+		context.Background(),
+		fetchingAccount.Username,
+		testrig.URLMustParse(remoteURI),
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Avatar should be set, even though the
+	// account wasn't stored when it was loaded.
+	suite.Equal(avatarURL, fetchedAccount.AvatarRemoteURL)
+	suite.NotEmpty(fetchedAccount.AvatarMediaAttachmentID)
+
+	avatar, err := suite.db.GetAttachmentByID(context.Background(), fetchedAccount.AvatarMediaAttachmentID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(fetchedAccount.ID, avatar.AccountID)
+	suite.True(*avatar.Avatar)
+	suite.True(*avatar.Cached)
+}
+
 func TestAccountTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountTestSuite))
 }
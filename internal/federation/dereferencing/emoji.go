@@ -116,6 +116,25 @@ func (d *Dereferencer) populateEmojis(ctx context.Context, rawEmojis []*gtsmodel
 			continue
 		}
 
+		// Check whether media policy
+		// of the domain allows fetching.
+		policy, err := d.state.DB.MatchDomainMediaPolicy(ctx, e.Domain)
+		if err != nil {
+			log.Errorf(ctx, "error getting media policy for emoji %s: %s", shortcodeDomain, err)
+			continue
+		}
+
+		if !policy.Fetches() {
+			if gotEmoji != nil {
+				// Keep what we have
+				// without refreshing.
+				gotEmojis = append(gotEmojis, gotEmoji)
+			}
+
+			log.Debugf(ctx, "not fetching emoji %s due to media policy", shortcodeDomain)
+			continue
+		}
+
 		var refresh bool
 
 		if gotEmoji != nil {
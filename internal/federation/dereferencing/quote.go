@@ -0,0 +1,76 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// dereferenceQuote ensures that the status quoted by the given status,
+// if any, is dereferenced, updating the quoting status in the database
+// to point to it. This is performed outside of the FedLocks held during
+// status enrichment, so that quote cycles (A quotes B quotes A) can't
+// deadlock. The quoted status itself is fetched WITHOUT dereferencing
+// its own thread or quote, so quote chains are only followed one level.
+//
+// Any error is logged rather than returned, as a quote that
+// can't be fetched should never prevent the quoting status
+// from being stored; the URI is kept for a later attempt.
+func (d *Dereferencer) dereferenceQuote(
+	ctx context.Context,
+	requestUser string,
+	status *gtsmodel.Status,
+) {
+	if status.QuoteOfURI == "" || status.QuoteOfID != "" {
+		// No quote, or
+		// already known.
+		return
+	}
+
+	quoteURI, err := url.Parse(status.QuoteOfURI)
+	if err != nil {
+		log.Debugf(ctx, "invalid quote uri %q: %v", status.QuoteOfURI, err)
+		return
+	}
+
+	if quoteURI.String() == status.URI {
+		// Ignore status
+		// quoting itself.
+		return
+	}
+
+	quoteOf, _, _, err := d.getStatusByURI(ctx, requestUser, quoteURI)
+	if err != nil && quoteOf == nil {
+		log.Debugf(ctx, "error dereferencing quoted status %s: %v", quoteURI, err)
+		return
+	}
+
+	// Set the quote on the status
+	// and persist in the database.
+	status.QuoteOfID = quoteOf.ID
+	status.QuoteOf = quoteOf
+	if err := d.state.DB.UpdateStatus(ctx, status, "quote_of_id"); err != nil {
+		err := gtserror.Newf("error updating quote of status %s: %w", status.URI, err)
+		log.Error(ctx, err)
+	}
+}
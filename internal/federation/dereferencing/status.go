@@ -97,6 +97,12 @@ func (d *Dereferencer) GetStatusByURI(ctx context.Context, requestUser string, u
 
 	} else if statusable != nil {
 
+		// Deref quoted status.
+		d.dereferenceQuote(ctx,
+			requestUser,
+			status,
+		)
+
 		// Deref parents + children.
 		d.dereferenceThread(ctx,
 			requestUser,
@@ -218,6 +224,12 @@ func (d *Dereferencer) RefreshStatus(
 	}
 
 	if statusable != nil {
+		// Deref quoted status.
+		d.dereferenceQuote(ctx,
+			requestUser,
+			latest,
+		)
+
 		// Deref parents + children.
 		d.dereferenceThread(ctx,
 			requestUser,
@@ -267,6 +279,7 @@ func (d *Dereferencer) RefreshStatusAsync(
 			return
 		}
 		if statusable != nil {
+			d.dereferenceQuote(ctx, requestUser, latest)
 			if err := d.DereferenceStatusAncestors(ctx, requestUser, latest); err != nil {
 				log.Error(ctx, err)
 			}
@@ -991,12 +1004,25 @@ func (d *Dereferencer) fetchStatusAttachments(ctx context.Context, tsport transp
 	// Allocate new slice to take the yet-to-be fetched attachment IDs.
 	status.AttachmentIDs = make([]string, len(status.Attachments))
 
+	var policy *gtsmodel.DomainMediaPolicy
+	if len(status.Attachments) > 0 {
+		// Check the media policy of the status author's domain,
+		// media from domains with a policy not to cache it
+		// doesn't need refetching when we already have it.
+		var err error
+		policy, err = d.mediaManager.AccountMediaPolicy(ctx, status.AccountID)
+		if err != nil {
+			log.Errorf(ctx, "error getting media policy: %v", err)
+		}
+	}
+
 	for i := range status.Attachments {
 		attachment := status.Attachments[i]
 
 		// Look for existing media attachment with remote URL first.
+		// Quarantined media won't be cached, but shouldn't be refetched either.
 		existing, ok := existing.GetAttachmentByRemoteURL(attachment.RemoteURL)
-		if ok && existing.ID != "" && *existing.Cached {
+		if ok && existing.ID != "" && (*existing.Cached || util.PtrValueOr(existing.Quarantined, false) || !policy.Caches()) {
 			status.Attachments[i] = existing
 			status.AttachmentIDs[i] = existing.ID
 			continue
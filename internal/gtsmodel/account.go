@@ -78,6 +78,7 @@ type Account struct {
 	FollowingURI          string     `bun:",nullzero,unique"`               // URI for getting the following list of this account
 	FollowersURI          string     `bun:",nullzero,unique"`               // URI for getting the followers list of this account
 	FeaturedCollectionURI string     `bun:",nullzero,unique"`               // URL for getting the featured collection list of this account
+	FeaturedTagsURI       string     `bun:",nullzero"`                      // URL for getting the featured hashtags collection of this account
 	ActorType             string     `bun:",nullzero,notnull"`              // What type of activitypub actor is this account?
 	PrivateKey            PrivateKey `bun:""`                               // Privatekey for signing activitypub requests, will only be defined for local accounts
 	PublicKey             PublicKey  `bun:",notnull"`                       // Publickey for authorizing signed activitypub requests, will be defined for both local and remote accounts
@@ -0,0 +1,41 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// BookmarkCollection refers to a named collection
+// into which the owning account can sort their bookmarks.
+type BookmarkCollection struct {
+	ID        string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                             // id of this item in the database
+	CreatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`          // when was item created
+	UpdatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`          // when was item last updated
+	Title     string    `bun:",nullzero,notnull,unique:bookmarkcollectionaccounttitle"`              // Title of this collection.
+	AccountID string    `bun:"type:CHAR(26),notnull,nullzero,unique:bookmarkcollectionaccounttitle"` // Account that created/owns the collection
+	Account   *Account  `bun:"-"`                                                                    // Account corresponding to accountID
+}
+
+// BookmarkCollectionEntry refers to a
+// single bookmark entry in a collection.
+type BookmarkCollectionEntry struct {
+	ID           string          `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                        // id of this item in the database
+	CreatedAt    time.Time       `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                     // when was item created
+	CollectionID string          `bun:"type:CHAR(26),notnull,nullzero,unique:bookmarkcollectionentrycollectionbookmark"` // ID of the collection that this entry belongs to.
+	BookmarkID   string          `bun:"type:CHAR(26),notnull,nullzero,unique:bookmarkcollectionentrycollectionbookmark"` // ID of the bookmark contained in the collection.
+	Bookmark     *StatusBookmark `bun:"-"`                                                                               // Bookmark corresponding to bookmarkID.
+}
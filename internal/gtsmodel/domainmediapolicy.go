@@ -0,0 +1,72 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// DomainMediaPolicy is an admin-managed policy determining how
+// media (attachments, avatars, headers and emojis) from a remote
+// domain, and any of its subdomains, is fetched and cached.
+type DomainMediaPolicy struct {
+	ID                 string      `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time   `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time   `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Domain             string      `bun:",nullzero,notnull,unique"`                                    // domain (and subdomains) this policy applies to, punycode
+	Policy             MediaPolicy `bun:",nullzero,notnull"`                                           // how to handle media from this domain
+	CacheDays          *int        `bun:",nullzero"`                                                   // for MediaPolicyCache, days to keep media cached for; nil means use the instance default
+	Comment            string      `bun:",nullzero"`                                                   // private comment on this policy
+	CreatedByAccountID string      `bun:"type:CHAR(26),nullzero,notnull"`                              // Account ID of the creator of this policy
+	CreatedByAccount   *Account    `bun:"-"`                                                           // Account corresponding to CreatedByAccountID
+}
+
+// Caches returns whether media from
+// this domain may be stored locally.
+func (p *DomainMediaPolicy) Caches() bool {
+	return p == nil || p.Policy == MediaPolicyCache
+}
+
+// Fetches returns whether media from
+// this domain may be fetched at all.
+func (p *DomainMediaPolicy) Fetches() bool {
+	return p == nil || p.Policy != MediaPolicyReject
+}
+
+// MediaPolicy determines how media from a remote domain is handled.
+type MediaPolicy string
+
+const (
+	// MediaPolicyCache fetches and caches media as usual, optionally
+	// keeping it cached for a different number of days than the
+	// instance default (see DomainMediaPolicy.CacheDays).
+	MediaPolicyCache MediaPolicy = "cache"
+
+	// MediaPolicyProxy fetches media to process it (eg., for
+	// thumbnail dimensions and blurhash), but doesn't store it.
+	// Requests for the media are streamed from the remote instead.
+	MediaPolicyProxy MediaPolicy = "proxy"
+
+	// MediaPolicyRemote fetches media to process it,
+	// but doesn't store it. Requests for the media are
+	// redirected to the media's URL on the remote.
+	MediaPolicyRemote MediaPolicy = "remote"
+
+	// MediaPolicyReject never fetches media from the domain.
+	// Attachments are kept as placeholders linking to the
+	// remote URL, and custom emojis are not fetched.
+	MediaPolicyReject MediaPolicy = "reject"
+)
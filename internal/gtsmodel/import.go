@@ -0,0 +1,129 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// ImportType describes the kind of
+// data contained in a user-level import.
+type ImportType uint8
+
+// Only ever add new import types to the *END* of the list
+// below, DO NOT insert them before/between other entries!
+
+const (
+	ImportTypeUnknown ImportType = iota
+	ImportTypeFollowing
+	ImportTypeBlocks
+	ImportTypeMutes
+	ImportTypeLists
+	ImportTypeBookmarks
+)
+
+func (t ImportType) String() string {
+	switch t {
+	case ImportTypeFollowing:
+		return "following"
+	case ImportTypeBlocks:
+		return "blocks"
+	case ImportTypeMutes:
+		return "mutes"
+	case ImportTypeLists:
+		return "lists"
+	case ImportTypeBookmarks:
+		return "bookmarks"
+	default:
+		return "unknown"
+	}
+}
+
+func NewImportType(in string) ImportType {
+	switch in {
+	case "following":
+		return ImportTypeFollowing
+	case "blocks":
+		return ImportTypeBlocks
+	case "mutes":
+		return ImportTypeMutes
+	case "lists":
+		return ImportTypeLists
+	case "bookmarks":
+		return ImportTypeBookmarks
+	default:
+		return ImportTypeUnknown
+	}
+}
+
+// ImportMode describes how the entries of an import
+// should be reconciled with an account's existing data.
+type ImportMode uint8
+
+// Only ever add new import modes to the *END* of the list
+// below, DO NOT insert them before/between other entries!
+
+const (
+	ImportModeUnknown ImportMode = iota
+	ImportModeMerge
+	ImportModeOverwrite
+)
+
+func (m ImportMode) String() string {
+	switch m {
+	case ImportModeMerge:
+		return "merge"
+	case ImportModeOverwrite:
+		return "overwrite"
+	default:
+		return "unknown"
+	}
+}
+
+func NewImportMode(in string) ImportMode {
+	switch in {
+	case "merge", "":
+		return ImportModeMerge
+	case "overwrite":
+		return ImportModeOverwrite
+	default:
+		return ImportModeUnknown
+	}
+}
+
+// Import models one CSV import of follows, blocks,
+// lists, etc, made by a local account, and the
+// progress made towards processing its entries.
+type Import struct {
+	ID             string     `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // ID of this item in the database.
+	CreatedAt      time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // Creation time of this item.
+	UpdatedAt      time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // Last updated time of this item.
+	CompletedAt    time.Time  `bun:"type:timestamptz,nullzero"`                                   // Completion time of this item.
+	AccountID      string     `bun:"type:CHAR(26),notnull,nullzero"`                              // Local account that created this import.
+	Account        *Account   `bun:"-"`                                                           // Account corresponding to AccountID.
+	Type           ImportType `bun:",nullzero,notnull"`                                           // Type of data being imported.
+	Mode           ImportMode `bun:",nullzero,notnull"`                                           // Mode of reconciliation with existing data.
+	TotalItems     int        `bun:",notnull,default:0"`                                          // Total number of entries parsed from the import file.
+	ProcessedItems int        `bun:",notnull,default:0"`                                          // Number of entries processed so far (successfully or not).
+	FailedItems    int        `bun:",notnull,default:0"`                                          // Number of processed entries that could not be imported.
+	Errors         []string   `bun:",array"`                                                      // String value of any error(s) encountered while processing.
+}
+
+// Completed returns true if
+// this import has finished.
+func (i *Import) Completed() bool {
+	return !i.CompletedAt.IsZero()
+}
@@ -43,4 +43,6 @@ type Instance struct {
 	Reputation             int64        `bun:",notnull,default:0"`                                          // Reputation score of this instance
 	Version                string       `bun:",nullzero"`                                                   // Version of the software used on this instance
 	Rules                  []Rule       `bun:"-"`                                                           // List of instance rules
+	VAPIDPublicKey         string       `bun:",nullzero"`                                                   // Base64url-encoded P-256 public key used to sign Web Push requests (local instance only).
+	VAPIDPrivateKey        string       `bun:",nullzero"`                                                   // Base64url-encoded P-256 private key used to sign Web Push requests (local instance only).
 }
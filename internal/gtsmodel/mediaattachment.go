@@ -44,15 +44,17 @@ type MediaAttachment struct {
 	Avatar            *bool            `bun:",nullzero,notnull,default:false"`                             // Is this attachment being used as an avatar?
 	Header            *bool            `bun:",nullzero,notnull,default:false"`                             // Is this attachment being used as a header?
 	Cached            *bool            `bun:",nullzero,notnull,default:false"`                             // Is this attachment currently cached by our instance?
+	Quarantined       *bool            `bun:",nullzero,notnull,default:false"`                             // Was this attachment quarantined for matching a blocklisted media hash?
 }
 
 // File refers to the metadata for the whole file
 type File struct {
 	weaver.AutoMarshal
-	Path        string    `bun:",nullzero,notnull"`                                           // Path of the file in storage.
-	ContentType string    `bun:",nullzero,notnull"`                                           // MIME content type of the file.
-	FileSize    int       `bun:",notnull"`                                                    // File size in bytes
-	UpdatedAt   time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // When was the file last updated.
+	Path         string    `bun:",nullzero,notnull"`                                           // Path of the file in storage.
+	ContentType  string    `bun:",nullzero,notnull"`                                           // MIME content type of the file.
+	FileSize     int       `bun:",notnull"`                                                    // File size in bytes
+	UpdatedAt    time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // When was the file last updated.
+	OriginalPath string    `bun:",nullzero"`                                                   // Path in storage of the file as originally received, if it was converted and the original kept.
 }
 
 // Thumbnail refers to a small image thumbnail derived from a larger image, video, or audio file.
@@ -113,9 +115,9 @@ type Original struct {
 	Height    int      // height in pixels
 	Size      int      // size in pixels (width * height)
 	Aspect    float32  // aspect ratio (width / height)
-	Duration  *float32 // video-specific: duration of the video in seconds
+	Duration  *float32 // video/audio-specific: duration of the video/audio in seconds
 	Framerate *float32 // video-specific: fps
-	Bitrate   *uint64  // video-specific: bitrate
+	Bitrate   *uint64  // video/audio-specific: bitrate
 }
 
 // Focus describes the 'center' of the image for display purposes.
@@ -0,0 +1,34 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// MediaBlob is a content-addressed file in storage, which may
+// be shared between any number of media attachments with the
+// same contents (eg., the same image uploaded many times, or
+// the same remote avatar refetched). It is kept in storage for
+// as long as at least one attachment file references it.
+type MediaBlob struct {
+	Path      string    `bun:",pk,nullzero,notnull,unique"`                                 // Storage key of the blob, derived from its hash.
+	CreatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Hash      string    `bun:",nullzero,notnull"`                                           // Hex-encoded SHA-256 hash of the blob contents.
+	FileSize  int       `bun:",notnull,default:0"`                                          // Size of the blob in bytes.
+	RefCount  int       `bun:",notnull,default:0"`                                          // Number of attachment files referencing this blob.
+}
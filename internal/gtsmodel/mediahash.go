@@ -0,0 +1,50 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// MediaHash is an entry in the admin-managed blocklist of media
+// hashes. Media matching any entry on the list is refused when
+// uploaded locally, and quarantined when fetched from a remote.
+type MediaHash struct {
+	ID                 string        `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time     `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time     `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Type               MediaHashType `bun:",nullzero,notnull,unique:media_hashes_type_hash_uniq"`        // type of hash, ie., the algorithm used
+	Hash               string        `bun:",nullzero,notnull,unique:media_hashes_type_hash_uniq"`        // lowercase hex-encoded hash value
+	Comment            string        `bun:",nullzero"`                                                   // private comment on this hash, eg., where it came from
+	CreatedByAccountID string        `bun:"type:CHAR(26),nullzero,notnull"`                              // Account ID of the creator of this hash entry
+	CreatedByAccount   *Account      `bun:"-"`                                                           // Account corresponding to CreatedByAccountID
+}
+
+// MediaHashType is the algorithm used to calculate a MediaHash.
+type MediaHashType string
+
+const (
+	// MediaHashTypeSHA256 is a SHA-256 hash of the
+	// exact file contents, as received from the uploader
+	// or the remote server. Only matches identical files.
+	MediaHashTypeSHA256 MediaHashType = "sha256"
+
+	// MediaHashTypePHash is a 64-bit perceptual difference hash
+	// (dHash) of the decoded image, or of the first frame of a
+	// video. Also matches near-identical copies of an image,
+	// eg., when re-encoded, resized or slightly recoloured.
+	MediaHashTypePHash MediaHashType = "phash"
+)
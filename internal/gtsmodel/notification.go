@@ -32,6 +32,7 @@ type Notification struct {
 	StatusID         string           `bun:"type:CHAR(26),nullzero"`                                      // If the notification pertains to a status, what is the database ID of that status?
 	Status           *Status          `bun:"-"`                                                           // Status corresponding to StatusID. Can be nil, always check first + select using ID if necessary.
 	Read             *bool            `bun:",nullzero,notnull,default:false"`                             // Notification has been seen/read
+	Filtered         *bool            `bun:",nullzero,notnull,default:false"`                             // Notification was filtered by the target account's notification policy
 }
 
 // NotificationType describes the reason/type of this notification.
@@ -0,0 +1,72 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// NotificationPolicy represents a local account's policy for filtering
+// notifications from accounts it may not want to hear from. Notifications
+// caught by the policy are not dropped, but are set as filtered, and
+// surfaced to the account via a NotificationRequest for review instead.
+type NotificationPolicy struct {
+	ID                    string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt             time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt             time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID             string    `bun:"type:CHAR(26),nullzero,notnull,unique"`                       // ID of the local account this policy belongs to.
+	FilterNotFollowing    *bool     `bun:",nullzero,notnull,default:false"`                             // Filter notifications from accounts the account doesn't follow.
+	FilterNotFollowers    *bool     `bun:",nullzero,notnull,default:false"`                             // Filter notifications from accounts that don't follow the account.
+	FilterNewAccounts     *bool     `bun:",nullzero,notnull,default:false"`                             // Filter notifications from accounts created less than NewAccountsDays ago.
+	NewAccountsDays       int       `bun:",nullzero,notnull,default:30"`                                // Number of days after creation that an account is considered new.
+	FilterPrivateMentions *bool     `bun:",nullzero,notnull,default:false"`                             // Filter direct mentions from accounts the account doesn't follow, unless they're replies to the account.
+}
+
+// Filters returns true if
+// any filter of the policy
+// is currently enabled.
+func (p *NotificationPolicy) Filters() bool {
+	return *p.FilterNotFollowing ||
+		*p.FilterNotFollowers ||
+		*p.FilterNewAccounts ||
+		*p.FilterPrivateMentions
+}
+
+// NotificationRequest groups together filtered notifications
+// from one origin account to one local target account, so that
+// the target account can review, and accept or dismiss, them.
+type NotificationRequest struct {
+	ID                 string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                             // id of this item in the database
+	CreatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`          // when was item created
+	UpdatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`          // when was item last updated
+	AccountID          string    `bun:"type:CHAR(26),nullzero,notnull,unique:notificationrequestaccountfrom"` // ID of the local account that the filtered notifications target.
+	Account            *Account  `bun:"-"`                                                                    // Account corresponding to AccountID.
+	FromAccountID      string    `bun:"type:CHAR(26),nullzero,notnull,unique:notificationrequestaccountfrom"` // ID of the account that the filtered notifications originate from.
+	FromAccount        *Account  `bun:"-"`                                                                    // Account corresponding to FromAccountID.
+	LastStatusID       string    `bun:"type:CHAR(26),nullzero"`                                               // ID of the status of the most recent filtered notification, if any.
+	LastStatus         *Status   `bun:"-"`                                                                    // Status corresponding to LastStatusID.
+	NotificationsCount int       `bun:",nullzero,notnull,default:0"`                                          // Number of filtered notifications in this request.
+}
+
+// NotificationPermission records that a local account has accepted
+// a notification request from another account, so that notifications
+// from that account will no longer be filtered by its policy.
+type NotificationPermission struct {
+	ID            string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                // id of this item in the database
+	CreatedAt     time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`             // when was item created
+	AccountID     string    `bun:"type:CHAR(26),nullzero,notnull,unique:notificationpermissionaccountfrom"` // ID of the local account giving the permission.
+	FromAccountID string    `bun:"type:CHAR(26),nullzero,notnull,unique:notificationpermissionaccountfrom"` // ID of the account permitted to notify AccountID.
+}
@@ -54,6 +54,9 @@ type Status struct {
 	BoostOfAccountID         string             `bun:"type:CHAR(26),nullzero"`                                      // id of the account that owns the boosted status
 	BoostOf                  *Status            `bun:"-"`                                                           // status that corresponds to boostOfID
 	BoostOfAccount           *Account           `bun:"rel:belongs-to"`                                              // account that corresponds to boostOfAccountID
+	QuoteOfID                string             `bun:"type:CHAR(26),nullzero"`                                      // id of the status this status quotes
+	QuoteOfURI               string             `bun:",nullzero"`                                                   // activitypub uri of the status this status quotes
+	QuoteOf                  *Status            `bun:"-"`                                                           // status corresponding to quoteOfID
 	ThreadID                 string             `bun:"type:CHAR(26),nullzero"`                                      // id of the thread to which this status belongs; only set for remote statuses if a local account is involved at some point in the thread, otherwise null
 	PollID                   string             `bun:"type:CHAR(26),nullzero"`                                      //
 	Poll                     *Poll              `bun:"-"`                                                           //
@@ -0,0 +1,57 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// AccountStorageUsage represents the total size of media stored for
+// one local account, kept up to date as media is created and deleted,
+// along with any storage quota set on the account by an admin.
+type AccountStorageUsage struct {
+	AccountID  string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // ID of the local account this usage belongs to.
+	CreatedAt  time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt  time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	MediaSize  int       `bun:",notnull,default:0"`                                          // Size in bytes of media attachments (and their thumbnails), excluding avatar and header.
+	AvatarSize int       `bun:",notnull,default:0"`                                          // Size in bytes of avatar media attachments (and their thumbnails).
+	HeaderSize int       `bun:",notnull,default:0"`                                          // Size in bytes of header media attachments (and their thumbnails).
+	EmojiSize  int       `bun:",notnull,default:0"`                                          // Size in bytes of local custom emoji. Only set on the instance account, which owns them.
+	Quota      *int      `bun:""`                                                            // Max total size in bytes this account may store; nil means use the instance default, 0 means unlimited.
+}
+
+// Total returns the total size in bytes of all media stored for the account.
+func (u *AccountStorageUsage) Total() int {
+	return u.MediaSize + u.AvatarSize + u.HeaderSize + u.EmojiSize
+}
+
+// InstanceStorageUsage represents the total size
+// of media stored by this instance, broken down
+// by type, and by whether it's local or remote.
+type InstanceStorageUsage struct {
+	LocalMediaSize  int // Size in bytes of local media attachments, excluding avatars and headers.
+	LocalAvatarSize int // Size in bytes of local avatars.
+	LocalHeaderSize int // Size in bytes of local headers.
+	LocalEmojiSize  int // Size in bytes of local custom emoji.
+	RemoteMediaSize int // Size in bytes of cached remote media attachments, including avatars and headers.
+	RemoteEmojiSize int // Size in bytes of cached remote custom emoji.
+}
+
+// Total returns the total size in bytes of all media stored by the instance.
+func (u *InstanceStorageUsage) Total() int {
+	return u.LocalMediaSize + u.LocalAvatarSize + u.LocalHeaderSize +
+		u.LocalEmojiSize + u.RemoteMediaSize + u.RemoteEmojiSize
+}
@@ -0,0 +1,62 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// TimelineEntry is one item persisted from the index of
+// a home or list timeline, so that the timeline can be
+// warmed up from the database again after a restart.
+type TimelineEntry struct {
+	ID               string       `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                        // id of this item in the database
+	CreatedAt        time.Time    `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`     // when was item created
+	TimelineType     TimelineType `bun:",nullzero,notnull,unique:timelineentrytimelineitem"`              // Type of timeline this entry belongs to.
+	TimelineID       string       `bun:"type:CHAR(26),nullzero,notnull,unique:timelineentrytimelineitem"` // ID of the timeline (account ID for home, list ID for list).
+	ItemID           string       `bun:"type:CHAR(26),nullzero,notnull,unique:timelineentrytimelineitem"` // ID of the timelined status.
+	AccountID        string       `bun:"type:CHAR(26),nullzero,notnull"`                                  // ID of the account that created the status.
+	BoostOfID        string       `bun:"type:CHAR(26),nullzero"`                                          // ID of the boosted status, if status is a boost.
+	BoostOfAccountID string       `bun:"type:CHAR(26),nullzero"`                                          // ID of the account that created the boosted status, if status is a boost.
+}
+
+// GetID implements timeline.Timelineable{}.
+func (t *TimelineEntry) GetID() string {
+	return t.ItemID
+}
+
+// GetAccountID implements timeline.Timelineable{}.
+func (t *TimelineEntry) GetAccountID() string {
+	return t.AccountID
+}
+
+// GetBoostOfID implements timeline.Timelineable{}.
+func (t *TimelineEntry) GetBoostOfID() string {
+	return t.BoostOfID
+}
+
+// GetBoostOfAccountID implements timeline.Timelineable{}.
+func (t *TimelineEntry) GetBoostOfAccountID() string {
+	return t.BoostOfAccountID
+}
+
+// TimelineType denotes the type of a persisted timeline.
+type TimelineType string
+
+const (
+	TimelineTypeHome TimelineType = "home" // Home timeline of an account.
+	TimelineTypeList TimelineType = "list" // Timeline of a list.
+)
@@ -38,6 +38,7 @@ type User struct {
 	LastSignInAt           time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did this user last sign in?
 	LastSignInIP           net.IP       `bun:",nullzero"`                                                   // What's the previous IP of this user?
 	SignInCount            int          `bun:",notnull,default:0"`                                          // How many times has this user signed in?
+	LastActiveAt           time.Time    `bun:"type:timestamptz,nullzero"`                                   // When was this user last active, ie., signed in or used a token?
 	InviteID               string       `bun:"type:CHAR(26),nullzero"`                                      // id of the user who invited this user (who let this joker in?)
 	ChosenLanguages        []string     `bun:",nullzero"`                                                   // What languages does this user want to see?
 	FilteredLanguages      []string     `bun:",nullzero"`                                                   // What languages does this user not want to see?
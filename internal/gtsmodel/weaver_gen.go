@@ -90,6 +90,7 @@ type __is_Account[T ~struct {
 	FollowingURI            string     "bun:\",nullzero,unique\""
 	FollowersURI            string     "bun:\",nullzero,unique\""
 	FeaturedCollectionURI   string     "bun:\",nullzero,unique\""
+	FeaturedTagsURI         string     "bun:\",nullzero\""
 	ActorType               string     "bun:\",nullzero,notnull\""
 	PrivateKey              PrivateKey "bun:\"\""
 	PublicKey               PublicKey  "bun:\",notnull\""
@@ -148,6 +149,7 @@ func (x *Account) WeaverMarshal(enc *codegen.Encoder) {
 	enc.String(x.FollowingURI)
 	enc.String(x.FollowersURI)
 	enc.String(x.FeaturedCollectionURI)
+	enc.String(x.FeaturedTagsURI)
 	enc.String(x.ActorType)
 	enc.EncodeBinaryMarshaler(&x.PrivateKey)
 	enc.EncodeBinaryMarshaler(&x.PublicKey)
@@ -204,6 +206,7 @@ func (x *Account) WeaverUnmarshal(dec *codegen.Decoder) {
 	x.FollowingURI = dec.String()
 	x.FollowersURI = dec.String()
 	x.FeaturedCollectionURI = dec.String()
+	x.FeaturedTagsURI = dec.String()
 	x.ActorType = dec.String()
 	dec.DecodeBinaryUnmarshaler(&x.PrivateKey)
 	dec.DecodeBinaryUnmarshaler(&x.PublicKey)
@@ -592,10 +595,11 @@ var _ codegen.AutoMarshal = (*File)(nil)
 
 type __is_File[T ~struct {
 	weaver.AutoMarshal
-	Path        string    "bun:\",nullzero,notnull\""
-	ContentType string    "bun:\",nullzero,notnull\""
-	FileSize    int       "bun:\",notnull\""
-	UpdatedAt   time.Time "bun:\"type:timestamptz,nullzero,notnull,default:current_timestamp\""
+	Path         string    "bun:\",nullzero,notnull\""
+	ContentType  string    "bun:\",nullzero,notnull\""
+	FileSize     int       "bun:\",notnull\""
+	UpdatedAt    time.Time "bun:\"type:timestamptz,nullzero,notnull,default:current_timestamp\""
+	OriginalPath string    "bun:\",nullzero\""
 }] struct{}
 
 var _ __is_File[File]
@@ -608,6 +612,7 @@ func (x *File) WeaverMarshal(enc *codegen.Encoder) {
 	enc.String(x.ContentType)
 	enc.Int(x.FileSize)
 	enc.EncodeBinaryMarshaler(&x.UpdatedAt)
+	enc.String(x.OriginalPath)
 }
 
 func (x *File) WeaverUnmarshal(dec *codegen.Decoder) {
@@ -618,6 +623,7 @@ func (x *File) WeaverUnmarshal(dec *codegen.Decoder) {
 	x.ContentType = dec.String()
 	x.FileSize = dec.Int()
 	dec.DecodeBinaryUnmarshaler(&x.UpdatedAt)
+	x.OriginalPath = dec.String()
 }
 
 var _ codegen.AutoMarshal = (*FileMeta)(nil)
@@ -697,6 +703,7 @@ type __is_MediaAttachment[T ~struct {
 	Avatar            *bool            "bun:\",nullzero,notnull,default:false\""
 	Header            *bool            "bun:\",nullzero,notnull,default:false\""
 	Cached            *bool            "bun:\",nullzero,notnull,default:false\""
+	Quarantined       *bool            "bun:\",nullzero,notnull,default:false\""
 }] struct{}
 
 var _ __is_MediaAttachment[MediaAttachment]
@@ -723,6 +730,7 @@ func (x *MediaAttachment) WeaverMarshal(enc *codegen.Encoder) {
 	serviceweaver_enc_ptr_bool_31f02903(enc, x.Avatar)
 	serviceweaver_enc_ptr_bool_31f02903(enc, x.Header)
 	serviceweaver_enc_ptr_bool_31f02903(enc, x.Cached)
+	serviceweaver_enc_ptr_bool_31f02903(enc, x.Quarantined)
 }
 
 func (x *MediaAttachment) WeaverUnmarshal(dec *codegen.Decoder) {
@@ -747,6 +755,7 @@ func (x *MediaAttachment) WeaverUnmarshal(dec *codegen.Decoder) {
 	x.Avatar = serviceweaver_dec_ptr_bool_31f02903(dec)
 	x.Header = serviceweaver_dec_ptr_bool_31f02903(dec)
 	x.Cached = serviceweaver_dec_ptr_bool_31f02903(dec)
+	x.Quarantined = serviceweaver_dec_ptr_bool_31f02903(dec)
 }
 
 var _ codegen.AutoMarshal = (*Original)(nil)
@@ -0,0 +1,77 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// WebPushSubscription represents a Web Push subscription created
+// by a client app for one of its access tokens, to which alerts
+// about new notifications should be delivered, even when the
+// client app itself isn't running.
+type WebPushSubscription struct {
+	ID                 string        `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time     `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time     `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID          string        `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the account that owns this subscription.
+	TokenID            string        `bun:"type:CHAR(26),nullzero,notnull,unique"`                       // ID of the access token this subscription belongs to; one subscription per token.
+	Endpoint           string        `bun:",nullzero,notnull"`                                           // URL of the push endpoint to deliver alerts to.
+	Auth               string        `bun:",nullzero,notnull"`                                           // Base64url-encoded auth secret of the subscription.
+	P256dh             string        `bun:",nullzero,notnull"`                                           // Base64url-encoded P-256 public key of the subscription.
+	AlertFollow        *bool         `bun:",nullzero,notnull,default:false"`                             // Alert on new follows.
+	AlertFollowRequest *bool         `bun:",nullzero,notnull,default:false"`                             // Alert on new follow requests.
+	AlertFavourite     *bool         `bun:",nullzero,notnull,default:false"`                             // Alert on new faves.
+	AlertReblog        *bool         `bun:",nullzero,notnull,default:false"`                             // Alert on new boosts.
+	AlertMention       *bool         `bun:",nullzero,notnull,default:false"`                             // Alert on new mentions.
+	AlertPoll          *bool         `bun:",nullzero,notnull,default:false"`                             // Alert on ended polls.
+	AlertStatus        *bool         `bun:",nullzero,notnull,default:false"`                             // Alert on new statuses from accounts with notifications enabled.
+	Policy             WebPushPolicy `bun:",nullzero,notnull,default:'all'"`                             // Whose actions should trigger alerts.
+}
+
+// Alerts returns whether this subscription
+// wants alerts for the given notification type.
+func (w *WebPushSubscription) Alerts(notificationType NotificationType) bool {
+	var alert *bool
+	switch notificationType {
+	case NotificationFollow:
+		alert = w.AlertFollow
+	case NotificationFollowRequest:
+		alert = w.AlertFollowRequest
+	case NotificationFave:
+		alert = w.AlertFavourite
+	case NotificationReblog:
+		alert = w.AlertReblog
+	case NotificationMention:
+		alert = w.AlertMention
+	case NotificationPoll:
+		alert = w.AlertPoll
+	case NotificationStatus:
+		alert = w.AlertStatus
+	}
+	return alert != nil && *alert
+}
+
+// WebPushPolicy describes whose actions
+// should trigger Web Push alerts.
+type WebPushPolicy string
+
+const (
+	WebPushPolicyAll      WebPushPolicy = "all"      // Alert for notifications from anyone.
+	WebPushPolicyFollowed WebPushPolicy = "followed" // Alert only for notifications from accounts the user follows.
+	WebPushPolicyFollower WebPushPolicy = "follower" // Alert only for notifications from accounts that follow the user.
+	WebPushPolicyNone     WebPushPolicy = "none"     // Don't alert at all.
+)
@@ -18,10 +18,15 @@
 package iotools
 
 import (
+	"errors"
 	"io"
 	"os"
 )
 
+// ErrSizeLimitExceeded is returned when reading
+// past the limit from a LimitReadCloser.
+var ErrSizeLimitExceeded = errors.New("size limit exceeded")
+
 // ReadFnCloser takes an io.Reader and wraps it to use the provided function to implement io.Closer.
 func ReadFnCloser(r io.Reader, close func() error) io.ReadCloser {
 	return &readFnCloser{
@@ -56,6 +61,41 @@ func (r *writeFnCloser) Close() error {
 	return r.close()
 }
 
+// LimitReadCloser wraps rc to return ErrSizeLimitExceeded
+// when reading more than limit bytes from it. Unlike an
+// io.LimitReader, this doesn't just silently stop reading.
+func LimitReadCloser(rc io.ReadCloser, limit int64) io.ReadCloser {
+	return &limitReadCloser{ReadCloser: rc, n: limit}
+}
+
+type limitReadCloser struct {
+	io.ReadCloser
+	n int64 // bytes left to read
+}
+
+func (r *limitReadCloser) Read(b []byte) (int, error) {
+	if r.n < 0 {
+		return 0, ErrSizeLimitExceeded
+	}
+
+	// Read at most one byte past
+	// the limit, to know if it's
+	// been exceeded.
+	if int64(len(b)) > r.n+1 {
+		b = b[:r.n+1]
+	}
+
+	n, err := r.ReadCloser.Read(b)
+	r.n -= int64(n)
+
+	if r.n < 0 {
+		// Drop the extra byte.
+		return n - 1, ErrSizeLimitExceeded
+	}
+
+	return n, err
+}
+
 // SilentReader wraps an io.Reader to silence any
 // error output during reads. Instead they are stored
 // and accessible (not concurrency safe!) via .Error().
@@ -0,0 +1,286 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+// flacPicture parses a FLAC picture metadata block (also used
+// by Vorbis comments), returning the picture type and image data.
+func flacPicture(b []byte) (uint32, []byte, bool) {
+	if len(b) < 8 {
+		return 0, nil, false
+	}
+
+	picType := binary.BigEndian.Uint32(b)
+	b = b[4:]
+
+	// Skip MIME type and description.
+	for i := 0; i < 2; i++ {
+		if len(b) < 4 {
+			return 0, nil, false
+		}
+
+		n := uint64(binary.BigEndian.Uint32(b))
+		if n > uint64(len(b)-4) {
+			return 0, nil, false
+		}
+		b = b[4+n:]
+	}
+
+	// Skip width, height, depth and colors,
+	// then read the picture data length.
+	if len(b) < 20 {
+		return 0, nil, false
+	}
+
+	n := uint64(binary.BigEndian.Uint32(b[16:]))
+	b = b[20:]
+	if n == 0 || n > uint64(len(b)) {
+		return 0, nil, false
+	}
+
+	return picType, b[:n], true
+}
+
+// probeFLAC probes a FLAC file, reading duration
+// and cover art from the metadata blocks, then
+// walking the audio frames to determine levels.
+func probeFLAC(r io.ReadSeeker, size int64) (*audioProbe, error) {
+	var (
+		probe      audioProbe
+		br         = bufio.NewReader(r)
+		hdr        = make([]byte, 4)
+		off        = int64(4)
+		sampleRate int
+		samples    int64
+		minFrame   int
+	)
+
+	if _, err := io.ReadFull(br, hdr); err != nil {
+		return nil, fmt.Errorf("error reading flac header: %w", err)
+	}
+
+	if string(hdr) != "fLaC" {
+		return nil, errors.New("error probing flac: invalid header")
+	}
+
+	for last := false; !last; {
+		if _, err := io.ReadFull(br, hdr); err != nil {
+			return nil, fmt.Errorf("error reading flac metadata: %w", err)
+		}
+
+		last = hdr[0]&0x80 != 0
+		blockType := hdr[0] & 0x7F
+		length := int(hdr[1])<<16 | int(hdr[2])<<8 | int(hdr[3])
+		off += 4 + int64(length)
+
+		var block []byte
+		if blockType == 0 || (blockType == 6 && length <= maxAudioTagSize) {
+			// Only read STREAMINFO and PICTURE blocks.
+			block = make([]byte, length)
+			if _, err := io.ReadFull(br, block); err != nil {
+				return nil, fmt.Errorf("error reading flac metadata: %w", err)
+			}
+		} else if _, err := br.Discard(length); err != nil {
+			return nil, fmt.Errorf("error reading flac metadata: %w", err)
+		}
+
+		switch blockType {
+		case 0: // STREAMINFO
+			if len(block) < 18 {
+				return nil, errors.New("error probing flac: invalid streaminfo")
+			}
+
+			minFrame = int(block[4])<<16 | int(block[5])<<8 | int(block[6])
+			sampleRate = int(block[10])<<12 | int(block[11])<<4 | int(block[12])>>4
+			samples = int64(block[13]&0x0F)<<32 | int64(binary.BigEndian.Uint32(block[14:18]))
+
+		case 6: // PICTURE
+			picType, data, ok := flacPicture(block)
+			if ok && (picType == 3 || probe.cover == nil) {
+				probe.cover = data
+			}
+		}
+	}
+
+	if sampleRate == 0 {
+		return nil, errors.New("error probing flac: invalid sample rate")
+	}
+
+	var (
+		frameOff     = int64(-1) // offset of last frame
+		frameSamples int         // block size of last frame
+		counted      int64       // samples in walked frames
+	)
+
+	for {
+		// Scan for the first
+		// frame sync code byte.
+		b, err := br.ReadSlice(0xFF)
+		off += int64(len(b))
+
+		if err == bufio.ErrBufferFull {
+			continue
+		} else if err != nil {
+			break
+		}
+
+		hdr, _ := br.Peek(15)
+		blockSize, ok := parseFLACFrame(hdr, sampleRate)
+		if !ok {
+			continue
+		}
+
+		// Frame header starts at
+		// the 0xFF sync byte.
+		start := off - 1
+
+		if frameOff >= 0 {
+			probe.levels = append(probe.levels, audioLevel{
+				at:    float64(counted) / float64(sampleRate),
+				level: float64(start-frameOff) / float64(frameSamples),
+			})
+			counted += int64(frameSamples)
+		}
+
+		frameOff = start
+		frameSamples = blockSize
+
+		// Skip to the earliest
+		// possible next frame.
+		if minFrame > 1 {
+			n, _ := br.Discard(minFrame - 1)
+			off += int64(n)
+		}
+	}
+
+	if frameOff >= 0 {
+		// Account for the final frame.
+		probe.levels = append(probe.levels, audioLevel{
+			at:    float64(counted) / float64(sampleRate),
+			level: float64(size-frameOff) / float64(frameSamples),
+		})
+		counted += int64(frameSamples)
+	}
+
+	if samples == 0 {
+		// Total samples unknown
+		// in stream info header.
+		samples = counted
+	}
+
+	probe.duration = float64(samples) / float64(sampleRate)
+	return &probe, nil
+}
+
+// parseFLACFrame parses a FLAC frame header (following
+// the initial 0xFF sync byte) from b, returning the block
+// size in samples, or false if not a valid frame header.
+func parseFLACFrame(b []byte, sampleRate int) (int, bool) {
+	if len(b) < 4 || b[0]&0xFE != 0xF8 {
+		// Second half of sync code
+		// (plus a reserved zero bit).
+		return 0, false
+	}
+
+	var (
+		bsBits = b[1] >> 4
+		srBits = b[1] & 0x0F
+		chBits = b[2] >> 4
+		ssBits = (b[2] >> 1) & 7
+	)
+
+	if bsBits == 0 || srBits == 15 || chBits > 10 || ssBits == 3 || b[2]&1 != 0 {
+		// Reserved values.
+		return 0, false
+	}
+
+	// Length of UTF-8 style coded frame / sample
+	// number is given by the leading one bits.
+	n := bits.LeadingZeros8(^b[3])
+	switch n {
+	case 0:
+		n = 1
+	case 1, 8:
+		return 0, false
+	}
+
+	// Header length, sans 0xFF byte.
+	l := 3 + n
+
+	var blockSize int
+	switch {
+	case bsBits == 1:
+		blockSize = 192
+	case bsBits <= 5:
+		blockSize = 576 << (bsBits - 2)
+	case bsBits == 6:
+		l++
+		if len(b) > l {
+			blockSize = int(b[l-1]) + 1
+		}
+	case bsBits == 7:
+		l += 2
+		if len(b) > l {
+			blockSize = int(binary.BigEndian.Uint16(b[l-2:])) + 1
+		}
+	default:
+		blockSize = 256 << (bsBits - 8)
+	}
+
+	switch srBits {
+	case 12:
+		l++
+	case 13, 14:
+		l += 2
+	}
+
+	if len(b) <= l || blockSize == 0 {
+		return 0, false
+	}
+
+	// Verify the header CRC, which covers
+	// all header bytes including the 0xFF.
+	crc := crc8(0, 0xFF)
+	for _, c := range b[:l] {
+		crc = crc8(crc, c)
+	}
+
+	return blockSize, crc == b[l]
+}
+
+// crc8 updates the given CRC-8 (polynomial
+// 0x07) as used by FLAC frame headers with c.
+func crc8(crc byte, c byte) byte {
+	crc ^= c
+	for i := 0; i < 8; i++ {
+		if crc&0x80 != 0 {
+			crc = crc<<1 ^ 0x07
+		} else {
+			crc <<= 1
+		}
+	}
+	return crc
+}
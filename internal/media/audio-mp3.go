@@ -0,0 +1,524 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+var (
+	// mpegSampleRates are the MPEG-1 audio sample
+	// rates by header index; MPEG-2 rates are half
+	// these, and MPEG-2.5 rates a quarter.
+	mpegSampleRates = [3]int{44100, 48000, 32000}
+
+	// MPEG audio bitrates in kbps by header index.
+	mpeg1Bitrates = [3][15]int{
+		{0, 32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448}, // layer I
+		{0, 32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384},    // layer II
+		{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320},     // layer III
+	}
+	mpeg2Bitrates = [2][15]int{
+		{0, 32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256}, // layer I
+		{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},      // layers II + III
+	}
+)
+
+// mpegFrame contains the details
+// of an MPEG audio frame header.
+type mpegFrame struct {
+	mpeg1      bool // MPEG-1, else MPEG-2 / MPEG-2.5
+	layer      int  // 1, 2 or 3
+	crc        bool // header followed by 16 bit CRC
+	mono       bool // single channel
+	sampleRate int
+	bitrate    int // in bits per second
+	samples    int // samples per frame
+	length     int // total frame length in bytes
+}
+
+// parseMPEGFrame parses an MPEG audio frame
+// header from the first 4 bytes of b, returning
+// false if b does not start with a valid header.
+func parseMPEGFrame(b []byte) (mpegFrame, bool) {
+	var f mpegFrame
+
+	if len(b) < 4 || b[0] != 0xFF || b[1]&0xE0 != 0xE0 {
+		// No frame sync.
+		return f, false
+	}
+
+	var (
+		version   = (b[1] >> 3) & 3 // 0: 2.5, 1: reserved, 2: 2, 3: 1
+		layerBits = (b[1] >> 1) & 3 // 0: reserved, 1: III, 2: II, 3: I
+		brIdx     = b[2] >> 4
+		srIdx     = (b[2] >> 2) & 3
+		padding   = int(b[2]>>1) & 1
+	)
+
+	if version == 1 || layerBits == 0 ||
+		brIdx == 0 || brIdx == 15 || srIdx == 3 {
+		// Reserved or unsupported
+		// (eg. free format) values.
+		return f, false
+	}
+
+	f.mpeg1 = version == 3
+	f.layer = 4 - int(layerBits)
+	f.crc = b[1]&1 == 0
+	f.mono = b[3]>>6 == 3
+
+	f.sampleRate = mpegSampleRates[srIdx]
+	switch version {
+	case 2:
+		f.sampleRate /= 2
+	case 0:
+		f.sampleRate /= 4
+	}
+
+	switch {
+	case f.mpeg1:
+		f.bitrate = mpeg1Bitrates[f.layer-1][brIdx] * 1000
+	case f.layer == 1:
+		f.bitrate = mpeg2Bitrates[0][brIdx] * 1000
+	default:
+		f.bitrate = mpeg2Bitrates[1][brIdx] * 1000
+	}
+
+	switch {
+	case f.layer == 1:
+		f.samples = 384
+		f.length = (12*f.bitrate/f.sampleRate + padding) * 4
+	case f.layer == 2 || f.mpeg1:
+		f.samples = 1152
+		f.length = 144*f.bitrate/f.sampleRate + padding
+	default:
+		f.samples = 576
+		f.length = 72*f.bitrate/f.sampleRate + padding
+	}
+
+	return f, true
+}
+
+// sideInfo returns the offset and length of the
+// layer III side information in the frame.
+func (f *mpegFrame) sideInfo() (int, int) {
+	offset := 4
+	if f.crc {
+		offset += 2
+	}
+
+	switch {
+	case f.mpeg1 && f.mono:
+		return offset, 17
+	case f.mpeg1:
+		return offset, 32
+	case f.mono:
+		return offset, 9
+	default:
+		return offset, 17
+	}
+}
+
+// level returns an approximation of the audio level of the given frame
+// bytes. For layer III this is derived from the global gain of the first
+// granule of the first channel, which tracks loudness closely enough to
+// draw a waveform. Layers I and II are assumed to be of constant level.
+func (f *mpegFrame) level(frame []byte) float64 {
+	if f.layer != 3 {
+		return 1
+	}
+
+	offset, length := f.sideInfo()
+	if len(frame) < offset+length {
+		return 0
+	}
+	sideInfo := frame[offset : offset+length]
+
+	// Bit offset of the granule info, after main_data_begin,
+	// private bits and (MPEG-1 only) scale factor selection.
+	var bit int
+	switch {
+	case f.mpeg1 && f.mono:
+		bit = 9 + 5 + 4
+	case f.mpeg1:
+		bit = 9 + 3 + 8
+	case f.mono:
+		bit = 8 + 1
+	default:
+		bit = 8 + 2
+	}
+
+	// part2_3_length is the number of main
+	// data bits used by this granule; if that's
+	// zero then this is a frame of silence.
+	if readBits(sideInfo, bit, 12) == 0 {
+		return 0
+	}
+
+	// Skip part2_3_length and big_values
+	// to get to the 8 bit global_gain,
+	// which is on a 1.5dB step scale.
+	gain := readBits(sideInfo, bit+12+9, 8)
+	return math.Pow(2, float64(gain)/4)
+}
+
+// readBits reads n big-endian bits
+// from b, starting at bit offset.
+func readBits(b []byte, offset int, n int) uint32 {
+	var v uint32
+	for i := offset; i < offset+n; i++ {
+		v <<= 1
+		if b[i/8]&(0x80>>(i%8)) != 0 {
+			v |= 1
+		}
+	}
+	return v
+}
+
+// isVBRHeaderFrame returns whether the given (first) frame
+// is a Xing / Info / VBRI header frame that contains no audio.
+func isVBRHeaderFrame(f *mpegFrame, frame []byte) bool {
+	offset, length := f.sideInfo()
+	for _, at := range []struct {
+		offset int
+		tags   []string
+	}{
+		{offset + length, []string{"Xing", "Info"}},
+		{4 + 32, []string{"VBRI"}},
+	} {
+		if len(frame) < at.offset+4 {
+			continue
+		}
+
+		tag := string(frame[at.offset : at.offset+4])
+		for _, t := range at.tags {
+			if tag == t {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// probeMP3 probes an MPEG audio file, reading
+// cover art from any ID3v2 tag, then walking the
+// MPEG frames to determine duration and levels.
+func probeMP3(r io.ReadSeeker, size int64) (*audioProbe, error) {
+	var (
+		probe audioProbe
+		start int64
+		end   = size
+	)
+
+	hdr := make([]byte, 10)
+	if _, err := io.ReadFull(r, hdr); err != nil {
+		return nil, fmt.Errorf("error reading mp3 header: %w", err)
+	}
+
+	if string(hdr[:3]) == "ID3" {
+		// ID3v2 tag, size excludes the tag header (and footer).
+		tagSize := int64(synchsafe(hdr[6:10]))
+		start = 10 + tagSize
+		if hdr[5]&0x10 != 0 {
+			start += 10
+		}
+
+		if tagSize <= maxAudioTagSize {
+			tag := make([]byte, tagSize)
+			if _, err := io.ReadFull(r, tag); err != nil {
+				return nil, fmt.Errorf("error reading id3 tag: %w", err)
+			}
+			probe.cover = id3Picture(hdr[3], hdr[5], tag)
+		}
+	}
+
+	if end-128 > start {
+		// Check for trailing ID3v1 tag,
+		// which is not part of the audio.
+		if _, err := r.Seek(end-128, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("error seeking mp3: %w", err)
+		}
+
+		if _, err := io.ReadFull(r, hdr[:3]); err != nil {
+			return nil, fmt.Errorf("error reading mp3: %w", err)
+		}
+
+		if string(hdr[:3]) == "TAG" {
+			end -= 128
+		}
+	}
+
+	if _, err := r.Seek(start, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("error seeking mp3: %w", err)
+	}
+
+	var (
+		br         = bufio.NewReader(io.LimitReader(r, end-start))
+		frames     int
+		sampleRate int
+		audioStart = int64(-1)
+		samples    int64
+	)
+
+	for off := start; off < end; {
+		frame, _ := br.Peek(4)
+		f, ok := parseMPEGFrame(frame)
+
+		if ok {
+			// Get the whole frame, if available.
+			frame, _ = br.Peek(f.length)
+		}
+
+		if !ok || (sampleRate != 0 && f.sampleRate != sampleRate) {
+			if len(frame) == 0 {
+				// Reached end.
+				break
+			}
+
+			if audioStart < 0 && off-start > 64*1024 {
+				return nil, errors.New("error probing mp3: no mpeg audio frame found")
+			}
+
+			// Not a valid frame header,
+			// skip forward to resync.
+			_, _ = br.Discard(1)
+			off++
+			continue
+		}
+
+		if audioStart < 0 {
+			audioStart = off
+			sampleRate = f.sampleRate
+
+			if isVBRHeaderFrame(&f, frame) {
+				// Don't count this as audio.
+				_, _ = br.Discard(f.length)
+				off += int64(f.length)
+				continue
+			}
+		}
+
+		probe.levels = append(probe.levels, audioLevel{
+			at:    float64(samples) / float64(sampleRate),
+			level: f.level(frame),
+		})
+
+		frames++
+		samples += int64(f.samples)
+		n, _ := br.Discard(f.length)
+		off += int64(n)
+	}
+
+	if frames == 0 {
+		return nil, errors.New("error probing mp3: no mpeg audio frame found")
+	}
+
+	probe.duration = float64(samples) / float64(sampleRate)
+	probe.bitrate = uint64(float64((end-audioStart)*8) / probe.duration)
+
+	return &probe, nil
+}
+
+// synchsafe decodes an ID3v2 synchsafe integer.
+func synchsafe(b []byte) uint32 {
+	var v uint32
+	for _, c := range b {
+		v = v<<7 | uint32(c&0x7F)
+	}
+	return v
+}
+
+// id3Unsync reverses ID3v2 unsynchronisation.
+func id3Unsync(b []byte) []byte {
+	return bytes.ReplaceAll(b, []byte{0xFF, 0x00}, []byte{0xFF})
+}
+
+// id3Picture returns the front cover from an ID3v2 tag of
+// given major version and flags, else the first picture.
+func id3Picture(version byte, flags byte, tag []byte) []byte {
+	if version < 2 || version > 4 {
+		// Unknown ID3v2 version.
+		return nil
+	}
+
+	if flags&0x80 != 0 {
+		// Whole tag is unsynchronised.
+		tag = id3Unsync(tag)
+	}
+
+	if flags&0x40 != 0 && version > 2 && len(tag) >= 4 {
+		// Skip extended header, the size of
+		// which includes itself only in v2.4.
+		skip := int(synchsafe(tag[:4]))
+		if version == 3 {
+			skip = int(binary.BigEndian.Uint32(tag[:4])) + 4
+		}
+
+		if skip > len(tag) {
+			return nil
+		}
+		tag = tag[skip:]
+	}
+
+	hdrLen := 10
+	if version == 2 {
+		hdrLen = 6
+	}
+
+	var first []byte
+
+	for len(tag) >= hdrLen && tag[0] != 0 {
+		var (
+			id    string
+			size  int
+			fmtFl byte
+		)
+
+		switch version {
+		case 2:
+			id = string(tag[:3])
+			size = int(tag[3])<<16 | int(tag[4])<<8 | int(tag[5])
+		case 3:
+			id = string(tag[:4])
+			size = int(binary.BigEndian.Uint32(tag[4:8]))
+			fmtFl = tag[9]
+		case 4:
+			id = string(tag[:4])
+			size = int(synchsafe(tag[4:8]))
+			fmtFl = tag[9]
+		}
+
+		if size < 0 || size > len(tag)-hdrLen {
+			// Truncated tag.
+			break
+		}
+
+		body := tag[hdrLen : hdrLen+size]
+		tag = tag[hdrLen+size:]
+
+		if id != "APIC" && id != "PIC" {
+			continue
+		}
+
+		switch version {
+		case 3:
+			if fmtFl&0xC0 != 0 {
+				// Compressed or encrypted.
+				continue
+			}
+
+		case 4:
+			if fmtFl&0x0C != 0 {
+				// Compressed or encrypted.
+				continue
+			}
+
+			if fmtFl&0x02 != 0 {
+				// Frame is unsynchronised.
+				body = id3Unsync(body)
+			}
+
+			if fmtFl&0x01 != 0 && len(body) >= 4 {
+				// Skip data length indicator.
+				body = body[4:]
+			}
+		}
+
+		picType, data, ok := id3APIC(body, version == 2)
+		if !ok {
+			continue
+		}
+
+		if picType == 3 {
+			// Front cover.
+			return data
+		}
+
+		if first == nil {
+			first = data
+		}
+	}
+
+	return first
+}
+
+// id3APIC parses an ID3v2 attached picture frame
+// body, returning the picture type and image data.
+func id3APIC(body []byte, v22 bool) (byte, []byte, bool) {
+	if len(body) < 1 {
+		return 0, nil, false
+	}
+
+	// Text encoding
+	// of description.
+	enc := body[0]
+	body = body[1:]
+
+	if v22 {
+		// 3 char image format.
+		if len(body) < 3 {
+			return 0, nil, false
+		}
+		body = body[3:]
+	} else {
+		// Null-terminated MIME type.
+		i := bytes.IndexByte(body, 0)
+		if i < 0 {
+			return 0, nil, false
+		}
+		body = body[i+1:]
+	}
+
+	if len(body) < 1 {
+		return 0, nil, false
+	}
+
+	picType := body[0]
+	body = body[1:]
+
+	// Skip null-terminated description,
+	// UTF-16 encodings use double nulls.
+	if enc == 1 || enc == 2 {
+		i := 0
+		for ; i+1 < len(body); i += 2 {
+			if body[i] == 0 && body[i+1] == 0 {
+				break
+			}
+		}
+
+		if i+1 >= len(body) {
+			return 0, nil, false
+		}
+		body = body[i+2:]
+	} else {
+		i := bytes.IndexByte(body, 0)
+		if i < 0 {
+			return 0, nil, false
+		}
+		body = body[i+1:]
+	}
+
+	return picType, body, len(body) > 0
+}
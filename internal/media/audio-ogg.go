@@ -0,0 +1,254 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// probeOgg probes an Ogg Vorbis or Opus file, reading cover
+// art from the comment header, then walking the pages of
+// the (first) logical stream to determine duration and levels.
+func probeOgg(r io.ReadSeeker, _ int64) (*audioProbe, error) {
+	var (
+		probe   audioProbe
+		br      = bufio.NewReader(r)
+		hdr     = make([]byte, 27)
+		serial  uint32
+		packets [][]byte // header packets
+		packet  []byte   // partial packet
+		rate    int64
+		preSkip int64
+
+		// Granule position of last audio page
+		// and the bytes in pages since then.
+		lastGranule = int64(-1)
+		pageBytes   int
+	)
+
+	for page := 0; ; page++ {
+		if _, err := io.ReadFull(br, hdr); err != nil {
+			if page == 0 {
+				return nil, fmt.Errorf("error reading ogg page: %w", err)
+			}
+			break
+		}
+
+		if string(hdr[:4]) != "OggS" {
+			if page == 0 {
+				return nil, errors.New("error probing ogg: invalid page header")
+			}
+
+			// Corrupt page, we
+			// can't continue.
+			break
+		}
+
+		var (
+			granule    = int64(binary.LittleEndian.Uint64(hdr[6:14]))
+			pageSerial = binary.LittleEndian.Uint32(hdr[14:18])
+			segments   = make([]byte, hdr[26])
+			bodyLen    int
+		)
+
+		if _, err := io.ReadFull(br, segments); err != nil {
+			break
+		}
+
+		for _, s := range segments {
+			bodyLen += int(s)
+		}
+
+		if page == 0 {
+			serial = pageSerial
+		}
+
+		if pageSerial != serial || len(packets) >= 2 {
+			// Not a header page, or another
+			// logical stream; skip the body.
+			if _, err := br.Discard(bodyLen); err != nil {
+				break
+			}
+		} else {
+			body := make([]byte, bodyLen)
+			if _, err := io.ReadFull(br, body); err != nil {
+				break
+			}
+
+			// Reassemble header packets
+			// from the lacing values.
+			for _, s := range segments {
+				if len(packet) < maxAudioTagSize {
+					packet = append(packet, body[:s]...)
+				}
+				body = body[s:]
+
+				if s < 255 {
+					packets = append(packets, packet)
+					packet = nil
+				}
+			}
+		}
+
+		if pageSerial != serial {
+			continue
+		}
+
+		if len(packets) > 0 && rate == 0 {
+			// Identify codec from the first packet.
+			switch id := packets[0]; {
+			case len(id) >= 16 && string(id[:7]) == "\x01vorbis":
+				rate = int64(binary.LittleEndian.Uint32(id[12:16]))
+
+			case len(id) >= 12 && string(id[:8]) == "OpusHead":
+				// Opus granule positions are always
+				// at 48kHz, regardless of input rate.
+				rate = 48000
+				preSkip = int64(binary.LittleEndian.Uint16(id[10:12]))
+
+			default:
+				return nil, errors.New("error probing ogg: unsupported codec")
+			}
+
+			if rate == 0 {
+				return nil, errors.New("error probing ogg: invalid sample rate")
+			}
+		}
+
+		if len(packets) < 2 || granule < 0 {
+			// Header page, or no
+			// packet ends on this
+			// page (granule of -1).
+			pageBytes += 27 + len(segments) + bodyLen
+			continue
+		}
+
+		pageBytes += 27 + len(segments) + bodyLen
+
+		if lastGranule >= 0 && granule > lastGranule {
+			probe.levels = append(probe.levels, audioLevel{
+				at:    float64(lastGranule-preSkip) / float64(rate),
+				level: float64(pageBytes) / float64(granule-lastGranule),
+			})
+		}
+
+		lastGranule = granule
+		pageBytes = 0
+	}
+
+	if len(packets) < 2 {
+		return nil, errors.New("error probing ogg: missing headers")
+	}
+
+	// Comment header prefix is the codec
+	// identifier (sans packet type for Opus).
+	comments := packets[1]
+	switch {
+	case bytes.HasPrefix(comments, []byte("\x03vorbis")):
+		comments = comments[7:]
+	case bytes.HasPrefix(comments, []byte("OpusTags")):
+		comments = comments[8:]
+	default:
+		comments = nil
+	}
+	probe.cover = vorbisCommentPicture(comments)
+
+	probe.duration = float64(lastGranule-preSkip) / float64(rate)
+	return &probe, nil
+}
+
+// vorbisCommentPicture returns the front cover from the
+// given Vorbis comments (as also used by Opus), else the
+// first picture, from either METADATA_BLOCK_PICTURE or
+// legacy COVERART comments.
+func vorbisCommentPicture(b []byte) []byte {
+	// next returns next length-prefixed
+	// string in b, or false if none.
+	next := func() ([]byte, bool) {
+		if len(b) < 4 {
+			return nil, false
+		}
+
+		n := uint64(binary.LittleEndian.Uint32(b))
+		if n > uint64(len(b)-4) {
+			return nil, false
+		}
+
+		s := b[4 : 4+n]
+		b = b[4+n:]
+		return s, true
+	}
+
+	// Skip vendor string.
+	if _, ok := next(); !ok || len(b) < 4 {
+		return nil
+	}
+
+	count := binary.LittleEndian.Uint32(b)
+	b = b[4:]
+
+	var first []byte
+
+	for i := uint32(0); i < count; i++ {
+		comment, ok := next()
+		if !ok {
+			break
+		}
+
+		key, value, ok := strings.Cut(string(comment), "=")
+		if !ok {
+			continue
+		}
+
+		data, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			continue
+		}
+
+		switch strings.ToUpper(key) {
+		case "METADATA_BLOCK_PICTURE":
+			picType, pic, ok := flacPicture(data)
+			if !ok {
+				continue
+			}
+
+			if picType == 3 {
+				// Front cover.
+				return pic
+			}
+
+			if first == nil {
+				first = pic
+			}
+
+		case "COVERART":
+			if first == nil {
+				first = data
+			}
+		}
+	}
+
+	return first
+}
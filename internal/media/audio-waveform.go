@@ -0,0 +1,89 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// Waveform image dimensions.
+const (
+	waveformWidth  = 512
+	waveformHeight = 256
+	waveformBars   = 64
+	waveformGap    = 2
+)
+
+var (
+	waveformBackground = color.RGBA{42, 43, 47, 255}
+	waveformForeground = color.RGBA{201, 204, 214, 255}
+)
+
+// audioLevel is an approximation of
+// audio loudness at a point in time.
+type audioLevel struct {
+	at    float64 // in seconds
+	level float64 // relative, in arbitrary units
+}
+
+// waveformImage draws a bar waveform image from the given audio levels
+// spread over duration. Each bar shows the peak level in its time span,
+// relative to the overall peak. Empty levels give a flat waveform.
+func waveformImage(levels []audioLevel, duration float64) *gtsImage {
+	img := image.NewRGBA(image.Rect(0, 0, waveformWidth, waveformHeight))
+	draw.Draw(img, img.Bounds(), &image.Uniform{waveformBackground}, image.Point{}, draw.Src)
+
+	// Gather peak level per bar.
+	var (
+		bars [waveformBars]float64
+		peak float64
+	)
+
+	for _, l := range levels {
+		i := int(l.at / duration * waveformBars)
+		i = max(0, min(i, waveformBars-1))
+		bars[i] = max(bars[i], l.level)
+		peak = max(peak, l.level)
+	}
+
+	const (
+		barWidth  = waveformWidth/waveformBars - waveformGap
+		maxHeight = waveformHeight * 3 / 4
+		minHeight = 4
+	)
+
+	for i, level := range bars {
+		height := minHeight
+		if peak > 0 {
+			height = max(height, int(level/peak*maxHeight))
+		}
+
+		// Draw bar centered vertically.
+		x := i*(barWidth+waveformGap) + waveformGap/2
+		y := (waveformHeight - height) / 2
+		draw.Draw(img,
+			image.Rect(x, y, x+barWidth, y+height),
+			&image.Uniform{waveformForeground},
+			image.Point{}, draw.Src,
+		)
+	}
+
+	return &gtsImage{image: img}
+}
@@ -0,0 +1,365 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/abema/go-mp4"
+	"github.com/disintegration/imaging"
+	"github.com/superseriousbusiness/gotosocial/internal/iotools"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// maxAudioTagSize is the maximum size of embedded audio
+// metadata (ID3 tags, FLAC picture blocks, Vorbis comments)
+// that will be read into memory when looking for cover art.
+const maxAudioTagSize = 16 * 1024 * 1024
+
+type gtsAudio struct {
+	preview  *gtsImage // embedded cover art, else generated waveform
+	duration float32   // in seconds
+	bitrate  uint64
+}
+
+// audioProbe contains the details
+// gathered while probing an audio file.
+type audioProbe struct {
+	duration float64      // in seconds
+	bitrate  uint64       // zero if not known
+	cover    []byte       // encoded cover art image, if any
+	levels   []audioLevel // approximate audio levels over time
+}
+
+// decodeAudio probes the given audio stream of contentType for its duration
+// and bitrate, and returns them along with an image to use as a preview:
+// either the embedded cover art, or a generated waveform if there is none.
+func decodeAudio(r io.Reader, contentType string) (*gtsAudio, error) {
+	// we need a readseeker to probe the audio...
+	tfs, err := iotools.TempFileSeeker(r)
+	if err != nil {
+		return nil, fmt.Errorf("error creating temp file seeker: %w", err)
+	}
+	defer func() {
+		if err := tfs.Close(); err != nil {
+			log.Errorf(nil, "error closing temp file seeker: %s", err)
+		}
+	}()
+
+	// Get file size, then rewind
+	// to the start for probing.
+	size, err := tfs.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, fmt.Errorf("error seeking temp file: %w", err)
+	}
+
+	if _, err := tfs.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("error seeking temp file: %w", err)
+	}
+
+	var probe *audioProbe
+
+	switch contentType {
+	case mimeAudioMpeg:
+		probe, err = probeMP3(tfs, size)
+	case mimeAudioOgg:
+		probe, err = probeOgg(tfs, size)
+	case mimeAudioFlac:
+		probe, err = probeFLAC(tfs, size)
+	case mimeAudioWav:
+		probe, err = probeWAV(tfs, size)
+	case mimeAudioMp4:
+		probe, err = probeM4A(tfs, size)
+	default:
+		err = fmt.Errorf("unsupported audio type %s", contentType)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if probe.duration <= 0 || math.IsInf(probe.duration, 0) || math.IsNaN(probe.duration) {
+		return nil, errors.New("error determining audio metadata: [duration]")
+	}
+
+	audio := gtsAudio{
+		duration: float32(probe.duration),
+		bitrate:  probe.bitrate,
+	}
+
+	if audio.bitrate == 0 {
+		// Fall back to
+		// average bitrate.
+		audio.bitrate = uint64(float64(size*8) / probe.duration)
+	}
+
+	if len(probe.cover) > 0 {
+		// Try to use embedded cover art as preview.
+		cover, err := decodeImage(
+			bytes.NewReader(probe.cover),
+			imaging.AutoOrientation(true),
+		)
+		if err != nil {
+			log.Warnf(nil, "error decoding embedded cover art, using waveform: %v", err)
+		} else {
+			audio.preview = cover
+		}
+	}
+
+	if audio.preview == nil {
+		// No (usable) cover art, draw a waveform instead.
+		audio.preview = waveformImage(probe.levels, probe.duration)
+	}
+
+	return &audio, nil
+}
+
+// WAV audio format tags.
+const (
+	wavFormatPCM        = 0x0001
+	wavFormatFloat      = 0x0003
+	wavFormatExtensible = 0xFFFE
+)
+
+// probeWAV probes a RIFF WAVE file, reading the format and
+// data chunks to determine duration, bitrate and levels.
+func probeWAV(r io.ReadSeeker, size int64) (*audioProbe, error) {
+	var (
+		probe   audioProbe
+		br      = bufio.NewReader(r)
+		hdr     = make([]byte, 12)
+		off     = int64(12)
+		fmtInfo []byte
+	)
+
+	if _, err := io.ReadFull(br, hdr); err != nil {
+		return nil, fmt.Errorf("error reading wav header: %w", err)
+	}
+
+	if string(hdr[:4]) != "RIFF" || string(hdr[8:12]) != "WAVE" {
+		return nil, errors.New("error probing wav: invalid header")
+	}
+
+	for {
+		if _, err := io.ReadFull(br, hdr[:8]); err != nil {
+			return nil, errors.New("error probing wav: no data chunk")
+		}
+
+		var (
+			id     = string(hdr[:4])
+			length = int64(binary.LittleEndian.Uint32(hdr[4:8]))
+		)
+		off += 8
+
+		if id == "data" {
+			if fmtInfo == nil {
+				return nil, errors.New("error probing wav: no format chunk")
+			}
+
+			if off+length > size {
+				// Length unknown (eg. streamed),
+				// or truncated, use the remainder.
+				length = size - off
+			}
+
+			return probeWAVData(r, &probe, fmtInfo, off, length)
+		}
+
+		if id == "fmt " && length >= 16 && length <= 64 {
+			fmtInfo = make([]byte, length)
+			if _, err := io.ReadFull(br, fmtInfo); err != nil {
+				return nil, fmt.Errorf("error reading wav format: %w", err)
+			}
+		} else if _, err := br.Discard(int(length)); err != nil {
+			return nil, errors.New("error probing wav: no data chunk")
+		}
+
+		// Chunks are word-aligned.
+		if length%2 != 0 {
+			_, _ = br.Discard(1)
+			length++
+		}
+
+		off += length
+	}
+}
+
+// waveformWindows is the number of sample windows
+// read from PCM audio data to determine levels.
+const waveformWindows = 4 * waveformBars
+
+// probeWAVData fills in probe from the given format chunk, and the
+// data chunk at offset of length, by sampling windows of PCM audio.
+func probeWAVData(r io.ReadSeeker, probe *audioProbe, fmtInfo []byte, offset int64, length int64) (*audioProbe, error) {
+	var (
+		format     = binary.LittleEndian.Uint16(fmtInfo[0:2])
+		byteRate   = int64(binary.LittleEndian.Uint32(fmtInfo[8:12]))
+		blockAlign = int64(binary.LittleEndian.Uint16(fmtInfo[12:14]))
+		bits       = int(binary.LittleEndian.Uint16(fmtInfo[14:16]))
+	)
+
+	if format == wavFormatExtensible && len(fmtInfo) >= 26 {
+		// Actual format is the first
+		// two bytes of the sub format.
+		format = binary.LittleEndian.Uint16(fmtInfo[24:26])
+	}
+
+	if byteRate == 0 || blockAlign == 0 {
+		return nil, errors.New("error probing wav: invalid format")
+	}
+
+	probe.duration = float64(length) / float64(byteRate)
+	probe.bitrate = uint64(byteRate * 8)
+
+	// Get level function for sample
+	// format; if we can't read samples
+	// we'll end up with a flat waveform.
+	var sample func([]byte) float64
+	switch {
+	case format == wavFormatPCM && bits == 8:
+		sample = func(b []byte) float64 { return (float64(b[0]) - 128) / 128 }
+	case format == wavFormatPCM && bits == 16:
+		sample = func(b []byte) float64 { return float64(int16(binary.LittleEndian.Uint16(b))) / (1 << 15) }
+	case format == wavFormatPCM && bits == 24:
+		sample = func(b []byte) float64 {
+			return float64(int32(uint32(b[0])<<8|uint32(b[1])<<16|uint32(b[2])<<24)>>8) / (1 << 23)
+		}
+	case format == wavFormatPCM && bits == 32:
+		sample = func(b []byte) float64 { return float64(int32(binary.LittleEndian.Uint32(b))) / (1 << 31) }
+	case format == wavFormatFloat && bits == 32:
+		sample = func(b []byte) float64 { return float64(math.Float32frombits(binary.LittleEndian.Uint32(b))) }
+	default:
+		return probe, nil
+	}
+
+	var (
+		sampleSize = bits / 8
+		blocks     = length / blockAlign
+		step       = blocks / waveformWindows
+		window     = min(step, 4096) * blockAlign
+		buf        = make([]byte, window)
+	)
+
+	if step == 0 {
+		// Too short to
+		// sample windows.
+		return probe, nil
+	}
+
+	for i := int64(0); i < waveformWindows; i++ {
+		at := i * step * blockAlign
+		if _, err := r.Seek(offset+at, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("error seeking wav: %w", err)
+		}
+
+		n, _ := io.ReadFull(r, buf)
+
+		// Take the peak of all samples in the window.
+		var peak float64
+		for j := 0; j+sampleSize <= n; j += sampleSize {
+			peak = max(peak, math.Abs(sample(buf[j:j+sampleSize])))
+		}
+
+		probe.levels = append(probe.levels, audioLevel{
+			at:    float64(at) / float64(byteRate),
+			level: peak,
+		})
+	}
+
+	return probe, nil
+}
+
+// probeM4A probes an MPEG-4 audio file, reading duration,
+// bitrate and levels from the (longest) audio track, and
+// cover art from the iTunes-style metadata, if present.
+func probeM4A(r io.ReadSeeker, _ int64) (*audioProbe, error) {
+	var probe audioProbe
+
+	// probe the file to extract useful metadata from it; as for video, see:
+	// https://github.com/abema/go-mp4/blob/7d8e5a7c5e644e0394261b0cf72fef79ce246d31/mp4tool/probe/probe.go#L85-L154
+	info, err := mp4.Probe(r)
+	if err != nil {
+		return nil, fmt.Errorf("error during mp4 probe: %w", err)
+	}
+
+	for _, tr := range info.Tracks {
+		if tr.AVC != nil || tr.Timescale == 0 {
+			// Not an audio track.
+			continue
+		}
+
+		d := float64(tr.Duration) / float64(tr.Timescale)
+		if d <= probe.duration {
+			continue
+		}
+
+		probe.duration = d
+		probe.bitrate = tr.Samples.GetBitrate(tr.Timescale)
+		if probe.bitrate == 0 {
+			probe.bitrate = info.Segments.GetBitrate(tr.TrackID, tr.Timescale)
+		}
+
+		// Derive levels from sample
+		// sizes relative to duration.
+		var at uint64
+		probe.levels = probe.levels[:0]
+		for _, s := range tr.Samples {
+			if s.TimeDelta == 0 {
+				continue
+			}
+
+			probe.levels = append(probe.levels, audioLevel{
+				at:    float64(at) / float64(tr.Timescale),
+				level: float64(s.Size) / float64(s.TimeDelta),
+			})
+			at += uint64(s.TimeDelta)
+		}
+	}
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("error seeking mp4: %w", err)
+	}
+
+	// Look for iTunes-style cover art.
+	boxes, err := mp4.ExtractBoxWithPayload(r, nil, mp4.BoxPath{
+		mp4.BoxTypeMoov(),
+		mp4.BoxTypeUdta(),
+		mp4.BoxTypeMeta(),
+		mp4.BoxTypeIlst(),
+		mp4.StrToBoxType("covr"),
+		mp4.BoxTypeData(),
+	})
+	if err != nil {
+		log.Warnf(nil, "error extracting mp4 cover art: %v", err)
+	}
+
+	for _, box := range boxes {
+		if data, ok := box.Payload.(*mp4.Data); ok && len(data.Data) > 0 {
+			probe.cover = data.Data
+			break
+		}
+	}
+
+	return &probe, nil
+}
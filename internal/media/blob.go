@@ -0,0 +1,217 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+
+	"codeberg.org/gruf/go-mutexes"
+	"codeberg.org/gruf/go-store/v2/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/iotools"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/regexes"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+// blobLocks serializes storing and releasing of
+// content-addressed blobs by path. This is kept at
+// package level as there may be more than one
+// Manager in use within a process.
+var blobLocks mutexes.MutexMap
+
+// StoreBlob hashes the contents of the given reader and adds a
+// reference to the content-addressed blob stored under that hash,
+// writing it to storage if no such blob exists yet. Returns the
+// storage path of the blob, and the size of its contents.
+//
+// Each successful call to StoreBlob must be balanced by
+// a call to ReleaseFile once the reference is dropped.
+func (m *Manager) StoreBlob(ctx context.Context, r io.Reader, ext string) (string, int64, error) {
+	// Spool contents to a temporary file,
+	// hashing them as we go, since we can't
+	// know the storage path until we're done.
+	hash := sha256.New()
+	tfs, err := iotools.TempFileSeeker(io.TeeReader(r, hash))
+	if err != nil {
+		return "", 0, gtserror.Newf("error creating temp file seeker: %w", err)
+	}
+
+	defer func() {
+		if err := tfs.Close(); err != nil {
+			log.Errorf(ctx, "error closing temp file seeker: %v", err)
+		}
+	}()
+
+	// Temp file is left at the end
+	// of contents, ie. at its size.
+	size, err := tfs.Seek(0, io.SeekEnd)
+	if err != nil {
+		return "", 0, gtserror.Newf("error seeking temp file: %w", err)
+	}
+
+	if _, err := tfs.Seek(0, io.SeekStart); err != nil {
+		return "", 0, gtserror.Newf("error seeking temp file: %w", err)
+	}
+
+	// Derive storage path from contents.
+	sum := hex.EncodeToString(hash.Sum(nil))
+	path := uris.StoragePathForBlob(sum, ext)
+
+	// Serialize storing + releasing of this blob on this node,
+	// so concurrent callers never see it half-written, or have
+	// it removed from under them by a release of the last ref.
+	unlock := blobLocks.Lock(path)
+	defer unlock()
+
+	// Write the blob to storage before adding our reference,
+	// so any referenced blob is always fully stored. If the
+	// blob is already stored, it was written by an earlier
+	// caller that held the lock, so it's complete.
+	if err := m.ensureBlob(ctx, path, tfs); err != nil {
+		return "", 0, err
+	}
+
+	// Add our reference to the blob. This is atomic in
+	// the database, so refs are counted correctly across
+	// nodes. If ours is the only reference, the blob may
+	// have been removed by another node releasing the last
+	// reference since we checked, so check again while the
+	// blob is locked against releases in the database.
+	blob := &gtsmodel.MediaBlob{
+		Path:     path,
+		Hash:     sum,
+		FileSize: int(size),
+	}
+	if err := m.state.DB.AcquireMediaBlob(ctx, blob, func() error {
+		return m.ensureBlob(ctx, path, tfs)
+	}); err != nil {
+		// If we just wrote the blob it's now
+		// untracked, and will be pruned later.
+		return "", 0, gtserror.Newf("error acquiring media blob %s: %w", path, err)
+	}
+
+	return path, size, nil
+}
+
+// ensureBlob writes the given contents of a blob
+// to storage at path, if it isn't already stored.
+func (m *Manager) ensureBlob(ctx context.Context, path string, r io.ReadSeeker) error {
+	have, err := m.state.Storage.Has(ctx, path)
+	if err != nil {
+		return gtserror.Newf("error checking storage for blob %s: %w", path, err)
+	}
+
+	if have {
+		// Already
+		// stored.
+		return nil
+	}
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return gtserror.Newf("error seeking blob contents: %w", err)
+	}
+
+	if _, err := m.state.Storage.PutStream(ctx, path, r); err != nil {
+		// Don't leave a partially written blob
+		// in storage for later callers to find.
+		if err := m.deleteFile(ctx, path); err != nil {
+			log.Errorf(ctx, "error removing partial blob: %v", err)
+		}
+		return gtserror.Newf("error writing blob to storage: %w", err)
+	}
+
+	return nil
+}
+
+// ReleaseFile drops a reference to the file at given storage
+// path, only removing it from storage once it is unreferenced.
+// Files that aren't content-addressed blobs (eg. those stored
+// before deduplication) are always removed from storage.
+func (m *Manager) ReleaseFile(ctx context.Context, path string) error {
+	if regexes.BlobPath.MatchString(path) {
+		// See StoreBlob.
+		unlock := blobLocks.Lock(path)
+		defer unlock()
+
+		// Drop our reference to the blob, removing
+		// it from storage if it was the last one.
+		_, err := m.state.DB.ReleaseMediaBlob(ctx, path, func() error {
+			return m.deleteFile(ctx, path)
+		})
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("error releasing media blob %s: %w", path, err)
+		}
+
+		if err == nil {
+			// Released (and
+			// maybe removed).
+			return nil
+		}
+	}
+
+	// Not a tracked blob,
+	// just remove the file.
+	return m.deleteFile(ctx, path)
+}
+
+// deleteFile removes the file at given path from storage.
+func (m *Manager) deleteFile(ctx context.Context, path string) error {
+	// Remove the file from storage.
+	err := m.state.Storage.Delete(ctx, path)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return gtserror.Newf("error removing %s from storage: %w", path, err)
+	}
+
+	return nil
+}
+
+// ReleaseAttachment drops the references held by given media
+// attachment to its stored files. Blob references are only held
+// while an attachment is cached, so an uncached attachment will
+// only have any untracked leftover files removed.
+func (m *Manager) ReleaseAttachment(ctx context.Context, media *gtsmodel.MediaAttachment) error {
+	var errs gtserror.MultiError
+
+	for _, path := range []string{
+		media.File.Path,
+		media.File.OriginalPath,
+		media.Thumbnail.Path,
+	} {
+		if path == "" {
+			continue
+		}
+
+		if !*media.Cached && regexes.BlobPath.MatchString(path) {
+			// Not holding a reference.
+			continue
+		}
+
+		if err := m.ReleaseFile(ctx, path); err != nil {
+			errs.Append(err)
+		}
+	}
+
+	return errs.Combine()
+}
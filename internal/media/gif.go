@@ -0,0 +1,298 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/h2non/filetype/matchers"
+	"github.com/h2non/filetype/types"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+// gifTranscodeTimeout is the longest
+// ffmpeg may take to convert a GIF.
+const gifTranscodeTimeout = time.Minute
+
+// gtsGIF contains the first frame and timing
+// of an animated GIF, which has been converted
+// to a format we can't decode frames from.
+type gtsGIF struct {
+	frame     *gtsImage
+	duration  float32 // in seconds
+	framerate float32
+}
+
+// scanGIF reads the blocks of the GIF image from r without
+// decoding any image data, returning the number of frames
+// in it, and the total duration of those frames in seconds.
+func scanGIF(r io.Reader) (frames int, duration float32, err error) {
+	br := bufio.NewReader(r)
+
+	// Read header + logical screen descriptor.
+	var hdr [13]byte
+	if _, err := io.ReadFull(br, hdr[:]); err != nil {
+		return 0, 0, fmt.Errorf("error reading gif header: %w", err)
+	}
+
+	if string(hdr[:3]) != "GIF" {
+		return 0, 0, errors.New("not a gif")
+	}
+
+	if flags := hdr[10]; flags&0x80 != 0 {
+		// Skip global color table.
+		n := 3 << (int(flags&0x07) + 1)
+		if _, err := br.Discard(n); err != nil {
+			return 0, 0, fmt.Errorf("error reading gif color table: %w", err)
+		}
+	}
+
+	// Delay of the next frame,
+	// in 1/100ths of a second.
+	var delay int
+
+	for {
+		block, err := br.ReadByte()
+		if errors.Is(err, io.EOF) && frames > 0 {
+			// Missing trailer, but
+			// we've got the frames.
+			return frames, duration, nil
+		} else if err != nil {
+			return 0, 0, fmt.Errorf("error reading gif block: %w", err)
+		}
+
+		switch block {
+
+		// Extension.
+		case 0x21:
+			label, err := br.ReadByte()
+			if err != nil {
+				return 0, 0, fmt.Errorf("error reading gif extension: %w", err)
+			}
+
+			if label == 0xF9 {
+				// Graphic control extension: block size,
+				// flags, delay (little endian uint16),
+				// transparent color index, terminator.
+				var gce [6]byte
+				if _, err := io.ReadFull(br, gce[:]); err != nil {
+					return 0, 0, fmt.Errorf("error reading gif graphic control extension: %w", err)
+				}
+				delay = int(gce[2]) | int(gce[3])<<8
+				continue
+			}
+
+			if err := skipGIFSubBlocks(br); err != nil {
+				return 0, 0, fmt.Errorf("error reading gif extension: %w", err)
+			}
+
+		// Image descriptor.
+		case 0x2C:
+			var desc [9]byte
+			if _, err := io.ReadFull(br, desc[:]); err != nil {
+				return 0, 0, fmt.Errorf("error reading gif image descriptor: %w", err)
+			}
+
+			if flags := desc[8]; flags&0x80 != 0 {
+				// Skip local color table.
+				n := 3 << (int(flags&0x07) + 1)
+				if _, err := br.Discard(n); err != nil {
+					return 0, 0, fmt.Errorf("error reading gif color table: %w", err)
+				}
+			}
+
+			// Skip LZW minimum code size,
+			// followed by the image data.
+			if _, err := br.Discard(1); err != nil {
+				return 0, 0, fmt.Errorf("error reading gif image data: %w", err)
+			}
+
+			if err := skipGIFSubBlocks(br); err != nil {
+				return 0, 0, fmt.Errorf("error reading gif image data: %w", err)
+			}
+
+			if delay <= 1 {
+				// Browsers play frames with
+				// no (or a tiny) delay at 10/s.
+				delay = 10
+			}
+
+			frames++
+			duration += float32(delay) / 100
+			delay = 0
+
+		// Trailer.
+		case 0x3B:
+			return frames, duration, nil
+
+		default:
+			return 0, 0, fmt.Errorf("unexpected gif block %#x", block)
+		}
+	}
+}
+
+// skipGIFSubBlocks skips a sequence of GIF data
+// sub-blocks, up to and including the terminator.
+func skipGIFSubBlocks(br *bufio.Reader) error {
+	for {
+		size, err := br.ReadByte()
+		if err != nil {
+			return err
+		}
+
+		if size == 0 {
+			// Terminator.
+			return nil
+		}
+
+		if _, err := br.Discard(int(size)); err != nil {
+			return err
+		}
+	}
+}
+
+// transcodeGIF converts the animated GIF read from r to the
+// given format (see config.MediaGIFTranscode*) using ffmpeg,
+// and returns a reader of the converted file along with its
+// file type. Closing the reader removes the converted file.
+//
+// Converting fails if it takes longer than gifTranscodeTimeout,
+// or if the converted file would be larger than the max size
+// configured for media of the converted type.
+func transcodeGIF(ctx context.Context, r io.Reader, format string) (io.ReadCloser, types.Type, error) {
+	var (
+		typ     types.Type
+		args    []string
+		maxSize int64
+	)
+
+	switch format {
+	case config.MediaGIFTranscodeMP4:
+		typ = matchers.TypeMp4
+		maxSize = int64(config.GetMediaVideoMaxSize())
+		args = []string{
+			"-c:v", "libx264",
+			"-preset", "veryfast",
+			"-crf", "23",
+			// Most players only support 4:2:0
+			// chroma subsampling, which requires
+			// even width and height dimensions.
+			"-pix_fmt", "yuv420p",
+			"-vf", "scale=trunc(iw/2)*2:trunc(ih/2)*2",
+			// Put metadata at the start so the
+			// video can play while it's loading.
+			"-movflags", "+faststart",
+		}
+
+	case config.MediaGIFTranscodeWebP:
+		typ = matchers.TypeWebp
+		maxSize = int64(config.GetMediaImageMaxSize())
+		args = []string{
+			"-c:v", "libwebp_anim",
+			"-quality", "75",
+			"-loop", "0", // loop forever
+		}
+
+	default:
+		return nil, types.Unknown, fmt.Errorf("unsupported gif transcode format: %s", format)
+	}
+
+	// The mp4 muxer needs to seek in its
+	// output, so write to a temp file.
+	out, err := os.CreateTemp("", "gotosocial-gif-*."+typ.Extension)
+	if err != nil {
+		return nil, types.Unknown, fmt.Errorf("error creating temp file: %w", err)
+	}
+	path := out.Name()
+	_ = out.Close()
+
+	args = append([]string{
+		"-hide_banner",
+		"-loglevel", "error",
+		"-f", "gif",
+		"-i", "pipe:0",
+		"-an", // no audio
+	}, args...)
+	args = append(args,
+		// Stop writing once the output
+		// is over the max size we'd accept.
+		"-fs", strconv.FormatInt(maxSize+1, 10),
+		"-y", path,
+	)
+
+	// Don't let a slow conversion
+	// hold up processing forever.
+	ctx, cancel := context.WithTimeout(ctx, gifTranscodeTimeout)
+	defer cancel()
+
+	var stderr bytes.Buffer
+
+	//nolint:gosec
+	cmd := exec.CommandContext(ctx, config.GetMediaFFmpegPath(), args...)
+	cmd.Stdin = r
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		_ = os.Remove(path)
+		return nil, types.Unknown, fmt.Errorf("error running ffmpeg: %w: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		_ = os.Remove(path)
+		return nil, types.Unknown, fmt.Errorf("error opening converted file: %w", err)
+	}
+
+	tf := &tempFile{File: f}
+
+	// ffmpeg exits cleanly when it hits the
+	// -fs limit, so check for truncated output.
+	stat, err := f.Stat()
+	if err != nil {
+		_ = tf.Close()
+		return nil, types.Unknown, fmt.Errorf("error checking converted file: %w", err)
+	}
+
+	if stat.Size() > maxSize {
+		_ = tf.Close()
+		return nil, types.Unknown, fmt.Errorf("converted file is larger than max size of %d bytes", maxSize)
+	}
+
+	return tf, typ, nil
+}
+
+// tempFile wraps an *os.File
+// to remove it when it's closed.
+type tempFile struct{ *os.File }
+
+func (f *tempFile) Close() error {
+	err := f.File.Close()
+	if rmErr := os.Remove(f.Name()); err == nil {
+		err = rmErr
+	}
+	return err
+}
@@ -0,0 +1,512 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+
+	"github.com/disintegration/imaging"
+)
+
+// maxHEIFMetaSize is the maximum size of the meta box
+// of a HEIF (HEIC / AVIF) image that will be read into
+// memory, which describes the items within the file.
+const maxHEIFMetaSize = 4 * 1024 * 1024
+
+var errHEIFTruncated = errors.New("error parsing heif: truncated box")
+
+// heifExtent is a range of bytes in a HEIF file.
+type heifExtent struct {
+	offset int64
+	length int64
+}
+
+// heifMeta contains the details
+// gathered from a HEIF meta box.
+type heifMeta struct {
+	// extents of any Exif or XMP metadata items.
+	metadata []heifExtent
+
+	// rotation and mirroring transformations
+	// of the primary image, in order, which
+	// decoders are told not to apply.
+	transforms []func(image.Image) *image.NRGBA
+}
+
+// heifLocation is the location of an item's
+// data, as described by the meta box iloc.
+type heifLocation struct {
+	method  uint64 // construction method
+	base    uint64 // base offset
+	extents []heifExtent
+}
+
+// heifProperty is an item property
+// box in the meta box ipco.
+type heifProperty struct {
+	typ     string
+	payload []byte
+}
+
+// parseHEIFMeta finds and parses the top-level meta box
+// of the HEIF file in rs, which may be anywhere in the file.
+func parseHEIFMeta(rs io.ReadSeeker) (*heifMeta, error) {
+	size, err := rs.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, fmt.Errorf("error seeking heif: %w", err)
+	}
+
+	var hdr [16]byte
+	for off := int64(0); off+8 <= size; {
+		if _, err := rs.Seek(off, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("error seeking heif: %w", err)
+		}
+
+		if _, err := io.ReadFull(rs, hdr[:8]); err != nil {
+			return nil, fmt.Errorf("error reading heif box: %w", err)
+		}
+
+		var (
+			typ   = string(hdr[4:8])
+			start = off + 8
+			end   = off + int64(binary.BigEndian.Uint32(hdr[0:4]))
+		)
+
+		switch end - off {
+		case 0:
+			// Box extends to
+			// the end of file.
+			end = size
+
+		case 1:
+			// Box has a 64-bit
+			// "largesize" field.
+			if _, err := io.ReadFull(rs, hdr[8:16]); err != nil {
+				return nil, fmt.Errorf("error reading heif box: %w", err)
+			}
+			start += 8
+			end = off + int64(binary.BigEndian.Uint64(hdr[8:16]))
+		}
+
+		if end < start || end > size {
+			return nil, errors.New("error parsing heif: invalid box size")
+		}
+
+		if typ != "meta" {
+			off = end
+			continue
+		}
+
+		if end-start > maxHEIFMetaSize {
+			return nil, errors.New("error parsing heif: meta box too large")
+		}
+
+		buf := make([]byte, end-start)
+		if _, err := io.ReadFull(rs, buf); err != nil {
+			return nil, fmt.Errorf("error reading heif meta: %w", err)
+		}
+
+		return parseHEIFMetaBox(buf, start, size)
+	}
+
+	return nil, errors.New("error parsing heif: no meta box")
+}
+
+// parseHEIFMetaBox parses the given meta box payload, found at
+// off in a HEIF file of size, for the locations of metadata
+// items and the transformations of the primary image.
+func parseHEIFMetaBox(b []byte, off int64, size int64) (*heifMeta, error) {
+	var (
+		meta heifMeta
+
+		primary   uint64
+		items     = make(map[uint64]bool)
+		locations = make(map[uint64]heifLocation)
+		props     []heifProperty
+		assocs    = make(map[uint64][]int)
+		idat      = int64(-1)
+	)
+
+	// Skip version and flags.
+	if len(b) < 4 {
+		return nil, errHEIFTruncated
+	}
+
+	err := heifChildBoxes(b[4:], off+4, func(typ string, p []byte, off int64) error {
+		switch typ {
+		case "pitm":
+			f := heifFields{b: p}
+			if v, _ := f.fullBox(); v == 0 {
+				primary = f.uint(2)
+			} else {
+				primary = f.uint(4)
+			}
+			return f.err
+
+		case "iinf":
+			return parseHEIFIinf(p, items)
+
+		case "iloc":
+			return parseHEIFIloc(p, locations)
+
+		case "idat":
+			idat = off
+
+		case "iprp":
+			return heifChildBoxes(p, off, func(typ string, p []byte, _ int64) error {
+				switch typ {
+				case "ipco":
+					return heifChildBoxes(p, 0, func(typ string, p []byte, _ int64) error {
+						props = append(props, heifProperty{typ, p})
+						return nil
+					})
+
+				case "ipma":
+					return parseHEIFIpma(p, assocs)
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for id := range items {
+		loc, ok := locations[id]
+		if !ok {
+			continue
+		}
+
+		for _, e := range loc.extents {
+			e.offset += int64(loc.base)
+
+			switch loc.method {
+			case 0:
+				// Offset into file.
+			case 1:
+				// Offset into idat box.
+				if idat < 0 {
+					return nil, errors.New("error parsing heif: no idat box")
+				}
+				e.offset += idat
+			default:
+				// Constructed from other
+				// items, which are stripped
+				// in their own right if needed.
+				continue
+			}
+
+			if e.length == 0 {
+				// Extent runs to
+				// the end of file.
+				e.length = size - e.offset
+			}
+
+			if e.offset < 0 || e.length < 0 || e.offset+e.length > size {
+				return nil, errors.New("error parsing heif: invalid item extent")
+			}
+
+			meta.metadata = append(meta.metadata, e)
+		}
+	}
+
+	for _, i := range assocs[primary] {
+		if i <= 0 || i > len(props) || len(props[i-1].payload) < 1 {
+			continue
+		}
+
+		prop := props[i-1]
+		switch prop.typ {
+		case "irot":
+			// Rotate anti-clockwise
+			// by angle * 90 degrees.
+			switch prop.payload[0] & 0x3 {
+			case 1:
+				meta.transforms = append(meta.transforms, imaging.Rotate90)
+			case 2:
+				meta.transforms = append(meta.transforms, imaging.Rotate180)
+			case 3:
+				meta.transforms = append(meta.transforms, imaging.Rotate270)
+			}
+
+		case "imir":
+			// Mirror about the vertical
+			// axis (0) or horizontal (1).
+			if prop.payload[0]&0x1 == 0 {
+				meta.transforms = append(meta.transforms, imaging.FlipH)
+			} else {
+				meta.transforms = append(meta.transforms, imaging.FlipV)
+			}
+		}
+	}
+
+	return &meta, nil
+}
+
+// parseHEIFIinf parses the given item info box payload,
+// adding the IDs of any Exif or XMP metadata items to items.
+func parseHEIFIinf(b []byte, items map[uint64]bool) error {
+	f := heifFields{b: b}
+	if v, _ := f.fullBox(); v == 0 {
+		f.uint(2) // entry count
+	} else {
+		f.uint(4) // entry count
+	}
+
+	if f.err != nil {
+		return f.err
+	}
+
+	return heifChildBoxes(f.b, 0, func(typ string, p []byte, _ int64) error {
+		if typ != "infe" {
+			return nil
+		}
+
+		var (
+			f           = heifFields{b: p}
+			v, _        = f.fullBox()
+			id          uint64
+			itemType    string
+			contentType string
+		)
+
+		switch v {
+		case 0, 1:
+			id = f.uint(2)
+			f.uint(2) // protection index
+			f.cstring()
+			contentType = f.cstring()
+
+		default:
+			if v == 2 {
+				id = f.uint(2)
+			} else {
+				id = f.uint(4)
+			}
+			f.uint(2) // protection index
+			itemType = string(f.bytes(4))
+			if itemType == "mime" {
+				f.cstring()
+				contentType = f.cstring()
+			}
+		}
+
+		if f.err != nil {
+			return f.err
+		}
+
+		if itemType == "Exif" || contentType == "application/rdf+xml" {
+			items[id] = true
+		}
+
+		return nil
+	})
+}
+
+// parseHEIFIloc parses the given item location box
+// payload, adding the locations of items to locations.
+func parseHEIFIloc(b []byte, locations map[uint64]heifLocation) error {
+	var (
+		f    = heifFields{b: b}
+		v, _ = f.fullBox()
+
+		sizes      = f.uint(2)
+		offsetSize = int(sizes >> 12 & 0xF)
+		lengthSize = int(sizes >> 8 & 0xF)
+		baseSize   = int(sizes >> 4 & 0xF)
+		indexSize  = 0
+		count      uint64
+	)
+
+	if v == 1 || v == 2 {
+		indexSize = int(sizes & 0xF)
+	}
+
+	if v < 2 {
+		count = f.uint(2)
+	} else {
+		count = f.uint(4)
+	}
+
+	for i := uint64(0); i < count && f.err == nil; i++ {
+		var (
+			id  uint64
+			loc heifLocation
+		)
+
+		if v < 2 {
+			id = f.uint(2)
+		} else {
+			id = f.uint(4)
+		}
+
+		if v == 1 || v == 2 {
+			loc.method = f.uint(2) & 0xF
+		}
+
+		f.uint(2) // data reference index
+		loc.base = f.uint(baseSize)
+
+		extents := f.uint(2)
+		for j := uint64(0); j < extents && f.err == nil; j++ {
+			f.uint(indexSize)
+			loc.extents = append(loc.extents, heifExtent{
+				offset: int64(f.uint(offsetSize)),
+				length: int64(f.uint(lengthSize)),
+			})
+		}
+
+		locations[id] = loc
+	}
+
+	return f.err
+}
+
+// parseHEIFIpma parses the given item property association box
+// payload, adding the 1-based indices of each item's properties
+// in the item property container box to assocs.
+func parseHEIFIpma(b []byte, assocs map[uint64][]int) error {
+	var (
+		f       = heifFields{b: b}
+		v, fl   = f.fullBox()
+		entries = f.uint(4)
+	)
+
+	for i := uint64(0); i < entries && f.err == nil; i++ {
+		var id uint64
+		if v < 1 {
+			id = f.uint(2)
+		} else {
+			id = f.uint(4)
+		}
+
+		n := f.uint(1)
+		for j := uint64(0); j < n && f.err == nil; j++ {
+			// Each association has an "essential"
+			// bit followed by the property index.
+			if fl&0x1 != 0 {
+				assocs[id] = append(assocs[id], int(f.uint(2)&0x7FFF))
+			} else {
+				assocs[id] = append(assocs[id], int(f.uint(1)&0x7F))
+			}
+		}
+	}
+
+	return f.err
+}
+
+// heifChildBoxes calls fn with the type, payload and payload
+// offset of each box within b, a box payload found at off.
+func heifChildBoxes(b []byte, off int64, fn func(typ string, payload []byte, off int64) error) error {
+	for len(b) > 0 {
+		if len(b) < 8 {
+			return errHEIFTruncated
+		}
+
+		var (
+			size = uint64(binary.BigEndian.Uint32(b[0:4]))
+			typ  = string(b[4:8])
+			hdr  = uint64(8)
+		)
+
+		switch size {
+		case 0:
+			size = uint64(len(b))
+		case 1:
+			if len(b) < 16 {
+				return errHEIFTruncated
+			}
+			size = binary.BigEndian.Uint64(b[8:16])
+			hdr = 16
+		}
+
+		if size < hdr || size > uint64(len(b)) {
+			return errHEIFTruncated
+		}
+
+		if err := fn(typ, b[hdr:size], off+int64(hdr)); err != nil {
+			return err
+		}
+
+		b = b[size:]
+		off += int64(size)
+	}
+
+	return nil
+}
+
+// heifFields is a bounds-checked reader of
+// big-endian fields from a HEIF box payload.
+type heifFields struct {
+	b   []byte
+	err error
+}
+
+// fullBox reads the version and flags of a "full" box.
+func (f *heifFields) fullBox() (version uint64, flags uint64) {
+	return f.uint(1), f.uint(3)
+}
+
+// uint reads an unsigned integer of n (<= 8) bytes.
+func (f *heifFields) uint(n int) uint64 {
+	var v uint64
+	for _, c := range f.bytes(n) {
+		v = v<<8 | uint64(c)
+	}
+	return v
+}
+
+// bytes reads the next n bytes.
+func (f *heifFields) bytes(n int) []byte {
+	if f.err != nil {
+		return nil
+	}
+
+	if n > len(f.b) {
+		f.err = errHEIFTruncated
+		return nil
+	}
+
+	b := f.b[:n]
+	f.b = f.b[n:]
+	return b
+}
+
+// cstring reads a null-terminated string.
+func (f *heifFields) cstring() string {
+	if f.err != nil {
+		return ""
+	}
+
+	i := bytes.IndexByte(f.b, 0)
+	if i < 0 {
+		f.err = errHEIFTruncated
+		return ""
+	}
+
+	s := string(f.b[:i])
+	f.b = f.b[i+1:]
+	return s
+}
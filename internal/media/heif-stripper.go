@@ -0,0 +1,80 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"fmt"
+	"io"
+)
+
+// heifMetadataStripper wraps an io.Reader of a HEIF (HEIC / AVIF)
+// file to zero out the bytes of any Exif or XMP metadata items.
+//
+// Unlike with JPEG and PNG, where metadata is found in segments /
+// chunks that can be skipped over while streaming, HEIF metadata
+// items are just ranges of bytes in the file, described by the meta
+// box. Zeroing them (rather than removing them) means all the other
+// offsets in the meta box remain valid, without needing a rewrite.
+type heifMetadataStripper struct {
+	// Reader is the wrapped io.Reader.
+	Reader io.Reader
+
+	// extents of metadata to zero out.
+	extents []heifExtent
+
+	// off is the current offset in the file.
+	off int64
+}
+
+// stripHEIFMetadata parses the meta box of the HEIF file
+// in rs, and returns a reader of the file from the start
+// with any Exif or XMP metadata items zeroed out.
+func stripHEIFMetadata(rs io.ReadSeeker) (io.Reader, error) {
+	meta, err := parseHEIFMeta(rs)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := rs.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("error seeking heif: %w", err)
+	}
+
+	return &heifMetadataStripper{
+		Reader:  rs,
+		extents: meta.metadata,
+	}, nil
+}
+
+// Read implements io.Reader.
+func (r *heifMetadataStripper) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+
+	// Zero out any part of the
+	// read bytes within extents.
+	start, end := r.off, r.off+int64(n)
+	for _, e := range r.extents {
+		from := max(start, e.offset)
+		to := min(end, e.offset+e.length)
+		if from < to {
+			clear(p[from-start : to-start])
+		}
+	}
+
+	r.off = end
+	return n, err
+}
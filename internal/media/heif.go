@@ -0,0 +1,136 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"io"
+	"sync"
+
+	"github.com/gen2brain/avif"
+	"github.com/gen2brain/heic"
+	"github.com/h2non/filetype/matchers"
+	"github.com/h2non/filetype/matchers/isobmff"
+	"github.com/h2non/filetype/types"
+)
+
+var (
+	// typeHeic and typeAvif are the file types
+	// of HEIF images, which filetype either only
+	// partially recognizes (HEIC) or not at all.
+	typeHeic = types.Type{MIME: types.NewMIME(mimeImageHeic), Extension: mimeHeic}
+	typeAvif = types.Type{MIME: types.NewMIME(mimeImageAvif), Extension: mimeAvif}
+
+	// heifBrands maps the ISOBMFF
+	// brands of HEIF images to types.
+	heifBrands = map[string]types.Type{
+		"heic": typeHeic,
+		"heix": typeHeic,
+		"heim": typeHeic,
+		"heis": typeHeic,
+		"avif": typeAvif,
+		"avis": typeAvif,
+	}
+
+	// heifMu serializes decoding of HEIF images, as
+	// each decoder shares a single WebAssembly module
+	// instance which is not safe for concurrent use.
+	heifMu sync.Mutex
+)
+
+// matchHEIF checks the brands of the ftyp box at
+// the start of buf for those of HEIC or AVIF images.
+func matchHEIF(buf []byte) (types.Type, bool) {
+	if !isobmff.IsISOBMFF(buf) {
+		return types.Unknown, false
+	}
+
+	major, _, compatible := isobmff.GetFtyp(buf)
+	for _, brand := range append([]string{major}, compatible...) {
+		if t, ok := heifBrands[brand]; ok {
+			return t, true
+		}
+	}
+
+	return types.Unknown, false
+}
+
+// decodeHEIF decodes the primary image of the HEIC or AVIF
+// image of contentType from r, applying its rotation and
+// mirroring properties (which the decoders ignore), as is
+// done for the orientation of JPEG images.
+func decodeHEIF(r io.Reader, contentType string) (*gtsImage, error) {
+	// The decoders read the whole
+	// file into memory anyway.
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading heif: %w", err)
+	}
+
+	meta, err := parseHEIFMeta(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	var img image.Image
+
+	heifMu.Lock()
+	switch contentType {
+	case mimeImageHeic:
+		img, err = heic.Decode(bytes.NewReader(b))
+	case mimeImageAvif:
+		img, err = avif.Decode(bytes.NewReader(b))
+	default:
+		err = fmt.Errorf("unsupported heif type %s", contentType)
+	}
+	heifMu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, transform := range meta.transforms {
+		img = transform(img)
+	}
+
+	return &gtsImage{image: img}, nil
+}
+
+// transcodeHEIF decodes the HEIC or AVIF image of contentType
+// from rs, and returns a reader of it re-encoded as JPEG, or as
+// PNG if it has transparency, along with the new file type.
+// Metadata items are not carried over into the new image.
+func transcodeHEIF(rs io.ReadSeeker, contentType string) (io.Reader, types.Type, error) {
+	if _, err := rs.Seek(0, io.SeekStart); err != nil {
+		return nil, types.Unknown, fmt.Errorf("error seeking heif: %w", err)
+	}
+
+	img, err := decodeHEIF(rs, contentType)
+	if err != nil {
+		return nil, types.Unknown, err
+	}
+
+	if o, ok := img.image.(interface{ Opaque() bool }); ok && o.Opaque() {
+		return img.ToJPEG(&jpeg.Options{Quality: 90}), matchers.TypeJpeg, nil
+	}
+
+	return img.ToPNG(), matchers.TypePng, nil
+}
@@ -39,7 +39,14 @@ var SupportedMIMETypes = []string{
 	mimeImageGif,
 	mimeImagePng,
 	mimeImageWebp,
+	mimeImageHeic,
+	mimeImageAvif,
 	mimeVideoMp4,
+	mimeAudioMpeg,
+	mimeAudioOgg,
+	mimeAudioFlac,
+	mimeAudioWav,
+	mimeAudioMp4,
 }
 
 var SupportedEmojiMIMETypes = []string{
@@ -93,10 +100,11 @@ func (m *Manager) PreProcessMedia(
 			UpdatedAt:   now,
 			ContentType: "application/octet-stream",
 		},
-		Thumbnail: gtsmodel.Thumbnail{UpdatedAt: now},
-		Avatar:    util.Ptr(false),
-		Header:    util.Ptr(false),
-		Cached:    util.Ptr(false),
+		Thumbnail:   gtsmodel.Thumbnail{UpdatedAt: now},
+		Avatar:      util.Ptr(false),
+		Header:      util.Ptr(false),
+		Cached:      util.Ptr(false),
+		Quarantined: util.Ptr(false),
 	}
 
 	attachment.URL = uris.URIForAttachment(
@@ -166,6 +174,10 @@ func (m *Manager) PreProcessMedia(
 		mgr:    m,
 	}
 
+	if ai != nil && ai.Domain != nil {
+		processingMedia.domain = *ai.Domain
+	}
+
 	return processingMedia
 }
 
@@ -373,8 +385,12 @@ func (m *Manager) PreProcessEmoji(
 			emoji.VisibleInPicker = ai.VisibleInPicker
 		}
 
-		if ai.CategoryID != nil {
+		if ai.CategoryID != nil && *ai.CategoryID != emoji.CategoryID {
 			emoji.CategoryID = *ai.CategoryID
+
+			// Drop any category loaded
+			// for the old category ID.
+			emoji.Category = nil
 		}
 	}
 
@@ -20,15 +20,23 @@ package media_test
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
 	"path"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"codeberg.org/gruf/go-store/v2/storage"
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
@@ -435,6 +443,126 @@ func (suite *ManagerTestSuite) TestSimpleJpegProcessBlocking() {
 	suite.Equal(processedThumbnailBytesExpected, processedThumbnailBytes)
 }
 
+func (suite *ManagerTestSuite) TestSimpleJpegProcessBlockingDeduplicated() {
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test image
+		b, err := os.ReadFile("./test/test-jpeg.jpg")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	// process the same media twice, for different accounts
+	attachment1, err := suite.manager.PreProcessMedia(data, "01FS1X72SK9ZPW0J1QQ68BD264", nil).LoadAttachment(ctx)
+	suite.NoError(err)
+	attachment2, err := suite.manager.PreProcessMedia(data, "01F8MH17FWEB39HZJ76B6VXSKF", nil).LoadAttachment(ctx)
+	suite.NoError(err)
+
+	// attachments should still be served from their own urls...
+	suite.NotEqual(attachment1.URL, attachment2.URL)
+	suite.NotEqual(attachment1.Thumbnail.URL, attachment2.Thumbnail.URL)
+
+	// ...but be stored in the same content-addressed blobs
+	suite.Equal(attachment1.File.Path, attachment2.File.Path)
+	suite.Equal(attachment1.Thumbnail.Path, attachment2.Thumbnail.Path)
+	suite.Regexp(`^blobs/[0-9a-f]{2}/[0-9a-f]{64}\.jpg$`, attachment1.File.Path)
+	suite.Regexp(`^blobs/[0-9a-f]{2}/[0-9a-f]{64}\.jpg$`, attachment1.Thumbnail.Path)
+
+	// each blob should be referenced by both attachments
+	blob, err := suite.db.GetMediaBlobByPath(ctx, attachment1.File.Path)
+	suite.NoError(err)
+	suite.Equal(2, blob.RefCount)
+	suite.Equal(269739, blob.FileSize)
+
+	blob, err = suite.db.GetMediaBlobByPath(ctx, attachment1.Thumbnail.Path)
+	suite.NoError(err)
+	suite.Equal(2, blob.RefCount)
+
+	// releasing one attachment should leave files in place for the other
+	suite.NoError(suite.manager.ReleaseAttachment(ctx, attachment1))
+
+	blob, err = suite.db.GetMediaBlobByPath(ctx, attachment2.File.Path)
+	suite.NoError(err)
+	suite.Equal(1, blob.RefCount)
+
+	have, err := suite.storage.Has(ctx, attachment2.File.Path)
+	suite.NoError(err)
+	suite.True(have)
+
+	// releasing the last reference should remove blobs entirely
+	suite.NoError(suite.manager.ReleaseAttachment(ctx, attachment2))
+
+	_, err = suite.db.GetMediaBlobByPath(ctx, attachment2.File.Path)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	for _, p := range []string{
+		attachment2.File.Path,
+		attachment2.Thumbnail.Path,
+	} {
+		have, err := suite.storage.Has(ctx, p)
+		suite.NoError(err)
+		suite.False(have)
+	}
+}
+
+func (suite *ManagerTestSuite) TestStoreBlobConcurrent() {
+	ctx := context.Background()
+
+	b, err := os.ReadFile("./test/test-jpeg.jpg")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// store the same blob from many callers at once
+	const callers = 10
+	var (
+		wg    sync.WaitGroup
+		paths = make([]string, callers)
+		errs  = make([]error, callers)
+	)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			paths[i], _, errs[i] = suite.manager.StoreBlob(ctx, bytes.NewReader(b), "jpg")
+		}(i)
+	}
+	wg.Wait()
+
+	// every caller should have a complete blob
+	for i := 0; i < callers; i++ {
+		suite.NoError(errs[i])
+		suite.Equal(paths[0], paths[i])
+	}
+
+	stored, err := suite.storage.Get(ctx, paths[0])
+	suite.NoError(err)
+	suite.Equal(b, stored)
+
+	blob, err := suite.db.GetMediaBlobByPath(ctx, paths[0])
+	suite.NoError(err)
+	suite.Equal(callers, blob.RefCount)
+
+	// releasing all but one should leave the blob in place
+	for i := 0; i < callers-1; i++ {
+		suite.NoError(suite.manager.ReleaseFile(ctx, paths[i]))
+	}
+
+	have, err := suite.storage.Has(ctx, paths[0])
+	suite.NoError(err)
+	suite.True(have)
+
+	// and releasing the last should remove it
+	suite.NoError(suite.manager.ReleaseFile(ctx, paths[0]))
+
+	have, err = suite.storage.Has(ctx, paths[0])
+	suite.NoError(err)
+	suite.False(have)
+}
+
 func (suite *ManagerTestSuite) TestSimpleJpegProcessPartial() {
 	ctx := context.Background()
 
@@ -465,7 +593,7 @@ func (suite *ManagerTestSuite) TestSimpleJpegProcessPartial() {
 
 	// Since we're cutting off the byte stream
 	// halfway through, we should get an error here.
-	suite.EqualError(err, "store: error writing media to storage: scan-data is unbounded; EOI not encountered before EOF")
+	suite.EqualError(err, "store: error writing media to storage: StoreBlob: error creating temp file seeker: scan-data is unbounded; EOI not encountered before EOF")
 	suite.NotNil(attachment)
 
 	// make sure it's got the stuff set on it that we expect
@@ -814,6 +942,471 @@ func (suite *ManagerTestSuite) TestNotAnMp4ProcessBlocking() {
 	suite.Equal(gtsmodel.FileTypeUnknown, attachment.Type)
 }
 
+func (suite *ManagerTestSuite) TestMp3CoverArtProcessBlocking() {
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test mp3 with ID3 cover art
+		b, err := os.ReadFile("./test/test-mp3-cover.mp3")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, nil)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// file meta should be correctly derived from the audio,
+	// with the 1x1 cover art used for the thumbnail
+	suite.Equal(gtsmodel.FileTypeAudio, attachment.Type)
+	suite.Zero(attachment.FileMeta.Original.Width)
+	suite.Zero(attachment.FileMeta.Original.Height)
+	suite.EqualValues(float32(2.6122448), *attachment.FileMeta.Original.Duration)
+	suite.EqualValues(127706, *attachment.FileMeta.Original.Bitrate)
+	suite.EqualValues(gtsmodel.Small{
+		Width: 1, Height: 1, Size: 1, Aspect: 1,
+	}, attachment.FileMeta.Small)
+	suite.Equal("audio/mpeg", attachment.File.ContentType)
+	suite.Equal("image/jpeg", attachment.Thumbnail.ContentType)
+	suite.Equal(42433, attachment.File.FileSize)
+	suite.True(strings.HasSuffix(attachment.URL, ".mp3"))
+
+	// the original file should be stored unchanged
+	stored, err := suite.storage.Get(ctx, attachment.File.Path)
+	suite.NoError(err)
+	suite.Len(stored, 42433)
+
+	stored, err = suite.storage.Get(ctx, attachment.Thumbnail.Path)
+	suite.NoError(err)
+	suite.NotEmpty(stored)
+}
+
+func (suite *ManagerTestSuite) TestOpusCoverArtProcessBlocking() {
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test opus file with
+		// METADATA_BLOCK_PICTURE comment cover art
+		b, err := os.ReadFile("./test/test-opus-cover.opus")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, nil)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// duration should account for the opus pre-skip
+	suite.Equal(gtsmodel.FileTypeAudio, attachment.Type)
+	suite.EqualValues(float32(1.9935), *attachment.FileMeta.Original.Duration)
+	suite.EqualValues(15759, *attachment.FileMeta.Original.Bitrate)
+	suite.EqualValues(gtsmodel.Small{
+		Width: 1, Height: 1, Size: 1, Aspect: 1,
+	}, attachment.FileMeta.Small)
+	suite.Equal("audio/ogg", attachment.File.ContentType)
+	suite.Equal("image/jpeg", attachment.Thumbnail.ContentType)
+}
+
+func (suite *ManagerTestSuite) TestWavWaveformProcessBlocking() {
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test 16-bit pcm wav
+		b, err := os.ReadFile("./test/test-wav.wav")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, nil)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// no cover art, so a waveform should be generated
+	suite.Equal(gtsmodel.FileTypeAudio, attachment.Type)
+	suite.EqualValues(float32(2), *attachment.FileMeta.Original.Duration)
+	suite.EqualValues(128000, *attachment.FileMeta.Original.Bitrate)
+	suite.EqualValues(gtsmodel.Small{
+		Width: 512, Height: 256, Size: 131072, Aspect: 2,
+	}, attachment.FileMeta.Small)
+	suite.Equal("audio/wav", attachment.File.ContentType)
+	suite.Equal("image/jpeg", attachment.Thumbnail.ContentType)
+	suite.NotEmpty(attachment.Blurhash)
+}
+
+func (suite *ManagerTestSuite) TestFlacWaveformProcessBlocking() {
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test flac
+		b, err := os.ReadFile("./test/test-flac.flac")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, nil)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	suite.Equal(gtsmodel.FileTypeAudio, attachment.Type)
+	suite.EqualValues(float32(1.8575964), *attachment.FileMeta.Original.Duration)
+	suite.EqualValues(1128, *attachment.FileMeta.Original.Bitrate)
+	suite.EqualValues(gtsmodel.Small{
+		Width: 512, Height: 256, Size: 131072, Aspect: 2,
+	}, attachment.FileMeta.Small)
+	suite.Equal("audio/flac", attachment.File.ContentType)
+	suite.True(strings.HasSuffix(attachment.URL, ".flac"))
+}
+
+func (suite *ManagerTestSuite) TestHeicProcessBlocking() {
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test heic with exif and xmp metadata
+		b, err := os.ReadFile("./test/test-heic-exif.heic")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, nil)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// file meta should be correctly derived from the image
+	suite.Equal(gtsmodel.FileTypeImage, attachment.Type)
+	suite.EqualValues(gtsmodel.Original{
+		Width: 512, Height: 512, Size: 262144, Aspect: 1,
+	}, attachment.FileMeta.Original)
+	suite.EqualValues(gtsmodel.Small{
+		Width: 512, Height: 512, Size: 262144, Aspect: 1,
+	}, attachment.FileMeta.Small)
+	suite.Equal("image/heic", attachment.File.ContentType)
+	suite.True(strings.HasSuffix(attachment.File.Path, ".heic"))
+	suite.Equal("image/jpeg", attachment.Thumbnail.ContentType)
+	suite.Equal(22679, attachment.File.FileSize)
+	suite.NotEmpty(attachment.Blurhash)
+
+	// the stored file should be kept as heic,
+	// but with its exif and xmp items zeroed
+	processedFullBytes, err := suite.storage.Get(ctx, attachment.File.Path)
+	suite.NoError(err)
+	suite.Len(processedFullBytes, 22679)
+	suite.NotContains(string(processedFullBytes), "Exif\x00\x00II*")
+	suite.NotContains(string(processedFullBytes), "<?xpacket")
+}
+
+func (suite *ManagerTestSuite) TestAvifTranscodeProcessBlocking() {
+	ctx := context.Background()
+
+	// convert heif images on the way in
+	config.SetMediaHEIFTranscode(true)
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test avif with exif and xmp metadata
+		b, err := os.ReadFile("./test/test-avif-exif.avif")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, nil)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// the image has no transparency so
+	// it should have been stored as jpeg
+	suite.Equal(gtsmodel.FileTypeImage, attachment.Type)
+	suite.EqualValues(gtsmodel.Original{
+		Width: 512, Height: 512, Size: 262144, Aspect: 1,
+	}, attachment.FileMeta.Original)
+	suite.Equal("image/jpeg", attachment.File.ContentType)
+	suite.True(strings.HasSuffix(attachment.File.Path, ".jpg"))
+	suite.True(strings.HasSuffix(attachment.URL, ".jpg"))
+	suite.Equal("image/jpeg", attachment.Thumbnail.ContentType)
+	suite.NotEmpty(attachment.Blurhash)
+
+	processedFullBytes, err := suite.storage.Get(ctx, attachment.File.Path)
+	suite.NoError(err)
+	suite.Equal(attachment.File.FileSize, len(processedFullBytes))
+	suite.Equal([]byte{0xFF, 0xD8}, processedFullBytes[:2])
+	suite.NotContains(string(processedFullBytes), "<?xpacket")
+}
+
+func (suite *ManagerTestSuite) TestAnimatedGIFNoFFmpegProcessBlocking() {
+	ctx := context.Background()
+
+	// try to convert gifs with an ffmpeg that isn't there
+	config.SetMediaGIFTranscode(config.MediaGIFTranscodeMP4)
+	config.SetMediaFFmpegPath("/does/not/exist/ffmpeg")
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from an animated gif
+		b, err := os.ReadFile("./test/big-panda.gif")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, nil)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// conversion failed so the
+	// gif should be stored as-is
+	suite.Equal(gtsmodel.FileTypeImage, attachment.Type)
+	suite.Equal("image/gif", attachment.File.ContentType)
+	suite.True(strings.HasSuffix(attachment.File.Path, ".gif"))
+	suite.Empty(attachment.File.OriginalPath)
+	suite.Equal("image/jpeg", attachment.Thumbnail.ContentType)
+	suite.NotEmpty(attachment.Blurhash)
+}
+
+func (suite *ManagerTestSuite) TestAnimatedGIFTranscodeTooBigProcessBlocking() {
+	ctx := context.Background()
+
+	if _, err := exec.LookPath("ffmpeg"); err != nil {
+		suite.T().Skip("ffmpeg not installed")
+	}
+
+	// convert gifs to mp4, with a
+	// tiny max size for the result
+	config.SetMediaGIFTranscode(config.MediaGIFTranscodeMP4)
+	config.SetMediaVideoMaxSize(1024)
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from an animated gif
+		b, err := os.ReadFile("./test/big-panda.gif")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, nil)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// converted file was too big so
+	// the gif should be stored as-is
+	suite.Equal(gtsmodel.FileTypeImage, attachment.Type)
+	suite.Equal("image/gif", attachment.File.ContentType)
+	suite.True(strings.HasSuffix(attachment.File.Path, ".gif"))
+	suite.Empty(attachment.File.OriginalPath)
+}
+
+func (suite *ManagerTestSuite) TestAnimatedGIFTranscodeMP4ProcessBlocking() {
+	ctx := context.Background()
+
+	if _, err := exec.LookPath("ffmpeg"); err != nil {
+		suite.T().Skip("ffmpeg not installed")
+	}
+
+	// convert gifs to mp4, keeping the original
+	config.SetMediaGIFTranscode(config.MediaGIFTranscodeMP4)
+	config.SetMediaGIFKeepOriginal(true)
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from an animated gif
+		b, err := os.ReadFile("./test/big-panda.gif")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, nil)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// the gif is animated so it
+	// should be stored as gifv
+	suite.Equal(gtsmodel.FileTypeGifv, attachment.Type)
+	suite.Equal("video/mp4", attachment.File.ContentType)
+	suite.True(strings.HasSuffix(attachment.File.Path, ".mp4"))
+	suite.Equal(500, attachment.FileMeta.Original.Width)
+	suite.Equal(300, attachment.FileMeta.Original.Height)
+	suite.NotNil(attachment.FileMeta.Original.Duration)
+	suite.Equal("image/jpeg", attachment.Thumbnail.ContentType)
+	suite.NotEmpty(attachment.Blurhash)
+
+	// the original gif should be kept
+	suite.True(strings.HasSuffix(attachment.File.OriginalPath, ".gif"))
+	originalBytes, err := suite.storage.Get(ctx, attachment.File.OriginalPath)
+	suite.NoError(err)
+	suite.Equal([]byte("GIF89a"), originalBytes[:6])
+}
+
+func (suite *ManagerTestSuite) TestBlockedSHA256LocalProcessBlocking() {
+	ctx := context.Background()
+
+	b, err := os.ReadFile("./test/test-jpeg.jpg")
+	if err != nil {
+		panic(err)
+	}
+
+	// blocklist the exact file contents
+	sum := sha256.Sum256(b)
+	if err := suite.db.PutMediaHash(ctx, &gtsmodel.MediaHash{
+		ID:                 "01HTFRKBCWJ9B4Q6XCZ9V2N3P5",
+		Type:               gtsmodel.MediaHashTypeSHA256,
+		Hash:               hex.EncodeToString(sum[:]),
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, nil)
+
+	// do a blocking call to fetch the attachment
+	_, err = processingMedia.LoadAttachment(ctx)
+	suite.ErrorIs(err, media.ErrBlockedMedia)
+
+	// the local upload should have been refused entirely
+	_, err = suite.db.GetAttachmentByID(ctx, processingMedia.AttachmentID())
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *ManagerTestSuite) TestBlockedPHashRemoteProcessBlocking() {
+	ctx := context.Background()
+
+	// a flat image has a perceptual hash of 0
+	if err := suite.db.PutMediaHash(ctx, &gtsmodel.MediaHash{
+		ID:                 "01HTFRKBCWJ9B4Q6XCZ9V2N3P5",
+		Type:               gtsmodel.MediaHashTypePHash,
+		Hash:               media.FormatPHash(0),
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a flat white test image
+		b, err := os.ReadFile("./test/test-jpeg-1x1px-white.jpg")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	account := suite.testAccounts["remote_account_1"]
+	remoteURL := "http://fossbros-anonymous.io/attachments/original/flat.jpg"
+
+	// get any existing reports of the account
+	reportsBefore, err := suite.db.GetReports(ctx, nil, "", account.ID, "", "", "", 0)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		suite.FailNow(err.Error())
+	}
+
+	// process the media as remote media
+	processingMedia := suite.manager.PreProcessMedia(data, account.ID, &media.AdditionalMediaInfo{
+		RemoteURL: &remoteURL,
+	})
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// the remote media should be kept, but quarantined
+	suite.False(*attachment.Cached)
+	suite.True(*attachment.Quarantined)
+	suite.Empty(attachment.Blurhash)
+
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	suite.NoError(err)
+	suite.True(*dbAttachment.Quarantined)
+
+	// nothing should be left in storage
+	have, err := suite.storage.Has(ctx, attachment.File.Path)
+	suite.NoError(err)
+	suite.False(have)
+
+	// and the account should have been reported
+	reports, err := suite.db.GetReports(ctx, nil, "", account.ID, "", "", "", 0)
+	suite.NoError(err)
+	suite.Len(reports, len(reportsBefore)+1)
+
+	var reported bool
+	for _, report := range reports {
+		if strings.Contains(report.Comment, attachment.ID) {
+			reported = true
+		}
+	}
+	suite.True(reported)
+}
+
 func (suite *ManagerTestSuite) TestSimpleJpegProcessBlockingNoContentLengthGiven() {
 	ctx := context.Background()
 
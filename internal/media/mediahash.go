@@ -0,0 +1,191 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/disintegration/imaging"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// phashMaxDistance is the maximum number of bits by which
+// two perceptual hashes may differ and still be considered
+// to be of the same image. Out of 64 bits, this allows for
+// re-encoding, resizing etc., with few false positives.
+const phashMaxDistance = 8
+
+// ErrBlockedMedia is returned when loading local media
+// that matches an entry on the media hash blocklist.
+var ErrBlockedMedia = errors.New("media matches blocklisted hash")
+
+// perceptualHash returns the 64-bit difference
+// hash (dHash) of the image, by shrinking it to
+// 9x8 grayscale pixels and comparing each pixel
+// with its right-hand neighbour.
+func (i *gtsImage) perceptualHash() uint64 {
+	small := imaging.Grayscale(imaging.Resize(i.image, 9, 8, imaging.Box))
+
+	var hash uint64
+	for y := 0; y < 8; y++ {
+		row := small.Pix[y*small.Stride:]
+		for x := 0; x < 8; x++ {
+			hash <<= 1
+
+			// Gray pixels have equal R, G
+			// and B, so compare only R.
+			if row[x*4] > row[(x+1)*4] {
+				hash |= 1
+			}
+		}
+	}
+
+	return hash
+}
+
+// FormatPHash returns the hex-encoded
+// form of given perceptual hash, as
+// stored in the media hash blocklist.
+func FormatPHash(phash uint64) string {
+	return fmt.Sprintf("%016x", phash)
+}
+
+// ParsePHash parses the hex-encoded
+// form of a perceptual hash.
+func ParsePHash(s string) (uint64, error) {
+	phash, err := strconv.ParseUint(s, 16, 64)
+	if err != nil || len(s) != 16 {
+		return 0, fmt.Errorf("perceptual hash %q should be 16 hex characters", s)
+	}
+	return phash, nil
+}
+
+// matchHashes checks the media's content hashes
+// against the media hash blocklist, returning the
+// first matching blocklisted hash, if any.
+func (p *ProcessingMedia) matchHashes(ctx context.Context) (*gtsmodel.MediaHash, error) {
+	if p.sum != "" {
+		hash, err := p.mgr.state.DB.MatchMediaSHA256(ctx, p.sum)
+		if err != nil {
+			return nil, gtserror.Newf("error matching sha256 hash: %w", err)
+		}
+
+		if hash != nil {
+			return hash, nil
+		}
+	}
+
+	if p.phash != nil {
+		hash, err := p.mgr.state.DB.MatchMediaPHash(ctx, *p.phash, phashMaxDistance)
+		if err != nil {
+			return nil, gtserror.Newf("error matching perceptual hash: %w", err)
+		}
+
+		if hash != nil {
+			return hash, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// quarantine drops the stored files of remote media
+// matching the given blocklisted hash, marking it as
+// quarantined so that it isn't served or refetched.
+func (p *ProcessingMedia) quarantine(ctx context.Context, hash *gtsmodel.MediaHash) error {
+	if err := p.mgr.ReleaseAttachment(ctx, p.media); err != nil {
+		return err
+	}
+
+	// Files are no longer stored, and
+	// no blob references are held.
+	p.media.Cached = util.Ptr(false)
+	p.media.Quarantined = util.Ptr(true)
+
+	// Don't keep a blurred
+	// preview of it either.
+	p.media.Blurhash = ""
+
+	p.blocked = hash
+	return nil
+}
+
+// reportQuarantined opens a report on behalf of the instance
+// account against the owner of the given quarantined media,
+// so that admins can review it, and the status it belongs to.
+func (m *Manager) reportQuarantined(
+	ctx context.Context,
+	media *gtsmodel.MediaAttachment,
+	hash *gtsmodel.MediaHash,
+) error {
+	instanceAcc, err := m.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return gtserror.Newf("error getting instance account: %w", err)
+	}
+
+	targetAcc, err := m.state.DB.GetAccountByID(ctx, media.AccountID)
+	if err != nil {
+		return gtserror.Newf("error getting account %s: %w", media.AccountID, err)
+	}
+
+	var statusIDs []string
+	if media.StatusID != "" {
+		statusIDs = []string{media.StatusID}
+	}
+
+	reportID := id.NewULID()
+	report := &gtsmodel.Report{
+		ID:              reportID,
+		URI:             uris.GenerateURIForReport(reportID),
+		AccountID:       instanceAcc.ID,
+		Account:         instanceAcc,
+		TargetAccountID: targetAcc.ID,
+		TargetAccount:   targetAcc,
+		Comment: fmt.Sprintf(
+			"Media %s was quarantined automatically, as it matches blocklisted %s hash %s.",
+			media.ID, hash.Type, hash.ID,
+		),
+		StatusIDs: statusIDs,
+		Forwarded: util.Ptr(false),
+	}
+
+	if err := m.state.DB.PutReport(ctx, report); err != nil {
+		return gtserror.Newf("error inserting report: %w", err)
+	}
+
+	// Process side effects, ie.,
+	// notify admins of the report.
+	m.state.Workers.EnqueueClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ObjectProfile,
+		APActivityType: ap.ActivityFlag,
+		GTSModel:       report,
+		OriginAccount:  instanceAcc,
+		TargetAccount:  targetAcc,
+	})
+
+	return nil
+}
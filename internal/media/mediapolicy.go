@@ -0,0 +1,92 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"errors"
+
+	"codeberg.org/gruf/go-store/v2/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// ErrRejectedMedia is returned when loading a remote emoji
+// from a domain whose media policy is to reject all media.
+var ErrRejectedMedia = errors.New("media from domain is rejected by media policy")
+
+// AccountMediaPolicy returns the media policy applying to media
+// owned by the account with given ID, or nil if there is none
+// (including when the account is local, or not yet stored).
+func (m *Manager) AccountMediaPolicy(ctx context.Context, accountID string) (*gtsmodel.DomainMediaPolicy, error) {
+	account, err := m.state.DB.GetAccountByID(
+		gtscontext.SetBarebones(ctx),
+		accountID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error getting account %s: %w", accountID, err)
+	}
+
+	if account == nil || account.IsLocal() {
+		return nil, nil
+	}
+
+	policy, err := m.state.DB.MatchDomainMediaPolicy(ctx, account.Domain)
+	if err != nil {
+		return nil, gtserror.Newf("error matching media policy for %s: %w", account.Domain, err)
+	}
+
+	return policy, nil
+}
+
+// uncache drops the stored files of media that was processed
+// under a media policy that doesn't allow caching it, keeping
+// the attachment details (type, dimensions, blurhash) as-is.
+func (p *ProcessingMedia) uncache(ctx context.Context) error {
+	if err := p.mgr.ReleaseAttachment(ctx, p.media); err != nil {
+		return err
+	}
+
+	// Files are no longer stored, and
+	// no blob references are held.
+	p.media.Cached = util.Ptr(false)
+	return nil
+}
+
+// uncache drops the stored images of an emoji that was
+// processed under a media policy that doesn't allow
+// caching it, keeping the emoji details as-is.
+func (p *ProcessingEmoji) uncache(ctx context.Context) error {
+	var errs gtserror.MultiError
+
+	for _, path := range []string{
+		p.emoji.ImagePath,
+		p.emoji.ImageStaticPath,
+	} {
+		err := p.mgr.state.Storage.Delete(ctx, path)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			errs.Appendf("error removing %s: %w", path, err)
+		}
+	}
+
+	p.emoji.Cached = util.Ptr(false)
+	return errs.Combine()
+}
@@ -39,14 +39,15 @@ import (
 // ProcessingEmoji represents an emoji currently processing. It exposes
 // various functions for retrieving data from the process.
 type ProcessingEmoji struct {
-	emoji     *gtsmodel.Emoji   // processing emoji details
-	existing  bool              // indicates whether this is an existing emoji ID being refreshed / recached
-	newPathID string            // new emoji path ID to use when being refreshed
-	dataFn    DataFunc          // load-data function, returns media stream
-	done      bool              // done is set when process finishes with non ctx canceled type error
-	proc      runners.Processor // proc helps synchronize only a singular running processing instance
-	err       error             // error stores permanent error value when done
-	mgr       *Manager          // mgr instance (access to db / storage)
+	emoji     *gtsmodel.Emoji             // processing emoji details
+	existing  bool                        // indicates whether this is an existing emoji ID being refreshed / recached
+	newPathID string                      // new emoji path ID to use when being refreshed
+	dataFn    DataFunc                    // load-data function, returns media stream
+	done      bool                        // done is set when process finishes with non ctx canceled type error
+	proc      runners.Processor           // proc helps synchronize only a singular running processing instance
+	err       error                       // error stores permanent error value when done
+	mgr       *Manager                    // mgr instance (access to db / storage)
+	policy    *gtsmodel.DomainMediaPolicy // media policy of the remote domain, if any, set once stored
 }
 
 // EmojiID returns the ID of the underlying emoji without blocking processing.
@@ -121,6 +122,15 @@ func (p *ProcessingEmoji) load(ctx context.Context) (*gtsmodel.Emoji, bool, erro
 			return err
 		}
 
+		// Emojis from domains with a policy not
+		// to cache their media have now been
+		// processed, but aren't kept.
+		if !p.policy.Caches() {
+			if err = p.uncache(ctx); err != nil {
+				return err
+			}
+		}
+
 		if p.existing {
 			// Existing emoji we're updating, so only update.
 			err = p.mgr.state.DB.UpdateEmoji(ctx, p.emoji)
@@ -143,6 +153,21 @@ func (p *ProcessingEmoji) load(ctx context.Context) (*gtsmodel.Emoji, bool, erro
 // and updates the underlying attachment fields as necessary. It will then stream
 // bytes from p's reader directly into storage so that it can be retrieved later.
 func (p *ProcessingEmoji) store(ctx context.Context) error {
+	if !p.emoji.IsLocal() {
+		// Check the media policy of
+		// the remote domain, if any.
+		policy, err := p.mgr.state.DB.MatchDomainMediaPolicy(ctx, p.emoji.Domain)
+		if err != nil {
+			return gtserror.Newf("error matching media policy for %s: %w", p.emoji.Domain, err)
+		}
+
+		if !policy.Fetches() {
+			return gtserror.Newf("%w: %s", ErrRejectedMedia, p.emoji.Domain)
+		}
+
+		p.policy = policy
+	}
+
 	// Load media from provided data fn.
 	rc, sz, err := p.dataFn(ctx)
 	if err != nil {
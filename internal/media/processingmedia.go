@@ -20,7 +20,8 @@ package media
 import (
 	"bytes"
 	"context"
-	"errors"
+	"crypto/sha256"
+	"encoding/hex"
 	"image/jpeg"
 	"io"
 	"time"
@@ -29,11 +30,12 @@ import (
 	"codeberg.org/gruf/go-runners"
 	"codeberg.org/superseriousbusiness/exif-terminator"
 	"github.com/disintegration/imaging"
-	"github.com/h2non/filetype"
+	"github.com/h2non/filetype/types"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/iotools"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
-	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
@@ -42,13 +44,19 @@ import (
 // currently being processed. It exposes functions
 // for retrieving data from the process.
 type ProcessingMedia struct {
-	media   *gtsmodel.MediaAttachment // processing media attachment details
-	dataFn  DataFunc                  // load-data function, returns media stream
-	recache bool                      // recaching existing (uncached) media
-	done    bool                      // done is set when process finishes with non ctx canceled type error
-	proc    runners.Processor         // proc helps synchronize only a singular running processing instance
-	err     error                     // error stores permanent error value when done
-	mgr     *Manager                  // mgr instance (access to db / storage)
+	media   *gtsmodel.MediaAttachment   // processing media attachment details
+	dataFn  DataFunc                    // load-data function, returns media stream
+	recache bool                        // recaching existing (uncached) media
+	done    bool                        // done is set when process finishes with non ctx canceled type error
+	proc    runners.Processor           // proc helps synchronize only a singular running processing instance
+	err     error                       // error stores permanent error value when done
+	mgr     *Manager                    // mgr instance (access to db / storage)
+	gif     *gtsGIF                     // set when media is an animated GIF that's been converted
+	sum     string                      // hex-encoded SHA-256 hash of the data as received, set once stored
+	phash   *uint64                     // perceptual hash of the decoded image, set once finished
+	blocked *gtsmodel.MediaHash         // set when remote media matched a blocklisted hash and was quarantined
+	policy  *gtsmodel.DomainMediaPolicy // media policy of the remote domain, if any, set once stored
+	domain  string                      // domain of the remote account owning the media, if known
 }
 
 // AttachmentID returns the ID of the underlying
@@ -149,16 +157,57 @@ func (p *ProcessingMedia) load(ctx context.Context) (*gtsmodel.MediaAttachment,
 			errs.Append(finishErr)
 		}
 
+		// Check media against the hash blocklist
+		// now that it's been hashed and decoded.
+		if *p.media.Cached {
+			hash, matchErr := p.matchHashes(ctx)
+			switch {
+			case matchErr != nil:
+				errs.Append(matchErr)
+
+			case hash != nil && p.media.RemoteURL == "":
+				// Refuse local media outright,
+				// don't even put it in the db.
+				if releaseErr := p.mgr.ReleaseAttachment(ctx, p.media); releaseErr != nil {
+					errs.Append(releaseErr)
+				}
+				p.media.Cached = util.Ptr(false)
+				errs.Append(gtserror.Newf("%w %s", ErrBlockedMedia, hash.ID))
+				err = errs.Combine()
+				return err
+
+			case hash != nil:
+				// Keep remote media as a
+				// placeholder, but drop it.
+				if quarantineErr := p.quarantine(ctx, hash); quarantineErr != nil {
+					errs.Append(quarantineErr)
+				}
+			}
+		}
+
+		// Media from domains with a policy not to cache
+		// their media has now been processed (for type,
+		// dimensions and blurhash), but isn't kept.
+		if *p.media.Cached && !p.policy.Caches() {
+			if uncacheErr := p.uncache(ctx); uncacheErr != nil {
+				errs.Append(uncacheErr)
+			}
+		}
+
 		// If this isn't a file we were able to process,
 		// we may have partially stored it (eg., it's a
 		// jpeg, which is fine, but streaming it to storage
 		// was interrupted halfway through and so it was
 		// never decoded). Try to clean up in this case.
 		if p.media.Type == gtsmodel.FileTypeUnknown {
-			deleteErr := p.mgr.state.Storage.Delete(ctx, p.media.File.Path)
-			if deleteErr != nil && !errors.Is(deleteErr, storage.ErrNotFound) {
-				errs.Append(deleteErr)
+			releaseErr := p.mgr.ReleaseAttachment(ctx, p.media)
+			if releaseErr != nil {
+				errs.Append(releaseErr)
 			}
+
+			// Files are no longer stored, and
+			// no blob references are held.
+			p.media.Cached = util.Ptr(false)
 		}
 
 		var dbErr error
@@ -173,12 +222,25 @@ func (p *ProcessingMedia) load(ctx context.Context) (*gtsmodel.MediaAttachment,
 			// (We only want to update if everything went OK so far,
 			// otherwise we'd better leave previous version alone.)
 			dbErr = p.mgr.state.DB.UpdateAttachment(ctx, p.media)
+
+		default:
+			// Previous version is being left alone, so
+			// drop any blob references acquired here.
+			dbErr = p.mgr.ReleaseAttachment(ctx, p.media)
 		}
 
 		if dbErr != nil {
 			errs.Append(dbErr)
 		}
 
+		if p.blocked != nil && dbErr == nil {
+			// Let admins know what happened.
+			reportErr := p.mgr.reportQuarantined(ctx, p.media, p.blocked)
+			if reportErr != nil {
+				errs.Append(reportErr)
+			}
+		}
+
 		err = errs.Combine()
 		return err
 	})
@@ -186,10 +248,44 @@ func (p *ProcessingMedia) load(ctx context.Context) (*gtsmodel.MediaAttachment,
 	return p.media, done, err
 }
 
+// mediaPolicy returns the media policy applying to p, checked
+// by domain if it was given, since the owning account may not
+// be stored yet (eg., when fetching a new account's avatar).
+func (p *ProcessingMedia) mediaPolicy(ctx context.Context) (*gtsmodel.DomainMediaPolicy, error) {
+	if p.domain == "" {
+		return p.mgr.AccountMediaPolicy(ctx, p.media.AccountID)
+	}
+
+	policy, err := p.mgr.state.DB.MatchDomainMediaPolicy(ctx, p.domain)
+	if err != nil {
+		return nil, gtserror.Newf("error matching media policy for %s: %w", p.domain, err)
+	}
+
+	return policy, nil
+}
+
 // store calls the data function attached to p if it hasn't been called yet,
 // and updates the underlying attachment fields as necessary. It will then stream
 // bytes from p's reader directly into storage so that it can be retrieved later.
 func (p *ProcessingMedia) store(ctx context.Context) error {
+	if p.media.RemoteURL != "" {
+		// Check the media policy of
+		// the remote domain, if any.
+		policy, err := p.mediaPolicy(ctx)
+		if err != nil {
+			return err
+		}
+
+		if !policy.Fetches() {
+			// Never fetch media from this domain,
+			// keep it as a placeholder instead.
+			log.Debugf(ctx, "not fetching %s due to media policy", p.media.RemoteURL)
+			return nil
+		}
+
+		p.policy = policy
+	}
+
 	// Load media from provided data fun
 	rc, sz, err := p.dataFn(ctx)
 	if err != nil {
@@ -203,12 +299,22 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 		}
 	}()
 
+	// Hash the data as it's received, so
+	// it can be checked against blocklisted
+	// hashes of files (before cleaning them).
+	hash := sha256.New()
+	src := io.TeeReader(rc, hash)
+
 	// Assume we're given correct file
 	// size, we can overwrite this later
 	// once we know THE TRUTH.
 	fileSize := int(sz)
 	p.media.File.FileSize = fileSize
 
+	// Any original kept when previously
+	// caching this media has been released.
+	p.media.File.OriginalPath = ""
+
 	// Prepare to read bytes from
 	// file header or magic number.
 	hdrBuf := newHdrBuf(fileSize)
@@ -224,7 +330,7 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 	//
 	// In other words, rather counterintuitively, we
 	// can only proceed on no error or unexpected error!
-	n, err := io.ReadFull(rc, hdrBuf)
+	n, err := io.ReadFull(src, hdrBuf)
 	if err != nil {
 		if err != io.ErrUnexpectedEOF {
 			return gtserror.Newf("error reading first bytes of incoming media: %w", err)
@@ -244,24 +350,68 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 	// Parse file type info from header buffer.
 	// This should only ever error if the buffer
 	// is empty (ie., the attachment is 0 bytes).
-	info, err := filetype.Match(hdrBuf)
+	info, err := matchFileType(hdrBuf)
 	if err != nil {
 		return gtserror.Newf("error parsing file type: %w", err)
 	}
 
 	// Recombine header bytes with remaining stream
-	r := io.MultiReader(bytes.NewReader(hdrBuf), rc)
+	r := io.MultiReader(bytes.NewReader(hdrBuf), src)
 
 	// Assume we'll put
 	// this file in storage.
 	store := true
 
+	// Set to original file to keep
+	// in storage after converting.
+	var original io.ReadSeeker
+
 	switch info.Extension {
 	case "mp4":
 		// No problem.
 
 	case "gif":
-		// No problem
+		if config.GetMediaGIFTranscode() == config.MediaGIFTranscodeOff {
+			// Keep GIFs as-is.
+			break
+		}
+
+		// We need a readseeker to check for animation,
+		// and to read the GIF again after converting.
+		tfs, err := iotools.TempFileSeeker(r)
+		if err != nil {
+			return gtserror.Newf("error creating temp file seeker: %w", err)
+		}
+
+		defer func() {
+			if err := tfs.Close(); err != nil {
+				log.Errorf(ctx, "error closing temp file seeker: %v", err)
+			}
+		}()
+
+		var converted io.ReadCloser
+		converted, info, err = p.convertGIF(ctx, tfs, info)
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			if err := converted.Close(); err != nil {
+				log.Errorf(ctx, "error closing converted gif: %v", err)
+			}
+		}()
+
+		r = converted
+
+		if p.gif != nil && config.GetMediaGIFKeepOriginal() {
+			original = tfs
+		}
+
+	case "mp3", "ogg", "flac", "wav", "m4a":
+		// No problem, but prefer the registered
+		// audio MIME types over the legacy ones
+		// filetype gives for some (eg. audio/x-flac).
+		info.MIME.Value = audioMIMETypes[info.Extension]
 
 	case "jpg", "jpeg", "png", "webp":
 		if fileSize > 0 {
@@ -273,6 +423,37 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 			}
 		}
 
+	case "heic", "avif":
+		// HEIF metadata items are described by the meta
+		// box, which may be anywhere in the file, so we
+		// need a readseeker to strip or transcode them.
+		tfs, err := iotools.TempFileSeeker(r)
+		if err != nil {
+			return gtserror.Newf("error creating temp file seeker: %w", err)
+		}
+
+		defer func() {
+			if err := tfs.Close(); err != nil {
+				log.Errorf(ctx, "error closing temp file seeker: %v", err)
+			}
+		}()
+
+		if config.GetMediaHEIFTranscode() {
+			// Convert to a widely supported format,
+			// which drops metadata items as it goes.
+			r, info, err = transcodeHEIF(tfs, info.MIME.Value)
+			if err != nil {
+				return gtserror.Newf("error transcoding image: %w", err)
+			}
+		} else {
+			// Keep the original format, but zero out
+			// metadata items as we're streaming it.
+			r, err = stripHEIFMetadata(tfs)
+			if err != nil {
+				return gtserror.Newf("error cleaning metadata: %w", err)
+			}
+		}
+
 	default:
 		// The file is not a supported format that
 		// we can process, so we can't do much with it.
@@ -321,23 +502,17 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 		return nil
 	}
 
-	// File shouldn't already exist in storage at this point,
-	// but we do a check as it's worth logging / cleaning up.
-	if have, _ := p.mgr.state.Storage.Has(ctx, p.media.File.Path); have {
-		log.Warnf(ctx, "media already exists at storage path: %s", p.media.File.Path)
-
-		// Attempt to remove existing media at storage path (might be broken / out-of-date)
-		if err := p.mgr.state.Storage.Delete(ctx, p.media.File.Path); err != nil {
-			return gtserror.Newf("error removing media from storage: %v", err)
-		}
-	}
-
-	// Write the final reader stream to our storage.
-	wroteSize, err := p.mgr.state.Storage.PutStream(ctx, p.media.File.Path, r)
+	// Write the final reader stream to our storage,
+	// sharing the blob with any identical media.
+	path, wroteSize, err := p.mgr.StoreBlob(ctx, r, info.Extension)
 	if err != nil {
 		return gtserror.Newf("error writing media to storage: %w", err)
 	}
 
+	// Media is served from its attachment
+	// URL, but stored under the blob path.
+	p.media.File.Path = path
+
 	// Set actual written size
 	// as authoritative file size.
 	p.media.File.FileSize = int(wroteSize)
@@ -345,9 +520,88 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 	// We can now consider this cached.
 	p.media.Cached = util.Ptr(true)
 
+	// Read anything left unread while storing
+	// (eg., trailing data) to finish the hash.
+	if _, err := io.Copy(io.Discard, src); err != nil {
+		return gtserror.Newf("error reading remaining media data: %w", err)
+	}
+	p.sum = hex.EncodeToString(hash.Sum(nil))
+
+	if original != nil {
+		if _, err := original.Seek(0, io.SeekStart); err != nil {
+			return gtserror.Newf("error seeking original file: %w", err)
+		}
+
+		// Keep the original file in storage too,
+		// as a blob released with the attachment.
+		path, _, err := p.mgr.StoreBlob(ctx, original, mimeGif)
+		if err != nil {
+			return gtserror.Newf("error writing original file to storage: %w", err)
+		}
+
+		p.media.File.OriginalPath = path
+	}
+
 	return nil
 }
 
+// convertGIF converts the GIF read from rs to the configured
+// format if it's animated, decoding its first frame to make a
+// thumbnail from later on. Static GIFs, or animated ones that
+// can't be converted (eg., ffmpeg isn't installed), are kept
+// as they are. Returns a reader of the (maybe converted) file,
+// which must be closed, and its type.
+func (p *ProcessingMedia) convertGIF(ctx context.Context, rs io.ReadSeeker, info types.Type) (io.ReadCloser, types.Type, error) {
+	unconverted := func() (io.ReadCloser, types.Type, error) {
+		if _, err := rs.Seek(0, io.SeekStart); err != nil {
+			return nil, types.Unknown, gtserror.Newf("error seeking gif: %w", err)
+		}
+		return io.NopCloser(rs), info, nil
+	}
+
+	frames, duration, err := scanGIF(rs)
+	if err != nil {
+		// Might still be decodable,
+		// leave that up to finish().
+		log.Warnf(ctx, "error scanning gif: %v", err)
+		return unconverted()
+	}
+
+	if frames < 2 {
+		// Not animated.
+		return unconverted()
+	}
+
+	if _, err := rs.Seek(0, io.SeekStart); err != nil {
+		return nil, types.Unknown, gtserror.Newf("error seeking gif: %w", err)
+	}
+
+	// Decode the first frame as we can't
+	// decode the converted file's frames.
+	frame, err := decodeImage(rs)
+	if err != nil {
+		return nil, types.Unknown, gtserror.Newf("error decoding gif: %w", err)
+	}
+
+	if _, err := rs.Seek(0, io.SeekStart); err != nil {
+		return nil, types.Unknown, gtserror.Newf("error seeking gif: %w", err)
+	}
+
+	converted, convertedInfo, err := transcodeGIF(ctx, rs, config.GetMediaGIFTranscode())
+	if err != nil {
+		log.Warnf(ctx, "error converting animated gif, storing it as-is: %v", err)
+		return unconverted()
+	}
+
+	p.gif = &gtsGIF{
+		frame:     frame,
+		duration:  duration,
+		framerate: float32(frames) / duration,
+	}
+
+	return converted, convertedInfo, nil
+}
+
 func (p *ProcessingMedia) finish(ctx context.Context) error {
 	// Make a jolly assumption about thumbnail type.
 	p.media.Thumbnail.ContentType = mimeImageJpeg
@@ -382,10 +636,112 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 		return nil
 	}
 
+	// fullImg is the processed version of
+	// the original (stripped + reoriented).
+	var fullImg *gtsImage
+
+	if p.gif != nil {
+		// Converted animated GIF, use the first
+		// frame + timing taken from the original.
+		fullImg = p.gif.frame
+		p.gif.frame = nil
+
+		// Set GIF timing in attachment info.
+		p.media.FileMeta.Original.Duration = &p.gif.duration
+		p.media.FileMeta.Original.Framerate = &p.gif.framerate
+
+		// Converted to a silent looping MP4
+		// video (gifv), or an animated WebP
+		// image, which clients treat as a GIF.
+		if p.media.File.ContentType == mimeVideoMp4 {
+			p.media.Type = gtsmodel.FileTypeGifv
+		} else {
+			p.media.Type = gtsmodel.FileTypeImage
+		}
+	} else {
+		var err error
+		fullImg, err = p.decodeFile(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	if p.media.Type != gtsmodel.FileTypeAudio {
+		// Hash the full-size image to check against
+		// blocklisted perceptual hashes. (Audio has
+		// just a preview, which may be a waveform).
+		phash := fullImg.perceptualHash()
+		p.phash = &phash
+
+		// Set full-size dimensions in attachment info,
+		// (audio has none, the image is just a preview).
+		p.media.FileMeta.Original.Width = int(fullImg.Width())
+		p.media.FileMeta.Original.Height = int(fullImg.Height())
+		p.media.FileMeta.Original.Size = int(fullImg.Size())
+		p.media.FileMeta.Original.Aspect = fullImg.AspectRatio()
+	}
+
+	// Get smaller thumbnail image
+	thumbImg := fullImg.Thumbnail()
+
+	// Garbage collector, you may
+	// now take our large son.
+	fullImg = nil
+
+	// Only generate blurhash
+	// from thumb if necessary.
+	if p.media.Blurhash == "" {
+		hash, err := thumbImg.Blurhash()
+		if err != nil {
+			return gtserror.Newf("error generating blurhash: %w", err)
+		}
+
+		// Set the attachment blurhash.
+		p.media.Blurhash = hash
+	}
+
+	// Create a thumbnail JPEG encoder stream.
+	enc := thumbImg.ToJPEG(&jpeg.Options{
+		// Good enough for
+		// a thumbnail.
+		Quality: 70,
+	})
+
+	// Stream-encode the JPEG thumbnail image into storage.
+	path, sz, err := p.mgr.StoreBlob(ctx, enc, "jpg")
+	if err != nil {
+		return gtserror.Newf("error stream-encoding thumbnail to storage: %w", err)
+	}
+
+	// Thumbnail is stored under the blob path.
+	p.media.Thumbnail.Path = path
+
+	// Set thumbnail dimensions in attachment info.
+	p.media.FileMeta.Small = gtsmodel.Small{
+		Width:  int(thumbImg.Width()),
+		Height: int(thumbImg.Height()),
+		Size:   int(thumbImg.Size()),
+		Aspect: thumbImg.AspectRatio(),
+	}
+
+	// Set written image size.
+	p.media.Thumbnail.FileSize = int(sz)
+
+	// Finally set the attachment as processed and update time.
+	p.media.Processing = gtsmodel.ProcessingStatusProcessed
+	p.media.File.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// decodeFile decodes the stored original file of the media,
+// setting its type and any metadata (eg. duration) found
+// along the way, and returns the image to thumbnail it from.
+func (p *ProcessingMedia) decodeFile(ctx context.Context) (*gtsImage, error) {
 	// Get a stream to the original file for further processing.
 	rc, err := p.mgr.state.Storage.GetStream(ctx, p.media.File.Path)
 	if err != nil {
-		return gtserror.Newf("error loading file from storage: %w", err)
+		return nil, gtserror.Newf("error loading file from storage: %w", err)
 	}
 	defer rc.Close()
 
@@ -404,7 +760,7 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 			imaging.AutoOrientation(true),
 		)
 		if err != nil {
-			return gtserror.Newf("error decoding image: %w", err)
+			return nil, gtserror.Newf("error decoding image: %w", err)
 		}
 
 		// Mark as no longer unknown type now
@@ -418,7 +774,18 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 			imaging.AutoOrientation(true),
 		)
 		if err != nil {
-			return gtserror.Newf("error decoding image: %w", err)
+			return nil, gtserror.Newf("error decoding image: %w", err)
+		}
+
+		// Mark as no longer unknown type now
+		// we know for sure we can decode it.
+		p.media.Type = gtsmodel.FileTypeImage
+
+	// .heic, .avif image type
+	case mimeImageHeic, mimeImageAvif:
+		fullImg, err = decodeHEIF(rc, p.media.File.ContentType)
+		if err != nil {
+			return nil, gtserror.Newf("error decoding image: %w", err)
 		}
 
 		// Mark as no longer unknown type now
@@ -429,7 +796,7 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 	case mimeVideoMp4:
 		video, err := decodeVideoFrame(rc)
 		if err != nil {
-			return gtserror.Newf("error decoding video: %w", err)
+			return nil, gtserror.Newf("error decoding video: %w", err)
 		}
 
 		// Set video frame as image.
@@ -443,77 +810,31 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 		// Mark as no longer unknown type now
 		// we know for sure we can decode it.
 		p.media.Type = gtsmodel.FileTypeVideo
-	}
-
-	// fullImg should be in-memory by
-	// now so we're done with storage.
-	if err := rc.Close(); err != nil {
-		return gtserror.Newf("error closing file: %w", err)
-	}
-
-	// Set full-size dimensions in attachment info.
-	p.media.FileMeta.Original.Width = int(fullImg.Width())
-	p.media.FileMeta.Original.Height = int(fullImg.Height())
-	p.media.FileMeta.Original.Size = int(fullImg.Size())
-	p.media.FileMeta.Original.Aspect = fullImg.AspectRatio()
-
-	// Get smaller thumbnail image
-	thumbImg := fullImg.Thumbnail()
-
-	// Garbage collector, you may
-	// now take our large son.
-	fullImg = nil
 
-	// Only generate blurhash
-	// from thumb if necessary.
-	if p.media.Blurhash == "" {
-		hash, err := thumbImg.Blurhash()
+	// .mp3, .ogg, .flac, .wav, .m4a audio type
+	case mimeAudioMpeg, mimeAudioOgg, mimeAudioFlac, mimeAudioWav, mimeAudioMp4:
+		audio, err := decodeAudio(rc, p.media.File.ContentType)
 		if err != nil {
-			return gtserror.Newf("error generating blurhash: %w", err)
+			return nil, gtserror.Newf("error decoding audio: %w", err)
 		}
 
-		// Set the attachment blurhash.
-		p.media.Blurhash = hash
-	}
-
-	// Thumbnail shouldn't already exist in storage at this point,
-	// but we do a check as it's worth logging / cleaning up.
-	if have, _ := p.mgr.state.Storage.Has(ctx, p.media.Thumbnail.Path); have {
-		log.Warnf(ctx, "thumbnail already exists at storage path: %s", p.media.Thumbnail.Path)
-
-		// Attempt to remove existing thumbnail at storage path (might be broken / out-of-date)
-		if err := p.mgr.state.Storage.Delete(ctx, p.media.Thumbnail.Path); err != nil {
-			return gtserror.Newf("error removing thumbnail from storage: %v", err)
-		}
-	}
+		// Set cover art or waveform as image.
+		fullImg = audio.preview
 
-	// Create a thumbnail JPEG encoder stream.
-	enc := thumbImg.ToJPEG(&jpeg.Options{
-		// Good enough for
-		// a thumbnail.
-		Quality: 70,
-	})
+		// Set audio metadata in attachment info.
+		p.media.FileMeta.Original.Duration = &audio.duration
+		p.media.FileMeta.Original.Bitrate = &audio.bitrate
 
-	// Stream-encode the JPEG thumbnail image into storage.
-	sz, err := p.mgr.state.Storage.PutStream(ctx, p.media.Thumbnail.Path, enc)
-	if err != nil {
-		return gtserror.Newf("error stream-encoding thumbnail to storage: %w", err)
+		// Mark as no longer unknown type now
+		// we know for sure we can decode it.
+		p.media.Type = gtsmodel.FileTypeAudio
 	}
 
-	// Set thumbnail dimensions in attachment info.
-	p.media.FileMeta.Small = gtsmodel.Small{
-		Width:  int(thumbImg.Width()),
-		Height: int(thumbImg.Height()),
-		Size:   int(thumbImg.Size()),
-		Aspect: thumbImg.AspectRatio(),
+	// fullImg should be in-memory by
+	// now so we're done with storage.
+	if err := rc.Close(); err != nil {
+		return nil, gtserror.Newf("error closing file: %w", err)
 	}
 
-	// Set written image size.
-	p.media.Thumbnail.FileSize = int(sz)
-
-	// Finally set the attachment as processed and update time.
-	p.media.Processing = gtsmodel.ProcessingStatusProcessed
-	p.media.File.UpdatedAt = time.Now()
-
-	return nil
+	return fullImg, nil
 }
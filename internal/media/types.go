@@ -27,6 +27,7 @@ import (
 const (
 	mimeImage = "image"
 	mimeVideo = "video"
+	mimeAudio = "audio"
 
 	mimeJpeg      = "jpeg"
 	mimeImageJpeg = mimeImage + "/" + mimeJpeg
@@ -40,10 +41,40 @@ const (
 	mimeWebp      = "webp"
 	mimeImageWebp = mimeImage + "/" + mimeWebp
 
+	mimeHeic      = "heic"
+	mimeImageHeic = mimeImage + "/" + mimeHeic
+
+	mimeAvif      = "avif"
+	mimeImageAvif = mimeImage + "/" + mimeAvif
+
 	mimeMp4      = "mp4"
 	mimeVideoMp4 = mimeVideo + "/" + mimeMp4
+
+	mimeMpeg      = "mpeg"
+	mimeAudioMpeg = mimeAudio + "/" + mimeMpeg
+
+	mimeOgg      = "ogg"
+	mimeAudioOgg = mimeAudio + "/" + mimeOgg
+
+	mimeFlac      = "flac"
+	mimeAudioFlac = mimeAudio + "/" + mimeFlac
+
+	mimeWav      = "wav"
+	mimeAudioWav = mimeAudio + "/" + mimeWav
+
+	mimeAudioMp4 = mimeAudio + "/" + mimeMp4
 )
 
+// audioMIMETypes maps supported audio
+// file extensions to their MIME types.
+var audioMIMETypes = map[string]string{
+	"mp3":  mimeAudioMpeg,
+	"ogg":  mimeAudioOgg,
+	"flac": mimeAudioFlac,
+	"wav":  mimeAudioWav,
+	"m4a":  mimeAudioMp4,
+}
+
 type Size string
 
 const (
@@ -70,6 +101,9 @@ type AdditionalMediaInfo struct {
 	StatusID *string
 	// URL of the media on a remote instance; defaults to "".
 	RemoteURL *string
+	// Domain of the remote account owning this media, used to check
+	// its media policy; defaults to looking up the owning account.
+	Domain *string
 	// Image description of this media; defaults to "".
 	Description *string
 	// Blurhash of this media; defaults to "".
@@ -17,6 +17,15 @@
 
 package media
 
+import (
+	"bytes"
+	"io"
+
+	"github.com/h2non/filetype"
+	"github.com/h2non/filetype/matchers"
+	"github.com/h2non/filetype/types"
+)
+
 // newHdrBuf returns a buffer of suitable size to
 // read bytes from a file header or magic number.
 //
@@ -40,3 +49,54 @@ func newHdrBuf(fileSize int) []byte {
 
 	return make([]byte, bufSize)
 }
+
+// matchFileType parses file type info from the given
+// header buffer, as read into a buffer from newHdrBuf.
+// This should only ever error if the buffer is empty.
+func matchFileType(hdrBuf []byte) (types.Type, error) {
+	info, err := filetype.Match(hdrBuf)
+	if err != nil {
+		return types.Unknown, err
+	}
+
+	if info == filetype.Unknown {
+		// filetype only recognizes MP3 files that start with an
+		// ID3 tag or an MPEG-1 layer III frame, so check for the
+		// frame sync of other MPEG audio layers / versions.
+		if _, ok := parseMPEGFrame(hdrBuf); ok {
+			info = matchers.TypeMp3
+		}
+	}
+
+	if info == filetype.Unknown || info == matchers.TypeHeif {
+		// filetype doesn't recognize AVIF images, or
+		// HEIC images without a "heic" brand, so check
+		// the brands of ISOBMFF files for HEIF images.
+		if t, ok := matchHEIF(hdrBuf); ok {
+			info = t
+		}
+	}
+
+	return info, nil
+}
+
+// SniffFileType reads the file header from r, returning its
+// parsed file type info, and a reader which reads the header
+// bytes followed by the rest of r. Unrecognized files are
+// returned as filetype.Unknown, with no error.
+func SniffFileType(r io.Reader) (types.Type, io.Reader, error) {
+	hdrBuf := newHdrBuf(0)
+
+	n, err := io.ReadFull(r, hdrBuf)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return types.Unknown, nil, err
+	}
+	hdrBuf = hdrBuf[:n]
+
+	info, err := matchFileType(hdrBuf)
+	if err != nil {
+		return types.Unknown, nil, err
+	}
+
+	return info, io.MultiReader(bytes.NewReader(hdrBuf), r), nil
+}
@@ -31,5 +31,9 @@ func Gzip() gin.HandlerFunc {
 		return func(ctx *gin.Context) {}
 	}
 
-	return gzip.Gzip(gzip.DefaultCompression)
+	return gzip.Gzip(gzip.DefaultCompression,
+		// Never compress streaming endpoints, as
+		// compression buffers streamed events.
+		gzip.WithExcludedPaths([]string{"/api/v1/streaming"}),
+	)
 }
@@ -29,6 +29,7 @@ import (
 	"net/http"
 	"runtime"
 	"strconv"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -40,6 +41,22 @@ import (
 // token represents a request that is being processed.
 type token struct{}
 
+// throttleReleaseKey is the gin context key
+// under which a throttled request's release
+// function is stored, see ReleaseThrottle().
+const throttleReleaseKey = "gts-throttle-release"
+
+// ReleaseThrottle releases the throttling token held by the
+// current request (if any), removing it from the count of open
+// requests. Long-lived handlers, like event streams, should call
+// this once they're done with any expensive work, so they don't
+// prevent other requests from being processed while they're open.
+func ReleaseThrottle(c *gin.Context) {
+	if release, ok := c.Get(throttleReleaseKey); ok {
+		release.(func())()
+	}
+}
+
 // Throttle returns a gin middleware that performs throttling of incoming requests,
 // ensuring that only a certain number of requests are handled concurrently, to reduce
 // congestion of the server.
@@ -96,12 +113,15 @@ func Throttle(cpuMultiplier int, retryAfter time.Duration) gin.HandlerFunc {
 	}
 
 	return func(c *gin.Context) {
-		// Always decrement request counter.
-		defer func() { requestCount.Add(-1) }()
-
 		// Increment request count.
 		n := requestCount.Add(1)
 
+		// Always decrement request counter,
+		// unless already done by a release.
+		var decrOnce sync.Once
+		decr := func() { requestCount.Add(-1) }
+		defer decrOnce.Do(decr)
+
 		// Check whether the request
 		// count is over queue limit.
 		if n > int64(queueLimit) {
@@ -129,11 +149,20 @@ func Throttle(cpuMultiplier int, retryAfter time.Duration) gin.HandlerFunc {
 			// received a token, allowing
 			// request to be processed.
 
-			defer func() {
-				// when we're finished, return
-				// this token to the bucket.
-				tokens <- tok
-			}()
+			var releaseOnce sync.Once
+			release := func() {
+				releaseOnce.Do(func() {
+					// when we're finished, return
+					// this token to the bucket.
+					tokens <- tok
+					decrOnce.Do(decr)
+				})
+			}
+			defer release()
+
+			// Allow handlers to
+			// release token early.
+			c.Set(throttleReleaseKey, release)
 
 			// Process
 			// request!
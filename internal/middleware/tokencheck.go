@@ -19,6 +19,7 @@ package middleware
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -27,6 +28,10 @@ import (
 	"github.com/superseriousbusiness/oauth2/v4"
 )
 
+// activityInterval is the minimum time between
+// recording token use as user activity.
+const activityInterval = 24 * time.Hour
+
 // TokenCheck returns a new gin middleware for validating oauth tokens in requests.
 //
 // The middleware checks the request Authorization header for a valid oauth Bearer token.
@@ -42,6 +47,9 @@ import (
 // Next, it will look up the *gtsmodel.Account for the User. If the Account has been suspended, then the
 // middleware will return early. Otherwise, it will set the Account on the gin context too.
 //
+// The user's last active time is refreshed at most once per day while they keep using
+// a token, so that instance activity (eg., monthly active users) includes app users.
+//
 // Finally, it will check the client ID of the token to see if a *gtsmodel.Application can be retrieved
 // for that client ID. This will also be set on the gin context.
 //
@@ -135,6 +143,14 @@ func TokenCheck(dbConn db.DB, validateBearerToken func(r *http.Request) (oauth2.
 			}
 
 			c.Set(oauth.SessionAuthorizedAccount, user.Account)
+
+			if time.Since(user.LastActiveAt) > activityInterval {
+				// Record token use as user activity.
+				user.LastActiveAt = time.Now()
+				if err := dbConn.UpdateUser(ctx, user, "last_active_at"); err != nil {
+					log.Errorf(ctx, "error recording activity for user %s: %v", userID, err)
+				}
+			}
 		}
 
 		// check for application token
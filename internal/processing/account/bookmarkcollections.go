@@ -0,0 +1,340 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gorilla/feeds"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// bookmarkCollectionExportBatch is the number of bookmarks
+// to fetch from the database per batch when exporting.
+const bookmarkCollectionExportBatch = 100
+
+// BookmarkCollectionsGet returns all bookmark collections owned by the requesting account.
+func (p *Processor) BookmarkCollectionsGet(ctx context.Context, requestingAccount *gtsmodel.Account) ([]*apimodel.BookmarkCollection, gtserror.WithCode) {
+	collections, err := p.state.DB.GetBookmarkCollectionsByAccountID(ctx, requestingAccount.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting bookmark collections: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiCollections := make([]*apimodel.BookmarkCollection, 0, len(collections))
+	for _, collection := range collections {
+		apiCollection, errWithCode := p.apiBookmarkCollection(ctx, collection)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		apiCollections = append(apiCollections, apiCollection)
+	}
+
+	return apiCollections, nil
+}
+
+// BookmarkCollectionGet returns one bookmark collection owned by the requesting account.
+func (p *Processor) BookmarkCollectionGet(ctx context.Context, requestingAccount *gtsmodel.Account, collectionID string) (*apimodel.BookmarkCollection, gtserror.WithCode) {
+	collection, errWithCode := p.getOwnedBookmarkCollection(ctx, requestingAccount, collectionID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiBookmarkCollection(ctx, collection)
+}
+
+// BookmarkCollectionCreate creates a new bookmark collection with
+// the given title for the requesting account. Title should have
+// already been validated by the time it reaches this function.
+func (p *Processor) BookmarkCollectionCreate(ctx context.Context, requestingAccount *gtsmodel.Account, title string) (*apimodel.BookmarkCollection, gtserror.WithCode) {
+	collection := &gtsmodel.BookmarkCollection{
+		ID:        id.NewULID(),
+		Title:     title,
+		AccountID: requestingAccount.ID,
+	}
+
+	if err := p.state.DB.PutBookmarkCollection(ctx, collection); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			err = errors.New("you already have a bookmark collection with this title")
+			return nil, gtserror.NewErrorConflict(err, err.Error())
+		}
+		err = gtserror.Newf("db error putting bookmark collection: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiBookmarkCollection(ctx, collection)
+}
+
+// BookmarkCollectionUpdate renames the given bookmark collection. Title
+// should have already been validated by the time it reaches this function.
+func (p *Processor) BookmarkCollectionUpdate(ctx context.Context, requestingAccount *gtsmodel.Account, collectionID string, title string) (*apimodel.BookmarkCollection, gtserror.WithCode) {
+	collection, errWithCode := p.getOwnedBookmarkCollection(ctx, requestingAccount, collectionID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	collection.Title = title
+	if err := p.state.DB.UpdateBookmarkCollection(ctx, collection, "title"); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			err = errors.New("you already have a bookmark collection with this title")
+			return nil, gtserror.NewErrorConflict(err, err.Error())
+		}
+		err = gtserror.Newf("db error updating bookmark collection: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiBookmarkCollection(ctx, collection)
+}
+
+// BookmarkCollectionDelete deletes the given bookmark collection. The
+// bookmarks in the collection are left alone, only their entries go.
+func (p *Processor) BookmarkCollectionDelete(ctx context.Context, requestingAccount *gtsmodel.Account, collectionID string) gtserror.WithCode {
+	collection, errWithCode := p.getOwnedBookmarkCollection(ctx, requestingAccount, collectionID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.state.DB.DeleteBookmarkCollectionByID(ctx, collection.ID); err != nil {
+		err = gtserror.Newf("db error deleting bookmark collection: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// BookmarkCollectionAdd assigns the requesting account's bookmarks of the
+// given statuses to the given collection. Each status must already be
+// bookmarked by the requesting account.
+func (p *Processor) BookmarkCollectionAdd(ctx context.Context, requestingAccount *gtsmodel.Account, collectionID string, statusIDs []string) gtserror.WithCode {
+	collection, errWithCode := p.getOwnedBookmarkCollection(ctx, requestingAccount, collectionID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	entries := make([]*gtsmodel.BookmarkCollectionEntry, 0, len(statusIDs))
+	for _, statusID := range statusIDs {
+		bookmarkID, errWithCode := p.getBookmarkID(ctx, requestingAccount, statusID)
+		if errWithCode != nil {
+			return errWithCode
+		}
+
+		entries = append(entries, &gtsmodel.BookmarkCollectionEntry{
+			ID:           id.NewULID(),
+			CollectionID: collection.ID,
+			BookmarkID:   bookmarkID,
+		})
+	}
+
+	if err := p.state.DB.PutBookmarkCollectionEntries(ctx, entries); err != nil {
+		err = gtserror.Newf("db error putting bookmark collection entries: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// BookmarkCollectionRemove removes the requesting account's bookmarks of
+// the given statuses from the given collection. The bookmarks themselves
+// are left alone.
+func (p *Processor) BookmarkCollectionRemove(ctx context.Context, requestingAccount *gtsmodel.Account, collectionID string, statusIDs []string) gtserror.WithCode {
+	collection, errWithCode := p.getOwnedBookmarkCollection(ctx, requestingAccount, collectionID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	for _, statusID := range statusIDs {
+		bookmarkID, errWithCode := p.getBookmarkID(ctx, requestingAccount, statusID)
+		if errWithCode != nil {
+			return errWithCode
+		}
+
+		if err := p.state.DB.DeleteBookmarkCollectionEntry(ctx, collection.ID, bookmarkID); err != nil {
+			err = gtserror.Newf("db error deleting bookmark collection entry: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return nil
+}
+
+// BookmarkCollectionExportJSON returns all statuses bookmarked in the
+// given collection that are still visible to the requesting account,
+// newest bookmark first.
+func (p *Processor) BookmarkCollectionExportJSON(ctx context.Context, requestingAccount *gtsmodel.Account, collectionID string) ([]*apimodel.Status, gtserror.WithCode) {
+	collection, errWithCode := p.getOwnedBookmarkCollection(ctx, requestingAccount, collectionID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiStatuses := make([]*apimodel.Status, 0)
+	errWithCode = p.rangeBookmarkCollection(ctx, requestingAccount, collection, func(status *gtsmodel.Status) {
+		apiStatus, err := p.converter.StatusToAPIStatus(ctx, status, requestingAccount)
+		if err != nil {
+			log.Errorf(ctx, "error converting bookmarked status to api: %v", err)
+			return
+		}
+
+		apiStatuses = append(apiStatuses, apiStatus)
+	})
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return apiStatuses, nil
+}
+
+// BookmarkCollectionExportRSS returns an RSS feed of all statuses
+// bookmarked in the given collection that are still visible to
+// the requesting account, newest bookmark first.
+func (p *Processor) BookmarkCollectionExportRSS(ctx context.Context, requestingAccount *gtsmodel.Account, collectionID string) (string, gtserror.WithCode) {
+	collection, errWithCode := p.getOwnedBookmarkCollection(ctx, requestingAccount, collectionID)
+	if errWithCode != nil {
+		return "", errWithCode
+	}
+
+	feed := &feeds.Feed{
+		Title:       "Bookmarks: " + collection.Title,
+		Description: "Statuses bookmarked by @" + requestingAccount.Username + " in " + collection.Title,
+		Link:        &feeds.Link{Href: requestingAccount.URL},
+		Updated:     collection.UpdatedAt,
+	}
+
+	errWithCode = p.rangeBookmarkCollection(ctx, requestingAccount, collection, func(status *gtsmodel.Status) {
+		item, err := p.converter.StatusToRSSItem(ctx, status)
+		if err != nil {
+			log.Errorf(ctx, "error converting bookmarked status to feed item: %v", err)
+			return
+		}
+
+		feed.Add(item)
+	})
+	if errWithCode != nil {
+		return "", errWithCode
+	}
+
+	return stringifyFeed(feed)
+}
+
+// rangeBookmarkCollection calls fn for each status bookmarked in the
+// given collection that is visible to the requesting account, paging
+// through the collection in batches, newest bookmark first.
+func (p *Processor) rangeBookmarkCollection(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	collection *gtsmodel.BookmarkCollection,
+	fn func(*gtsmodel.Status),
+) gtserror.WithCode {
+	var maxID string
+
+	for {
+		// Page through bookmark IDs rather than loaded
+		// bookmarks, so that a batch of bookmarks which
+		// all fail to load doesn't end the range early.
+		bookmarkIDs, err := p.state.DB.GetBookmarkCollectionBookmarkIDs(ctx,
+			collection.ID,
+			bookmarkCollectionExportBatch,
+			maxID,
+			"",
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err = gtserror.Newf("db error getting bookmark collection bookmark ids: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		if len(bookmarkIDs) == 0 {
+			// Reached the end.
+			return nil
+		}
+
+		// Set the cursor for the next batch.
+		maxID = bookmarkIDs[len(bookmarkIDs)-1]
+
+		for _, bookmarkID := range bookmarkIDs {
+			bookmark, err := p.state.DB.GetStatusBookmark(ctx, bookmarkID)
+			if err != nil {
+				log.Errorf(ctx, "error getting bookmark %s: %v", bookmarkID, err)
+				continue
+			}
+
+			visible, err := p.filter.StatusVisible(ctx, requestingAccount, bookmark.Status)
+			if err != nil {
+				log.Errorf(ctx, "error checking bookmarked status visibility: %v", err)
+				continue
+			}
+
+			if !visible {
+				continue
+			}
+
+			fn(bookmark.Status)
+		}
+	}
+}
+
+// getOwnedBookmarkCollection gets the bookmark collection with the
+// given ID, returning a not found error if it doesn't exist or
+// isn't owned by the requesting account.
+func (p *Processor) getOwnedBookmarkCollection(ctx context.Context, requestingAccount *gtsmodel.Account, collectionID string) (*gtsmodel.BookmarkCollection, gtserror.WithCode) {
+	collection, err := p.state.DB.GetBookmarkCollectionByID(ctx, collectionID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting bookmark collection: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if collection == nil || collection.AccountID != requestingAccount.ID {
+		err := fmt.Errorf("bookmark collection %s not found", collectionID)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	return collection, nil
+}
+
+// getBookmarkID returns the ID of the requesting
+// account's bookmark of the given status.
+func (p *Processor) getBookmarkID(ctx context.Context, requestingAccount *gtsmodel.Account, statusID string) (string, gtserror.WithCode) {
+	bookmarkID, err := p.state.DB.GetStatusBookmarkID(ctx, requestingAccount.ID, statusID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err := fmt.Errorf("status %s is not bookmarked", statusID)
+			return "", gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+
+		err = gtserror.Newf("db error getting bookmark: %w", err)
+		return "", gtserror.NewErrorInternalError(err)
+	}
+
+	return bookmarkID, nil
+}
+
+func (p *Processor) apiBookmarkCollection(ctx context.Context, collection *gtsmodel.BookmarkCollection) (*apimodel.BookmarkCollection, gtserror.WithCode) {
+	apiCollection, err := p.converter.BookmarkCollectionToAPIBookmarkCollection(ctx, collection)
+	if err != nil {
+		err = gtserror.Newf("error converting bookmark collection to api: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiCollection, nil
+}
@@ -0,0 +1,116 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type BookmarkCollectionsTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *BookmarkCollectionsTestSuite) TestCreateAddExportDelete() {
+	var (
+		ctx            = context.Background()
+		requestingAcct = suite.testAccounts["local_account_1"]
+		bookmarked     = suite.testStatuses["admin_account_status_1"]
+	)
+
+	collection, errWithCode := suite.accountProcessor.BookmarkCollectionCreate(ctx, requestingAcct, "Research")
+	suite.NoError(errWithCode)
+	suite.Equal("Research", collection.Title)
+
+	// Titles must be unique per account.
+	_, errWithCode = suite.accountProcessor.BookmarkCollectionCreate(ctx, requestingAcct, "Research")
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	errWithCode = suite.accountProcessor.BookmarkCollectionAdd(ctx, requestingAcct, collection.ID, []string{bookmarked.ID})
+	suite.NoError(errWithCode)
+
+	// Adding again is a no-op.
+	errWithCode = suite.accountProcessor.BookmarkCollectionAdd(ctx, requestingAcct, collection.ID, []string{bookmarked.ID})
+	suite.NoError(errWithCode)
+
+	resp, errWithCode := suite.accountProcessor.BookmarksGet(ctx, requestingAcct, collection.ID, 20, "", "")
+	suite.NoError(errWithCode)
+	suite.Len(resp.Items, 1)
+	suite.Equal(bookmarked.ID, resp.Items[0].(*apimodel.Status).ID)
+	suite.Contains(resp.NextLink, "collection_id="+collection.ID)
+
+	statuses, errWithCode := suite.accountProcessor.BookmarkCollectionExportJSON(ctx, requestingAcct, collection.ID)
+	suite.NoError(errWithCode)
+	suite.Len(statuses, 1)
+	suite.Equal(bookmarked.ID, statuses[0].ID)
+
+	feed, errWithCode := suite.accountProcessor.BookmarkCollectionExportRSS(ctx, requestingAcct, collection.ID)
+	suite.NoError(errWithCode)
+	suite.Contains(feed, "<title>Bookmarks: Research</title>")
+	suite.Contains(feed, bookmarked.URL)
+
+	errWithCode = suite.accountProcessor.BookmarkCollectionRemove(ctx, requestingAcct, collection.ID, []string{bookmarked.ID})
+	suite.NoError(errWithCode)
+
+	resp, errWithCode = suite.accountProcessor.BookmarksGet(ctx, requestingAcct, collection.ID, 20, "", "")
+	suite.NoError(errWithCode)
+	suite.Empty(resp.Items)
+
+	errWithCode = suite.accountProcessor.BookmarkCollectionDelete(ctx, requestingAcct, collection.ID)
+	suite.NoError(errWithCode)
+
+	// The bookmark itself is untouched.
+	resp, errWithCode = suite.accountProcessor.BookmarksGet(ctx, requestingAcct, "", 20, "", "")
+	suite.NoError(errWithCode)
+	suite.Len(resp.Items, 1)
+
+	_, errWithCode = suite.accountProcessor.BookmarkCollectionGet(ctx, requestingAcct, collection.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *BookmarkCollectionsTestSuite) TestAddNotBookmarked() {
+	var (
+		ctx            = context.Background()
+		requestingAcct = suite.testAccounts["local_account_1"]
+		notBookmarked  = suite.testStatuses["local_account_2_status_1"]
+	)
+
+	collection, errWithCode := suite.accountProcessor.BookmarkCollectionCreate(ctx, requestingAcct, "Nope")
+	suite.NoError(errWithCode)
+
+	errWithCode = suite.accountProcessor.BookmarkCollectionAdd(ctx, requestingAcct, collection.ID, []string{notBookmarked.ID})
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func (suite *BookmarkCollectionsTestSuite) TestGetOtherAccountsCollection() {
+	ctx := context.Background()
+
+	collection, errWithCode := suite.accountProcessor.BookmarkCollectionCreate(ctx, suite.testAccounts["local_account_1"], "Mine")
+	suite.NoError(errWithCode)
+
+	_, errWithCode = suite.accountProcessor.BookmarkCollectionGet(ctx, suite.testAccounts["local_account_2"], collection.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestBookmarkCollectionsTestSuite(t *testing.T) {
+	suite.Run(t, new(BookmarkCollectionsTestSuite))
+}
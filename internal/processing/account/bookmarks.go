@@ -30,9 +30,27 @@ import (
 )
 
 // BookmarksGet returns a pageable response of statuses that are bookmarked by requestingAccount.
-// Paging for this response is done based on bookmark ID rather than status ID.
-func (p *Processor) BookmarksGet(ctx context.Context, requestingAccount *gtsmodel.Account, limit int, maxID string, minID string) (*apimodel.PageableResponse, gtserror.WithCode) {
-	bookmarks, err := p.state.DB.GetStatusBookmarks(ctx, requestingAccount.ID, limit, maxID, minID)
+// Paging for this response is done based on bookmark ID rather than status ID. If collectionID
+// is set, only bookmarks in the requesting account's collection with that ID are returned.
+func (p *Processor) BookmarksGet(ctx context.Context, requestingAccount *gtsmodel.Account, collectionID string, limit int, maxID string, minID string) (*apimodel.PageableResponse, gtserror.WithCode) {
+	var (
+		bookmarks   []*gtsmodel.StatusBookmark
+		extraParams []string
+		err         error
+	)
+
+	if collectionID == "" {
+		bookmarks, err = p.state.DB.GetStatusBookmarks(ctx, requestingAccount.ID, limit, maxID, minID)
+	} else {
+		collection, errWithCode := p.getOwnedBookmarkCollection(ctx, requestingAccount, collectionID)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		bookmarks, err = p.state.DB.GetBookmarkCollectionBookmarks(ctx, collection.ID, limit, maxID, minID)
+		extraParams = []string{"collection_id=" + collection.ID}
+	}
+
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
@@ -83,10 +101,11 @@ func (p *Processor) BookmarksGet(ctx context.Context, requestingAccount *gtsmode
 	}
 
 	return util.PackagePageableResponse(util.PageableResponseParams{
-		Items:          items,
-		Path:           "/api/v1/bookmarks",
-		NextMaxIDValue: nextMaxIDValue,
-		PrevMinIDValue: prevMinIDValue,
-		Limit:          limit,
+		Items:            items,
+		Path:             "/api/v1/bookmarks",
+		NextMaxIDValue:   nextMaxIDValue,
+		PrevMinIDValue:   prevMinIDValue,
+		Limit:            limit,
+		ExtraQueryParams: extraParams,
 	})
 }
@@ -465,6 +465,12 @@ func (p *Processor) deleteAccountPeripheral(ctx context.Context, account *gtsmod
 		return gtserror.Newf("error deleting bookmarks targeting account: %w", err)
 	}
 
+	// Delete all bookmark collections owned by given account.
+	if err := p.state.DB.DeleteBookmarkCollectionsByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting bookmark collections by account: %w", err)
+	}
+
 	// Delete all faves owned by given account.
 	if err := p.state.DB.DeleteStatusFaves(ctx, account.ID, ""); // nocollapse
 	err != nil && !errors.Is(err, db.ErrNoEntries) {
@@ -485,6 +491,43 @@ func (p *Processor) deleteAccountPeripheral(ctx context.Context, account *gtsmod
 		return gtserror.Newf("error deleting poll votes by account: %w", err)
 	}
 
+	// Delete all endorsements owned by or targeting given account.
+	if err := p.state.DB.DeleteAccountEndorsements(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting endorsements by or targeting account: %w", err)
+	}
+
+	// Delete all hashtags featured by given account.
+	if err := p.state.DB.DeleteAccountFeaturedTags(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting featured tags by account: %w", err)
+	}
+
+	// Delete all hashtags followed by given account.
+	if err := p.state.DB.DeleteAccountFollowedTags(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting followed tags by account: %w", err)
+	}
+
+	// Delete all push subscriptions owned by given account.
+	if err := p.state.DB.DeleteWebPushSubscriptionsByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting push subscriptions by account: %w", err)
+	}
+
+	// Delete notification policy of given account, and
+	// notification requests + permissions to or from it.
+	if err := p.state.DB.DeleteAccountNotificationPolicyData(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting notification policy data by account: %w", err)
+	}
+
+	// Delete home timeline of given account,
+	// including any persisted timeline entries.
+	if err := p.state.Timelines.Home.RemoveTimeline(ctx, account.ID); err != nil {
+		return gtserror.Newf("error deleting home timeline: %w", err)
+	}
+
 	return nil
 }
 
@@ -584,6 +627,7 @@ func stubbifyUser(user *gtsmodel.User) ([]string, error) {
 	user.LastSignInAt = never
 	user.LastSignInIP = net.IPv4zero
 	user.SignInCount = 1
+	user.LastActiveAt = never
 	user.Locale = ""
 	user.CreatedByApplicationID = ""
 	user.LastEmailedAt = never
@@ -600,6 +644,7 @@ func stubbifyUser(user *gtsmodel.User) ([]string, error) {
 		"last_sign_in_at",
 		"last_sign_in_ip",
 		"sign_in_count",
+		"last_active_at",
 		"locale",
 		"created_by_application_id",
 		"last_emailed_at",
@@ -0,0 +1,202 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// EndorsementCreate handles the requesting account endorsing
+// (featuring on its profile) the target account. The requesting
+// account must already be following the target account.
+func (p *Processor) EndorsementCreate(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode) {
+	targetAccount, errWithCode := p.getEndorsementTarget(ctx, requestingAccount, targetAccountID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	following, err := p.state.DB.IsFollowing(ctx, requestingAccount.ID, targetAccount.ID)
+	if err != nil {
+		err = gtserror.Newf("db error checking following: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if !following {
+		const text = "you must be following this account to endorse it"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	endorsed, err := p.state.DB.IsEndorsed(ctx, requestingAccount.ID, targetAccount.ID)
+	if err != nil {
+		err = gtserror.Newf("db error checking endorsed: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if endorsed {
+		// Already endorsed,
+		// nothing to do.
+		return p.RelationshipGet(ctx, requestingAccount, targetAccount.ID)
+	}
+
+	if err := p.state.DB.PutEndorsement(ctx, &gtsmodel.Endorsement{
+		ID:              id.NewULID(),
+		AccountID:       requestingAccount.ID,
+		TargetAccountID: targetAccount.ID,
+	}); err != nil {
+		err = gtserror.Newf("db error putting endorsement: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Federate the change to the account's profile.
+	p.federateProfileUpdate(ctx, requestingAccount)
+
+	return p.RelationshipGet(ctx, requestingAccount, targetAccount.ID)
+}
+
+// EndorsementRemove handles the requesting account
+// no longer endorsing the target account, if it was.
+func (p *Processor) EndorsementRemove(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode) {
+	targetAccount, errWithCode := p.getEndorsementTarget(ctx, requestingAccount, targetAccountID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	endorsed, err := p.state.DB.IsEndorsed(ctx, requestingAccount.ID, targetAccount.ID)
+	if err != nil {
+		err = gtserror.Newf("db error checking endorsed: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if !endorsed {
+		// Not endorsed,
+		// nothing to do.
+		return p.RelationshipGet(ctx, requestingAccount, targetAccount.ID)
+	}
+
+	err = p.state.DB.DeleteEndorsement(ctx, requestingAccount.ID, targetAccount.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error deleting endorsement: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Federate the change to the account's profile.
+	p.federateProfileUpdate(ctx, requestingAccount)
+
+	return p.RelationshipGet(ctx, requestingAccount, targetAccount.ID)
+}
+
+// EndorsementsGet returns a page of accounts endorsed by the requesting account.
+func (p *Processor) EndorsementsGet(ctx context.Context, requestingAccount *gtsmodel.Account, page *paging.Page) (*apimodel.PageableResponse, gtserror.WithCode) {
+	return p.endorsementsGet(ctx, requestingAccount, requestingAccount.ID, "/api/v1/endorsements", page)
+}
+
+// AccountEndorsementsGet returns a page of accounts endorsed by the target account,
+// filtered by visibility according to the requesting account (which may be nil).
+func (p *Processor) AccountEndorsementsGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string, page *paging.Page) (*apimodel.PageableResponse, gtserror.WithCode) {
+	// Fetch target account to check it exists, and visibility of requester->target.
+	_, errWithCode := p.c.GetVisibleTargetAccount(ctx, requestingAccount, targetAccountID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.endorsementsGet(ctx, requestingAccount, targetAccountID, "/api/v1/accounts/"+targetAccountID+"/endorsements", page)
+}
+
+// WebEndorsementsGet returns accounts endorsed by the target account,
+// suitable for serving via the web profile page. Only accounts visible
+// to the unauthenticated web viewer are returned.
+func (p *Processor) WebEndorsementsGet(ctx context.Context, targetAccountID string) ([]*apimodel.Account, gtserror.WithCode) {
+	endorsements, err := p.state.DB.GetAccountEndorsements(ctx, targetAccountID, &paging.Page{Limit: 40})
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting endorsements: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.c.GetVisibleAPIAccounts(ctx,
+		nil,
+		func(i int) *gtsmodel.Account { return endorsements[i].TargetAccount },
+		len(endorsements),
+	), nil
+}
+
+func (p *Processor) endorsementsGet(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	accountID string,
+	path string,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	endorsements, err := p.state.DB.GetAccountEndorsements(ctx, accountID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting endorsements: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Check for empty response.
+	count := len(endorsements)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := endorsements[count-1].ID
+	hi := endorsements[0].ID
+
+	// Func to fetch endorsement target at index.
+	getIdx := func(i int) *gtsmodel.Account {
+		return endorsements[i].TargetAccount
+	}
+
+	// Get a filtered slice of public API account models.
+	items := p.c.GetVisibleAPIAccountsPaged(ctx,
+		requestingAccount,
+		getIdx,
+		len(endorsements),
+	)
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  path,
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// getEndorsementTarget is a convenience function which:
+//   - Checks if account is trying to endorse/unendorse itself.
+//   - Returns not found if target should not be visible to requester.
+//   - Returns target account according to its id.
+func (p *Processor) getEndorsementTarget(ctx context.Context, requester *gtsmodel.Account, targetID string) (*gtsmodel.Account, gtserror.WithCode) {
+	// Account can't endorse or unendorse itself.
+	if requester.ID == targetID {
+		const text = "account can't endorse or unendorse itself"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	// Fetch the target account for requesting user account.
+	return p.c.GetVisibleTargetAccount(ctx, requester, targetID)
+}
@@ -0,0 +1,119 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+type EndorsementsTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *EndorsementsTestSuite) TestEndorseUnendorse() {
+	var (
+		ctx            = context.Background()
+		requestingAcct = suite.testAccounts["local_account_1"]
+		targetAcct     = suite.testAccounts["admin_account"]
+	)
+
+	// Endorse an account we follow.
+	relationship, errWithCode := suite.accountProcessor.EndorsementCreate(ctx, requestingAcct, targetAcct.ID)
+	suite.NoError(errWithCode)
+	suite.True(relationship.Endorsed)
+
+	// Profile update should be federated.
+	suite.checkProfileUpdate(requestingAcct.ID)
+
+	// Endorsing again is a no-op.
+	relationship, errWithCode = suite.accountProcessor.EndorsementCreate(ctx, requestingAcct, targetAcct.ID)
+	suite.NoError(errWithCode)
+	suite.True(relationship.Endorsed)
+	suite.Empty(suite.fromClientAPIChan)
+
+	resp, errWithCode := suite.accountProcessor.EndorsementsGet(ctx, requestingAcct, &paging.Page{Limit: 40})
+	suite.NoError(errWithCode)
+	suite.Len(resp.Items, 1)
+	suite.Equal(targetAcct.ID, resp.Items[0].(*apimodel.Account).ID)
+
+	// Visible to others too.
+	resp, errWithCode = suite.accountProcessor.AccountEndorsementsGet(ctx, nil, requestingAcct.ID, &paging.Page{Limit: 40})
+	suite.NoError(errWithCode)
+	suite.Len(resp.Items, 1)
+
+	relationship, errWithCode = suite.accountProcessor.EndorsementRemove(ctx, requestingAcct, targetAcct.ID)
+	suite.NoError(errWithCode)
+	suite.False(relationship.Endorsed)
+	suite.checkProfileUpdate(requestingAcct.ID)
+
+	resp, errWithCode = suite.accountProcessor.EndorsementsGet(ctx, requestingAcct, &paging.Page{Limit: 40})
+	suite.NoError(errWithCode)
+	suite.Empty(resp.Items)
+
+	// Unendorsing again is a no-op.
+	relationship, errWithCode = suite.accountProcessor.EndorsementRemove(ctx, requestingAcct, targetAcct.ID)
+	suite.NoError(errWithCode)
+	suite.False(relationship.Endorsed)
+	suite.Empty(suite.fromClientAPIChan)
+}
+
+// checkProfileUpdate checks that the next client API message
+// is a profile Update of the account with the given ID.
+func (suite *EndorsementsTestSuite) checkProfileUpdate(accountID string) {
+	msg := <-suite.fromClientAPIChan
+	suite.Equal(ap.ObjectProfile, msg.APObjectType)
+	suite.Equal(ap.ActivityUpdate, msg.APActivityType)
+	suite.Equal(accountID, msg.OriginAccount.ID)
+}
+
+func (suite *EndorsementsTestSuite) TestEndorseNotFollowing() {
+	var (
+		ctx            = context.Background()
+		requestingAcct = suite.testAccounts["local_account_2"]
+		targetAcct     = suite.testAccounts["admin_account"]
+	)
+
+	_, errWithCode := suite.accountProcessor.EndorsementCreate(ctx, requestingAcct, targetAcct.ID)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func (suite *EndorsementsTestSuite) TestUnfollowRemovesEndorsement() {
+	var (
+		ctx            = context.Background()
+		requestingAcct = suite.testAccounts["local_account_1"]
+		targetAcct     = suite.testAccounts["local_account_2"]
+	)
+
+	_, errWithCode := suite.accountProcessor.EndorsementCreate(ctx, requestingAcct, targetAcct.ID)
+	suite.NoError(errWithCode)
+
+	relationship, errWithCode := suite.accountProcessor.FollowRemove(ctx, requestingAcct, targetAcct.ID)
+	suite.NoError(errWithCode)
+	suite.False(relationship.Endorsed)
+}
+
+func TestEndorsementsTestSuite(t *testing.T) {
+	suite.Run(t, new(EndorsementsTestSuite))
+}
@@ -0,0 +1,258 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// ExportFollowing returns a CSV of accounts followed
+// by the requesting account, in the same format used
+// by Mastodon's following_accounts.csv export.
+func (p *Processor) ExportFollowing(ctx context.Context, requestingAccount *gtsmodel.Account) ([][]string, gtserror.WithCode) {
+	follows, err := p.state.DB.GetAccountFollows(ctx, requestingAccount.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting follows: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	records := make([][]string, 0, len(follows)+1)
+	records = append(records, []string{
+		"Account address",
+		"Show boosts",
+		"Notify on new posts",
+		"Languages",
+	})
+
+	for _, follow := range follows {
+		target, err := p.exportAccount(ctx, follow.TargetAccount, follow.TargetAccountID)
+		if err != nil {
+			log.Errorf(ctx, "error getting follow target: %v", err)
+			continue
+		}
+
+		records = append(records, []string{
+			accountAddress(target),
+			strconv.FormatBool(*follow.ShowReblogs),
+			strconv.FormatBool(*follow.Notify),
+			"", // We don't do per-follow languages.
+		})
+	}
+
+	return records, nil
+}
+
+// ExportFollowers returns a CSV of accounts
+// following the requesting account.
+func (p *Processor) ExportFollowers(ctx context.Context, requestingAccount *gtsmodel.Account) ([][]string, gtserror.WithCode) {
+	follows, err := p.state.DB.GetAccountFollowers(ctx, requestingAccount.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting followers: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	records := make([][]string, 0, len(follows)+1)
+	records = append(records, []string{
+		"Account address",
+	})
+
+	for _, follow := range follows {
+		origin, err := p.exportAccount(ctx, follow.Account, follow.AccountID)
+		if err != nil {
+			log.Errorf(ctx, "error getting follow origin: %v", err)
+			continue
+		}
+
+		records = append(records, []string{
+			accountAddress(origin),
+		})
+	}
+
+	return records, nil
+}
+
+// ExportBlocks returns a CSV of accounts blocked by the
+// requesting account, in the same (header-less) format
+// used by Mastodon's blocked_accounts.csv export.
+func (p *Processor) ExportBlocks(ctx context.Context, requestingAccount *gtsmodel.Account) ([][]string, gtserror.WithCode) {
+	blocks, err := p.state.DB.GetAccountBlocks(ctx, requestingAccount.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting blocks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	records := make([][]string, 0, len(blocks))
+	for _, block := range blocks {
+		target, err := p.exportAccount(ctx, block.TargetAccount, block.TargetAccountID)
+		if err != nil {
+			log.Errorf(ctx, "error getting block target: %v", err)
+			continue
+		}
+
+		records = append(records, []string{
+			accountAddress(target),
+		})
+	}
+
+	return records, nil
+}
+
+// ExportLists returns a CSV of list titles and the
+// accounts they contain, in the same (header-less)
+// format used by Mastodon's lists.csv export.
+func (p *Processor) ExportLists(ctx context.Context, requestingAccount *gtsmodel.Account) ([][]string, gtserror.WithCode) {
+	lists, err := p.state.DB.GetListsForAccountID(ctx, requestingAccount.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting lists: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	records := make([][]string, 0)
+	for _, list := range lists {
+		entries, err := p.state.DB.GetListEntries(ctx, list.ID, "", "", "", 0)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err = gtserror.Newf("db error getting entries of list %s: %w", list.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		for _, entry := range entries {
+			if err := p.state.DB.PopulateListEntry(ctx, entry); err != nil {
+				log.Errorf(ctx, "error populating list entry: %v", err)
+				continue
+			}
+
+			target, err := p.exportAccount(ctx,
+				entry.Follow.TargetAccount,
+				entry.Follow.TargetAccountID,
+			)
+			if err != nil {
+				log.Errorf(ctx, "error getting list entry target: %v", err)
+				continue
+			}
+
+			records = append(records, []string{
+				list.Title,
+				accountAddress(target),
+			})
+		}
+	}
+
+	return records, nil
+}
+
+// ExportBookmarks returns a CSV of URIs of statuses
+// bookmarked by the requesting account, in the same
+// format used by Mastodon's bookmarks.csv export.
+func (p *Processor) ExportBookmarks(ctx context.Context, requestingAccount *gtsmodel.Account) ([][]string, gtserror.WithCode) {
+	records := make([][]string, 0)
+
+	// Page down through all
+	// bookmarks, newest first.
+	var maxID string
+	for {
+		bookmarks, err := p.state.DB.GetStatusBookmarks(ctx, requestingAccount.ID, 100, maxID, "")
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err = gtserror.Newf("db error getting bookmarks: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if len(bookmarks) == 0 {
+			// Reached the end.
+			break
+		}
+
+		for _, bookmark := range bookmarks {
+			status, err := p.state.DB.GetStatusByID(
+				gtscontext.SetBarebones(ctx),
+				bookmark.StatusID,
+			)
+			if err != nil {
+				log.Errorf(ctx, "error getting bookmarked status: %v", err)
+				continue
+			}
+
+			records = append(records, []string{
+				status.URI,
+			})
+		}
+
+		// Next page starts from
+		// the oldest bookmark seen.
+		maxID = bookmarks[len(bookmarks)-1].ID
+	}
+
+	return records, nil
+}
+
+// ExportFollowedTags returns a (header-less) CSV
+// of names of hashtags followed by the requesting
+// account, with the leading # included.
+func (p *Processor) ExportFollowedTags(ctx context.Context, requestingAccount *gtsmodel.Account) ([][]string, gtserror.WithCode) {
+	followedTags, err := p.state.DB.GetAccountFollowedTags(ctx, requestingAccount.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting followed tags: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	records := make([][]string, 0, len(followedTags))
+	for _, followedTag := range followedTags {
+		if followedTag.Tag == nil {
+			log.Errorf(ctx, "tag %s of followed tag not found", followedTag.TagID)
+			continue
+		}
+
+		records = append(records, []string{
+			"#" + followedTag.Tag.Name,
+		})
+	}
+
+	return records, nil
+}
+
+// exportAccount returns the given account if set, else
+// fetching a barebones account model by the given ID.
+func (p *Processor) exportAccount(ctx context.Context, account *gtsmodel.Account, accountID string) (*gtsmodel.Account, error) {
+	if account != nil {
+		return account, nil
+	}
+
+	return p.state.DB.GetAccountByID(
+		gtscontext.SetBarebones(ctx),
+		accountID,
+	)
+}
+
+// accountAddress returns the username@domain
+// address of the given account, using the
+// configured account domain for local accounts.
+func accountAddress(account *gtsmodel.Account) string {
+	domain := account.Domain
+	if domain == "" {
+		domain = config.GetAccountDomain()
+	}
+	return account.Username + "@" + domain
+}
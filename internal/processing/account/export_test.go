@@ -0,0 +1,69 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ExportTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *ExportTestSuite) TestExportFollowing() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["local_account_1"]
+
+	records, errWithCode := suite.accountProcessor.ExportFollowing(ctx, requestingAccount)
+	suite.NoError(errWithCode)
+	suite.Equal([][]string{
+		{"Account address", "Show boosts", "Notify on new posts", "Languages"},
+		{"1happyturtle@localhost:8080", "true", "false", ""},
+		{"admin@localhost:8080", "true", "false", ""},
+	}, records)
+}
+
+func (suite *ExportTestSuite) TestExportLists() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["local_account_1"]
+
+	records, errWithCode := suite.accountProcessor.ExportLists(ctx, requestingAccount)
+	suite.NoError(errWithCode)
+	suite.ElementsMatch([][]string{
+		{"Cool Ass Posters From This Instance", "1happyturtle@localhost:8080"},
+		{"Cool Ass Posters From This Instance", "admin@localhost:8080"},
+	}, records)
+}
+
+func (suite *ExportTestSuite) TestExportBlocks() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["local_account_2"]
+
+	records, errWithCode := suite.accountProcessor.ExportBlocks(ctx, requestingAccount)
+	suite.NoError(errWithCode)
+	suite.Equal([][]string{
+		{"foss_satan@fossbros-anonymous.io"},
+	}, records)
+}
+
+func TestExportTestSuite(t *testing.T) {
+	suite.Run(t, new(ExportTestSuite))
+}
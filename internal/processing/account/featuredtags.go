@@ -0,0 +1,224 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+// maxFeaturedTags is the maximum number of hashtags
+// one account may feature on their profile. Should
+// match max_featured_tags served in instance info.
+const maxFeaturedTags = 10
+
+// FeaturedTagsGet returns the hashtags featured by the requesting account.
+func (p *Processor) FeaturedTagsGet(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+) ([]*apimodel.FeaturedTag, gtserror.WithCode) {
+	return p.featuredTagsGet(ctx, requestingAccount.ID)
+}
+
+// AccountFeaturedTagsGet returns the hashtags featured by the target
+// account, or an empty slice if a block exists between requester and target.
+func (p *Processor) AccountFeaturedTagsGet(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	targetAccountID string,
+) ([]*apimodel.FeaturedTag, gtserror.WithCode) {
+	targetAccount, err := p.state.DB.GetAccountByID(ctx, targetAccountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err := fmt.Errorf("account %s not found", targetAccountID)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+		err := gtserror.Newf("db error getting account %s: %w", targetAccountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if requestingAccount != nil {
+		blocked, err := p.state.DB.IsEitherBlocked(ctx, requestingAccount.ID, targetAccount.ID)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if blocked {
+			// Block exists between accounts.
+			// Just return empty featured tags.
+			return []*apimodel.FeaturedTag{}, nil
+		}
+	}
+
+	return p.featuredTagsGet(ctx, targetAccount.ID)
+}
+
+func (p *Processor) featuredTagsGet(ctx context.Context, accountID string) ([]*apimodel.FeaturedTag, gtserror.WithCode) {
+	featuredTags, err := p.state.DB.GetAccountFeaturedTags(ctx, accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting featured tags for account %s: %w", accountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiFeaturedTags := make([]*apimodel.FeaturedTag, 0, len(featuredTags))
+	for _, featuredTag := range featuredTags {
+		if featuredTag.Tag == nil {
+			// Tag was deleted
+			// out from under us.
+			continue
+		}
+
+		apiFeaturedTag, err := p.converter.FeaturedTagToAPIFeaturedTag(ctx, featuredTag)
+		if err != nil {
+			log.Errorf(ctx, "error converting featured tag to api featured tag: %v", err)
+			continue
+		}
+		apiFeaturedTags = append(apiFeaturedTags, apiFeaturedTag)
+	}
+
+	return apiFeaturedTags, nil
+}
+
+// FeaturedTagCreate features the given hashtag on
+// the requesting account's profile, creating the
+// hashtag in the database if it doesn't exist yet.
+func (p *Processor) FeaturedTagCreate(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	name string,
+) (*apimodel.FeaturedTag, gtserror.WithCode) {
+	normalized, ok := text.NormalizeHashtag(name)
+	if !ok {
+		err := fmt.Errorf("invalid hashtag name: %s", name)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	featuredTags, err := p.state.DB.GetAccountFeaturedTags(ctx, requestingAccount.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting featured tags: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if len(featuredTags) >= maxFeaturedTags {
+		err := fmt.Errorf("you cannot feature more than %d hashtags", maxFeaturedTags)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	tag, err := p.state.DB.GetTagByName(ctx, normalized)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting tag %s: %w", normalized, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if tag == nil {
+		// We didn't have a tag with
+		// this name, create one.
+		tag = &gtsmodel.Tag{
+			ID:   id.NewULID(),
+			Name: normalized,
+		}
+
+		if err := p.state.DB.PutTag(ctx, tag); err != nil {
+			err := gtserror.Newf("db error putting new tag %s: %w", normalized, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	for _, featuredTag := range featuredTags {
+		if featuredTag.TagID == tag.ID {
+			err := fmt.Errorf("hashtag %s is already featured", normalized)
+			return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+	}
+
+	featuredTag := &gtsmodel.FeaturedTag{
+		ID:        id.NewULID(),
+		AccountID: requestingAccount.ID,
+		Account:   requestingAccount,
+		TagID:     tag.ID,
+		Tag:       tag,
+	}
+
+	if err := p.state.DB.PutFeaturedTag(ctx, featuredTag); err != nil {
+		err := gtserror.Newf("db error putting featured tag: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Federate the change to the account's profile.
+	p.federateProfileUpdate(ctx, requestingAccount)
+
+	apiFeaturedTag, err := p.converter.FeaturedTagToAPIFeaturedTag(ctx, featuredTag)
+	if err != nil {
+		err := gtserror.Newf("error converting featured tag: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiFeaturedTag, nil
+}
+
+// FeaturedTagDelete stops featuring the given featured
+// tag ID on the requesting account's profile.
+func (p *Processor) FeaturedTagDelete(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	featuredTagID string,
+) gtserror.WithCode {
+	featuredTag, err := p.state.DB.GetFeaturedTagByID(ctx, featuredTagID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting featured tag %s: %w", featuredTagID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if featuredTag == nil || featuredTag.AccountID != requestingAccount.ID {
+		// Don't leak existence of other accounts' featured tags.
+		err := fmt.Errorf("featured tag %s not found", featuredTagID)
+		return gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	if err := p.state.DB.DeleteFeaturedTagByID(ctx, featuredTag.ID); err != nil {
+		err := gtserror.Newf("db error deleting featured tag %s: %w", featuredTag.ID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Federate the change to the account's profile.
+	p.federateProfileUpdate(ctx, requestingAccount)
+
+	return nil
+}
+
+// federateProfileUpdate enqueues an Update of the given
+// account's profile, to be sent out to its followers.
+func (p *Processor) federateProfileUpdate(ctx context.Context, account *gtsmodel.Account) {
+	p.state.Workers.EnqueueClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ObjectProfile,
+		APActivityType: ap.ActivityUpdate,
+		GTSModel:       account,
+		OriginAccount:  account,
+	})
+}
@@ -0,0 +1,85 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FeaturedTagsTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *FeaturedTagsTestSuite) TestFeaturedTagCreateDelete() {
+	var (
+		ctx            = context.Background()
+		requestingAcct = suite.testAccounts["admin_account"]
+	)
+
+	// Feature an existing hashtag used by admin.
+	featuredTag, errWithCode := suite.accountProcessor.FeaturedTagCreate(ctx, requestingAcct, "#Welcome")
+	suite.NoError(errWithCode)
+	suite.Equal("welcome", featuredTag.Name)
+	suite.Equal("http://localhost:8080/tags/welcome", featuredTag.URL)
+	suite.Equal(1, featuredTag.StatusesCount)
+	suite.NotNil(featuredTag.LastStatusAt)
+
+	// Featuring it again should fail.
+	_, errWithCode = suite.accountProcessor.FeaturedTagCreate(ctx, requestingAcct, "welcome")
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	// Feature a brand new hashtag.
+	newTag, errWithCode := suite.accountProcessor.FeaturedTagCreate(ctx, requestingAcct, "somethingnew")
+	suite.NoError(errWithCode)
+	suite.Equal("somethingnew", newTag.Name)
+	suite.Zero(newTag.StatusesCount)
+	suite.Nil(newTag.LastStatusAt)
+
+	// Invalid hashtag should fail.
+	_, errWithCode = suite.accountProcessor.FeaturedTagCreate(ctx, requestingAcct, "not a tag!")
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	featuredTags, errWithCode := suite.accountProcessor.FeaturedTagsGet(ctx, requestingAcct)
+	suite.NoError(errWithCode)
+	suite.Len(featuredTags, 2)
+
+	// Another account can see them too.
+	featuredTags, errWithCode = suite.accountProcessor.AccountFeaturedTagsGet(ctx, suite.testAccounts["local_account_1"], requestingAcct.ID)
+	suite.NoError(errWithCode)
+	suite.Len(featuredTags, 2)
+
+	// Another account can't delete them.
+	errWithCode = suite.accountProcessor.FeaturedTagDelete(ctx, suite.testAccounts["local_account_1"], featuredTag.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	errWithCode = suite.accountProcessor.FeaturedTagDelete(ctx, requestingAcct, featuredTag.ID)
+	suite.NoError(errWithCode)
+
+	featuredTags, errWithCode = suite.accountProcessor.FeaturedTagsGet(ctx, requestingAcct)
+	suite.NoError(errWithCode)
+	suite.Len(featuredTags, 1)
+	suite.Equal("somethingnew", featuredTags[0].Name)
+}
+
+func TestFeaturedTagsTestSuite(t *testing.T) {
+	suite.Run(t, new(FeaturedTagsTestSuite))
+}
@@ -218,6 +218,16 @@ func (p *Processor) getFollowTarget(ctx context.Context, requester *gtsmodel.Acc
 	return p.c.GetVisibleTargetAccount(ctx, requester, targetID)
 }
 
+// Unfollow has requesting account unfollow (and un follow request)
+// target account, removing any endorsement along with the follow.
+// Unlike FollowRemove, no side effects are processed, so this is
+// for callers where the other side already knows, eg., when
+// handling a block received from one of the accounts.
+func (p *Processor) Unfollow(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccount *gtsmodel.Account) error {
+	_, err := p.unfollow(ctx, requestingAccount, targetAccount)
+	return err
+}
+
 // unfollow is a convenience function for having requesting account
 // unfollow (and un follow request) target account, if follows and/or
 // follow requests exist.
@@ -250,6 +260,31 @@ func (p *Processor) unfollow(ctx context.Context, requestingAccount *gtsmodel.Ac
 			return msgs, nil
 		}
 
+		// Accounts can only endorse accounts they
+		// follow, so remove endorsement if present.
+		endorsed, err := p.state.DB.IsEndorsed(ctx, requestingAccount.ID, targetAccount.ID)
+		if err != nil {
+			err = gtserror.Newf("error checking endorsement from %s targeting %s: %w", requestingAccount.ID, targetAccount.ID, err)
+			return nil, err
+		}
+
+		if endorsed {
+			err = p.state.DB.DeleteEndorsement(ctx, requestingAccount.ID, targetAccount.ID)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				err = gtserror.Newf("error deleting endorsement from %s targeting %s: %w", requestingAccount.ID, targetAccount.ID, err)
+				return nil, err
+			}
+
+			// Endorsements are shown on the
+			// profile, so federate the change.
+			msgs = append(msgs, messages.FromClientAPI{
+				APObjectType:   ap.ObjectProfile,
+				APActivityType: ap.ActivityUpdate,
+				GTSModel:       requestingAccount,
+				OriginAccount:  requestingAccount,
+			})
+		}
+
 		// Follow status changed, process side effects.
 		msgs = append(msgs, messages.FromClientAPI{
 			APObjectType:   ap.ActivityFollow,
@@ -0,0 +1,718 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"codeberg.org/gruf/go-bytesize"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	// maxImportSize is the maximum
+	// accepted size of a CSV import file.
+	maxImportSize = 2 * bytesize.MiB
+
+	// maxImportEntries is the maximum number
+	// of entries accepted in one CSV import.
+	maxImportEntries = 20000
+
+	// maxImportErrors is the maximum number
+	// of error strings stored on an import.
+	maxImportErrors = 100
+
+	// importUpdateEvery is how many entries are
+	// processed between storing import progress.
+	importUpdateEvery = 25
+)
+
+// importEntry is one parsed line of an import CSV.
+type importEntry struct {
+	address     string // Account address (following, blocks, lists).
+	showReblogs *bool  // Show boosts (following only).
+	notify      *bool  // Notify on new posts (following only).
+	listTitle   string // Title of list to add account to (lists only).
+	statusURI   string // URI of status to bookmark (bookmarks only).
+}
+
+// Import parses the given CSV file according to the given import
+// type, stores a new import model, and enqueues the parsed entries
+// for asynchronous processing. The progress of the import can
+// later be checked using ImportGet.
+func (p *Processor) Import(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	form *apimodel.ImportRequest,
+) (*apimodel.Import, gtserror.WithCode) {
+	importType := gtsmodel.NewImportType(form.Type)
+	switch importType {
+	case gtsmodel.ImportTypeUnknown:
+		err := fmt.Errorf("import type %s not recognized", form.Type)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+
+	case gtsmodel.ImportTypeMutes:
+		err := errors.New("this instance does not support muting accounts, so mutes cannot be imported")
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	importMode := gtsmodel.NewImportMode(form.Mode)
+	if importMode == gtsmodel.ImportModeUnknown {
+		err := fmt.Errorf("import mode %s not recognized", form.Mode)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if form.Data == nil || form.Data.Size == 0 {
+		err := errors.New("no import data provided")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if form.Data.Size > int64(maxImportSize) {
+		err := fmt.Errorf("import data size %d exceeds maximum of %s", form.Data.Size, maxImportSize)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	file, err := form.Data.Open()
+	if err != nil {
+		err := gtserror.Newf("error opening import data: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	defer file.Close()
+
+	entries, err := parseImport(importType, file)
+	if err != nil {
+		err := fmt.Errorf("error parsing import data: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	imp := &gtsmodel.Import{
+		ID:         id.NewULID(),
+		AccountID:  requestingAccount.ID,
+		Account:    requestingAccount,
+		Type:       importType,
+		Mode:       importMode,
+		TotalItems: len(entries),
+	}
+
+	if err := p.state.DB.PutImport(ctx, imp); err != nil {
+		err := gtserror.Newf("db error putting import: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Do the rest of the work asynchronously.
+	p.state.Workers.ClientAPI.Enqueue(func(ctx context.Context) {
+		p.processImport(ctx, imp, entries)
+	})
+
+	apiImport, err := p.converter.ImportToAPIImport(ctx, imp)
+	if err != nil {
+		err := gtserror.Newf("error converting import: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiImport, nil
+}
+
+// ImportGet returns the import with the given
+// ID, if it was created by the requesting account.
+func (p *Processor) ImportGet(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	importID string,
+) (*apimodel.Import, gtserror.WithCode) {
+	imp, err := p.state.DB.GetImportByID(ctx, importID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting import %s: %w", importID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if imp == nil || imp.AccountID != requestingAccount.ID {
+		// Don't leak existence of other accounts' imports.
+		err := fmt.Errorf("import %s not found", importID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	apiImport, err := p.converter.ImportToAPIImport(ctx, imp)
+	if err != nil {
+		err := gtserror.Newf("error converting import: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiImport, nil
+}
+
+// ImportsGet returns a page of imports
+// created by the requesting account.
+func (p *Processor) ImportsGet(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	imports, err := p.state.DB.GetAccountImports(ctx, requestingAccount.ID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting imports: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(imports)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	var (
+		items = make([]interface{}, 0, count)
+
+		// Set next + prev values before
+		// API converting, so caller can
+		// still page properly.
+		lo = imports[count-1].ID
+		hi = imports[0].ID
+	)
+
+	for _, imp := range imports {
+		apiImport, err := p.converter.ImportToAPIImport(ctx, imp)
+		if err != nil {
+			log.Errorf(ctx, "error converting import to api: %v", err)
+			continue
+		}
+		items = append(items, apiImport)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/import",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// processImport processes each of the given entries for
+// the given import, storing progress as it goes along.
+func (p *Processor) processImport(
+	ctx context.Context,
+	imp *gtsmodel.Import,
+	entries []importEntry,
+) {
+	// Keys of entries imported successfully,
+	// used when overwriting existing data.
+	imported := make(map[string]struct{}, len(entries))
+
+	// Lists touched by this import, by title.
+	lists := make(map[string]*gtsmodel.List)
+
+	for i, entry := range entries {
+		key, err := p.importEntry(ctx, imp, lists, entry)
+		if err != nil {
+			imp.FailedItems++
+			if len(imp.Errors) < maxImportErrors {
+				imp.Errors = append(imp.Errors, err.Error())
+			}
+		} else {
+			imported[key] = struct{}{}
+		}
+		imp.ProcessedItems++
+
+		if (i+1)%importUpdateEvery == 0 {
+			// Store progress made so far.
+			if err := p.state.DB.UpdateImport(ctx, imp,
+				"processed_items",
+				"failed_items",
+				"errors",
+			); err != nil {
+				log.Errorf(ctx, "db error updating import %s: %v", imp.ID, err)
+			}
+		}
+	}
+
+	switch {
+	case imp.Mode != gtsmodel.ImportModeOverwrite:
+		// Merging, nothing to remove.
+
+	case imp.FailedItems > 0:
+		// Failed entries (eg., on a transient remote
+		// error) aren't in imported, so overwriting now
+		// would remove data the user meant to keep.
+		if len(imp.Errors) < maxImportErrors {
+			imp.Errors = append(imp.Errors, fmt.Sprintf(
+				"%d entries failed to import, so existing data was not removed; "+
+					"import again once the failures are resolved to overwrite",
+				imp.FailedItems,
+			))
+		}
+
+	default:
+		// Remove any existing data
+		// not present in the import.
+		errs := p.overwriteImport(ctx, imp, lists, imported)
+		for _, err := range errs {
+			if len(imp.Errors) < maxImportErrors {
+				imp.Errors = append(imp.Errors, err.Error())
+			}
+		}
+	}
+
+	// Mark as completed in the db,
+	// storing errors for later review.
+	imp.CompletedAt = time.Now()
+	if err := p.state.DB.UpdateImport(ctx, imp,
+		"completed_at",
+		"processed_items",
+		"failed_items",
+		"errors",
+	); err != nil {
+		log.Errorf(ctx, "db error marking import %s as completed: %v", imp.ID, err)
+	}
+}
+
+// importEntry imports the given entry according to the type of
+// the given import, returning a key which identifies what was
+// imported (eg., target account ID, status ID, list entry).
+func (p *Processor) importEntry(
+	ctx context.Context,
+	imp *gtsmodel.Import,
+	lists map[string]*gtsmodel.List,
+	entry importEntry,
+) (string, error) {
+	switch imp.Type {
+
+	case gtsmodel.ImportTypeFollowing:
+		target, err := p.importAccount(ctx, imp.Account, entry.address)
+		if err != nil {
+			return "", err
+		}
+
+		if _, errWithCode := p.FollowCreate(ctx, imp.Account, &apimodel.AccountFollowRequest{
+			ID:      target.ID,
+			Reblogs: entry.showReblogs,
+			Notify:  entry.notify,
+		}); errWithCode != nil {
+			return "", fmt.Errorf("error following %s: %w", entry.address, errWithCode)
+		}
+
+		return target.ID, nil
+
+	case gtsmodel.ImportTypeBlocks:
+		target, err := p.importAccount(ctx, imp.Account, entry.address)
+		if err != nil {
+			return "", err
+		}
+
+		if _, errWithCode := p.BlockCreate(ctx, imp.Account, target.ID); errWithCode != nil {
+			return "", fmt.Errorf("error blocking %s: %w", entry.address, errWithCode)
+		}
+
+		return target.ID, nil
+
+	case gtsmodel.ImportTypeLists:
+		return p.importListEntry(ctx, imp.Account, lists, entry)
+
+	case gtsmodel.ImportTypeBookmarks:
+		return p.importBookmark(ctx, imp.Account, entry.statusURI)
+
+	default:
+		return "", fmt.Errorf("import type %s not supported", imp.Type)
+	}
+}
+
+// importAccount resolves the account with the given
+// username@domain address, dereferencing it via
+// webfinger if it's not yet known to this instance.
+func (p *Processor) importAccount(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	address string,
+) (*gtsmodel.Account, error) {
+	username, domain, err := util.ExtractNamestringParts("@" + strings.TrimPrefix(address, "@"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid account address %s", address)
+	}
+
+	account, _, err := p.federator.GetAccountByUsernameDomain(ctx,
+		requestingAccount.Username,
+		username,
+		domain,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving account %s: %w", address, err)
+	}
+
+	if account.ID == requestingAccount.ID {
+		return nil, fmt.Errorf("account %s is the importing account", address)
+	}
+
+	if !account.SuspendedAt.IsZero() {
+		return nil, fmt.Errorf("account %s is suspended", address)
+	}
+
+	return account, nil
+}
+
+// importListEntry adds the account with the given address
+// to the list with the given title, creating the list and
+// following the account first, if necessary.
+func (p *Processor) importListEntry(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	lists map[string]*gtsmodel.List,
+	entry importEntry,
+) (string, error) {
+	target, err := p.importAccount(ctx, requestingAccount, entry.address)
+	if err != nil {
+		return "", err
+	}
+
+	list, err := p.importList(ctx, requestingAccount, lists, entry.listTitle)
+	if err != nil {
+		return "", err
+	}
+
+	// List entries can only be
+	// created for existing follows.
+	follow, err := p.state.DB.GetFollow(ctx, requestingAccount.ID, target.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return "", gtserror.Newf("db error getting follow: %w", err)
+	}
+
+	if follow == nil {
+		// Not following yet,
+		// try to follow now.
+		rel, errWithCode := p.FollowCreate(ctx, requestingAccount, &apimodel.AccountFollowRequest{
+			ID: target.ID,
+		})
+		if errWithCode != nil {
+			return "", fmt.Errorf("error following %s: %w", entry.address, errWithCode)
+		}
+
+		if !rel.Following {
+			// Target must approve this follow,
+			// so we can't add them to a list yet.
+			return "", fmt.Errorf("follow request to %s is pending, cannot add to list %s", entry.address, list.Title)
+		}
+
+		follow, err = p.state.DB.GetFollow(ctx, requestingAccount.ID, target.ID)
+		if err != nil {
+			return "", gtserror.Newf("db error getting follow: %w", err)
+		}
+	}
+
+	key := list.ID + "/" + target.ID
+
+	included, err := p.state.DB.ListIncludesAccount(ctx, list.ID, target.ID)
+	if err != nil {
+		return "", gtserror.Newf("db error checking list entries: %w", err)
+	}
+
+	if included {
+		// Already in list,
+		// nothing to do.
+		return key, nil
+	}
+
+	if err := p.state.DB.PutListEntries(ctx, []*gtsmodel.ListEntry{{
+		ID:       id.NewULID(),
+		ListID:   list.ID,
+		FollowID: follow.ID,
+	}}); err != nil {
+		return "", gtserror.Newf("db error putting list entry: %w", err)
+	}
+
+	return key, nil
+}
+
+// importList returns the requesting account's list with the
+// given title, creating it if it doesn't exist yet.
+func (p *Processor) importList(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	lists map[string]*gtsmodel.List,
+	title string,
+) (*gtsmodel.List, error) {
+	if list, ok := lists[title]; ok {
+		// Already seen.
+		return list, nil
+	}
+
+	existing, err := p.state.DB.GetListsForAccountID(ctx, requestingAccount.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting lists: %w", err)
+	}
+
+	for _, list := range existing {
+		if list.Title == title {
+			lists[title] = list
+			return list, nil
+		}
+	}
+
+	list := &gtsmodel.List{
+		ID:            id.NewULID(),
+		Title:         title,
+		AccountID:     requestingAccount.ID,
+		RepliesPolicy: gtsmodel.RepliesPolicyFollowed,
+		Exclusive:     util.Ptr(false),
+	}
+
+	if err := p.state.DB.PutList(ctx, list); err != nil {
+		return nil, gtserror.Newf("db error putting list %s: %w", title, err)
+	}
+
+	lists[title] = list
+	return list, nil
+}
+
+// importBookmark bookmarks the status with the given URI for
+// the requesting account, dereferencing it if necessary.
+func (p *Processor) importBookmark(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	statusURI string,
+) (string, error) {
+	uri, err := url.Parse(statusURI)
+	if err != nil || (uri.Scheme != "https" && uri.Scheme != "http") {
+		return "", fmt.Errorf("invalid status uri %s", statusURI)
+	}
+
+	status, _, err := p.federator.GetStatusByURI(ctx, requestingAccount.Username, uri)
+	if err != nil {
+		return "", fmt.Errorf("error resolving status %s: %w", statusURI, err)
+	}
+
+	visible, err := p.filter.StatusVisible(ctx, requestingAccount, status)
+	if err != nil {
+		return "", gtserror.Newf("error checking status visibility: %w", err)
+	}
+
+	if !visible {
+		return "", fmt.Errorf("status %s is not visible", statusURI)
+	}
+
+	bookmarkID, err := p.state.DB.GetStatusBookmarkID(ctx, requestingAccount.ID, status.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return "", gtserror.Newf("db error checking existing bookmark: %w", err)
+	}
+
+	if bookmarkID != "" {
+		// Already bookmarked.
+		return status.ID, nil
+	}
+
+	if err := p.state.DB.PutStatusBookmark(ctx, &gtsmodel.StatusBookmark{
+		ID:              id.NewULID(),
+		AccountID:       requestingAccount.ID,
+		TargetAccountID: status.AccountID,
+		StatusID:        status.ID,
+	}); err != nil {
+		return "", gtserror.Newf("db error putting bookmark: %w", err)
+	}
+
+	if err := p.c.InvalidateTimelinedStatus(ctx, requestingAccount.ID, status.ID); err != nil {
+		log.Errorf(ctx, "error invalidating status from timelines: %v", err)
+	}
+
+	return status.ID, nil
+}
+
+// overwriteImport removes any of the importing account's existing
+// follows, blocks, list entries, or bookmarks (according to import
+// type) which were not contained in the given imported keys. Only
+// call this when every entry of the import succeeded.
+func (p *Processor) overwriteImport(
+	ctx context.Context,
+	imp *gtsmodel.Import,
+	lists map[string]*gtsmodel.List,
+	imported map[string]struct{},
+) gtserror.MultiError {
+	var (
+		account = imp.Account
+		errs    gtserror.MultiError
+	)
+
+	switch imp.Type {
+
+	case gtsmodel.ImportTypeFollowing:
+		follows, err := p.state.DB.GetAccountFollows(gtscontext.SetBarebones(ctx), account.ID, nil)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			errs.Appendf("db error getting follows: %w", err)
+			return errs
+		}
+
+		for _, follow := range follows {
+			if _, ok := imported[follow.TargetAccountID]; ok {
+				continue
+			}
+
+			if _, errWithCode := p.FollowRemove(ctx, account, follow.TargetAccountID); errWithCode != nil {
+				errs.Appendf("error unfollowing %s: %w", follow.TargetAccountID, errWithCode)
+			}
+		}
+
+	case gtsmodel.ImportTypeBlocks:
+		blocks, err := p.state.DB.GetAccountBlocks(gtscontext.SetBarebones(ctx), account.ID, nil)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			errs.Appendf("db error getting blocks: %w", err)
+			return errs
+		}
+
+		for _, block := range blocks {
+			if _, ok := imported[block.TargetAccountID]; ok {
+				continue
+			}
+
+			if _, errWithCode := p.BlockRemove(ctx, account, block.TargetAccountID); errWithCode != nil {
+				errs.Appendf("error unblocking %s: %w", block.TargetAccountID, errWithCode)
+			}
+		}
+
+	case gtsmodel.ImportTypeLists:
+		// Only lists contained in the import are
+		// overwritten, other lists are left alone.
+		for _, list := range lists {
+			entries, err := p.state.DB.GetListEntries(ctx, list.ID, "", "", "", 0)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				errs.Appendf("db error getting entries of list %s: %w", list.ID, err)
+				continue
+			}
+
+			for _, entry := range entries {
+				if err := p.state.DB.PopulateListEntry(ctx, entry); err != nil {
+					errs.Appendf("error populating list entry %s: %w", entry.ID, err)
+					continue
+				}
+
+				key := list.ID + "/" + entry.Follow.TargetAccountID
+				if _, ok := imported[key]; ok {
+					continue
+				}
+
+				if err := p.state.DB.DeleteListEntry(ctx, entry.ID); err != nil {
+					errs.Appendf("db error deleting list entry %s: %w", entry.ID, err)
+				}
+			}
+		}
+
+	case gtsmodel.ImportTypeBookmarks:
+		bookmarks, err := p.state.DB.GetStatusBookmarks(ctx, account.ID, 0, "", "")
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			errs.Appendf("db error getting bookmarks: %w", err)
+			return errs
+		}
+
+		for _, bookmark := range bookmarks {
+			if _, ok := imported[bookmark.StatusID]; ok {
+				continue
+			}
+
+			if err := p.state.DB.DeleteStatusBookmark(ctx, bookmark.ID); err != nil {
+				errs.Appendf("db error deleting bookmark %s: %w", bookmark.ID, err)
+			}
+		}
+	}
+
+	return errs
+}
+
+// parseImport parses CSV data in the format exported by
+// Mastodon (or GoToSocial) for the given import type.
+func parseImport(importType gtsmodel.ImportType, r io.Reader) ([]importEntry, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1 // Allow variable fields.
+	cr.TrimLeadingSpace = true
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) != 0 && len(records[0]) != 0 &&
+		strings.EqualFold(records[0][0], "account address") {
+		// Drop header row.
+		records = records[1:]
+	}
+
+	entries := make([]importEntry, 0, len(records))
+	for i, record := range records {
+		if len(record) == 0 || strings.TrimSpace(record[0]) == "" {
+			// Skip empty lines.
+			continue
+		}
+
+		var entry importEntry
+
+		switch importType {
+		case gtsmodel.ImportTypeFollowing:
+			entry.address = strings.TrimSpace(record[0])
+			if len(record) > 1 {
+				entry.showReblogs = parseImportBool(record[1])
+			}
+			if len(record) > 2 {
+				entry.notify = parseImportBool(record[2])
+			}
+
+		case gtsmodel.ImportTypeBlocks:
+			entry.address = strings.TrimSpace(record[0])
+
+		case gtsmodel.ImportTypeLists:
+			if len(record) < 2 {
+				return nil, fmt.Errorf("line %d: expected list title and account address", i+1)
+			}
+			entry.listTitle = strings.TrimSpace(record[0])
+			entry.address = strings.TrimSpace(record[1])
+
+		case gtsmodel.ImportTypeBookmarks:
+			entry.statusURI = strings.TrimSpace(record[0])
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if len(entries) == 0 {
+		return nil, errors.New("no entries found")
+	}
+
+	if len(entries) > maxImportEntries {
+		return nil, fmt.Errorf("%d entries exceeds maximum of %d", len(entries), maxImportEntries)
+	}
+
+	return entries, nil
+}
+
+// parseImportBool parses a boolean CSV field,
+// returning nil if it's empty or not a boolean.
+func parseImportBool(in string) *bool {
+	b, err := strconv.ParseBool(strings.TrimSpace(in))
+	if err != nil {
+		return nil
+	}
+	return &b
+}
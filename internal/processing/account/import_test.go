@@ -0,0 +1,136 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ImportTestSuite struct {
+	AccountStandardTestSuite
+}
+
+// importCSV imports the given CSV data as the given
+// type and mode, waiting for the import to finish.
+func (suite *ImportTestSuite) importCSV(importType string, mode string, data string) *apimodel.Import {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["local_account_1"]
+
+	path := filepath.Join(suite.T().TempDir(), "import.csv")
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	b, w, err := testrig.CreateMultipartFormData("data", path, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	form, err := multipart.NewReader(bytes.NewReader(b.Bytes()), w.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	imp, errWithCode := suite.accountProcessor.Import(ctx, requestingAccount, &apimodel.ImportRequest{
+		Data: form.File["data"][0],
+		Type: importType,
+		Mode: mode,
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	if !testrig.WaitFor(func() bool {
+		imp, errWithCode = suite.accountProcessor.ImportGet(ctx, requestingAccount, imp.ID)
+		return errWithCode == nil && imp.State == "finished"
+	}) {
+		suite.FailNow("timed out waiting for import to finish")
+	}
+
+	return imp
+}
+
+func (suite *ImportTestSuite) following(targetKey string) bool {
+	following, err := suite.db.IsFollowing(
+		context.Background(),
+		suite.testAccounts["local_account_1"].ID,
+		suite.testAccounts[targetKey].ID,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return following
+}
+
+func (suite *ImportTestSuite) TestImportFollowingMerge() {
+	imp := suite.importCSV("following", "merge",
+		"Account address,Show boosts,Notify on new posts,Languages\n"+
+			"admin@localhost:8080,true,false,\n",
+	)
+	suite.Equal(1, imp.ProcessedItems)
+	suite.Zero(imp.FailedItems)
+
+	// Existing follows not in
+	// the import should remain.
+	suite.True(suite.following("admin_account"))
+	suite.True(suite.following("local_account_2"))
+}
+
+func (suite *ImportTestSuite) TestImportFollowingOverwrite() {
+	imp := suite.importCSV("following", "overwrite",
+		"Account address,Show boosts,Notify on new posts,Languages\n"+
+			"admin@localhost:8080,true,false,\n",
+	)
+	suite.Equal(1, imp.ProcessedItems)
+	suite.Zero(imp.FailedItems)
+	suite.Empty(imp.Errors)
+
+	// Existing follows not in
+	// the import should be gone.
+	suite.True(suite.following("admin_account"))
+	suite.False(suite.following("local_account_2"))
+}
+
+func (suite *ImportTestSuite) TestImportFollowingOverwriteFailedEntry() {
+	imp := suite.importCSV("following", "overwrite",
+		"Account address,Show boosts,Notify on new posts,Languages\n"+
+			"admin@localhost:8080,true,false,\n"+
+			"1happyturtle@unknown-host.example.org,true,false,\n",
+	)
+	suite.Equal(2, imp.ProcessedItems)
+	suite.Equal(1, imp.FailedItems)
+	suite.Len(imp.Errors, 2)
+
+	// An entry failed, so nothing should
+	// have been removed by the overwrite.
+	suite.True(suite.following("admin_account"))
+	suite.True(suite.following("local_account_2"))
+}
+
+func TestImportTestSuite(t *testing.T) {
+	suite.Run(t, new(ImportTestSuite))
+}
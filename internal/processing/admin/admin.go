@@ -20,6 +20,7 @@ package admin
 import (
 	"github.com/superseriousbusiness/gotosocial/internal/cleaner"
 	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/emojipack"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
@@ -34,6 +35,7 @@ type Processor struct {
 	mediaManager        *media.Manager
 	transportController transport.Controller
 	emailSender         email.Sender
+	emojiPacks          *emojipack.Manager
 
 	// admin Actions currently
 	// undergoing processing
@@ -60,6 +62,7 @@ func New(
 		mediaManager:        mediaManager,
 		transportController: transportController,
 		emailSender:         emailSender,
+		emojiPacks:          emojipack.New(state, mediaManager),
 
 		actions: &Actions{
 			r:     make(map[string]*gtsmodel.AdminAction),
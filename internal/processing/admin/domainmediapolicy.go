@@ -0,0 +1,296 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// DomainMediaPolicyGet fetches the media policy with provided ID from the database.
+func (p *Processor) DomainMediaPolicyGet(ctx context.Context, id string) (*apimodel.DomainMediaPolicy, gtserror.WithCode) {
+	policy, errWithCode := p.getDomainMediaPolicy(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+	return toAPIDomainMediaPolicy(policy), nil
+}
+
+// DomainMediaPoliciesGet fetches all media policies stored in the database.
+func (p *Processor) DomainMediaPoliciesGet(ctx context.Context) ([]*apimodel.DomainMediaPolicy, gtserror.WithCode) {
+	policies, err := p.state.DB.GetDomainMediaPolicies(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("error selecting from database: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiPolicies := make([]*apimodel.DomainMediaPolicy, len(policies))
+	for i := range policies {
+		apiPolicies[i] = toAPIDomainMediaPolicy(policies[i])
+	}
+
+	return apiPolicies, nil
+}
+
+// DomainMediaPolicyCreate inserts a new media policy for the requested domain
+// into the database, marking it as created by provided admin account. If the
+// policy says not to cache media, media already cached from the domain is purged.
+func (p *Processor) DomainMediaPolicyCreate(ctx context.Context, admin *gtsmodel.Account, request *apimodel.DomainMediaPolicyRequest) (*apimodel.DomainMediaPolicy, gtserror.WithCode) {
+	domain, errWithCode := validateMediaPolicyDomain(util.PtrValueOr(request.Domain, ""))
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if request.Policy == nil {
+		const text = "no media policy provided"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	now := time.Now()
+	policy := &gtsmodel.DomainMediaPolicy{
+		ID:                 id.NewULID(),
+		CreatedAt:          now,
+		UpdatedAt:          now,
+		Domain:             domain,
+		Comment:            util.PtrValueOr(request.Comment, ""),
+		CreatedByAccountID: admin.ID,
+		CreatedByAccount:   admin,
+	}
+
+	if errWithCode := applyMediaPolicy(policy, request); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.PutDomainMediaPolicy(ctx, policy); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			text := fmt.Sprintf("a media policy for %s already exists", domain)
+			return nil, gtserror.NewErrorConflict(errors.New(text), text)
+		}
+		err := gtserror.Newf("error inserting into database: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if !policy.Caches() {
+		// Drop any media we already
+		// have cached from the domain.
+		p.purgeDomainMedia(policy.Domain)
+	}
+
+	return toAPIDomainMediaPolicy(policy), nil
+}
+
+// DomainMediaPolicyUpdate updates the policy, cache days and / or comment of
+// the media policy with provided ID. If the policy changes to no longer cache
+// media, media already cached from the domain is purged.
+func (p *Processor) DomainMediaPolicyUpdate(ctx context.Context, id string, request *apimodel.DomainMediaPolicyRequest) (*apimodel.DomainMediaPolicy, gtserror.WithCode) {
+	policy, errWithCode := p.getDomainMediaPolicy(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	cached := policy.Caches()
+
+	if errWithCode := applyMediaPolicy(policy, request); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if request.Comment != nil {
+		policy.Comment = *request.Comment
+	}
+
+	if err := p.state.DB.UpdateDomainMediaPolicy(ctx, policy,
+		"policy",
+		"cache_days",
+		"comment",
+	); err != nil {
+		err := gtserror.Newf("error updating database: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if cached && !policy.Caches() {
+		// Drop any media we already
+		// have cached from the domain.
+		p.purgeDomainMedia(policy.Domain)
+	}
+
+	return toAPIDomainMediaPolicy(policy), nil
+}
+
+// DomainMediaPolicyDelete deletes the media policy with provided ID from the
+// database, returning it. Media from the domain will be cached as usual again.
+func (p *Processor) DomainMediaPolicyDelete(ctx context.Context, id string) (*apimodel.DomainMediaPolicy, gtserror.WithCode) {
+	policy, errWithCode := p.getDomainMediaPolicy(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteDomainMediaPolicyByID(ctx, id); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("error deleting from database: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return toAPIDomainMediaPolicy(policy), nil
+}
+
+// MediaPurge triggers a non-blocking uncache of all remote media
+// and emoji from the given domain (and its subdomains), regardless
+// of age. Purged media is recached when next requested, unless the
+// media policy for the domain says otherwise.
+func (p *Processor) MediaPurge(ctx context.Context, domain string) gtserror.WithCode {
+	domain, errWithCode := validateMediaPolicyDomain(domain)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	p.purgeDomainMedia(domain)
+	return nil
+}
+
+// purgeDomainMedia starts a background task
+// uncaching all remote media from domain.
+func (p *Processor) purgeDomainMedia(domain string) {
+	go func() {
+		ctx := context.Background()
+		log.Infof(ctx, "purging cached media from %s", domain)
+		p.cleaner.Media().LogUncacheDomain(ctx, domain)
+		p.cleaner.Emoji().LogUncacheDomain(ctx, domain)
+	}()
+}
+
+// getDomainMediaPolicy fetches the media policy with provided ID from
+// the database, wrapping any error in an appropriate gtserror.WithCode.
+func (p *Processor) getDomainMediaPolicy(ctx context.Context, id string) (*gtsmodel.DomainMediaPolicy, gtserror.WithCode) {
+	policy, err := p.state.DB.GetDomainMediaPolicyByID(ctx, id)
+
+	switch {
+	// Successfully found.
+	case err == nil:
+		return policy, nil
+
+	// Policy does not exist with ID.
+	case errors.Is(err, db.ErrNoEntries):
+		const text = "media policy not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+
+	// Any other error type.
+	default:
+		err := gtserror.Newf("error selecting from database: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+}
+
+// validateMediaPolicyDomain validates the given remote
+// domain, returning it in punycode as stored in the database.
+func validateMediaPolicyDomain(domain string) (string, gtserror.WithCode) {
+	domain = strings.TrimSpace(domain)
+	if domain == "" {
+		const text = "no domain provided"
+		return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	punyDomain, err := util.Punify(domain)
+	if err != nil {
+		text := fmt.Sprintf("domain %q not valid", domain)
+		return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+	domain = punyDomain
+
+	if domain == config.GetHost() ||
+		domain == config.GetAccountDomain() {
+		const text = "media policies only apply to remote domains"
+		return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	return domain, nil
+}
+
+// applyMediaPolicy validates and sets the policy
+// and cache days from the request on the policy.
+func applyMediaPolicy(policy *gtsmodel.DomainMediaPolicy, request *apimodel.DomainMediaPolicyRequest) gtserror.WithCode {
+	if request.Policy != nil {
+		switch p := gtsmodel.MediaPolicy(strings.ToLower(*request.Policy)); p {
+		case gtsmodel.MediaPolicyCache,
+			gtsmodel.MediaPolicyProxy,
+			gtsmodel.MediaPolicyRemote,
+			gtsmodel.MediaPolicyReject:
+			policy.Policy = p
+		default:
+			text := fmt.Sprintf("media policy %q not recognized, should be one of: %s, %s, %s, %s",
+				*request.Policy,
+				gtsmodel.MediaPolicyCache,
+				gtsmodel.MediaPolicyProxy,
+				gtsmodel.MediaPolicyRemote,
+				gtsmodel.MediaPolicyReject,
+			)
+			return gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+	}
+
+	if request.CacheDays != nil {
+		switch days := *request.CacheDays; {
+		case days < 0:
+			const text = "cache days cannot be less than 0"
+			return gtserror.NewErrorBadRequest(errors.New(text), text)
+		case days == 0:
+			// Use instance default.
+			policy.CacheDays = nil
+		default:
+			policy.CacheDays = &days
+		}
+	}
+
+	if policy.Policy != gtsmodel.MediaPolicyCache &&
+		policy.CacheDays != nil {
+		if request.CacheDays != nil {
+			text := fmt.Sprintf("cache days can only be set for the %s policy", gtsmodel.MediaPolicyCache)
+			return gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+
+		// Left over from a
+		// previous policy.
+		policy.CacheDays = nil
+	}
+
+	return nil
+}
+
+// toAPIDomainMediaPolicy performs a simple conversion of database model DomainMediaPolicy to API model.
+func toAPIDomainMediaPolicy(policy *gtsmodel.DomainMediaPolicy) *apimodel.DomainMediaPolicy {
+	return &apimodel.DomainMediaPolicy{
+		ID:        policy.ID,
+		Domain:    policy.Domain,
+		Policy:    string(policy.Policy),
+		CacheDays: policy.CacheDays,
+		Comment:   policy.Comment,
+		CreatedBy: policy.CreatedByAccountID,
+		CreatedAt: util.FormatISO8601(policy.CreatedAt),
+		UpdatedAt: util.FormatISO8601(policy.UpdatedAt),
+	}
+}
@@ -0,0 +1,124 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type DomainMediaPolicyTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *DomainMediaPolicyTestSuite) TestCreateUpdateDelete() {
+	ctx := context.Background()
+	admin := suite.testAccounts["admin_account"]
+
+	policy, errWithCode := suite.adminProcessor.DomainMediaPolicyCreate(ctx, admin,
+		&apimodel.DomainMediaPolicyRequest{
+			Domain:    util.Ptr("Example.org"),
+			Policy:    util.Ptr("cache"),
+			CacheDays: util.Ptr(2),
+			Comment:   util.Ptr("big instance"),
+		},
+	)
+	suite.NoError(errWithCode)
+	suite.Equal("example.org", policy.Domain)
+	suite.Equal("cache", policy.Policy)
+	suite.Equal(util.Ptr(2), policy.CacheDays)
+	suite.Equal(admin.ID, policy.CreatedBy)
+
+	// Subdomains are covered by the policy.
+	matched, err := suite.db.MatchDomainMediaPolicy(ctx, "media.example.org")
+	suite.NoError(err)
+	suite.Equal(policy.ID, matched.ID)
+
+	// Switching away from the cache policy
+	// drops the now meaningless cache days.
+	policy, errWithCode = suite.adminProcessor.DomainMediaPolicyUpdate(ctx, policy.ID,
+		&apimodel.DomainMediaPolicyRequest{
+			Policy: util.Ptr("proxy"),
+		},
+	)
+	suite.NoError(errWithCode)
+	suite.Equal("proxy", policy.Policy)
+	suite.Nil(policy.CacheDays)
+	suite.Equal("big instance", policy.Comment)
+
+	_, errWithCode = suite.adminProcessor.DomainMediaPolicyDelete(ctx, policy.ID)
+	suite.NoError(errWithCode)
+
+	matched, err = suite.db.MatchDomainMediaPolicy(ctx, "media.example.org")
+	suite.NoError(err)
+	suite.Nil(matched)
+}
+
+func (suite *DomainMediaPolicyTestSuite) TestCreateInvalid() {
+	ctx := context.Background()
+	admin := suite.testAccounts["admin_account"]
+
+	for _, test := range []struct {
+		request *apimodel.DomainMediaPolicyRequest
+		err     string
+	}{
+		{
+			request: &apimodel.DomainMediaPolicyRequest{
+				Policy: util.Ptr("cache"),
+			},
+			err: "no domain provided",
+		},
+		{
+			request: &apimodel.DomainMediaPolicyRequest{
+				Domain: util.Ptr("localhost:8080"),
+				Policy: util.Ptr("cache"),
+			},
+			err: "media policies only apply to remote domains",
+		},
+		{
+			request: &apimodel.DomainMediaPolicyRequest{
+				Domain: util.Ptr("example.org"),
+				Policy: util.Ptr("hoard"),
+			},
+			err: "media policy \"hoard\" not recognized, should be one of: cache, proxy, remote, reject",
+		},
+		{
+			request: &apimodel.DomainMediaPolicyRequest{
+				Domain:    util.Ptr("example.org"),
+				Policy:    util.Ptr("remote"),
+				CacheDays: util.Ptr(3),
+			},
+			err: "cache days can only be set for the cache policy",
+		},
+	} {
+		_, errWithCode := suite.adminProcessor.DomainMediaPolicyCreate(ctx, admin, test.request)
+		if suite.Error(errWithCode) {
+			suite.Equal(http.StatusBadRequest, errWithCode.Code())
+			suite.Equal("Bad Request: "+test.err, errWithCode.Safe())
+		}
+	}
+}
+
+func TestDomainMediaPolicyTestSuite(t *testing.T) {
+	suite.Run(t, new(DomainMediaPolicyTestSuite))
+}
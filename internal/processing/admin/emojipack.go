@@ -0,0 +1,140 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/emojipack"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// EmojiPackImport imports each emoji in the submitted pack
+// archive as a local emoji, returning the result of each.
+func (p *Processor) EmojiPackImport(
+	ctx context.Context,
+	form *apimodel.EmojiPackImportRequest,
+) (*apimodel.MultiStatus, gtserror.WithCode) {
+	conflict, err := emojipack.ParseConflict(form.Conflict)
+	if err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	file, err := form.Pack.Open()
+	if err != nil {
+		err = gtserror.Newf("error opening attachment: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+	defer file.Close()
+
+	pack, err := emojipack.Read(file, form.Pack.Size)
+	if err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if len(pack.Emojis) == 0 {
+		err := gtserror.New("error importing emoji pack: 0 emojis found in pack")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	results := p.emojiPacks.Import(ctx, pack, conflict, form.CategoryName)
+	multiStatusEntries := make([]apimodel.MultiStatusEntry, 0, len(results))
+
+	for _, res := range results {
+		var entry apimodel.MultiStatusEntry
+
+		switch res.Outcome {
+		case emojipack.OutcomeFailed:
+			entry = apimodel.MultiStatusEntry{
+				// Use the shortcode from the pack as the resource value.
+				Resource: res.Shortcode,
+				Message:  res.Err.Error(),
+				Status:   http.StatusUnprocessableEntity,
+			}
+
+		case emojipack.OutcomeSkipped:
+			entry = apimodel.MultiStatusEntry{
+				Resource: res.Shortcode,
+				Message:  fmt.Sprintf("emoji with shortcode %s already exists", res.Shortcode),
+				Status:   http.StatusConflict,
+			}
+
+		default:
+			adminEmoji, err := p.converter.EmojiToAdminAPIEmoji(ctx, res.Emoji)
+			if err != nil {
+				err := gtserror.Newf("error converting emoji %s to admin emoji: %w", res.Emoji.ID, err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+
+			entry = apimodel.MultiStatusEntry{
+				// Use the imported admin emoji as the resource value,
+				// and the outcome (created, overwritten, renamed) as
+				// the message, so renames can be told apart.
+				Resource: adminEmoji,
+				Message:  string(res.Outcome),
+				Status:   http.StatusOK,
+			}
+		}
+
+		multiStatusEntries = append(multiStatusEntries, entry)
+	}
+
+	return apimodel.NewMultiStatus(multiStatusEntries), nil
+}
+
+// EmojiPackExport returns a pack archive in the given
+// format containing all local emojis on this instance.
+//
+// The archive is streamed to the returned content as it's
+// written, so the caller must close it when finished.
+func (p *Processor) EmojiPackExport(
+	ctx context.Context,
+	format string,
+) (*apimodel.Content, gtserror.WithCode) {
+	f, err := emojipack.ParseFormat(format)
+	if err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	pr, pw := io.Pipe()
+
+	go func() {
+		n, err := p.emojiPacks.Export(ctx, pw, f)
+		if err != nil {
+			log.Errorf(ctx, "error exporting emoji pack: %v", err)
+		} else {
+			log.Infof(ctx, "exported %d emojis", n)
+		}
+
+		// Pass any error on to the reader.
+		pw.CloseWithError(err)
+	}()
+
+	return &apimodel.Content{
+		ContentType:    f.ContentType(),
+		ContentLength:  -1,
+		ContentUpdated: time.Now(),
+		Content:        pr,
+	}, nil
+}
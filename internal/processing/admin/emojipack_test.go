@@ -0,0 +1,171 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/emojipack"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type EmojiPackTestSuite struct {
+	AdminStandardTestSuite
+}
+
+// packForm writes a pack containing the test rainbow
+// image under the given shortcodes, and returns it
+// as a multipart file header ready for importing.
+func (suite *EmojiPackTestSuite) packForm(shortcodes ...string) *multipart.FileHeader {
+	image, err := os.ReadFile("../../../testrig/media/rainbow-original.png")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	pack := new(emojipack.Pack)
+	for _, shortcode := range shortcodes {
+		pack.Emojis = append(pack.Emojis, &emojipack.Emoji{
+			Shortcode: shortcode,
+			Category:  "rainbows",
+			Filename:  shortcode + ".png",
+			Data:      image,
+		})
+	}
+
+	path := filepath.Join(suite.T().TempDir(), "pack.zip")
+	file, err := os.Create(path)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := emojipack.Write(file, emojipack.FormatZip, pack, "localhost:8080"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := file.Close(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	b, w, err := testrig.CreateMultipartFormData("pack", path, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	form, err := multipart.NewReader(bytes.NewReader(b.Bytes()), w.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return form.File["pack"][0]
+}
+
+func (suite *EmojiPackTestSuite) TestImportSkip() {
+	ctx := context.Background()
+
+	multiStatus, errWithCode := suite.adminProcessor.EmojiPackImport(ctx,
+		&apimodel.EmojiPackImportRequest{
+			Pack: suite.packForm("rainbow", "new_rainbow"),
+		},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal(2, multiStatus.Metadata.Total)
+	suite.Equal(1, multiStatus.Metadata.Success)
+
+	// Existing rainbow emoji should be skipped.
+	suite.Equal(http.StatusConflict, multiStatus.Data[0].Status)
+	suite.Equal("rainbow", multiStatus.Data[0].Resource)
+
+	// New one should be created in the pack's category.
+	suite.Equal(http.StatusOK, multiStatus.Data[1].Status)
+	suite.Equal("created", multiStatus.Data[1].Message)
+
+	emoji := multiStatus.Data[1].Resource.(*apimodel.AdminEmoji)
+	suite.Equal("new_rainbow", emoji.Shortcode)
+	suite.Equal("rainbows", emoji.Category)
+}
+
+func (suite *EmojiPackTestSuite) TestImportRename() {
+	ctx := context.Background()
+
+	multiStatus, errWithCode := suite.adminProcessor.EmojiPackImport(ctx,
+		&apimodel.EmojiPackImportRequest{
+			Pack:     suite.packForm("rainbow"),
+			Conflict: "rename",
+		},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal(http.StatusOK, multiStatus.Data[0].Status)
+	suite.Equal("renamed", multiStatus.Data[0].Message)
+
+	emoji := multiStatus.Data[0].Resource.(*apimodel.AdminEmoji)
+	suite.Equal("rainbow_2", emoji.Shortcode)
+}
+
+func (suite *EmojiPackTestSuite) TestImportOverwrite() {
+	ctx := context.Background()
+	existing := suite.testEmojis["rainbow"]
+
+	multiStatus, errWithCode := suite.adminProcessor.EmojiPackImport(ctx,
+		&apimodel.EmojiPackImportRequest{
+			Pack:     suite.packForm("rainbow"),
+			Conflict: "overwrite",
+		},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal(http.StatusOK, multiStatus.Data[0].Status)
+	suite.Equal("overwritten", multiStatus.Data[0].Message)
+
+	// Emoji should keep its ID but be
+	// moved into the pack's category.
+	emoji := multiStatus.Data[0].Resource.(*apimodel.AdminEmoji)
+	suite.Equal(existing.ID, emoji.ID)
+	suite.Equal("rainbows", emoji.Category)
+}
+
+func (suite *EmojiPackTestSuite) TestImportBadConflict() {
+	ctx := context.Background()
+
+	_, errWithCode := suite.adminProcessor.EmojiPackImport(ctx,
+		&apimodel.EmojiPackImportRequest{
+			Pack:     suite.packForm("rainbow"),
+			Conflict: "explode",
+		},
+	)
+	suite.EqualError(errWithCode, "unrecognized emoji conflict mode explode, must be one of skip, overwrite, rename")
+}
+
+func TestEmojiPackTestSuite(t *testing.T) {
+	suite.Run(t, new(EmojiPackTestSuite))
+}
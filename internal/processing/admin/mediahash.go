@@ -0,0 +1,247 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// MediaHashGet fetches the media hash with provided ID from the database.
+func (p *Processor) MediaHashGet(ctx context.Context, id string) (*apimodel.MediaHash, gtserror.WithCode) {
+	hash, err := p.state.DB.GetMediaHashByID(ctx, id)
+
+	switch {
+	// Successfully found.
+	case err == nil:
+		return toAPIMediaHash(hash), nil
+
+	// Hash does not exist with ID.
+	case errors.Is(err, db.ErrNoEntries):
+		const text = "media hash not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+
+	// Any other error type.
+	default:
+		err := gtserror.Newf("error selecting from database: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+}
+
+// MediaHashesGet fetches all media hashes stored in the database.
+func (p *Processor) MediaHashesGet(ctx context.Context) ([]*apimodel.MediaHash, gtserror.WithCode) {
+	hashes, err := p.state.DB.GetMediaHashes(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("error selecting from database: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiHashes := make([]*apimodel.MediaHash, len(hashes))
+	for i := range hashes {
+		apiHashes[i] = toAPIMediaHash(hashes[i])
+	}
+
+	return apiHashes, nil
+}
+
+// MediaHashCreate inserts the incoming media hash into the
+// database, marking it as created by provided admin account.
+func (p *Processor) MediaHashCreate(ctx context.Context, admin *gtsmodel.Account, request *apimodel.MediaHashRequest) (*apimodel.MediaHash, gtserror.WithCode) {
+	hashType, value, errWithCode := validateMediaHash(request.Type, request.Hash)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	now := time.Now()
+	hash := &gtsmodel.MediaHash{
+		ID:                 id.NewULID(),
+		CreatedAt:          now,
+		UpdatedAt:          now,
+		Type:               hashType,
+		Hash:               value,
+		Comment:            request.Comment,
+		CreatedByAccountID: admin.ID,
+		CreatedByAccount:   admin,
+	}
+
+	if err := p.state.DB.PutMediaHash(ctx, hash); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			text := fmt.Sprintf("%s hash %s is already blocklisted", hashType, value)
+			return nil, gtserror.NewErrorConflict(errors.New(text), text)
+		}
+		err := gtserror.Newf("error inserting into database: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return toAPIMediaHash(hash), nil
+}
+
+// MediaHashesImport inserts each media hash listed in the given
+// file, one per line as "<type> <hash> [comment]", into the database.
+// Blank lines, and lines starting with "#", are skipped.
+func (p *Processor) MediaHashesImport(ctx context.Context, admin *gtsmodel.Account, hashesF *multipart.FileHeader) (*apimodel.MultiStatus, gtserror.WithCode) {
+	// Open the provided file.
+	file, err := hashesF.Open()
+	if err != nil {
+		err = gtserror.Newf("error opening attachment: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+	defer file.Close()
+
+	// Parse each line of the file
+	// as a media hash create request.
+	var requests []*apimodel.MediaHashRequest
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.SplitN(line, " ", 3)
+		if len(fields) < 2 {
+			err := fmt.Errorf("error parsing line %q: expected \"<type> <hash> [comment]\"", line)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		request := &apimodel.MediaHashRequest{
+			Type: fields[0],
+			Hash: fields[1],
+		}
+
+		if len(fields) == 3 {
+			request.Comment = strings.TrimSpace(fields[2])
+		}
+
+		requests = append(requests, request)
+	}
+
+	if err := scanner.Err(); err != nil {
+		err = gtserror.Newf("error reading attachment: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if len(requests) == 0 {
+		err = gtserror.New("error importing media hashes: 0 entries provided")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Try to create each media hash, differentiating
+	// between successes and errors so that the caller
+	// can try failed imports again if desired.
+	multiStatusEntries := make([]apimodel.MultiStatusEntry, 0, len(requests))
+
+	for _, request := range requests {
+		var entry apimodel.MultiStatusEntry
+
+		hash, errWithCode := p.MediaHashCreate(ctx, admin, request)
+		if errWithCode != nil {
+			entry = apimodel.MultiStatusEntry{
+				// Use the failed hash entry as the resource value.
+				Resource: request.Type + " " + request.Hash,
+				Message:  errWithCode.Safe(),
+				Status:   errWithCode.Code(),
+			}
+		} else {
+			entry = apimodel.MultiStatusEntry{
+				// Use successfully created API model media hash as the resource value.
+				Resource: hash,
+				Message:  http.StatusText(http.StatusOK),
+				Status:   http.StatusOK,
+			}
+		}
+
+		multiStatusEntries = append(multiStatusEntries, entry)
+	}
+
+	return apimodel.NewMultiStatus(multiStatusEntries), nil
+}
+
+// MediaHashDelete deletes the media hash with provided ID from the database.
+func (p *Processor) MediaHashDelete(ctx context.Context, id string) gtserror.WithCode {
+	if _, err := p.state.DB.GetMediaHashByID(ctx, id); err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			const text = "media hash not found"
+			return gtserror.NewErrorNotFound(errors.New(text), text)
+		}
+		err := gtserror.Newf("error selecting from database: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.DeleteMediaHashByID(ctx, id); err != nil {
+		err := gtserror.Newf("error deleting from database: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// validateMediaHash validates the given media hash type and
+// value, returning them in the form stored in the database.
+func validateMediaHash(hashType string, value string) (gtsmodel.MediaHashType, string, gtserror.WithCode) {
+	value = strings.ToLower(value)
+
+	switch t := gtsmodel.MediaHashType(strings.ToLower(hashType)); t {
+	case gtsmodel.MediaHashTypeSHA256:
+		if b, err := hex.DecodeString(value); err != nil || len(b) != 32 {
+			text := fmt.Sprintf("sha256 hash %q should be 64 hex characters", value)
+			return "", "", gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+		return t, value, nil
+
+	case gtsmodel.MediaHashTypePHash:
+		phash, err := media.ParsePHash(value)
+		if err != nil {
+			return "", "", gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		return t, media.FormatPHash(phash), nil
+
+	default:
+		text := fmt.Sprintf("media hash type %q not recognized, should be one of: %s, %s",
+			hashType, gtsmodel.MediaHashTypeSHA256, gtsmodel.MediaHashTypePHash)
+		return "", "", gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+}
+
+// toAPIMediaHash performs a simple conversion of database model MediaHash to API model.
+func toAPIMediaHash(hash *gtsmodel.MediaHash) *apimodel.MediaHash {
+	return &apimodel.MediaHash{
+		ID:        hash.ID,
+		Type:      string(hash.Type),
+		Hash:      hash.Hash,
+		Comment:   hash.Comment,
+		CreatedBy: hash.CreatedByAccountID,
+		CreatedAt: util.FormatISO8601(hash.CreatedAt),
+	}
+}
@@ -0,0 +1,114 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// StorageUsageGet returns the total size of media stored by this instance.
+func (p *Processor) StorageUsageGet(ctx context.Context) (*apimodel.AdminStorageUsage, gtserror.WithCode) {
+	usage, err := p.state.DB.GetInstanceStorageUsage(ctx)
+	if err != nil {
+		err := gtserror.Newf("db error counting storage usage: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.converter.InstanceStorageUsageToAdminAPIStorageUsage(usage), nil
+}
+
+// AccountStorageUsageGet returns the size of media
+// stored for the local account with the given ID.
+func (p *Processor) AccountStorageUsageGet(ctx context.Context, accountID string) (*apimodel.AdminAccountStorageUsage, gtserror.WithCode) {
+	if errWithCode := p.checkLocalAccount(ctx, accountID); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	usage, err := p.state.DB.GetAccountStorageUsage(ctx, accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting storage usage for account %s: %w", accountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if usage == nil {
+		// Not counted yet, do it now.
+		usage, err = p.state.DB.UpdateAccountStorageUsage(ctx, accountID)
+		if err != nil {
+			err := gtserror.Newf("db error counting storage usage for account %s: %w", accountID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return p.converter.AccountStorageUsageToAdminAPIStorageUsage(usage), nil
+}
+
+// AccountStorageQuotaUpdate sets the storage quota of the local account
+// with the given ID. A nil quota resets the account to the instance default.
+func (p *Processor) AccountStorageQuotaUpdate(ctx context.Context, accountID string, quota *int) (*apimodel.AdminAccountStorageUsage, gtserror.WithCode) {
+	if quota != nil && *quota < 0 {
+		err := fmt.Errorf("quota must be 0 or greater, was %d", *quota)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if errWithCode := p.checkLocalAccount(ctx, accountID); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.UpdateAccountStorageQuota(ctx, accountID, quota); err != nil {
+		err := gtserror.Newf("db error updating storage quota for account %s: %w", accountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Recount usage so the returned
+	// sizes are guaranteed up to date.
+	usage, err := p.state.DB.UpdateAccountStorageUsage(ctx, accountID)
+	if err != nil {
+		err := gtserror.Newf("db error counting storage usage for account %s: %w", accountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.converter.AccountStorageUsageToAdminAPIStorageUsage(usage), nil
+}
+
+// checkLocalAccount returns an error if the account
+// with the given ID doesn't exist, or isn't local.
+func (p *Processor) checkLocalAccount(ctx context.Context, accountID string) gtserror.WithCode {
+	account, err := p.state.DB.GetAccountByID(ctx, accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting account %s: %w", accountID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if account == nil {
+		err := fmt.Errorf("account %s not found", accountID)
+		return gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	if !account.IsLocal() {
+		err := fmt.Errorf("account %s is not a local account", accountID)
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	return nil
+}
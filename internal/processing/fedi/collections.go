@@ -22,13 +22,16 @@ import (
 	"errors"
 	"net/http"
 	"net/url"
+	"slices"
 
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
 // InboxPost handles POST requests to a user's inbox for new activitypub messages.
@@ -336,3 +339,74 @@ func (p *Processor) FeaturedCollectionGet(ctx context.Context, requestedUser str
 
 	return data, nil
 }
+
+// FeaturedTagsCollectionGet returns a collection of the requested username's featured hashtags.
+// The returned collection has an `items` property which contains a list of Hashtag objects.
+func (p *Processor) FeaturedTagsCollectionGet(ctx context.Context, requestedUser string) (interface{}, gtserror.WithCode) {
+	// Authenticate the incoming request, getting related user accounts.
+	_, receiver, errWithCode := p.authenticate(ctx, requestedUser)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	featuredTags, err := p.state.DB.GetAccountFeaturedTags(ctx, receiver.ID)
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	// Skip any featured tags whose
+	// tag was deleted out from under us.
+	featuredTags = slices.DeleteFunc(featuredTags, func(f *gtsmodel.FeaturedTag) bool {
+		return f.Tag == nil
+	})
+
+	collection, err := p.converter.FeaturedTagsToASCollection(ctx, receiver.FeaturedTagsURI, featuredTags)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	data, err := ap.Serialize(collection)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return data, nil
+}
+
+// EndorsementsCollectionGet returns a collection of the accounts endorsed by the requested username.
+// The returned collection has an `items` property which contains a list of account URIs.
+func (p *Processor) EndorsementsCollectionGet(ctx context.Context, requestedUser string) (interface{}, gtserror.WithCode) {
+	// Authenticate the incoming request, getting related user accounts.
+	_, receiver, errWithCode := p.authenticate(ctx, requestedUser)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	endorsements, err := p.state.DB.GetAccountEndorsements(ctx, receiver.ID, nil)
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	// Skip any endorsed accounts
+	// that are no longer usable.
+	endorsements = slices.DeleteFunc(endorsements, func(e *gtsmodel.Endorsement) bool {
+		return e.TargetAccount == nil || !e.TargetAccount.SuspendedAt.IsZero()
+	})
+
+	endorsementsURI := uris.GenerateURIsForAccount(receiver.Username).EndorsementsURI
+	collection, err := p.converter.EndorsementsToASCollection(ctx, endorsementsURI, endorsements)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	data, err := ap.Serialize(collection)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return data, nil
+}
@@ -20,6 +20,7 @@ package media
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -27,7 +28,10 @@ import (
 	"os"
 	"path/filepath"
 
+	"codeberg.org/gruf/go-bytesize"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
@@ -102,21 +106,40 @@ func (p *Processor) Create(ctx context.Context, id string, form *apimodel.Attach
 		return nil, gtserror.NewErrorBadRequest(err, err.Error())
 	}
 
+	// make sure the account has room for this upload; this is
+	// only a first check, since the reported size can't be trusted
+	if errWithCode := p.checkQuota(ctx, id, form.File.Size); errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	// process the media attachment and load it immediately
-	media := p.mediaManager.PreProcessMedia(data, id, &media.AdditionalMediaInfo{
+	processingMedia := p.mediaManager.PreProcessMedia(data, id, &media.AdditionalMediaInfo{
 		Description: &form.Description,
 		FocusX:      &focusX,
 		FocusY:      &focusY,
 	})
 
-	attachment, err := media.LoadAttachment(ctx)
-	if err != nil {
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	if errors.Is(err, media.ErrBlockedMedia) {
+		const text = "this file is not allowed on this instance"
+		return nil, gtserror.NewErrorUnprocessableEntity(err, text)
+	} else if err != nil {
 		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
 	} else if attachment.Type == gtsmodel.FileTypeUnknown {
 		err = gtserror.Newf("could not process uploaded file with extension %s", attachment.File.ContentType)
 		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
 	}
 
+	// Storing the attachment has added its real processed size
+	// (file and thumbnail) to the account's usage, so check the
+	// account is still within its quota, removing it if not.
+	if errWithCode := p.checkQuota(ctx, id, 0); errWithCode != nil {
+		if delErr := p.Delete(ctx, attachment.ID); delErr != nil {
+			return nil, delErr
+		}
+		return nil, errWithCode
+	}
+
 	apiAttachment, err := p.converter.AttachmentToAPIAttachment(ctx, attachment)
 	if err != nil {
 		err := fmt.Errorf("error parsing media attachment to frontend type: %s", err)
@@ -125,3 +148,42 @@ func (p *Processor) Create(ctx context.Context, id string, form *apimodel.Attach
 
 	return &apiAttachment, nil
 }
+
+// checkQuota returns an error if storing size more bytes of media
+// would take the given local account over its storage quota. Passing
+// a size of 0 checks whether the account is already over its quota.
+func (p *Processor) checkQuota(ctx context.Context, accountID string, size int64) gtserror.WithCode {
+	usage, err := p.state.DB.GetAccountStorageUsage(ctx, accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("error getting storage usage for account %s: %w", accountID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if usage == nil {
+		// Usage hasn't been counted yet (eg., all
+		// the account's media was stored before
+		// quotas were introduced), so count it now.
+		usage, err = p.state.DB.UpdateAccountStorageUsage(ctx, accountID)
+		if err != nil {
+			err := gtserror.Newf("error counting storage usage for account %s: %w", accountID, err)
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	// Use the account's own quota if
+	// set, else the instance default.
+	quota := int(config.GetMediaAccountQuota())
+	if usage.Quota != nil {
+		quota = *usage.Quota
+	}
+
+	if quota > 0 && usage.Total()+int(size) > quota {
+		err := fmt.Errorf(
+			"storage quota exceeded: storing this file would use %s of quota of %s",
+			bytesize.Size(usage.Total()+int(size)), bytesize.Size(quota),
+		)
+		return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	return nil
+}
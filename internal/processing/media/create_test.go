@@ -0,0 +1,136 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media_test
+
+import (
+	"context"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	mediaprocessing "github.com/superseriousbusiness/gotosocial/internal/processing/media"
+)
+
+type CreateTestSuite struct {
+	MediaStandardTestSuite
+}
+
+// newAttachmentRequest stages a test image where Create
+// expects uploads to be, returning a request for it.
+func (suite *CreateTestSuite) newAttachmentRequest() *apimodel.AttachmentRequest {
+	b, err := os.ReadFile("../../../testrig/media/test-jpeg.jpg")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := os.MkdirAll(mediaprocessing.TmpMedia, 0o755); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	name := id.NewULID() + ".jpg"
+	path := filepath.Join(mediaprocessing.TmpMedia, name)
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.T().Cleanup(func() { os.Remove(path) })
+
+	return &apimodel.AttachmentRequest{
+		File: &multipart.FileHeader{Filename: name},
+	}
+}
+
+func (suite *CreateTestSuite) TestCreateOverQuota() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+
+	// Leave room for just 1 more byte.
+	usage, err := suite.db.UpdateAccountStorageUsage(ctx, account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	quota := usage.Total() + 1
+	if err := suite.db.UpdateAccountStorageQuota(ctx, account.ID, &quota); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Upload should be rejected.
+	attachment, errWithCode := suite.mediaProcessor.Create(ctx, account.ID, suite.newAttachmentRequest())
+	suite.Nil(attachment)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+	suite.Contains(errWithCode.Safe(), "storage quota exceeded")
+
+	// Remove the quota, upload should now be
+	// allowed, and counted towards usage.
+	quota = 0
+	if err := suite.db.UpdateAccountStorageQuota(ctx, account.ID, &quota); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	attachment, errWithCode = suite.mediaProcessor.Create(ctx, account.ID, suite.newAttachmentRequest())
+	suite.NoError(errWithCode)
+	suite.NotNil(attachment)
+
+	after, err := suite.db.GetAccountStorageUsage(ctx, account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Greater(after.MediaSize, usage.MediaSize)
+}
+
+func (suite *CreateTestSuite) TestCreateOverQuotaAfterProcessing() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+
+	fi, err := os.Stat("../../../testrig/media/test-jpeg.jpg")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Leave room for the upload itself, but
+	// not for the thumbnail generated from it.
+	usage, err := suite.db.UpdateAccountStorageUsage(ctx, account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	quota := usage.Total() + int(fi.Size())
+	if err := suite.db.UpdateAccountStorageQuota(ctx, account.ID, &quota); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Upload should be rejected once processed...
+	attachment, errWithCode := suite.mediaProcessor.Create(ctx, account.ID, suite.newAttachmentRequest())
+	suite.Nil(attachment)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+	suite.Contains(errWithCode.Safe(), "storage quota exceeded")
+
+	// ...and removed again, leaving usage as it was.
+	after, err := suite.db.GetAccountStorageUsage(ctx, account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(usage.Total(), after.Total())
+}
+
+func TestCreateTestSuite(t *testing.T) {
+	suite.Run(t, new(CreateTestSuite))
+}
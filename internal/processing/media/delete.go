@@ -23,7 +23,6 @@ import (
 	"fmt"
 	"strings"
 
-	"codeberg.org/gruf/go-store/v2/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 )
@@ -42,18 +41,9 @@ func (p *Processor) Delete(ctx context.Context, mediaAttachmentID string) gtserr
 
 	errs := []string{}
 
-	// delete the thumbnail from storage
-	if attachment.Thumbnail.Path != "" {
-		if err := p.state.Storage.Delete(ctx, attachment.Thumbnail.Path); err != nil && !errors.Is(err, storage.ErrNotFound) {
-			errs = append(errs, fmt.Sprintf("remove thumbnail at path %s: %s", attachment.Thumbnail.Path, err))
-		}
-	}
-
-	// delete the file from storage
-	if attachment.File.Path != "" {
-		if err := p.state.Storage.Delete(ctx, attachment.File.Path); err != nil && !errors.Is(err, storage.ErrNotFound) {
-			errs = append(errs, fmt.Sprintf("remove file at path %s: %s", attachment.File.Path, err))
-		}
+	// release the thumbnail and file from storage
+	if err := p.mediaManager.ReleaseAttachment(ctx, attachment); err != nil {
+		errs = append(errs, fmt.Sprintf("release files: %s", err))
 	}
 
 	// delete the attachment
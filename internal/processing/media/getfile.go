@@ -25,13 +25,17 @@ import (
 	"strings"
 	"time"
 
+	"codeberg.org/gruf/go-bytesize"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/iotools"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // GetFile retrieves a file from storage and streams it back
@@ -59,6 +63,30 @@ func (p *Processor) GetFile(
 	wantedMediaID := spl[0]
 	owningAccountID := form.AccountID
 
+	if _, errWithCode := p.getOwningAccount(ctx, requestingAccount, owningAccountID); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// the way we store emojis is a little different from the way we store other attachments,
+	// so we need to take different steps depending on the media type being requested
+	switch mediaType {
+	case media.TypeEmoji:
+		return p.getEmojiContent(ctx, wantedMediaID, owningAccountID, mediaSize)
+	case media.TypeAttachment, media.TypeHeader, media.TypeAvatar:
+		return p.getAttachmentContent(ctx, requestingAccount, wantedMediaID, owningAccountID, mediaSize)
+	default:
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("media type %s not recognized", mediaType))
+	}
+}
+
+/*
+	UTIL FUNCTIONS
+*/
+
+// getOwningAccount gets the account with the given ID that owns
+// requested media, making sure it's not suspended, and that it
+// and the requesting account (if any) don't block each other.
+func (p *Processor) getOwningAccount(ctx context.Context, requestingAccount *gtsmodel.Account, owningAccountID string) (*gtsmodel.Account, gtserror.WithCode) {
 	// get the account that owns the media and make sure it's not suspended
 	owningAccount, err := p.state.DB.GetAccountByID(ctx, owningAccountID)
 	if err != nil {
@@ -79,22 +107,9 @@ func (p *Processor) GetFile(
 		}
 	}
 
-	// the way we store emojis is a little different from the way we store other attachments,
-	// so we need to take different steps depending on the media type being requested
-	switch mediaType {
-	case media.TypeEmoji:
-		return p.getEmojiContent(ctx, wantedMediaID, owningAccountID, mediaSize)
-	case media.TypeAttachment, media.TypeHeader, media.TypeAvatar:
-		return p.getAttachmentContent(ctx, requestingAccount, wantedMediaID, owningAccountID, mediaSize)
-	default:
-		return nil, gtserror.NewErrorNotFound(fmt.Errorf("media type %s not recognized", mediaType))
-	}
+	return owningAccount, nil
 }
 
-/*
-	UTIL FUNCTIONS
-*/
-
 func parseType(s string) (media.Type, error) {
 	switch s {
 	case string(media.TypeAttachment):
@@ -134,6 +149,13 @@ func (p *Processor) getAttachmentContent(ctx context.Context, requestingAccount
 		return nil, gtserror.NewErrorNotFound(err)
 	}
 
+	if util.PtrValueOr(a.Quarantined, false) {
+		// Media matched the media hash blocklist,
+		// so it mustn't be served (or refetched).
+		err = gtserror.Newf("attachment %s is quarantined", wantedMediaID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
 	// If this is an "Unknown" file type, ie., one we
 	// tried to process and couldn't, or one we refused
 	// to process because it wasn't supported, then we
@@ -172,6 +194,48 @@ func (p *Processor) getAttachmentContent(ctx context.Context, requestingAccount
 			requestingUsername = requestingAccount.Username
 		}
 
+		policy, err := p.mediaManager.AccountMediaPolicy(ctx, a.AccountID)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if !policy.Caches() {
+			// Media policy of the remote domain says not
+			// to cache its media, so serve it from there.
+			switch mediaSize {
+			case media.SizeOriginal:
+				return p.getRemoteContent(ctx,
+					requestingUsername,
+					policy,
+					a.RemoteURL,
+					int64(a.File.FileSize),
+					maxAttachmentSize(),
+				)
+			case media.SizeSmall:
+				if a.Thumbnail.RemoteURL != "" {
+					return p.getRemoteContent(ctx,
+						requestingUsername,
+						policy,
+						a.Thumbnail.RemoteURL,
+						-1, // size of remote thumb unknown
+						maxAttachmentSize(),
+					)
+				}
+
+				// No remote thumbnail,
+				// fall back to original.
+				return p.getRemoteContent(ctx,
+					requestingUsername,
+					policy,
+					a.RemoteURL,
+					int64(a.File.FileSize),
+					maxAttachmentSize(),
+				)
+			default:
+				return nil, gtserror.NewErrorNotFound(fmt.Errorf("media size %s not recognized for attachment", mediaSize))
+			}
+		}
+
 		// Pour one out for tobi's original streamed recache
 		// (streaming data both to the client and storage).
 		// Gone and forever missed <3
@@ -262,6 +326,45 @@ func (p *Processor) getEmojiContent(ctx context.Context, fileName string, owning
 			return nil, gtserror.NewErrorNotFound(fmt.Errorf("error parsing remote emoji iri %s: %w", e.ImageRemoteURL, err))
 		}
 
+		policy, err := p.state.DB.MatchDomainMediaPolicy(ctx, e.Domain)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if !policy.Caches() {
+			// Media policy of the remote domain says not
+			// to cache its media, so serve it from there.
+			switch emojiSize {
+			case media.SizeOriginal:
+				return p.getRemoteContent(ctx, "",
+					policy,
+					e.ImageRemoteURL,
+					int64(e.ImageFileSize),
+					config.GetMediaEmojiRemoteMaxSize(),
+				)
+			case media.SizeStatic:
+				if e.ImageStaticRemoteURL != "" {
+					return p.getRemoteContent(ctx, "",
+						policy,
+						e.ImageStaticRemoteURL,
+						-1, // size of remote static unknown
+						config.GetMediaEmojiRemoteMaxSize(),
+					)
+				}
+
+				// No remote static image,
+				// fall back to original.
+				return p.getRemoteContent(ctx, "",
+					policy,
+					e.ImageRemoteURL,
+					int64(e.ImageFileSize),
+					config.GetMediaEmojiRemoteMaxSize(),
+				)
+			default:
+				return nil, gtserror.NewErrorNotFound(fmt.Errorf("media size %s not recognized for emoji", emojiSize))
+			}
+		}
+
 		dataFn := func(ctx context.Context) (io.ReadCloser, int64, error) {
 			t, err := p.transportController.NewTransportForUsername(ctx, "")
 			if err != nil {
@@ -299,6 +402,97 @@ func (p *Processor) getEmojiContent(ctx context.Context, fileName string, owning
 	return p.retrieveFromStorage(ctx, storagePath, emojiContent)
 }
 
+// getRemoteContent serves remote media which isn't cached due to the
+// media policy of its domain, either by redirecting the caller to the
+// given remote URL, or (for MediaPolicyProxy) by streaming it through.
+//
+// Proxied media is limited to maxSize bytes, and must look like an
+// image, video or audio file, which is then served as its sniffed
+// content type rather than whatever the remote claims it is.
+func (p *Processor) getRemoteContent(
+	ctx context.Context,
+	requestingUsername string,
+	policy *gtsmodel.DomainMediaPolicy,
+	rawURL string,
+	contentLength int64,
+	maxSize bytesize.Size,
+) (*apimodel.Content, gtserror.WithCode) {
+	remoteURL, err := url.Parse(rawURL)
+	if err != nil {
+		err = gtserror.Newf("error parsing remote media url %s: %w", rawURL, err)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if policy.Policy != gtsmodel.MediaPolicyProxy {
+		// Redirect to the remote,
+		// as with 'Unknown' media.
+		url := &storage.PresignedURL{
+			URL:    remoteURL,
+			Expiry: time.Now().Add(2 * time.Hour),
+		}
+
+		return &apimodel.Content{URL: url}, nil
+	}
+
+	t, err := p.transportController.NewTransportForUsername(ctx, requestingUsername)
+	if err != nil {
+		err = gtserror.Newf("error getting transport for %s: %w", requestingUsername, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	rc, size, err := t.DereferenceMedia(gtscontext.SetFastFail(ctx), remoteURL)
+	if err != nil {
+		err = gtserror.Newf("error proxying remote media %s: %w", rawURL, err)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if size > int64(maxSize) {
+		// Check size sent by remote up
+		// front, before reading anything.
+		_ = rc.Close()
+		err = gtserror.Newf("remote media %s size %s greater than max allowed %s", rawURL, bytesize.Size(size), maxSize)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	// Remote may not have sent a size (or could
+	// be lying), so also stop reading past max.
+	rc = iotools.LimitReadCloser(rc, int64(maxSize))
+
+	info, r, err := media.SniffFileType(rc)
+	if err != nil {
+		_ = rc.Close()
+		err = gtserror.Newf("error reading remote media %s: %w", rawURL, err)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	switch info.MIME.Type {
+	case "image", "video", "audio":
+		// Media, serve it.
+	default:
+		_ = rc.Close()
+		err = gtserror.Newf("remote media %s has unsupported file type %q", rawURL, info.MIME.Value)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if size >= 0 {
+		// Prefer length
+		// sent by remote.
+		contentLength = size
+	}
+
+	return &apimodel.Content{
+		ContentType:   info.MIME.Value,
+		ContentLength: contentLength,
+		Content:       iotools.ReadFnCloser(r, rc.Close),
+	}, nil
+}
+
+// maxAttachmentSize returns the max size of
+// a media attachment of any (supported) type.
+func maxAttachmentSize() bytesize.Size {
+	return max(config.GetMediaImageMaxSize(), config.GetMediaVideoMaxSize())
+}
+
 func (p *Processor) retrieveFromStorage(ctx context.Context, storagePath string, content *apimodel.Content) (*apimodel.Content, gtserror.WithCode) {
 	// If running on S3 storage with proxying disabled then
 	// just fetch a pre-signed URL instead of serving the content.
@@ -21,11 +21,13 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"net/http"
 	"path"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
@@ -110,8 +112,8 @@ func (suite *GetFileTestSuite) TestGetRemoteFileUncached() {
 	suite.NoError(err)
 	suite.True(*dbAttachment.Cached)
 
-	// the file should be back in storage at the same path as before
-	refreshedBytes, err := suite.storage.Get(ctx, testAttachment.File.Path)
+	// the file should be back in storage, as a deduplicated blob
+	refreshedBytes, err := suite.storage.Get(ctx, dbAttachment.File.Path)
 	suite.NoError(err)
 	suite.Equal(suite.testRemoteAttachments[testAttachment.RemoteURL].Data, refreshedBytes)
 }
@@ -156,15 +158,16 @@ func (suite *GetFileTestSuite) TestGetRemoteFileUncachedInterrupted() {
 	suite.NoError(content.Content.Close())
 
 	// the attachment should still be updated in the database even though the caller hung up
+	var dbAttachment *gtsmodel.MediaAttachment
 	if !testrig.WaitFor(func() bool {
-		dbAttachment, _ := suite.db.GetAttachmentByID(ctx, testAttachment.ID)
+		dbAttachment, _ = suite.db.GetAttachmentByID(ctx, testAttachment.ID)
 		return *dbAttachment.Cached
 	}) {
 		suite.FailNow("timed out waiting for attachment to be updated")
 	}
 
-	// the file should be back in storage at the same path as before
-	refreshedBytes, err := suite.storage.Get(ctx, testAttachment.File.Path)
+	// the file should be back in storage, as a deduplicated blob
+	refreshedBytes, err := suite.storage.Get(ctx, dbAttachment.File.Path)
 	suite.NoError(err)
 	suite.Equal(suite.testRemoteAttachments[testAttachment.RemoteURL].Data, refreshedBytes)
 }
@@ -208,6 +211,67 @@ func (suite *GetFileTestSuite) TestGetRemoteFileThumbnailUncached() {
 	suite.EqualValues(testAttachment.Thumbnail.FileSize, content.ContentLength)
 }
 
+// proxyRemoteAttachment uncaches the given remote attachment,
+// and sets the media policy of its domain to proxy its media.
+func (suite *GetFileTestSuite) proxyRemoteAttachment(ctx context.Context, testAttachment *gtsmodel.MediaAttachment) {
+	testAttachment.Cached = util.Ptr(false)
+	if err := suite.db.UpdateByID(ctx, testAttachment, testAttachment.ID, "cached"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.db.PutDomainMediaPolicy(ctx, &gtsmodel.DomainMediaPolicy{
+		ID:                 "01HT9ZJSMN2TW0BJ0Z4J6J5ZQK",
+		Domain:             "fossbros-anonymous.io",
+		Policy:             gtsmodel.MediaPolicyProxy,
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *GetFileTestSuite) TestGetRemoteFileProxied() {
+	ctx := context.Background()
+
+	testAttachment := suite.testAttachments["remote_account_1_status_1_attachment_1"]
+	suite.proxyRemoteAttachment(ctx, testAttachment)
+
+	content, errWithCode := suite.mediaProcessor.GetFile(ctx, nil, &apimodel.GetContentRequestForm{
+		AccountID: testAttachment.AccountID,
+		MediaType: string(media.TypeAttachment),
+		MediaSize: string(media.SizeOriginal),
+		FileName:  path.Base(testAttachment.File.Path),
+	})
+	suite.NoError(errWithCode)
+
+	b, err := io.ReadAll(content.Content)
+	suite.NoError(err)
+	suite.NoError(content.Content.Close())
+
+	// Served as-is from the remote,
+	// as its sniffed content type.
+	suite.Equal(suite.testRemoteAttachments[testAttachment.RemoteURL].Data, b)
+	suite.Equal("image/jpeg", content.ContentType)
+}
+
+func (suite *GetFileTestSuite) TestGetRemoteFileProxiedTooLarge() {
+	ctx := context.Background()
+
+	testAttachment := suite.testAttachments["remote_account_1_status_1_attachment_1"]
+	suite.proxyRemoteAttachment(ctx, testAttachment)
+
+	config.SetMediaImageMaxSize(64)
+	config.SetMediaVideoMaxSize(64)
+
+	content, errWithCode := suite.mediaProcessor.GetFile(ctx, nil, &apimodel.GetContentRequestForm{
+		AccountID: testAttachment.AccountID,
+		MediaType: string(media.TypeAttachment),
+		MediaSize: string(media.SizeOriginal),
+		FileName:  path.Base(testAttachment.File.Path),
+	})
+	suite.Nil(content)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
 func TestGetFileTestSuite(t *testing.T) {
 	suite.Run(t, &GetFileTestSuite{})
 }
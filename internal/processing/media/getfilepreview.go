@@ -0,0 +1,172 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// GetFilePreview returns the attachment at the given fileserver
+// path, along with the account and status it belongs to, so that
+// an HTML preview of it can be rendered for link unfurlers.
+//
+// Only avatars and headers currently in use, and attachments of
+// public statuses visible to the requester, can be previewed.
+// Emojis can't be previewed.
+func (p *Processor) GetFilePreview(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	form *apimodel.GetContentRequestForm,
+) (*apimodel.WebAttachment, gtserror.WithCode) {
+	if _, err := parseSize(form.MediaSize); err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("media size %s not valid", form.MediaSize))
+	}
+
+	mediaType, err := parseType(form.MediaType)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("media type %s not valid", form.MediaType))
+	}
+
+	if mediaType == media.TypeEmoji {
+		return nil, gtserror.NewErrorNotFound(errors.New("emojis can't be previewed"))
+	}
+
+	spl := strings.Split(form.FileName, ".")
+	if len(spl) != 2 || spl[0] == "" || spl[1] == "" {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("file name %s not parseable", form.FileName))
+	}
+	wantedMediaID := spl[0]
+
+	owningAccount, errWithCode := p.getOwningAccount(ctx, requestingAccount, form.AccountID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	attachment, err := p.state.DB.GetAttachmentByID(ctx, wantedMediaID)
+	if err != nil {
+		err = gtserror.Newf("attachment %s could not be taken from the db: %w", wantedMediaID, err)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if attachment.AccountID != owningAccount.ID {
+		err = gtserror.Newf("attachment %s is not owned by %s", wantedMediaID, owningAccount.ID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if util.PtrValueOr(attachment.Quarantined, false) {
+		err = gtserror.Newf("attachment %s is quarantined", wantedMediaID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	var status *gtsmodel.Status
+
+	switch mediaType {
+	case media.TypeAvatar:
+		if attachment.ID != owningAccount.AvatarMediaAttachmentID {
+			err = gtserror.Newf("attachment %s is not the current avatar of %s", wantedMediaID, owningAccount.ID)
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+
+	case media.TypeHeader:
+		if attachment.ID != owningAccount.HeaderMediaAttachmentID {
+			err = gtserror.Newf("attachment %s is not the current header of %s", wantedMediaID, owningAccount.ID)
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+
+	case media.TypeAttachment:
+		if attachment.StatusID == "" {
+			// Unattached media is only
+			// visible to its owner, via
+			// the client API, so 404.
+			err = gtserror.Newf("attachment %s is not attached to a status", wantedMediaID)
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+
+		status, err = p.state.DB.GetStatusByID(ctx, attachment.StatusID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err = gtserror.Newf("db error getting status %s: %w", attachment.StatusID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if status == nil {
+			err = gtserror.Newf("status %s of attachment %s not found", attachment.StatusID, wantedMediaID)
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+
+		visible, err := p.visFilter.StatusVisible(ctx, requestingAccount, status)
+		if err != nil {
+			err = gtserror.Newf("error checking visibility of status %s: %w", status.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if !visible {
+			err = gtserror.Newf("status %s is not visible to requester", status.ID)
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+
+		// Previews are there to be unfurled by anyone the
+		// link is shared with, whoever's requesting now,
+		// so only preview attachments of public statuses.
+		if status.Visibility != gtsmodel.VisibilityPublic {
+			err = gtserror.Newf("status %s is not public", status.ID)
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+	}
+
+	apiAttachment, err := p.converter.AttachmentToAPIAttachment(ctx, attachment)
+	if err != nil {
+		err = gtserror.Newf("error converting attachment %s: %w", attachment.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiAccount, err := p.converter.AccountToAPIAccountPublic(ctx, owningAccount)
+	if err != nil {
+		err = gtserror.Newf("error converting account %s: %w", owningAccount.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	webAttachment := &apimodel.WebAttachment{
+		Attachment:  &apiAttachment,
+		ContentType: attachment.File.ContentType,
+		Account:     apiAccount,
+	}
+
+	if status != nil {
+		webAttachment.Status, err = p.converter.StatusToWebStatus(ctx, status, nil)
+		if err != nil {
+			err = gtserror.Newf("error converting status %s: %w", status.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		// Hide media behind a
+		// click if CW'd / sensitive.
+		webAttachment.Sensitive = webAttachment.Status.Sensitive
+	}
+
+	return webAttachment, nil
+}
@@ -0,0 +1,75 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media_test
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+)
+
+type GetFilePreviewTestSuite struct {
+	MediaStandardTestSuite
+}
+
+func (suite *GetFilePreviewTestSuite) previewForm(attachment *gtsmodel.MediaAttachment) *apimodel.GetContentRequestForm {
+	return &apimodel.GetContentRequestForm{
+		AccountID: attachment.AccountID,
+		MediaType: string(media.TypeAttachment),
+		MediaSize: string(media.SizeOriginal),
+		FileName:  path.Base(attachment.File.Path),
+	}
+}
+
+func (suite *GetFilePreviewTestSuite) TestGetFilePreviewPublic() {
+	ctx := context.Background()
+	attachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+
+	preview, errWithCode := suite.mediaProcessor.GetFilePreview(ctx, nil, suite.previewForm(attachment))
+	suite.NoError(errWithCode)
+	suite.Equal(attachment.ID, preview.Attachment.ID)
+	suite.Equal(attachment.StatusID, preview.Status.ID)
+}
+
+func (suite *GetFilePreviewTestSuite) TestGetFilePreviewNotPublic() {
+	ctx := context.Background()
+	attachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+
+	status := suite.testStatuses["admin_account_status_1"]
+	status.Visibility = gtsmodel.VisibilityFollowersOnly
+	if err := suite.db.UpdateStatus(ctx, status, "visibility"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Even the status owner, who can see
+	// the status, shouldn't get a preview.
+	owner := suite.testAccounts["admin_account"]
+	preview, errWithCode := suite.mediaProcessor.GetFilePreview(ctx, owner, suite.previewForm(attachment))
+	suite.Nil(preview)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestGetFilePreviewTestSuite(t *testing.T) {
+	suite.Run(t, new(GetFilePreviewTestSuite))
+}
@@ -18,6 +18,7 @@
 package media
 
 import (
+	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
@@ -29,14 +30,16 @@ type Processor struct {
 	converter           *typeutils.Converter
 	mediaManager        *media.Manager
 	transportController transport.Controller
+	visFilter           *visibility.Filter
 }
 
 // New returns a new media processor.
-func New(state *state.State, converter *typeutils.Converter, mediaManager *media.Manager, transportController transport.Controller) Processor {
+func New(state *state.State, converter *typeutils.Converter, mediaManager *media.Manager, transportController transport.Controller, visFilter *visibility.Filter) Processor {
 	return Processor{
 		state:               state,
 		converter:           converter,
 		mediaManager:        mediaManager,
 		transportController: transportController,
+		visFilter:           visFilter,
 	}
 }
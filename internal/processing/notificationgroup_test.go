@@ -0,0 +1,183 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package processing_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// TODO: move this to the "internal/processing/timeline" pkg
+type NotificationGroupTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+// putNotifications puts two more faves of local_account_1's
+// first status into the database, followed by a follow, so
+// that local_account_1 has (from newest to oldest):
+//
+//   - follow from local_account_2
+//   - fave from remote_account_1
+//   - fave from local_account_2
+//   - fave from admin_account (already in testrig)
+func (suite *NotificationGroupTestSuite) putNotifications() []*gtsmodel.Notification {
+	var (
+		ctx      = context.Background()
+		target   = suite.testAccounts["local_account_1"]
+		statusID = suite.testStatuses["local_account_1_status_1"].ID
+	)
+
+	notifs := []*gtsmodel.Notification{
+		{
+			NotificationType: gtsmodel.NotificationFave,
+			OriginAccountID:  suite.testAccounts["local_account_2"].ID,
+			StatusID:         statusID,
+		},
+		{
+			NotificationType: gtsmodel.NotificationFave,
+			OriginAccountID:  suite.testAccounts["remote_account_1"].ID,
+			StatusID:         statusID,
+		},
+		{
+			NotificationType: gtsmodel.NotificationFollow,
+			OriginAccountID:  suite.testAccounts["local_account_2"].ID,
+		},
+	}
+
+	for _, n := range notifs {
+		n.ID = id.NewULID()
+		n.CreatedAt = time.Now()
+		n.TargetAccountID = target.ID
+		n.Read = util.Ptr(false)
+
+		if err := suite.db.PutNotification(ctx, n); err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		// Ensure IDs are strictly ascending.
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	return notifs
+}
+
+func (suite *NotificationGroupTestSuite) TestGroupedNotificationsGet() {
+	notifs := suite.putNotifications()
+
+	resp, errWithCode := suite.processor.Timeline().GroupedNotificationsGet(
+		context.Background(),
+		suite.testAutheds["local_account_1"],
+		"", "", "", 40, nil, nil,
+	)
+	suite.NoError(errWithCode)
+
+	groups := resp.Results.NotificationGroups
+	if !suite.Len(groups, 2) {
+		suite.FailNow("")
+	}
+
+	follow := groups[0]
+	suite.Equal("ungrouped-"+notifs[2].ID, follow.GroupKey)
+	suite.Equal("follow", follow.Type)
+	suite.Equal(1, follow.NotificationsCount)
+	suite.Empty(follow.StatusID)
+
+	faves := groups[1]
+	suite.Equal("favourite-"+suite.testStatuses["local_account_1_status_1"].ID, faves.GroupKey)
+	suite.Equal("favourite", faves.Type)
+	suite.Equal(3, faves.NotificationsCount)
+	suite.Equal(notifs[1].ID, faves.MostRecentNotificationID)
+	suite.Equal(notifs[1].ID, faves.PageMaxID)
+	suite.Equal("01F8Q0ANPTWW10DAKTX7BRPBJP", faves.PageMinID)
+	suite.Equal([]string{
+		suite.testAccounts["remote_account_1"].ID,
+		suite.testAccounts["local_account_2"].ID,
+		suite.testAccounts["admin_account"].ID,
+	}, faves.SampleAccountIDs)
+
+	suite.Len(resp.Results.Accounts, 3)
+	suite.Len(resp.Results.Statuses, 1)
+}
+
+func (suite *NotificationGroupTestSuite) TestGroupedNotificationsGetPaged() {
+	notifs := suite.putNotifications()
+
+	// First page should contain only the follow.
+	resp, errWithCode := suite.processor.Timeline().GroupedNotificationsGet(
+		context.Background(),
+		suite.testAutheds["local_account_1"],
+		"", "", "", 1, nil, nil,
+	)
+	suite.NoError(errWithCode)
+
+	if !suite.Len(resp.Results.NotificationGroups, 1) {
+		suite.FailNow("")
+	}
+	suite.Equal("follow", resp.Results.NotificationGroups[0].Type)
+	suite.Contains(resp.LinkHeader, "max_id="+notifs[2].ID)
+
+	// Next page should contain all the faves.
+	resp, errWithCode = suite.processor.Timeline().GroupedNotificationsGet(
+		context.Background(),
+		suite.testAutheds["local_account_1"],
+		notifs[2].ID, "", "", 1, nil, nil,
+	)
+	suite.NoError(errWithCode)
+
+	if !suite.Len(resp.Results.NotificationGroups, 1) {
+		suite.FailNow("")
+	}
+	suite.Equal(3, resp.Results.NotificationGroups[0].NotificationsCount)
+}
+
+func (suite *NotificationGroupTestSuite) TestGroupedNotificationsGetUngrouped() {
+	suite.putNotifications()
+
+	// Only group boosts, so each fave is its own group.
+	resp, errWithCode := suite.processor.Timeline().GroupedNotificationsGet(
+		context.Background(),
+		suite.testAutheds["local_account_1"],
+		"", "", "", 40, nil, []string{"reblog"},
+	)
+	suite.NoError(errWithCode)
+	suite.Len(resp.Results.NotificationGroups, 4)
+}
+
+func (suite *NotificationGroupTestSuite) TestNotificationsUnreadCountGet() {
+	suite.putNotifications()
+
+	// The notifications marker is set to the testrig
+	// fave, so the two new faves + follow are unread.
+	count, errWithCode := suite.processor.Timeline().NotificationsUnreadCountGet(
+		context.Background(),
+		suite.testAutheds["local_account_1"],
+		100, nil, nil,
+	)
+	suite.NoError(errWithCode)
+	suite.Equal(2, count.Count)
+}
+
+func TestNotificationGroupTestSuite(t *testing.T) {
+	suite.Run(t, &NotificationGroupTestSuite{})
+}
@@ -33,16 +33,19 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/processing/markers"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/media"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/polls"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/push"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/report"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/search"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/status"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/stream"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/tags"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/timeline"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/user"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/workers"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 )
 
 // Processor groups together processing functions and
@@ -75,10 +78,12 @@ type Processor struct {
 	markers  markers.Processor
 	media    media.Processor
 	polls    polls.Processor
+	push     push.Processor
 	report   report.Processor
 	search   search.Processor
 	status   status.Processor
 	stream   stream.Processor
+	tags     tags.Processor
 	timeline timeline.Processor
 	user     user.Processor
 	workers  workers.Processor
@@ -112,6 +117,10 @@ func (p *Processor) Polls() *polls.Processor {
 	return &p.polls
 }
 
+func (p *Processor) Push() *push.Processor {
+	return &p.push
+}
+
 func (p *Processor) Report() *report.Processor {
 	return &p.report
 }
@@ -128,6 +137,10 @@ func (p *Processor) Stream() *stream.Processor {
 	return &p.stream
 }
 
+func (p *Processor) Tags() *tags.Processor {
+	return &p.tags
+}
+
 func (p *Processor) Timeline() *timeline.Processor {
 	return &p.timeline
 }
@@ -149,6 +162,7 @@ func NewProcessor(
 	mediaManager *mm.Manager,
 	state *state.State,
 	emailSender email.Sender,
+	webPushSender webpush.Sender,
 ) *Processor {
 	var (
 		parseMentionFunc = GetParseMentionFunc(state, federator)
@@ -169,7 +183,7 @@ func NewProcessor(
 	// be required by the workers processor.
 	common := common.New(state, converter, federator, filter)
 	processor.account = account.New(&common, state, converter, mediaManager, oauthServer, federator, filter, parseMentionFunc)
-	processor.media = media.New(state, converter, mediaManager, federator.TransportController())
+	processor.media = media.New(state, converter, mediaManager, federator.TransportController(), filter)
 	processor.stream = stream.New(state, oauthServer)
 
 	// Instantiate the rest of the sub
@@ -180,7 +194,9 @@ func NewProcessor(
 	processor.list = list.New(state, converter)
 	processor.markers = markers.New(state, converter)
 	processor.polls = polls.New(&common, state, converter)
+	processor.push = push.New(state, converter)
 	processor.report = report.New(state, converter)
+	processor.tags = tags.New(&common, state, converter)
 	processor.timeline = timeline.New(state, converter, filter)
 	processor.search = search.New(state, federator, converter, filter)
 	processor.status = status.New(state, &common, &processor.polls, federator, converter, filter, parseMentionFunc)
@@ -195,6 +211,7 @@ func NewProcessor(
 		converter,
 		filter,
 		emailSender,
+		webPushSender,
 		&processor.account,
 		&processor.media,
 		&processor.stream,
@@ -220,7 +237,7 @@ func NewProcessorWithMedia(
 	//
 	// Start with sub processors that will
 	// be required by the workers processor.
-	processor.media = media.New(state, converter, mediaManager, nil)
+	processor.media = media.New(state, converter, mediaManager, nil, visibility.NewFilter(state))
 	processor.workers = workers.New(
 		state,
 		nil,
@@ -228,6 +245,7 @@ func NewProcessorWithMedia(
 		nil,
 		nil,
 		nil,
+		nil,
 		&processor.media,
 		nil,
 	)
@@ -0,0 +1,234 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package push
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
+)
+
+type Processor struct {
+	state     *state.State
+	converter *typeutils.Converter
+}
+
+func New(state *state.State, converter *typeutils.Converter) Processor {
+	return Processor{
+		state:     state,
+		converter: converter,
+	}
+}
+
+// Get returns the push subscription of the given access token.
+func (p *Processor) Get(
+	ctx context.Context,
+	accessToken string,
+) (*apimodel.PushSubscription, gtserror.WithCode) {
+	subscription, errWithCode := p.getSubscription(ctx, accessToken)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiSubscription(ctx, subscription)
+}
+
+// Create creates a push subscription for the given access token
+// of the given account, replacing any existing one for the token.
+func (p *Processor) Create(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	accessToken string,
+	form *apimodel.PushSubscriptionCreateRequest,
+) (*apimodel.PushSubscription, gtserror.WithCode) {
+	token, errWithCode := p.getToken(ctx, accessToken)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	endpoint := form.Endpoint()
+	if u, err := url.Parse(endpoint); err != nil || u.Scheme != "https" || u.Host == "" {
+		const text = "subscription endpoint must be an https URL"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if err := webpush.ValidateKeys(form.P256dh(), form.Auth()); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	policy, errWithCode := parsePolicy(form.Policy(), gtsmodel.WebPushPolicyAll)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	subscription := &gtsmodel.WebPushSubscription{
+		ID:        id.NewULID(),
+		AccountID: account.ID,
+		TokenID:   token.ID,
+		Endpoint:  endpoint,
+		P256dh:    form.P256dh(),
+		Auth:      form.Auth(),
+		Policy:    policy,
+	}
+	setAlerts(subscription, form.Alerts())
+
+	// Each token has at most one subscription,
+	// so drop any existing one before storing.
+	if err := p.state.DB.DeleteWebPushSubscriptionByTokenID(ctx, token.ID); err != nil {
+		err := gtserror.Newf("db error deleting push subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.PutWebPushSubscription(ctx, subscription); err != nil {
+		err := gtserror.Newf("db error putting push subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiSubscription(ctx, subscription)
+}
+
+// Update updates the alerts and policy of
+// the push subscription of the given access token.
+func (p *Processor) Update(
+	ctx context.Context,
+	accessToken string,
+	form *apimodel.PushSubscriptionUpdateRequest,
+) (*apimodel.PushSubscription, gtserror.WithCode) {
+	subscription, errWithCode := p.getSubscription(ctx, accessToken)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	subscription.Policy, errWithCode = parsePolicy(form.Policy(), subscription.Policy)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+	setAlerts(subscription, form.Alerts())
+
+	if err := p.state.DB.UpdateWebPushSubscription(ctx, subscription); err != nil {
+		err := gtserror.Newf("db error updating push subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiSubscription(ctx, subscription)
+}
+
+// Delete deletes the push subscription of the given
+// access token, if it has one.
+func (p *Processor) Delete(ctx context.Context, accessToken string) gtserror.WithCode {
+	token, errWithCode := p.getToken(ctx, accessToken)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.state.DB.DeleteWebPushSubscriptionByTokenID(ctx, token.ID); err != nil {
+		err := gtserror.Newf("db error deleting push subscription: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// getToken gets the token model with the given access token.
+func (p *Processor) getToken(ctx context.Context, accessToken string) (*gtsmodel.Token, gtserror.WithCode) {
+	token := new(gtsmodel.Token)
+	if err := p.state.DB.GetWhere(ctx, []db.Where{{Key: "access", Value: accessToken}}, token); err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			const text = "access token not found"
+			return nil, gtserror.NewErrorUnauthorized(errors.New(text), text)
+		}
+
+		err := gtserror.Newf("db error getting token: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return token, nil
+}
+
+// getSubscription gets the push subscription of the given access token.
+func (p *Processor) getSubscription(ctx context.Context, accessToken string) (*gtsmodel.WebPushSubscription, gtserror.WithCode) {
+	token, errWithCode := p.getToken(ctx, accessToken)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	subscription, err := p.state.DB.GetWebPushSubscriptionByTokenID(ctx, token.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			const text = "push subscription not found"
+			return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+		}
+
+		err := gtserror.Newf("db error getting push subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return subscription, nil
+}
+
+func (p *Processor) apiSubscription(ctx context.Context, subscription *gtsmodel.WebPushSubscription) (*apimodel.PushSubscription, gtserror.WithCode) {
+	apiSubscription, err := p.converter.PushSubscriptionToAPIPushSubscription(ctx, subscription)
+	if err != nil {
+		err := gtserror.Newf("error converting push subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiSubscription, nil
+}
+
+// parsePolicy parses the given push
+// policy, or returns def if it's empty.
+func parsePolicy(policy string, def gtsmodel.WebPushPolicy) (gtsmodel.WebPushPolicy, gtserror.WithCode) {
+	switch p := gtsmodel.WebPushPolicy(policy); p {
+	case "":
+		return def, nil
+
+	case gtsmodel.WebPushPolicyAll,
+		gtsmodel.WebPushPolicyFollowed,
+		gtsmodel.WebPushPolicyFollower,
+		gtsmodel.WebPushPolicyNone:
+		return p, nil
+
+	default:
+		text := fmt.Sprintf("invalid push policy %q, must be one of all, followed, follower, none", policy)
+		return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+}
+
+// setAlerts sets the alert fields of the
+// given subscription from the given alerts.
+func setAlerts(subscription *gtsmodel.WebPushSubscription, alerts apimodel.PushSubscriptionAlerts) {
+	subscription.AlertFollow = util.Ptr(alerts.Follow)
+	subscription.AlertFollowRequest = util.Ptr(alerts.FollowRequest)
+	subscription.AlertFavourite = util.Ptr(alerts.Favourite)
+	subscription.AlertMention = util.Ptr(alerts.Mention)
+	subscription.AlertReblog = util.Ptr(alerts.Reblog)
+	subscription.AlertPoll = util.Ptr(alerts.Poll)
+	subscription.AlertStatus = util.Ptr(alerts.Status)
+}
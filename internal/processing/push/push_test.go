@@ -0,0 +1,158 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package push_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/push"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+// Keys from the RFC 8291 example.
+const (
+	testP256dh = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+	testAuth   = "BTBZMqHH6r4Tts7J_aSIgg"
+)
+
+type PushTestSuite struct {
+	suite.Suite
+	state state.State
+	push  push.Processor
+
+	testAccounts map[string]*gtsmodel.Account
+	testTokens   map[string]*gtsmodel.Token
+}
+
+func (suite *PushTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+	suite.state.Caches.Init()
+	testrig.NewTestDB(&suite.state)
+	testrig.StandardDBSetup(suite.state.DB, nil)
+	suite.push = push.New(&suite.state, typeutils.NewConverter(&suite.state))
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testTokens = testrig.NewTestTokens()
+}
+
+func (suite *PushTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.state.DB)
+}
+
+func (suite *PushTestSuite) createRequest(endpoint string) *apimodel.PushSubscriptionCreateRequest {
+	form := &apimodel.PushSubscriptionCreateRequest{
+		Subscription: &apimodel.PushSubscriptionRequestSubscription{
+			Endpoint: endpoint,
+			Keys: apimodel.PushSubscriptionRequestKeys{
+				P256dh: testP256dh,
+				Auth:   testAuth,
+			},
+		},
+	}
+	form.Data = &apimodel.PushSubscriptionRequestData{
+		Alerts: &apimodel.PushSubscriptionAlerts{
+			Mention: true,
+			Follow:  true,
+		},
+		Policy: "followed",
+	}
+	return form
+}
+
+func (suite *PushTestSuite) TestCreateGetUpdateDelete() {
+	var (
+		ctx         = context.Background()
+		account     = suite.testAccounts["local_account_1"]
+		accessToken = suite.testTokens["local_account_1"].Access
+	)
+
+	// No subscription yet.
+	_, errWithCode := suite.push.Get(ctx, accessToken)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// Create one.
+	subscription, errWithCode := suite.push.Create(ctx, account, accessToken, suite.createRequest("https://push.example.org/1"))
+	suite.NoError(errWithCode)
+	suite.Equal("https://push.example.org/1", subscription.Endpoint)
+	suite.Equal("BLbdmf1NCfOCmExfx_SN8VF8ZX5UEQ89cD8WzNuoH4GjkX22jrsgjMlR-lgy4tvJmBxNuu6wFIdDLKMoZVOTdyU", subscription.ServerKey)
+	suite.True(subscription.Alerts.Mention)
+	suite.True(subscription.Alerts.Follow)
+	suite.False(subscription.Alerts.Favourite)
+	suite.Equal("followed", subscription.Policy)
+
+	// Creating again replaces it.
+	replaced, errWithCode := suite.push.Create(ctx, account, accessToken, suite.createRequest("https://push.example.org/2"))
+	suite.NoError(errWithCode)
+	suite.NotEqual(subscription.ID, replaced.ID)
+
+	got, errWithCode := suite.push.Get(ctx, accessToken)
+	suite.NoError(errWithCode)
+	suite.Equal(replaced.ID, got.ID)
+	suite.Equal("https://push.example.org/2", got.Endpoint)
+
+	// Update alerts; policy is left as-is.
+	updated, errWithCode := suite.push.Update(ctx, accessToken, &apimodel.PushSubscriptionUpdateRequest{
+		FormAlertFavourite: true,
+	})
+	suite.NoError(errWithCode)
+	suite.False(updated.Alerts.Mention)
+	suite.True(updated.Alerts.Favourite)
+	suite.Equal("followed", updated.Policy)
+
+	// Delete it.
+	errWithCode = suite.push.Delete(ctx, accessToken)
+	suite.NoError(errWithCode)
+
+	_, errWithCode = suite.push.Get(ctx, accessToken)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *PushTestSuite) TestCreateInvalid() {
+	var (
+		ctx         = context.Background()
+		account     = suite.testAccounts["local_account_1"]
+		accessToken = suite.testTokens["local_account_1"].Access
+	)
+
+	// Endpoint must be https.
+	_, errWithCode := suite.push.Create(ctx, account, accessToken, suite.createRequest("http://push.example.org/1"))
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	// Keys must be valid.
+	form := suite.createRequest("https://push.example.org/1")
+	form.Subscription.Keys.Auth = "nope"
+	_, errWithCode = suite.push.Create(ctx, account, accessToken, form)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	// Policy must be valid.
+	form = suite.createRequest("https://push.example.org/1")
+	form.Data.Policy = "everyone"
+	_, errWithCode = suite.push.Create(ctx, account, accessToken, form)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func TestPushTestSuite(t *testing.T) {
+	suite.Run(t, new(PushTestSuite))
+}
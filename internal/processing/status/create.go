@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"html"
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
@@ -104,6 +105,15 @@ func (p *Processor) Create(
 		return nil, errWithCode
 	}
 
+	// Check + attach quoted status.
+	if errWithCode := p.processQuote(ctx,
+		requester,
+		status,
+		form.QuoteID,
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	if errWithCode := p.processThreadID(ctx, status); errWithCode != nil {
 		return nil, errWithCode
 	}
@@ -194,6 +204,48 @@ func (p *Processor) processInReplyTo(ctx context.Context, requester *gtsmodel.Ac
 	return nil
 }
 
+func (p *Processor) processQuote(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status, quoteID string) gtserror.WithCode {
+	if quoteID == "" {
+		return nil
+	}
+
+	// Fetch target quoted status (checking visibility
+	// and blocks between requester and quoted author).
+	quoteOf, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+		requester,
+		quoteID,
+		nil,
+	)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	// If this is a boost, unwrap it to get source status.
+	quoteOf, errWithCode = p.c.UnwrapIfBoost(ctx,
+		requester,
+		quoteOf,
+	)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	// Only allow quoting statuses that their author intended
+	// to be widely visible, otherwise a quote would leak a
+	// private status to the quoting account's audience.
+	if quoteOf.Visibility != gtsmodel.VisibilityPublic &&
+		quoteOf.Visibility != gtsmodel.VisibilityUnlocked {
+		const text = "quoted status must be public or unlisted"
+		return gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	// Set status fields from quoteOf.
+	status.QuoteOfID = quoteOf.ID
+	status.QuoteOf = quoteOf
+	status.QuoteOfURI = quoteOf.URI
+
+	return nil
+}
+
 func (p *Processor) processThreadID(ctx context.Context, status *gtsmodel.Status) gtserror.WithCode {
 	// Status takes the thread ID of
 	// whatever it replies to, if set.
@@ -407,6 +459,12 @@ func (p *Processor) processContent(ctx context.Context, parseMention gtsmodel.Pa
 
 	// Collect formatted results.
 	status.Content = contentRes.HTML
+	if status.QuoteOf != nil {
+		// Append a link to the quoted status, as Misskey
+		// and Akkoma do, for any software that doesn't
+		// understand the quote properties.
+		status.Content += quoteFallback(status.QuoteOf)
+	}
 	status.Mentions = append(status.Mentions, contentRes.Mentions...)
 	status.Emojis = append(status.Emojis, contentRes.Emojis...)
 	status.Tags = append(status.Tags, contentRes.Tags...)
@@ -443,6 +501,17 @@ func (p *Processor) processContent(ctx context.Context, parseMention gtsmodel.Pa
 	return nil
 }
 
+// quoteFallback returns an HTML "RE: <link>"
+// paragraph pointing to the given quoted status.
+func quoteFallback(quoteOf *gtsmodel.Status) string {
+	link := quoteOf.URL
+	if link == "" {
+		link = quoteOf.URI
+	}
+	link = html.EscapeString(link)
+	return `<p class="quote-inline">RE: <a href="` + link + `">` + link + `</a></p>`
+}
+
 // gatherIDs is a small utility function to gather IDs from a slice of type T.
 func gatherIDs[T any](in []T, getID func(T) string) []string {
 	if getID == nil {
@@ -286,6 +286,64 @@ func (suite *StatusCreateTestSuite) TestProcessReplyToUnthreadedRemoteStatus() {
 	suite.NotEmpty(dbStatus.ThreadID)
 }
 
+func (suite *StatusCreateTestSuite) TestProcessQuote() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+	quotedStatus := suite.testStatuses["admin_account_status_1"]
+
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "look at this",
+			QuoteID:     quotedStatus.ID,
+			Visibility:  apimodel.VisibilityPublic,
+			Language:    "en",
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	}
+
+	apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.NotNil(apiStatus.Quote)
+	suite.Equal(apimodel.StatusQuoteStateAccepted, apiStatus.Quote.State)
+	suite.Equal(quotedStatus.ID, *apiStatus.Quote.QuotedStatusID)
+	suite.Contains(apiStatus.Content, `RE: <a href="`+quotedStatus.URL+`">`)
+
+	// Quote should be stored in the database.
+	dbStatus, err := suite.db.GetStatusByID(ctx, apiStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(quotedStatus.ID, dbStatus.QuoteOfID)
+	suite.Equal(quotedStatus.URI, dbStatus.QuoteOfURI)
+}
+
+func (suite *StatusCreateTestSuite) TestProcessQuoteFollowersOnly() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+	quotedStatus := suite.testStatuses["local_account_1_status_5"]
+
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "look at this",
+			QuoteID:     quotedStatus.ID,
+			Visibility:  apimodel.VisibilityPublic,
+			Language:    "en",
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	}
+
+	apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.Nil(apiStatus)
+	suite.EqualError(errWithCode, "quoted status must be public or unlisted")
+}
+
 func TestStatusCreateTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusCreateTestSuite))
 }
@@ -19,6 +19,7 @@ package stream
 
 import (
 	"context"
+	"errors"
 
 	"codeberg.org/gruf/go-kv"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -28,11 +29,40 @@ import (
 )
 
 // Open returns a new Stream for the given account, which will contain a channel for passing messages back to the caller.
-func (p *Processor) Open(ctx context.Context, account *gtsmodel.Account, streamType string) (*stream.Stream, gtserror.WithCode) {
+//
+// Hashtag stream types must be qualified with the tag name (see stream.HashtagStreamType).
+func (p *Processor) Open(ctx context.Context, account *gtsmodel.Account, streamTypes ...string) (*stream.Stream, gtserror.WithCode) {
+	return p.Resume(ctx, account, "", streamTypes...)
+}
+
+// Resume is like Open, but the returned Stream will first receive recent messages posted
+// after the message with given ID, if it's still available (see stream.Streams{}.Resume()).
+func (p *Processor) Resume(ctx context.Context, account *gtsmodel.Account, lastMsgID string, streamTypes ...string) (*stream.Stream, gtserror.WithCode) {
 	l := log.WithContext(ctx).WithFields(kv.Fields{
 		{"account", account.ID},
-		{"streamType", streamType},
+		{"lastMsgID", lastMsgID},
+		{"streamTypes", streamTypes},
 	}...)
 	l.Debug("received open stream request")
-	return p.streams.Open(account.ID, streamType), nil
+
+	if len(streamTypes) == 0 {
+		const text = "no stream type given"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	for _, streamType := range streamTypes {
+		switch streamType {
+		case stream.TimelineHashtag, stream.TimelineHashtagLocal:
+			const text = "hashtag streams require a tag"
+			return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+	}
+
+	return p.streams.Resume(account.ID, lastMsgID, streamTypes...), nil
+}
+
+// Subscribers returns the IDs of accounts with at
+// least one open stream matching any given type.
+func (p *Processor) Subscribers(streamTypes ...string) []string {
+	return p.streams.Subscribers(streamTypes...)
 }
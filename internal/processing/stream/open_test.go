@@ -19,9 +19,11 @@ package stream_test
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
 )
 
 type OpenStreamTestSuite struct {
@@ -35,6 +37,41 @@ func (suite *OpenStreamTestSuite) TestOpenStream() {
 	suite.NoError(errWithCode)
 }
 
+func (suite *OpenStreamTestSuite) TestOpenHashtagStreams() {
+	account := suite.testAccounts["local_account_1"]
+
+	_, errWithCode := suite.streamProcessor.Open(context.Background(), account,
+		stream.HashtagStreamType(stream.TimelineHashtag, "#Welcome"),
+		stream.HashtagStreamType(stream.TimelineHashtagLocal, "hashtag"),
+	)
+	suite.NoError(errWithCode)
+
+	suite.Equal([]string{account.ID}, suite.streamProcessor.Subscribers("hashtag#welcome"))
+	suite.Equal([]string{account.ID}, suite.streamProcessor.Subscribers("hashtag:local#hashtag"))
+	suite.Empty(suite.streamProcessor.Subscribers("hashtag#hashtag"))
+}
+
+func (suite *OpenStreamTestSuite) TestOpenHashtagStreamLocalTag() {
+	account := suite.testAccounts["local_account_1"]
+
+	// A tag named "local" must not be
+	// confused with the local timeline.
+	_, errWithCode := suite.streamProcessor.Open(context.Background(), account,
+		stream.HashtagStreamType(stream.TimelineHashtag, "local"),
+	)
+	suite.NoError(errWithCode)
+
+	suite.Equal([]string{account.ID}, suite.streamProcessor.Subscribers("hashtag#local"))
+	suite.Empty(suite.streamProcessor.Subscribers(stream.TimelineHashtagLocal))
+}
+
+func (suite *OpenStreamTestSuite) TestOpenHashtagStreamNoTag() {
+	account := suite.testAccounts["local_account_1"]
+
+	_, errWithCode := suite.streamProcessor.Open(context.Background(), account, stream.TimelineHashtag)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
 func TestOpenStreamTestSuite(t *testing.T) {
 	suite.Run(t, &OpenStreamTestSuite{})
 }
@@ -36,3 +36,22 @@ func New(state *state.State, oauthServer oauth.Server) Processor {
 		streams:     stream.Streams{},
 	}
 }
+
+// Relay relays all stream messages via the given broker,
+// delivering messages published to the broker by any node
+// to open streams on this node. See stream.Streams{}.Relay().
+func (p *Processor) Relay(broker stream.Broker) error {
+	return p.streams.Relay(broker)
+}
+
+// PublishTo publishes all stream messages to the given broker,
+// without listening for messages from it. See stream.Streams{}.PublishTo().
+func (p *Processor) PublishTo(broker stream.Broker) {
+	p.streams.PublishTo(broker)
+}
+
+// SetFilter sets the filter used to check which accounts
+// receive updates sent with UpdateAll. See stream.Streams{}.SetFilter().
+func (p *Processor) SetFilter(filter stream.Filter) {
+	p.streams.SetFilter(filter)
+}
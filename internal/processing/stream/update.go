@@ -29,7 +29,7 @@ import (
 )
 
 // Update streams the given update to any open, appropriate streams belonging to the given account.
-func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, status *apimodel.Status, streamType string) {
+func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, status *apimodel.Status, streamTypes ...string) {
 	b, err := json.Marshal(status)
 	if err != nil {
 		log.Errorf(ctx, "error marshaling json: %v", err)
@@ -38,6 +38,22 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, statu
 	p.streams.Post(ctx, account.ID, stream.Message{
 		Payload: byteutil.B2S(b),
 		Event:   stream.EventTypeUpdate,
-		Stream:  []string{streamType},
+		Stream:  streamTypes,
+	})
+}
+
+// UpdateAll streams the given status update to any open, appropriate streams belonging
+// to any account which passes the filter set with SetFilter, on this and other nodes.
+// The status should be rendered without a requesting account, as it is shared by all.
+func (p *Processor) UpdateAll(ctx context.Context, status *apimodel.Status, streamTypes ...string) {
+	b, err := json.Marshal(status)
+	if err != nil {
+		log.Errorf(ctx, "error marshaling json: %v", err)
+		return
+	}
+	p.streams.PostStatus(ctx, status.ID, stream.Message{
+		Payload: byteutil.B2S(b),
+		Event:   stream.EventTypeUpdate,
+		Stream:  streamTypes,
 	})
 }
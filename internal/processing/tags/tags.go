@@ -0,0 +1,193 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/common"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+)
+
+type Processor struct {
+	// common processor logic
+	c *common.Processor
+
+	state     *state.State
+	converter *typeutils.Converter
+}
+
+func New(common *common.Processor, state *state.State, converter *typeutils.Converter) Processor {
+	return Processor{
+		c:         common,
+		state:     state,
+		converter: converter,
+	}
+}
+
+// normalize normalizes the given hashtag
+// name, returning an error with code if
+// the name is not a valid hashtag.
+func normalize(name string) (string, gtserror.WithCode) {
+	normalized, ok := text.NormalizeHashtag(name)
+	if !ok {
+		err := fmt.Errorf("invalid hashtag name: %s", name)
+		return "", gtserror.NewErrorBadRequest(err, err.Error())
+	}
+	return normalized, nil
+}
+
+// getTag fetches the tag with the given normalized
+// name from the database, returning nil if it
+// doesn't exist yet.
+func (p *Processor) getTag(ctx context.Context, name string) (*gtsmodel.Tag, gtserror.WithCode) {
+	tag, err := p.state.DB.GetTagByName(ctx, name)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting tag %s: %w", name, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	return tag, nil
+}
+
+// toAPITag converts the given tag to its frontend API
+// model, setting whether the requester follows the tag.
+func (p *Processor) toAPITag(ctx context.Context, requester *gtsmodel.Account, tag *gtsmodel.Tag, following bool) (*apimodel.Tag, gtserror.WithCode) {
+	apiTag, err := p.converter.TagToAPITag(ctx, tag, true)
+	if err != nil {
+		err := gtserror.Newf("error converting tag %s to api model: %w", tag.Name, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	apiTag.Following = &following
+	return &apiTag, nil
+}
+
+// Get returns the hashtag with the given name, including
+// whether it is followed by the requesting account.
+func (p *Processor) Get(ctx context.Context, requester *gtsmodel.Account, name string) (*apimodel.Tag, gtserror.WithCode) {
+	normalized, errWithCode := normalize(name)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	tag, errWithCode := p.getTag(ctx, normalized)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if tag == nil {
+		err := fmt.Errorf("hashtag %s not found", normalized)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	following, err := p.state.DB.IsAccountFollowingTag(ctx, requester.ID, tag.ID)
+	if err != nil {
+		err := gtserror.Newf("db error checking tag follow: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.toAPITag(ctx, requester, tag, following)
+}
+
+// Follow makes the requesting account follow the hashtag
+// with the given name, creating the hashtag in the database
+// if it doesn't exist yet. Following an already-followed
+// hashtag is a no-op.
+func (p *Processor) Follow(ctx context.Context, requester *gtsmodel.Account, name string) (*apimodel.Tag, gtserror.WithCode) {
+	normalized, errWithCode := normalize(name)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	tag, errWithCode := p.getTag(ctx, normalized)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if tag == nil {
+		// We didn't have a tag with
+		// this name, create one.
+		tag = &gtsmodel.Tag{
+			ID:   id.NewULID(),
+			Name: normalized,
+		}
+
+		if err := p.state.DB.PutTag(ctx, tag); err != nil {
+			err := gtserror.Newf("db error putting new tag %s: %w", normalized, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	following, err := p.state.DB.IsAccountFollowingTag(ctx, requester.ID, tag.ID)
+	if err != nil {
+		err := gtserror.Newf("db error checking tag follow: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if !following {
+		followedTag := &gtsmodel.FollowedTag{
+			ID:        id.NewULID(),
+			AccountID: requester.ID,
+			Account:   requester,
+			TagID:     tag.ID,
+			Tag:       tag,
+		}
+
+		if err := p.state.DB.PutFollowedTag(ctx, followedTag); err != nil {
+			err := gtserror.Newf("db error putting followed tag: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return p.toAPITag(ctx, requester, tag, true)
+}
+
+// Unfollow makes the requesting account unfollow the hashtag
+// with the given name. Unfollowing a hashtag that isn't
+// followed is a no-op.
+func (p *Processor) Unfollow(ctx context.Context, requester *gtsmodel.Account, name string) (*apimodel.Tag, gtserror.WithCode) {
+	normalized, errWithCode := normalize(name)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	tag, errWithCode := p.getTag(ctx, normalized)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if tag == nil {
+		err := fmt.Errorf("hashtag %s not found", normalized)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	if err := p.state.DB.DeleteFollowedTag(ctx, requester.ID, tag.ID); err != nil {
+		err := gtserror.Newf("db error deleting followed tag: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.toAPITag(ctx, requester, tag, false)
+}
@@ -0,0 +1,124 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tags_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/common"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/tags"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type TagsTestSuite struct {
+	suite.Suite
+	state state.State
+	tags  tags.Processor
+
+	testAccounts map[string]*gtsmodel.Account
+}
+
+func (suite *TagsTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+	suite.state.Caches.Init()
+	testrig.StartNoopWorkers(&suite.state)
+	testrig.NewTestDB(&suite.state)
+	testrig.StandardDBSetup(suite.state.DB, nil)
+	converter := typeutils.NewConverter(&suite.state)
+	controller := testrig.NewTestTransportController(&suite.state, nil)
+	mediaMgr := media.NewManager(&suite.state)
+	federator := testrig.NewTestFederator(&suite.state, controller, mediaMgr)
+	filter := visibility.NewFilter(&suite.state)
+	common := common.New(&suite.state, converter, federator, filter)
+	suite.tags = tags.New(&common, &suite.state, converter)
+	suite.testAccounts = testrig.NewTestAccounts()
+}
+
+func (suite *TagsTestSuite) TearDownTest() {
+	testrig.StopWorkers(&suite.state)
+	testrig.StandardDBTeardown(suite.state.DB)
+}
+
+func (suite *TagsTestSuite) TestFollowUnfollow() {
+	ctx := context.Background()
+	requester := suite.testAccounts["local_account_1"]
+
+	// Not followed yet.
+	tag, errWithCode := suite.tags.Get(ctx, requester, "Welcome")
+	suite.NoError(errWithCode)
+	suite.Equal("welcome", tag.Name)
+	suite.False(*tag.Following)
+
+	// Follow the tag.
+	tag, errWithCode = suite.tags.Follow(ctx, requester, "#welcome")
+	suite.NoError(errWithCode)
+	suite.True(*tag.Following)
+
+	// Following again is a no-op.
+	_, errWithCode = suite.tags.Follow(ctx, requester, "welcome")
+	suite.NoError(errWithCode)
+
+	resp, errWithCode := suite.tags.FollowedTagsGet(ctx, requester, nil)
+	suite.NoError(errWithCode)
+	suite.Len(resp.Items, 1)
+
+	// Unfollow the tag.
+	tag, errWithCode = suite.tags.Unfollow(ctx, requester, "welcome")
+	suite.NoError(errWithCode)
+	suite.False(*tag.Following)
+
+	resp, errWithCode = suite.tags.FollowedTagsGet(ctx, requester, nil)
+	suite.NoError(errWithCode)
+	suite.Empty(resp.Items)
+}
+
+func (suite *TagsTestSuite) TestFollowNewTag() {
+	ctx := context.Background()
+	requester := suite.testAccounts["local_account_1"]
+
+	// Tag doesn't exist yet.
+	_, errWithCode := suite.tags.Get(ctx, requester, "brandnewtag")
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// Following creates it.
+	tag, errWithCode := suite.tags.Follow(ctx, requester, "brandnewtag")
+	suite.NoError(errWithCode)
+	suite.Equal("brandnewtag", tag.Name)
+	suite.True(*tag.Following)
+}
+
+func (suite *TagsTestSuite) TestFollowInvalid() {
+	ctx := context.Background()
+	requester := suite.testAccounts["local_account_1"]
+
+	_, errWithCode := suite.tags.Follow(ctx, requester, "not a tag!")
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func TestTagsTestSuite(t *testing.T) {
+	suite.Run(t, new(TagsTestSuite))
+}
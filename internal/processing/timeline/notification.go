@@ -61,30 +61,8 @@ func (p *Processor) NotificationsGet(ctx context.Context, authed *oauth.Auth, ma
 		}
 
 		// Ensure this notification should be shown to requester.
-		if n.OriginAccount != nil {
-			// Account is set, ensure it's visible to notif target.
-			visible, err := p.filter.AccountVisible(ctx, authed.Account, n.OriginAccount)
-			if err != nil {
-				log.Debugf(ctx, "skipping notification %s because of an error checking notification visibility: %s", n.ID, err)
-				continue
-			}
-
-			if !visible {
-				continue
-			}
-		}
-
-		if n.Status != nil {
-			// Status is set, ensure it's visible to notif target.
-			visible, err := p.filter.StatusVisible(ctx, authed.Account, n.Status)
-			if err != nil {
-				log.Debugf(ctx, "skipping notification %s because of an error checking notification visibility: %s", n.ID, err)
-				continue
-			}
-
-			if !visible {
-				continue
-			}
+		if !p.notificationVisible(ctx, authed.Account, n) {
+			continue
 		}
 
 		item, err := p.converter.NotificationToAPINotification(ctx, n)
@@ -142,3 +120,36 @@ func (p *Processor) NotificationsClear(ctx context.Context, authed *oauth.Auth)
 
 	return nil
 }
+
+// notificationVisible returns whether the given notification's origin
+// account and status (if set) are visible to the requesting account.
+// Errors checking visibility are logged, and treated as not visible.
+func (p *Processor) notificationVisible(ctx context.Context, requester *gtsmodel.Account, n *gtsmodel.Notification) bool {
+	if n.OriginAccount != nil {
+		// Account is set, ensure it's visible to notif target.
+		visible, err := p.filter.AccountVisible(ctx, requester, n.OriginAccount)
+		if err != nil {
+			log.Debugf(ctx, "skipping notification %s because of an error checking notification visibility: %s", n.ID, err)
+			return false
+		}
+
+		if !visible {
+			return false
+		}
+	}
+
+	if n.Status != nil {
+		// Status is set, ensure it's visible to notif target.
+		visible, err := p.filter.StatusVisible(ctx, requester, n.Status)
+		if err != nil {
+			log.Debugf(ctx, "skipping notification %s because of an error checking notification visibility: %s", n.ID, err)
+			return false
+		}
+
+		if !visible {
+			return false
+		}
+	}
+
+	return true
+}
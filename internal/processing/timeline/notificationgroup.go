@@ -0,0 +1,382 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package timeline
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	// Number of notifications to fetch from
+	// the database per batch when grouping.
+	notificationGroupBatchSize = 40
+
+	// Maximum number of notifications to scan when
+	// building one page of notification groups, so
+	// that a status with many thousands of faves
+	// doesn't make us walk the entire table.
+	notificationGroupMaxScan = 1000
+
+	// Maximum number of sample accounts
+	// to include per notification group.
+	notificationGroupMaxSampleAccounts = 8
+)
+
+// groupableNotificationTypes are the notification types
+// that may be grouped together by status. Other types
+// (mentions, polls etc) are always ungrouped.
+var groupableNotificationTypes = []string{
+	string(gtsmodel.NotificationFave),
+	string(gtsmodel.NotificationReblog),
+}
+
+// notificationGroup is one group of
+// notifications in the process of being
+// assembled, sorted by ID descending.
+type notificationGroup struct {
+	key    string
+	notifs []*gtsmodel.Notification
+}
+
+// notificationGroups is the result of
+// scanning notifications into groups.
+type notificationGroups struct {
+	groups []*notificationGroup
+	highID string // highest (newest) notification ID scanned
+	lowID  string // lowest (oldest) notification ID scanned
+}
+
+// GroupedNotificationsGet returns one page of notifications for the
+// requesting account, grouped by type and status where possible.
+//
+// Paging parameters apply to notification IDs as with NotificationsGet,
+// but limit applies to the number of groups rather than notifications.
+// groupedTypes can be used to restrict which of the groupable types
+// (favourite, reblog) are actually grouped; if empty, all are grouped.
+func (p *Processor) GroupedNotificationsGet(
+	ctx context.Context,
+	authed *oauth.Auth,
+	maxID string,
+	sinceID string,
+	minID string,
+	limit int,
+	excludeTypes []string,
+	groupedTypes []string,
+) (*apimodel.GroupedNotificationsResponse, gtserror.WithCode) {
+	scanned, errWithCode := p.groupNotifications(ctx,
+		authed.Account,
+		maxID,
+		sinceID,
+		minID,
+		limit,
+		excludeTypes,
+		groupedTypes,
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	results := &apimodel.GroupedNotificationsResults{
+		Accounts:           make([]*apimodel.Account, 0),
+		Statuses:           make([]*apimodel.Status, 0),
+		NotificationGroups: make([]*apimodel.NotificationGroup, 0, len(scanned.groups)),
+	}
+
+	if len(scanned.groups) == 0 {
+		// Nothing to page through.
+		return &apimodel.GroupedNotificationsResponse{
+			Results: results,
+		}, nil
+	}
+
+	var (
+		// Accounts + statuses already
+		// added to results, by ID.
+		accountIDs = make(map[string]struct{})
+		statusIDs  = make(map[string]struct{})
+	)
+
+	for _, group := range scanned.groups {
+		var (
+			newest = group.notifs[0]
+			oldest = group.notifs[len(group.notifs)-1]
+		)
+
+		apiGroup := &apimodel.NotificationGroup{
+			GroupKey:                 group.key,
+			NotificationsCount:       len(group.notifs),
+			Type:                     string(newest.NotificationType),
+			MostRecentNotificationID: newest.ID,
+			PageMinID:                oldest.ID,
+			PageMaxID:                newest.ID,
+			LatestPageNotificationAt: util.FormatISO8601(newest.CreatedAt),
+			SampleAccountIDs:         make([]string, 0, notificationGroupMaxSampleAccounts),
+			StatusID:                 newest.StatusID,
+		}
+
+		if newest.Status != nil {
+			if _, ok := statusIDs[newest.StatusID]; !ok {
+				apiStatus, err := p.converter.StatusToAPIStatus(ctx, newest.Status, authed.Account)
+				if err != nil {
+					log.Debugf(ctx, "skipping notification group %s because its status couldn't be converted: %v", group.key, err)
+					continue
+				}
+
+				statusIDs[newest.StatusID] = struct{}{}
+				results.Statuses = append(results.Statuses, apiStatus)
+			}
+		}
+
+		for _, n := range group.notifs {
+			if len(apiGroup.SampleAccountIDs) == notificationGroupMaxSampleAccounts {
+				break
+			}
+
+			if n.OriginAccount == nil ||
+				slices.Contains(apiGroup.SampleAccountIDs, n.OriginAccountID) {
+				// Missing, or already sampled (eg., fave, unfave, fave).
+				continue
+			}
+
+			if _, ok := accountIDs[n.OriginAccountID]; !ok {
+				apiAccount, err := p.converter.AccountToAPIAccountPublic(ctx, n.OriginAccount)
+				if err != nil {
+					log.Debugf(ctx, "skipping account %s in notification group: %v", n.OriginAccountID, err)
+					continue
+				}
+
+				accountIDs[n.OriginAccountID] = struct{}{}
+				results.Accounts = append(results.Accounts, apiAccount)
+			}
+
+			apiGroup.SampleAccountIDs = append(apiGroup.SampleAccountIDs, n.OriginAccountID)
+		}
+
+		results.NotificationGroups = append(results.NotificationGroups, apiGroup)
+	}
+
+	resp, errWithCode := util.PackagePageableResponse(util.PageableResponseParams{
+		Path:           "api/v2/notifications",
+		NextMaxIDValue: scanned.lowID,
+		PrevMinIDValue: scanned.highID,
+		Limit:          limit,
+	})
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return &apimodel.GroupedNotificationsResponse{
+		Results:    results,
+		LinkHeader: resp.LinkHeader,
+	}, nil
+}
+
+// NotificationsUnreadCountGet returns the number of notification
+// groups that are newer than the requesting account's notifications
+// marker, up to limit. If no marker has been set, all notification
+// groups are considered unread.
+func (p *Processor) NotificationsUnreadCountGet(
+	ctx context.Context,
+	authed *oauth.Auth,
+	limit int,
+	excludeTypes []string,
+	groupedTypes []string,
+) (*apimodel.NotificationsUnreadCount, gtserror.WithCode) {
+	var lastReadID string
+
+	marker, err := p.state.DB.GetMarker(ctx, authed.Account.ID, gtsmodel.MarkerNameNotifications)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting notifications marker: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if marker != nil {
+		lastReadID = marker.LastReadID
+	}
+
+	scanned, errWithCode := p.groupNotifications(ctx,
+		authed.Account,
+		"",
+		lastReadID,
+		"",
+		limit,
+		excludeTypes,
+		groupedTypes,
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return &apimodel.NotificationsUnreadCount{
+		Count: len(scanned.groups),
+	}, nil
+}
+
+// groupNotifications scans notifications targeting the given
+// account in batches, assembling them into at most limit groups.
+//
+// Scanning stops when the next notification would create a new
+// group beyond limit, so notifications of groups already on this
+// page may appear again (with the same key) on the next page.
+func (p *Processor) groupNotifications(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	maxID string,
+	sinceID string,
+	minID string,
+	limit int,
+	excludeTypes []string,
+	groupedTypes []string,
+) (*notificationGroups, gtserror.WithCode) {
+	var (
+		// When paging up from minID we must scan
+		// from oldest to newest, so that groups
+		// form from the notifications closest to
+		// minID, as a client would expect.
+		pageUp = (minID != "")
+
+		scanned = new(notificationGroups)
+		byKey   = make(map[string]*notificationGroup)
+		total   int
+		full    bool
+	)
+
+	if len(groupedTypes) == 0 {
+		groupedTypes = groupableNotificationTypes
+	}
+
+	for !full && total < notificationGroupMaxScan {
+		notifs, err := p.state.DB.GetAccountNotifications(ctx,
+			account.ID,
+			maxID,
+			sinceID,
+			minID,
+			notificationGroupBatchSize,
+			excludeTypes,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err = gtserror.Newf("db error getting notifications: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if len(notifs) == 0 {
+			// Reached the end.
+			break
+		}
+
+		// Set the cursor for the next
+		// batch before we start consuming.
+		if pageUp {
+			minID = notifs[0].ID
+			slices.Reverse(notifs)
+		} else {
+			maxID = notifs[len(notifs)-1].ID
+		}
+
+		for _, n := range notifs {
+			key := notificationGroupKey(n, groupedTypes)
+
+			group, ok := byKey[key]
+			if !ok && len(scanned.groups) == limit {
+				// Would create a new group past
+				// the limit; this page is done.
+				full = true
+				break
+			}
+
+			// Mark this notification as consumed, regardless
+			// of visibility, so caller can still page properly.
+			total++
+			if scanned.highID == "" || n.ID > scanned.highID {
+				scanned.highID = n.ID
+			}
+			if scanned.lowID == "" || n.ID < scanned.lowID {
+				scanned.lowID = n.ID
+			}
+
+			if !p.notificationVisible(ctx, account, n) {
+				continue
+			}
+
+			if !ok {
+				group = &notificationGroup{key: key}
+				byKey[key] = group
+				scanned.groups = append(scanned.groups, group)
+			}
+
+			group.notifs = append(group.notifs, n)
+		}
+
+		if len(notifs) < notificationGroupBatchSize {
+			// Reached the end.
+			break
+		}
+	}
+
+	// Ensure groups and the notifications within
+	// them are returned newest first, whichever
+	// direction we scanned them in.
+	for _, group := range scanned.groups {
+		slices.SortFunc(group.notifs, func(a, b *gtsmodel.Notification) int {
+			return compareIDsDesc(a.ID, b.ID)
+		})
+	}
+
+	slices.SortFunc(scanned.groups, func(a, b *notificationGroup) int {
+		return compareIDsDesc(a.notifs[0].ID, b.notifs[0].ID)
+	})
+
+	return scanned, nil
+}
+
+// notificationGroupKey returns the group key for the given
+// notification. Notifications of one of the given grouped
+// types that target a status are keyed by type + status,
+// everything else is keyed by its own notification ID.
+func notificationGroupKey(n *gtsmodel.Notification, groupedTypes []string) string {
+	if n.StatusID != "" &&
+		slices.Contains(groupableNotificationTypes, string(n.NotificationType)) &&
+		slices.Contains(groupedTypes, string(n.NotificationType)) {
+		return string(n.NotificationType) + "-" + n.StatusID
+	}
+
+	return "ungrouped-" + n.ID
+}
+
+// compareIDsDesc compares the two
+// given IDs for a descending sort.
+func compareIDsDesc(a, b string) int {
+	switch {
+	case a > b:
+		return -1
+	case a < b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,286 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package timeline
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// NotificationPolicyGet returns the notification policy of the requesting
+// account. If the account hasn't set a policy yet, the default (which
+// filters nothing) is returned, without storing it in the database.
+func (p *Processor) NotificationPolicyGet(ctx context.Context, requester *gtsmodel.Account) (*apimodel.NotificationPolicy, gtserror.WithCode) {
+	policy, errWithCode := p.getNotificationPolicy(ctx, requester)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiNotificationPolicy(ctx, policy)
+}
+
+// NotificationPolicyUpdate updates the notification policy of the requesting
+// account with the set fields of the given form, creating it if necessary.
+func (p *Processor) NotificationPolicyUpdate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	form *apimodel.NotificationPolicyUpdateRequest,
+) (*apimodel.NotificationPolicy, gtserror.WithCode) {
+	policy, errWithCode := p.getNotificationPolicy(ctx, requester)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if form.FilterNotFollowing != nil {
+		policy.FilterNotFollowing = form.FilterNotFollowing
+	}
+
+	if form.FilterNotFollowers != nil {
+		policy.FilterNotFollowers = form.FilterNotFollowers
+	}
+
+	if form.FilterNewAccounts != nil {
+		policy.FilterNewAccounts = form.FilterNewAccounts
+	}
+
+	if form.NewAccountsDays != nil {
+		if days := *form.NewAccountsDays; days < 1 || days > 365 {
+			const text = "new_accounts_days must be between 1 and 365"
+			return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+		policy.NewAccountsDays = *form.NewAccountsDays
+	}
+
+	if form.FilterPrivateMentions != nil {
+		policy.FilterPrivateMentions = form.FilterPrivateMentions
+	}
+
+	var err error
+	if policy.ID == "" {
+		// New policy, store it.
+		policy.ID = id.NewULID()
+		err = p.state.DB.PutNotificationPolicy(ctx, policy)
+	} else {
+		// Existing policy, update it.
+		err = p.state.DB.UpdateNotificationPolicy(ctx, policy)
+	}
+
+	if err != nil {
+		err = gtserror.Newf("db error storing notification policy: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiNotificationPolicy(ctx, policy)
+}
+
+// NotificationRequestsGet returns a page of notification
+// requests targeting the requesting account, newest first.
+func (p *Processor) NotificationRequestsGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	requests, err := p.state.DB.GetAccountNotificationRequests(ctx, requester.ID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting notification requests: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Check for empty response.
+	count := len(requests)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := requests[count-1].ID
+	hi := requests[0].ID
+
+	items := make([]interface{}, 0, count)
+	for _, request := range requests {
+		apiRequest, err := p.converter.NotificationRequestToAPINotificationRequest(ctx, request)
+		if err != nil {
+			log.Errorf(ctx, "error converting notification request: %v", err)
+			continue
+		}
+
+		items = append(items, apiRequest)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/notifications/requests",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// NotificationRequestGet returns one notification
+// request targeting the requesting account.
+func (p *Processor) NotificationRequestGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	requestID string,
+) (*apimodel.NotificationRequest, gtserror.WithCode) {
+	request, errWithCode := p.getNotificationRequest(ctx, requester, requestID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiRequest, err := p.converter.NotificationRequestToAPINotificationRequest(ctx, request)
+	if err != nil {
+		err = gtserror.Newf("error converting notification request: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiRequest, nil
+}
+
+// NotificationRequestAccept accepts the given notification request
+// targeting the requesting account. Filtered notifications in the
+// request are moved into the account's notifications, and future
+// notifications from the request's account will no longer be filtered.
+func (p *Processor) NotificationRequestAccept(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	requestID string,
+) gtserror.WithCode {
+	request, errWithCode := p.getNotificationRequest(ctx, requester, requestID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	permitted, err := p.state.DB.IsNotificationPermitted(ctx, requester.ID, request.FromAccountID)
+	if err != nil {
+		err = gtserror.Newf("db error checking notification permission: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if !permitted {
+		// Allow future notifications through.
+		if err := p.state.DB.PutNotificationPermission(ctx, &gtsmodel.NotificationPermission{
+			ID:            id.NewULID(),
+			AccountID:     requester.ID,
+			FromAccountID: request.FromAccountID,
+		}); err != nil {
+			err = gtserror.Newf("db error putting notification permission: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	if err := p.state.DB.UnfilterNotifications(ctx, requester.ID, request.FromAccountID); err != nil {
+		err = gtserror.Newf("db error unfiltering notifications: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.DeleteNotificationRequestByID(ctx, request.ID); err != nil {
+		err = gtserror.Newf("db error deleting notification request: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// NotificationRequestDismiss dismisses the given notification
+// request targeting the requesting account, deleting it along
+// with the filtered notifications in it. Future notifications
+// from the request's account will still be filtered.
+func (p *Processor) NotificationRequestDismiss(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	requestID string,
+) gtserror.WithCode {
+	request, errWithCode := p.getNotificationRequest(ctx, requester, requestID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.state.DB.DeleteFilteredNotifications(ctx, requester.ID, request.FromAccountID); err != nil {
+		err = gtserror.Newf("db error deleting filtered notifications: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.DeleteNotificationRequestByID(ctx, request.ID); err != nil {
+		err = gtserror.Newf("db error deleting notification request: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// getNotificationPolicy gets the notification policy of the given
+// account, or a new default policy (with no ID) if it has none.
+func (p *Processor) getNotificationPolicy(ctx context.Context, account *gtsmodel.Account) (*gtsmodel.NotificationPolicy, gtserror.WithCode) {
+	policy, err := p.state.DB.GetNotificationPolicy(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting notification policy: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if policy == nil {
+		policy = &gtsmodel.NotificationPolicy{
+			AccountID:             account.ID,
+			FilterNotFollowing:    util.Ptr(false),
+			FilterNotFollowers:    util.Ptr(false),
+			FilterNewAccounts:     util.Ptr(false),
+			NewAccountsDays:       30,
+			FilterPrivateMentions: util.Ptr(false),
+		}
+	}
+
+	return policy, nil
+}
+
+// getNotificationRequest gets the notification request with the
+// given ID, ensuring that it targets the given account.
+func (p *Processor) getNotificationRequest(ctx context.Context, account *gtsmodel.Account, requestID string) (*gtsmodel.NotificationRequest, gtserror.WithCode) {
+	request, err := p.state.DB.GetNotificationRequestByID(ctx, requestID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting notification request: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if request == nil || request.AccountID != account.ID {
+		// Don't leak existence of
+		// other accounts' requests.
+		err := gtserror.Newf("notification request %s not found", requestID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return request, nil
+}
+
+func (p *Processor) apiNotificationPolicy(ctx context.Context, policy *gtsmodel.NotificationPolicy) (*apimodel.NotificationPolicy, gtserror.WithCode) {
+	apiPolicy, err := p.converter.NotificationPolicyToAPINotificationPolicy(ctx, policy)
+	if err != nil {
+		err = gtserror.Newf("error converting notification policy: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiPolicy, nil
+}
@@ -0,0 +1,124 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package timeline
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/timeline"
+)
+
+// HomeTimelineStore returns a timeline.Store which persists
+// home timelines in the database, or nil if timeline
+// persistence is not enabled in the config.
+func HomeTimelineStore(state *state.State) timeline.Store {
+	return newStore(state, gtsmodel.TimelineTypeHome)
+}
+
+// ListTimelineStore returns a timeline.Store which persists
+// list timelines in the database, or nil if timeline
+// persistence is not enabled in the config.
+func ListTimelineStore(state *state.State) timeline.Store {
+	return newStore(state, gtsmodel.TimelineTypeList)
+}
+
+func newStore(state *state.State, timelineType gtsmodel.TimelineType) timeline.Store {
+	if !config.GetTimelinesPersistEnabled() {
+		// Return an untyped nil,
+		// so that the timeline
+		// manager sees no store.
+		return nil
+	}
+
+	return &store{
+		state:        state,
+		timelineType: timelineType,
+	}
+}
+
+// store implements timeline.Store
+// using the timeline_entries table.
+type store struct {
+	state        *state.State
+	timelineType gtsmodel.TimelineType
+}
+
+func (s *store) Load(ctx context.Context, timelineID string) ([]timeline.Timelineable, error) {
+	entries, err := s.state.DB.GetTimelineEntries(ctx,
+		s.timelineType,
+		timelineID,
+		config.GetTimelinesPersistMaxLength(),
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting timeline entries: %w", err)
+	}
+
+	items := make([]timeline.Timelineable, len(entries))
+	for i, entry := range entries {
+		items[i] = entry
+	}
+
+	return items, nil
+}
+
+func (s *store) Put(ctx context.Context, timelineID string, items []timeline.Timelineable) error {
+	entries := make([]*gtsmodel.TimelineEntry, len(items))
+	for i, item := range items {
+		entries[i] = &gtsmodel.TimelineEntry{
+			ID:               id.NewULID(),
+			TimelineType:     s.timelineType,
+			TimelineID:       timelineID,
+			ItemID:           item.GetID(),
+			AccountID:        item.GetAccountID(),
+			BoostOfID:        item.GetBoostOfID(),
+			BoostOfAccountID: item.GetBoostOfAccountID(),
+		}
+	}
+
+	return s.state.DB.PutTimelineEntries(ctx, entries)
+}
+
+func (s *store) Remove(ctx context.Context, timelineID string, itemID string) error {
+	return s.state.DB.DeleteTimelineEntry(ctx, s.timelineType, timelineID, itemID)
+}
+
+func (s *store) RemoveFromAll(ctx context.Context, itemID string) error {
+	return s.state.DB.DeleteTimelineEntriesByItemID(ctx, s.timelineType, itemID)
+}
+
+func (s *store) RemoveAllByOrBoosting(ctx context.Context, timelineID string, accountID string) error {
+	return s.state.DB.DeleteTimelineEntriesByOrBoosting(ctx, s.timelineType, timelineID, accountID)
+}
+
+func (s *store) RemoveTimeline(ctx context.Context, timelineID string) error {
+	return s.state.DB.DeleteTimelineEntries(ctx, s.timelineType, timelineID)
+}
+
+func (s *store) Compact(ctx context.Context) (int, error) {
+	return s.state.DB.TrimTimelineEntries(ctx,
+		s.timelineType,
+		config.GetTimelinesPersistMaxLength(),
+	)
+}
@@ -497,6 +497,83 @@ func (suite *FromClientAPITestSuite) TestProcessCreateStatusListRepliesPolicyLis
 	)
 }
 
+func (suite *FromClientAPITestSuite) TestProcessCreateStatusExclusiveList() {
+	// We're modifying the test list so take a copy.
+	testList := new(gtsmodel.List)
+	*testList = *suite.testLists["local_account_1_list_1"]
+
+	var (
+		ctx              = context.Background()
+		postingAccount   = suite.testAccounts["admin_account"]
+		receivingAccount = suite.testAccounts["local_account_1"]
+		streams          = suite.openStreams(ctx, receivingAccount, []string{testList.ID})
+		homeStream       = streams[stream.TimelineHome]
+		listStream       = streams[stream.TimelineList+":"+testList.ID]
+
+		// Admin account posts a new top-level status.
+		status = suite.newStatus(
+			ctx,
+			postingAccount,
+			gtsmodel.VisibilityPublic,
+			nil,
+			nil,
+		)
+		statusJSON = suite.statusJSON(
+			ctx,
+			status,
+			receivingAccount,
+		)
+	)
+
+	// Make the test list exclusive. Since admin is
+	// in the list, this means the status should be
+	// shown in the list, but not in the home timeline.
+	testList.Exclusive = util.Ptr(true)
+	if err := suite.db.UpdateList(ctx, testList, "exclusive"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Process the new status.
+	if err := suite.processor.Workers().ProcessFromClientAPI(
+		ctx,
+		messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       status,
+			OriginAccount:  postingAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Check message NOT in home stream.
+	suite.checkStreamed(
+		homeStream,
+		false,
+		"",
+		"",
+	)
+
+	// Check message in list stream.
+	suite.checkStreamed(
+		listStream,
+		true,
+		statusJSON,
+		stream.EventTypeUpdate,
+	)
+
+	// Status should not be in the
+	// home timeline from the db either.
+	statuses, err := suite.db.GetHomeTimeline(ctx, receivingAccount.ID, "", "", "", 20, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for _, s := range statuses {
+		suite.NotEqual(postingAccount.ID, s.AccountID)
+	}
+}
+
 func (suite *FromClientAPITestSuite) TestProcessCreateStatusListRepliesPolicyListOnlyNo() {
 	// We're modifying the test list so take a copy.
 	testList := new(gtsmodel.List)
@@ -418,38 +418,23 @@ func (p *fediAPI) CreateBlock(ctx context.Context, fMsg messages.FromFediAPI) er
 		log.Errorf(ctx, "error wiping items from target -> block's list timeline(s): %v", err)
 	}
 
-	// Remove any follows that existed between blocker + blockee.
-	if err := p.state.DB.DeleteFollow(
+	// Remove any follows and follow requests that existed
+	// between blocker + blockee. This goes via the account
+	// processor so that endorsements are removed with them.
+	if err := p.account.Unfollow(
 		ctx,
-		block.AccountID,
-		block.TargetAccountID,
-	); err != nil {
-		log.Errorf(ctx, "error deleting follow from block -> target: %v", err)
-	}
-
-	if err := p.state.DB.DeleteFollow(
-		ctx,
-		block.TargetAccountID,
-		block.AccountID,
+		block.Account,
+		block.TargetAccount,
 	); err != nil {
-		log.Errorf(ctx, "error deleting follow from target -> block: %v", err)
+		log.Errorf(ctx, "error unfollowing block -> target: %v", err)
 	}
 
-	// Remove any follow requests that existed between blocker + blockee.
-	if err := p.state.DB.DeleteFollowRequest(
+	if err := p.account.Unfollow(
 		ctx,
-		block.AccountID,
-		block.TargetAccountID,
-	); err != nil {
-		log.Errorf(ctx, "error deleting follow request from block -> target: %v", err)
-	}
-
-	if err := p.state.DB.DeleteFollowRequest(
-		ctx,
-		block.TargetAccountID,
-		block.AccountID,
+		block.TargetAccount,
+		block.Account,
 	); err != nil {
-		log.Errorf(ctx, "error deleting follow request from target -> block: %v", err)
+		log.Errorf(ctx, "error unfollowing target -> block: %v", err)
 	}
 
 	return nil
@@ -215,6 +215,93 @@ func (suite *FromFediAPITestSuite) TestProcessFave() {
 	suite.EqualValues([]string{stream.TimelineNotifications}, msg.Stream)
 }
 
+// TestProcessFaveFilteredByPolicy ensures that a fave from an account
+// that the faved account doesn't follow is filtered into a notification
+// request when the faved account's policy filters such notifications.
+func (suite *FromFediAPITestSuite) TestProcessFaveFilteredByPolicy() {
+	favedAccount := suite.testAccounts["local_account_1"]
+	favedStatus := suite.testStatuses["local_account_1_status_1"]
+	favingAccount := suite.testAccounts["remote_account_1"]
+
+	// Only accept notifications
+	// from accounts we follow.
+	if _, errWithCode := suite.processor.Timeline().NotificationPolicyUpdate(
+		context.Background(),
+		favedAccount,
+		&apimodel.NotificationPolicyUpdateRequest{
+			FilterNotFollowing: util.Ptr(true),
+		},
+	); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	wssStream, errWithCode := suite.processor.Stream().Open(context.Background(), favedAccount, stream.TimelineNotifications)
+	suite.NoError(errWithCode)
+
+	fave := &gtsmodel.StatusFave{
+		ID:              "01FGKJPXFTVQPG9YSSZ95ADS7Q",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		AccountID:       favingAccount.ID,
+		Account:         favingAccount,
+		TargetAccountID: favedAccount.ID,
+		TargetAccount:   favedAccount,
+		StatusID:        favedStatus.ID,
+		Status:          favedStatus,
+		URI:             favingAccount.URI + "/faves/aaaaaaaaaaaa",
+	}
+
+	err := suite.db.Put(context.Background(), fave)
+	suite.NoError(err)
+
+	err = suite.processor.Workers().ProcessFromFediAPI(context.Background(), messages.FromFediAPI{
+		APObjectType:     ap.ActivityLike,
+		APActivityType:   ap.ActivityCreate,
+		GTSModel:         fave,
+		ReceivingAccount: favedAccount,
+	})
+	suite.NoError(err)
+
+	// A filtered notification should exist for the fave.
+	notif, err := suite.db.GetNotification(
+		context.Background(),
+		gtsmodel.NotificationFave,
+		favedAccount.ID,
+		favingAccount.ID,
+		favedStatus.ID,
+	)
+	suite.NoError(err)
+	suite.True(*notif.Filtered)
+
+	// It should be in a request from the faving account.
+	request, err := suite.db.GetNotificationRequest(context.Background(), favedAccount.ID, favingAccount.ID)
+	suite.NoError(err)
+	suite.Equal(1, request.NotificationsCount)
+	suite.Equal(favedStatus.ID, request.LastStatusID)
+
+	// Nothing should have been streamed.
+	ctx, cncl := context.WithTimeout(context.Background(), time.Second)
+	defer cncl()
+	_, ok := wssStream.Recv(ctx)
+	suite.False(ok)
+
+	// Accept the request, notification
+	// should now be unfiltered.
+	errWithCode = suite.processor.Timeline().NotificationRequestAccept(context.Background(), favedAccount, request.ID)
+	suite.NoError(errWithCode)
+
+	notif, err = suite.db.GetNotificationByID(context.Background(), notif.ID)
+	suite.NoError(err)
+	suite.False(*notif.Filtered)
+
+	permitted, err := suite.db.IsNotificationPermitted(context.Background(), favedAccount.ID, favingAccount.ID)
+	suite.NoError(err)
+	suite.True(permitted)
+
+	_, err = suite.db.GetNotificationRequestByID(context.Background(), request.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
 // TestProcessFaveWithDifferentReceivingAccount ensures that when an account receives a fave that's for
 // another account in their AP inbox, a notification isn't streamed to the receiving account.
 //
@@ -370,6 +457,60 @@ func (suite *FromFediAPITestSuite) TestProcessAccountDelete() {
 	suite.Equal(dbAccount.ID, dbAccount.SuspensionOrigin)
 }
 
+func (suite *FromFediAPITestSuite) TestProcessBlockRemovesEndorsement() {
+	ctx := context.Background()
+
+	blockingAccount := suite.testAccounts["remote_account_1"]
+	receivingAccount := suite.testAccounts["local_account_1"]
+
+	// Have local_account_1 follow
+	// and endorse remote_account_1.
+	follow := &gtsmodel.Follow{
+		ID:              "01HS2V9CEQ3C2TQGXKXE7ZCNAE",
+		AccountID:       receivingAccount.ID,
+		TargetAccountID: blockingAccount.ID,
+		ShowReblogs:     util.Ptr(true),
+		URI:             fmt.Sprintf("%s/follows/01HS2V9CEQ3C2TQGXKXE7ZCNAE", receivingAccount.URI),
+		Notify:          util.Ptr(false),
+	}
+	if err := suite.db.PutFollow(ctx, follow); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.db.PutEndorsement(ctx, &gtsmodel.Endorsement{
+		ID:              "01HS2VA3R5BTJ5F0JSGQ5Q6ZN7",
+		AccountID:       receivingAccount.ID,
+		TargetAccountID: blockingAccount.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Now remote_account_1 blocks local_account_1.
+	err := suite.processor.Workers().ProcessFromFediAPI(ctx, messages.FromFediAPI{
+		APObjectType:   ap.ActivityBlock,
+		APActivityType: ap.ActivityCreate,
+		GTSModel: &gtsmodel.Block{
+			ID:              "01HS2VAT0K6SH1MP4J4MJ9Y2X0",
+			URI:             fmt.Sprintf("%s/blocks/01HS2VAT0K6SH1MP4J4MJ9Y2X0", blockingAccount.URI),
+			AccountID:       blockingAccount.ID,
+			Account:         blockingAccount,
+			TargetAccountID: receivingAccount.ID,
+			TargetAccount:   receivingAccount,
+		},
+		ReceivingAccount: receivingAccount,
+	})
+	suite.NoError(err)
+
+	// Follow and endorsement should both be gone.
+	following, err := suite.db.IsFollowing(ctx, receivingAccount.ID, blockingAccount.ID)
+	suite.NoError(err)
+	suite.False(following)
+
+	endorsed, err := suite.db.IsEndorsed(ctx, receivingAccount.ID, blockingAccount.ID)
+	suite.NoError(err)
+	suite.False(endorsed)
+}
+
 func (suite *FromFediAPITestSuite) TestProcessFollowRequestLocked() {
 	ctx := context.Background()
 
@@ -20,14 +20,20 @@ package workers
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
+// webPushTimeout is the maximum time allowed
+// for sending a notification's Web Push alerts.
+const webPushTimeout = time.Minute
+
 // notifyMentions iterates through mentions on the
 // given status, and notifies each mentioned account
 // that they have a new mention.
@@ -385,10 +391,27 @@ func (s *surface) notify(
 		StatusID:         statusID,
 	}
 
+	// Check whether the target's notification policy
+	// wants this notification filtered for review.
+	filtered, err := s.notificationFiltered(ctx, notif)
+	if err != nil {
+		return gtserror.Newf("error checking notification policy: %w", err)
+	}
+	notif.Filtered = &filtered
+
 	if err := s.state.DB.PutNotification(ctx, notif); err != nil {
 		return gtserror.Newf("error putting notification in database: %w", err)
 	}
 
+	if filtered {
+		// Don't stream or push filtered notifications,
+		// just add them to a request for user review.
+		if err := s.notifyRequest(ctx, notif); err != nil {
+			return gtserror.Newf("error updating notification request: %w", err)
+		}
+		return nil
+	}
+
 	// Stream notification to the user.
 	apiNotif, err := s.converter.NotificationToAPINotification(ctx, notif)
 	if err != nil {
@@ -396,5 +419,140 @@ func (s *surface) notify(
 	}
 	s.stream.Notify(ctx, targetAccount, apiNotif)
 
+	// Alert the user's push subscriptions, for
+	// when they're not actively using a client.
+	// This is done on a separate worker pool, with
+	// a timeout, as push services may be slow.
+	s.state.Workers.WebPush.Enqueue(func(ctx context.Context) {
+		ctx, cncl := context.WithTimeout(ctx, webPushTimeout)
+		defer cncl()
+
+		if err := s.webPushSender.Send(ctx, notif, apiNotif); err != nil {
+			log.Errorf(ctx, "error sending web push: %v", err)
+		}
+	})
+
+	return nil
+}
+
+// notificationFiltered returns whether the given notification
+// should be filtered according to the notification policy of
+// its target account, if the target account has set one.
+func (s *surface) notificationFiltered(
+	ctx context.Context,
+	notif *gtsmodel.Notification,
+) (bool, error) {
+	switch {
+	case notif.NotificationType == gtsmodel.NotificationPoll ||
+		notif.NotificationType == gtsmodel.NotificationStatus:
+		// Poll endings and statuses from
+		// accounts the target opted in to
+		// notifications from aren't filtered.
+		return false, nil
+
+	case notif.OriginAccountID == notif.TargetAccountID:
+		// Never filter self.
+		return false, nil
+	}
+
+	policy, err := s.state.DB.GetNotificationPolicy(ctx, notif.TargetAccountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, gtserror.Newf("error getting notification policy: %w", err)
+	}
+
+	if policy == nil || !policy.Filters() {
+		// Nothing to filter.
+		return false, nil
+	}
+
+	// Check if target has previously accepted
+	// a notification request from this origin.
+	permitted, err := s.state.DB.IsNotificationPermitted(ctx,
+		notif.TargetAccountID,
+		notif.OriginAccountID,
+	)
+	if err != nil {
+		return false, gtserror.Newf("error checking notification permission: %w", err)
+	}
+
+	if permitted {
+		return false, nil
+	}
+
+	following, err := s.state.DB.IsFollowing(ctx,
+		notif.TargetAccountID,
+		notif.OriginAccountID,
+	)
+	if err != nil {
+		return false, gtserror.Newf("error checking follow: %w", err)
+	}
+
+	if *policy.FilterNotFollowing && !following {
+		return true, nil
+	}
+
+	if *policy.FilterNotFollowers {
+		followedBy, err := s.state.DB.IsFollowing(ctx,
+			notif.OriginAccountID,
+			notif.TargetAccountID,
+		)
+		if err != nil {
+			return false, gtserror.Newf("error checking follow: %w", err)
+		}
+
+		if !followedBy {
+			return true, nil
+		}
+	}
+
+	if *policy.FilterNewAccounts {
+		newAccountsDays := time.Duration(policy.NewAccountsDays) * 24 * time.Hour
+		if time.Since(notif.OriginAccount.CreatedAt) < newAccountsDays {
+			return true, nil
+		}
+	}
+
+	if *policy.FilterPrivateMentions &&
+		!following &&
+		notif.NotificationType == gtsmodel.NotificationMention {
+		status, err := s.state.DB.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			notif.StatusID,
+		)
+		if err != nil {
+			return false, gtserror.Newf("error getting status: %w", err)
+		}
+
+		// Filter direct mentions from strangers,
+		// unless they're replying to the target.
+		if status.Visibility == gtsmodel.VisibilityDirect &&
+			status.InReplyToAccountID != notif.TargetAccountID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// notifyRequest adds the given filtered notification to
+// the notification request from its origin account to its
+// target account, creating the request if necessary.
+func (s *surface) notifyRequest(
+	ctx context.Context,
+	notif *gtsmodel.Notification,
+) error {
+	request := &gtsmodel.NotificationRequest{
+		ID:            id.NewULID(),
+		AccountID:     notif.TargetAccountID,
+		FromAccountID: notif.OriginAccountID,
+		LastStatusID:  notif.StatusID,
+	}
+
+	// Create the request, or count this notification
+	// on the existing one, in a single atomic upsert.
+	if err := s.state.DB.IncrementNotificationRequest(ctx, request); err != nil {
+		return gtserror.Newf("error incrementing notification request: %w", err)
+	}
+
 	return nil
 }
@@ -28,10 +28,13 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/stream"
 	"github.com/superseriousbusiness/gotosocial/internal/timeline"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // timelineAndNotifyStatus inserts the given status into the HOME
-// and LIST timelines of accounts that follow the status author.
+// and LIST timelines of accounts that follow the status author,
+// and into the HOME timelines of accounts that follow its hashtags.
+// The status is also streamed to any open hashtag streams.
 //
 // It will also handle notifications for any mentions attached to
 // the account, and notifications for any local accounts that want
@@ -66,6 +69,19 @@ func (s *surface) timelineAndNotifyStatus(ctx context.Context, status *gtsmodel.
 		return gtserror.Newf("error timelining status %s for followers: %w", status.ID, err)
 	}
 
+	// Timeline the status for each local account following
+	// one of its hashtags, skipping accounts that were
+	// already handled above as followers of the author.
+	if err := s.timelineStatusForTagFollowers(ctx, status, follows); err != nil {
+		return gtserror.Newf("error timelining status %s for tag followers: %w", status.ID, err)
+	}
+
+	// Stream the status to any
+	// open hashtag streams.
+	if err := s.streamStatusToHashtags(ctx, status); err != nil {
+		return gtserror.Newf("error streaming status %s to hashtag streams: %w", status.ID, err)
+	}
+
 	// Notify each local account that's mentioned by this status.
 	if err := s.notifyMentions(ctx, status); err != nil {
 		return gtserror.Newf("error notifying status mentions for status %s: %w", status.ID, err)
@@ -113,32 +129,37 @@ func (s *surface) timelineAndNotifyStatusForFollowers(
 
 		// Add status to any relevant lists
 		// for this follow, if applicable.
-		s.listTimelineStatusForFollow(
+		exclusive := s.listTimelineStatusForFollow(
 			ctx,
 			status,
 			follow,
 			&errs,
 		)
 
-		// Add status to home timeline for owner
-		// of this follow, if applicable.
-		homeTimelined, err := s.timelineStatus(
-			ctx,
-			s.state.Timelines.Home.IngestOne,
-			follow.AccountID, // home timelines are keyed by account ID
-			follow.Account,
-			status,
-			stream.TimelineHome,
-		)
-		if err != nil {
-			errs.Appendf("error home timelining status: %w", err)
-			continue
-		}
-
-		if !homeTimelined {
-			// If status wasn't added to home
-			// timeline, we shouldn't notify it.
-			continue
+		if !exclusive {
+			// Add status to home timeline for owner
+			// of this follow, if applicable. Follows
+			// in an exclusive list are skipped, since
+			// the owner wants to see those posts only
+			// in the list timeline.
+			homeTimelined, err := s.timelineStatus(
+				ctx,
+				s.state.Timelines.Home.IngestOne,
+				follow.AccountID, // home timelines are keyed by account ID
+				follow.Account,
+				status,
+				stream.TimelineHome,
+			)
+			if err != nil {
+				errs.Appendf("error home timelining status: %w", err)
+				continue
+			}
+
+			if !homeTimelined {
+				// If status wasn't added to home
+				// timeline, we shouldn't notify it.
+				continue
+			}
 		}
 
 		if !*follow.Notify {
@@ -155,7 +176,8 @@ func (s *surface) timelineAndNotifyStatusForFollowers(
 		// If we reach here, we know:
 		//
 		//   - This status is hometimelineable.
-		//   - This status was added to the home timeline for this follower.
+		//   - This status was added to the home timeline for this follower,
+		//     or would have been if not for an exclusive list.
 		//   - This follower wants to be notified when this account posts.
 		//   - This is a top-level post (not a reply or boost).
 		//
@@ -173,14 +195,219 @@ func (s *surface) timelineAndNotifyStatusForFollowers(
 	return errs.Combine()
 }
 
+// timelineStatusForTagFollowers adds the given status to the
+// home timelines of local accounts following any of its hashtags,
+// if the status is public and visible to them. Accounts owning
+// one of the given follows are skipped, as they've already had
+// the status timelined as followers of the status author.
+func (s *surface) timelineStatusForTagFollowers(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	follows []*gtsmodel.Follow,
+) error {
+	if len(status.TagIDs) == 0 ||
+		status.BoostOfID != "" ||
+		status.Visibility != gtsmodel.VisibilityPublic {
+		// Only public, top-level (ie., not boost)
+		// statuses with hashtags are timelined
+		// for hashtag followers.
+		return nil
+	}
+
+	accountIDs, err := s.state.DB.GetAccountIDsFollowingTags(ctx, status.TagIDs)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting tag followers: %w", err)
+	}
+
+	if len(accountIDs) == 0 {
+		// Nothing to do.
+		return nil
+	}
+
+	// Gather the IDs of accounts
+	// that were already handled.
+	handled := make(map[string]struct{}, len(follows))
+	for _, follow := range follows {
+		handled[follow.AccountID] = struct{}{}
+	}
+
+	var errs gtserror.MultiError
+
+	for _, accountID := range accountIDs {
+		if _, ok := handled[accountID]; ok {
+			// Already timelined.
+			continue
+		}
+
+		account, err := s.state.DB.GetAccountByID(ctx, accountID)
+		if err != nil {
+			errs.Appendf("db error getting tag follower account %s: %w", accountID, err)
+			continue
+		}
+
+		if !account.IsLocal() {
+			// Only local accounts
+			// have home timelines.
+			continue
+		}
+
+		// Check the status is visible to this tag follower,
+		// taking account of blocks in either direction.
+		timelineable, err := s.filter.StatusTagTimelineable(ctx, account, status)
+		if err != nil {
+			errs.Appendf("error checking status %s tagtimelineability: %w", status.ID, err)
+			continue
+		}
+
+		if !timelineable {
+			// Nothing to do.
+			continue
+		}
+
+		// Ensure tag follower
+		// hasn't muted the thread.
+		muted, err := s.state.DB.IsThreadMutedByAccount(
+			ctx,
+			status.ThreadID,
+			account.ID,
+		)
+		if err != nil {
+			errs.Appendf("error checking status thread mute %s: %w", status.ThreadID, err)
+			continue
+		}
+
+		if muted {
+			// Tag follower doesn't want
+			// to see this thread.
+			continue
+		}
+
+		// Add status to home timeline of tag follower.
+		if _, err := s.timelineStatus(
+			ctx,
+			s.state.Timelines.Home.IngestOne,
+			account.ID, // home timelines are keyed by account ID
+			account,
+			status,
+			stream.TimelineHome,
+		); err != nil {
+			errs.Appendf("error home timelining status for tag follower: %w", err)
+			// implicit continue
+		}
+	}
+
+	return errs.Combine()
+}
+
+// streamStatusToHashtags streams the given status to open
+// hashtag streams (including local hashtag streams, if the
+// status is local) for each of the hashtags it uses, if the
+// status is public. The status is posted once for all nodes,
+// each of which checks it's visible to each stream owner
+// using tagStreamable.
+func (s *surface) streamStatusToHashtags(ctx context.Context, status *gtsmodel.Status) error {
+	if len(status.TagIDs) == 0 ||
+		status.BoostOfID != "" ||
+		status.Visibility != gtsmodel.VisibilityPublic {
+		// Only public, top-level (ie., not boost)
+		// statuses with hashtags are streamed
+		// to hashtag streams.
+		return nil
+	}
+
+	tags := status.Tags
+	if !status.TagsPopulated() {
+		// Tags are keyed by ID in the followed
+		// tag timeline, so make sure we stream
+		// to exactly the same set of tags here.
+		var err error
+		tags, err = s.state.DB.GetTags(ctx, status.TagIDs)
+		if err != nil {
+			return gtserror.Newf("error getting tags for status %s: %w", status.ID, err)
+		}
+	}
+
+	// Gather the stream types
+	// this status belongs to.
+	streamTypes := make([]string, 0, 2*len(tags))
+	for _, tag := range tags {
+		streamTypes = append(streamTypes, stream.HashtagStreamType(
+			stream.TimelineHashtag, tag.Name,
+		))
+
+		if status.Account.IsLocal() {
+			streamTypes = append(streamTypes, stream.HashtagStreamType(
+				stream.TimelineHashtagLocal, tag.Name,
+			))
+		}
+	}
+
+	// Render the status without a requesting
+	// account, as it's shared by all streams.
+	apiStatus, err := s.converter.StatusToAPIStatus(ctx, status, nil)
+	if err != nil {
+		return gtserror.Newf("error converting status %s to frontend representation: %w", status.ID, err)
+	}
+
+	s.stream.UpdateAll(ctx, apiStatus, streamTypes...)
+	return nil
+}
+
+// tagStreamable is the stream filter used for statuses
+// streamed by streamStatusToHashtags, checking that the
+// status is visible to the account with the given ID
+// (taking account of blocks in either direction), and
+// that the account hasn't muted the status' thread.
+func (s *surface) tagStreamable(ctx context.Context, accountID string, statusID string) bool {
+	account, err := s.state.DB.GetAccountByID(ctx, accountID)
+	if err != nil {
+		log.Errorf(ctx, "db error getting stream account %s: %v", accountID, err)
+		return false
+	}
+
+	status, err := s.state.DB.GetStatusByID(ctx, statusID)
+	if err != nil {
+		log.Errorf(ctx, "db error getting status %s: %v", statusID, err)
+		return false
+	}
+
+	timelineable, err := s.filter.StatusTagTimelineable(ctx, account, status)
+	if err != nil {
+		log.Errorf(ctx, "error checking status %s tagtimelineability: %v", status.ID, err)
+		return false
+	}
+
+	if !timelineable {
+		return false
+	}
+
+	// Ensure account
+	// hasn't muted the thread.
+	muted, err := s.state.DB.IsThreadMutedByAccount(
+		ctx,
+		status.ThreadID,
+		account.ID,
+	)
+	if err != nil {
+		log.Errorf(ctx, "error checking status thread mute %s: %v", status.ThreadID, err)
+		return false
+	}
+
+	return !muted
+}
+
 // listTimelineStatusForFollow puts the given status
 // in any eligible lists owned by the given follower.
+//
+// Returns true if the follow is an entry in any exclusive
+// list, in which case the status should not be put in the
+// follower's home timeline.
 func (s *surface) listTimelineStatusForFollow(
 	ctx context.Context,
 	status *gtsmodel.Status,
 	follow *gtsmodel.Follow,
 	errs *gtserror.MultiError,
-) {
+) (exclusive bool) {
 	// To put this status in appropriate list timelines,
 	// we need to get each listEntry that pertains to
 	// this follow. Then, we want to iterate through all
@@ -196,11 +423,27 @@ func (s *surface) listTimelineStatusForFollow(
 	)
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
 		errs.Appendf("error getting list entries: %w", err)
-		return
+		return false
 	}
 
 	// Check eligibility for each list entry (if any).
 	for _, listEntry := range listEntries {
+		list, err := s.state.DB.GetListByID(
+			gtscontext.SetBarebones(ctx),
+			listEntry.ListID,
+		)
+		if err != nil {
+			errs.Appendf("error getting list %s: %w", listEntry.ListID, err)
+			continue
+		}
+
+		if util.PtrValueOr(list.Exclusive, false) {
+			// Status should be kept out of
+			// the home timeline, regardless
+			// of whether it's list eligible.
+			exclusive = true
+		}
+
 		eligible, err := s.listEligible(ctx, listEntry, status)
 		if err != nil {
 			errs.Appendf("error checking list eligibility: %w", err)
@@ -227,6 +470,8 @@ func (s *surface) listTimelineStatusForFollow(
 			// implicit continue
 		}
 	}
+
+	return exclusive
 }
 
 // listEligible checks if the given status is eligible
@@ -459,13 +704,19 @@ func (s *surface) timelineStatusUpdateForFollowers(
 
 		// Add status to any relevant lists
 		// for this follow, if applicable.
-		s.listTimelineStatusUpdateForFollow(
+		exclusive := s.listTimelineStatusUpdateForFollow(
 			ctx,
 			status,
 			follow,
 			&errs,
 		)
 
+		if exclusive {
+			// Follow is in an exclusive list,
+			// so status isn't in home timeline.
+			continue
+		}
+
 		// Add status to home timeline for owner
 		// of this follow, if applicable.
 		err = s.timelineStreamStatusUpdate(
@@ -485,12 +736,14 @@ func (s *surface) timelineStatusUpdateForFollowers(
 
 // listTimelineStatusUpdateForFollow pushes edits of the given status
 // into any eligible lists streams opened by the given follower.
+//
+// Returns true if the follow is an entry in any exclusive list.
 func (s *surface) listTimelineStatusUpdateForFollow(
 	ctx context.Context,
 	status *gtsmodel.Status,
 	follow *gtsmodel.Follow,
 	errs *gtserror.MultiError,
-) {
+) (exclusive bool) {
 	// To put this status in appropriate list timelines,
 	// we need to get each listEntry that pertains to
 	// this follow. Then, we want to iterate through all
@@ -506,11 +759,27 @@ func (s *surface) listTimelineStatusUpdateForFollow(
 	)
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
 		errs.Appendf("error getting list entries: %w", err)
-		return
+		return false
 	}
 
 	// Check eligibility for each list entry (if any).
 	for _, listEntry := range listEntries {
+		list, err := s.state.DB.GetListByID(
+			gtscontext.SetBarebones(ctx),
+			listEntry.ListID,
+		)
+		if err != nil {
+			errs.Appendf("error getting list %s: %w", listEntry.ListID, err)
+			continue
+		}
+
+		if util.PtrValueOr(list.Exclusive, false) {
+			// Status should be kept out of
+			// the home timeline, regardless
+			// of whether it's list eligible.
+			exclusive = true
+		}
+
 		eligible, err := s.listEligible(ctx, listEntry, status)
 		if err != nil {
 			errs.Appendf("error checking list eligibility: %w", err)
@@ -535,6 +804,8 @@ func (s *surface) listTimelineStatusUpdateForFollow(
 			// implicit continue
 		}
 	}
+
+	return exclusive
 }
 
 // timelineStatusUpdate streams the edited status to the user using the
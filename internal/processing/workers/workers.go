@@ -26,6 +26,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/processing/stream"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 	"github.com/superseriousbusiness/gotosocial/internal/workers"
 )
 
@@ -41,6 +42,7 @@ func New(
 	converter *typeutils.Converter,
 	filter *visibility.Filter,
 	emailSender email.Sender,
+	webPushSender webpush.Sender,
 	account *account.Processor,
 	media *media.Processor,
 	stream *stream.Processor,
@@ -48,13 +50,19 @@ func New(
 	// Init surface logic
 	// wrapper struct.
 	surface := &surface{
-		state:       state,
-		converter:   converter,
-		stream:      stream,
-		filter:      filter,
-		emailSender: emailSender,
+		state:         state,
+		converter:     converter,
+		stream:        stream,
+		filter:        filter,
+		emailSender:   emailSender,
+		webPushSender: webPushSender,
 	}
 
+	// Check the visibility of statuses
+	// streamed to all accounts' streams
+	// (ie., hashtag streams) per account.
+	stream.SetFilter(surface.tagStreamable)
+
 	// Init federate logic
 	// wrapper struct.
 	federate := &federate{
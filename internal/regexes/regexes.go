@@ -74,6 +74,7 @@ const (
 	blockPath         = userPathPrefix + `/` + blocks + `/(` + ulid + `)$`
 	reportPath        = `^/?` + reports + `/(` + ulid + `)$`
 	filePath          = `^/?(` + ulid + `)/([a-z]+)/([a-z]+)/(` + ulid + `)\.([a-z0-9]+)$`
+	blobPath          = `^/?blobs/([0-9a-f]{2})/([0-9a-f]{64})\.([a-z0-9]+)$`
 )
 
 var (
@@ -163,6 +164,11 @@ var (
 	// It captures the account id, media type, media size, file name, and file extension, eg
 	// `01F8MH1H7YV1Z7D2C8K2730QBF`, `attachment`, `small`, `01F8MH8RMYQ6MSNY3JM2XT1CQ5`, `jpeg`.
 	FilePath = regexp.MustCompile(filePath)
+
+	// BlobPath parses a content-addressed file storage path of the form blobs/[HASH_PREFIX]/[HASH].[EXT]
+	// eg blobs/9f/9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08.jpg
+	// It captures the hash prefix, hash, and file extension.
+	BlobPath = regexp.MustCompile(blobPath)
 )
 
 // bufpool is a memory pool of byte buffers for use in our regex utility functions.
@@ -19,10 +19,13 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"mime"
 	"net/url"
+	"os"
 	"path"
 	"time"
 
@@ -59,6 +62,11 @@ type Driver struct {
 	// Underlying storage
 	Storage storage.Storage
 
+	// Fallback storage, read from when a key isn't
+	// found in Storage. Set during migration between
+	// storage backends, when media is being copied.
+	Fallback storage.Storage
+
 	// S3-only parameters
 	Proxy          bool
 	Bucket         string
@@ -67,12 +75,20 @@ type Driver struct {
 
 // Get returns the byte value for key in storage.
 func (d *Driver) Get(ctx context.Context, key string) ([]byte, error) {
-	return d.Storage.ReadBytes(ctx, key)
+	b, err := d.Storage.ReadBytes(ctx, key)
+	if errors.Is(err, ErrNotFound) && d.Fallback != nil {
+		return d.Fallback.ReadBytes(ctx, key)
+	}
+	return b, err
 }
 
 // GetStream returns an io.ReadCloser for the value bytes at key in the storage.
 func (d *Driver) GetStream(ctx context.Context, key string) (io.ReadCloser, error) {
-	return d.Storage.ReadStream(ctx, key)
+	rc, err := d.Storage.ReadStream(ctx, key)
+	if errors.Is(err, ErrNotFound) && d.Fallback != nil {
+		return d.Fallback.ReadStream(ctx, key)
+	}
+	return rc, err
 }
 
 // Put writes the supplied value bytes at key in the storage
@@ -87,12 +103,108 @@ func (d *Driver) PutStream(ctx context.Context, key string, r io.Reader) (int64,
 
 // Remove attempts to remove the supplied key (and corresponding value) from storage.
 func (d *Driver) Delete(ctx context.Context, key string) error {
-	return d.Storage.Remove(ctx, key)
+	if d.Fallback == nil {
+		return d.Storage.Remove(ctx, key)
+	}
+
+	// Key may be stored in either backend, so
+	// only return ErrNotFound if it's in neither.
+	err := d.Storage.Remove(ctx, key)
+	fbErr := d.Fallback.Remove(ctx, key)
+
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return fbErr
+	case err != nil:
+		return err
+	case fbErr != nil && !errors.Is(fbErr, ErrNotFound):
+		return fbErr
+	default:
+		return nil
+	}
 }
 
 // Has checks if the supplied key is in the storage.
 func (d *Driver) Has(ctx context.Context, key string) (bool, error) {
-	return d.Storage.Stat(ctx, key)
+	have, err := d.Storage.Stat(ctx, key)
+	if err == nil && !have && d.Fallback != nil {
+		return d.Fallback.Stat(ctx, key)
+	}
+	return have, err
+}
+
+// FileInfo contains metadata
+// about a file in storage.
+type FileInfo struct {
+	// Size of the file in bytes,
+	// or < 0 if it's unknown.
+	Size int64
+
+	// ETag of the file, only
+	// set for S3 storage.
+	ETag string
+}
+
+// Stat returns metadata for the file at key in the storage,
+// or ErrNotFound if there's none. Unlike Has, it does not
+// check the fallback storage.
+func (d *Driver) Stat(ctx context.Context, key string) (*FileInfo, error) {
+	switch st := d.Storage.(type) {
+	case *storage.S3Storage:
+		info, err := st.Client().StatObject(ctx, d.Bucket, key, minio.StatObjectOptions{})
+		if err != nil {
+			if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+				return nil, ErrNotFound
+			}
+			return nil, err
+		}
+
+		return &FileInfo{
+			Size: info.Size,
+			ETag: info.ETag,
+		}, nil
+
+	case *storage.DiskStorage:
+		kpath, err := st.Filepath(key)
+		if err != nil {
+			return nil, err
+		}
+
+		info, err := os.Stat(kpath)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil, ErrNotFound
+			}
+			return nil, err
+		}
+
+		return &FileInfo{Size: info.Size()}, nil
+
+	case *storage.MemoryStorage:
+		// Already in memory, so
+		// this is cheap enough.
+		b, err := st.ReadBytes(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		return &FileInfo{Size: int64(len(b))}, nil
+
+	default:
+		// No way of getting file
+		// metadata, so just check
+		// that it exists.
+		have, err := st.Stat(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		if !have {
+			return nil, ErrNotFound
+		}
+
+		return &FileInfo{Size: -1}, nil
+	}
 }
 
 // WalkKeys walks the keys in the storage.
@@ -109,6 +221,11 @@ func (d *Driver) WalkKeys(ctx context.Context, walk func(context.Context, string
 
 // Close will close the storage, releasing any file locks.
 func (d *Driver) Close() error {
+	if d.Fallback != nil {
+		if err := d.Fallback.Close(); err != nil {
+			return err
+		}
+	}
 	return d.Storage.Close()
 }
 
@@ -130,6 +247,16 @@ func (d *Driver) URL(ctx context.Context, key string) *PresignedURL {
 		return &e.Value
 	}
 
+	if d.Fallback != nil {
+		// Key may not have been migrated to S3 yet, in
+		// which case it must be fetched from the fallback.
+		// URLs are only cached for keys found in S3, so
+		// this is only checked once per cached URL.
+		if have, _ := s3.Stat(ctx, key); !have {
+			return nil
+		}
+	}
+
 	u, err := s3.Client().PresignedGetObject(ctx, d.Bucket, key, urlCacheTTL, url.Values{
 		"response-content-type": []string{mime.TypeByExtension(path.Ext(key))},
 	})
@@ -203,7 +330,38 @@ func (d *Driver) ProbeCSPUri(ctx context.Context) (string, error) {
 }
 
 func AutoConfig(lock string) (*Driver, error) {
-	switch backend := config.GetStorageBackend(); backend {
+	backend := config.GetStorageBackend()
+	driver, err := Open(backend, lock)
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := config.GetStorageFallbackBackend()
+	if fallback == "" {
+		return driver, nil
+	}
+
+	if fallback == backend {
+		_ = driver.Close()
+		return nil, fmt.Errorf("storage fallback backend must differ from storage backend: %s", backend)
+	}
+
+	// Open the fallback backend to read
+	// from while migrating between them.
+	fbDriver, err := Open(fallback, lock)
+	if err != nil {
+		_ = driver.Close()
+		return nil, fmt.Errorf("error opening storage fallback backend: %w", err)
+	}
+
+	driver.Fallback = fbDriver.Storage
+	return driver, nil
+}
+
+// Open opens the given storage backend, configured
+// from the local / s3 storage configuration values.
+func Open(backend string, lock string) (*Driver, error) {
+	switch backend {
 	case "s3":
 		return NewS3Storage()
 	case "local":
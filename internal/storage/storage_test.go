@@ -0,0 +1,123 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"codeberg.org/gruf/go-store/v2/storage"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
+)
+
+// newFallbackDriver returns a driver storing to one memory
+// store, falling back to another which contains "old".
+func newFallbackDriver(t *testing.T) (*gtsstorage.Driver, *storage.MemoryStorage, *storage.MemoryStorage) {
+	ctx := context.Background()
+	primary := storage.OpenMemory(10, false)
+	fallback := storage.OpenMemory(10, false)
+
+	if _, err := fallback.WriteBytes(ctx, "old", []byte("old data")); err != nil {
+		t.Fatal(err)
+	}
+
+	return &gtsstorage.Driver{
+		Storage:  primary,
+		Fallback: fallback,
+	}, primary, fallback
+}
+
+func TestFallbackRead(t *testing.T) {
+	ctx := context.Background()
+	driver, _, _ := newFallbackDriver(t)
+
+	// Key only in the fallback
+	// should be read from there.
+	b, err := driver.Get(ctx, "old")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != "old data" {
+		t.Fatalf("expected old data, got %q", b)
+	}
+
+	have, err := driver.Has(ctx, "old")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !have {
+		t.Fatal("expected driver to have key from fallback")
+	}
+
+	// Key in neither should
+	// still be not found.
+	if _, err := driver.Get(ctx, "missing"); !errors.Is(err, gtsstorage.ErrNotFound) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestFallbackWrite(t *testing.T) {
+	ctx := context.Background()
+	driver, primary, fallback := newFallbackDriver(t)
+
+	if _, err := driver.Put(ctx, "new", []byte("new data")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Writes should only go
+	// to the primary storage.
+	if have, _ := primary.Stat(ctx, "new"); !have {
+		t.Fatal("expected new key in primary storage")
+	}
+
+	if have, _ := fallback.Stat(ctx, "new"); have {
+		t.Fatal("expected no new key in fallback storage")
+	}
+
+	// And be read back from there.
+	b, err := driver.Get(ctx, "new")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != "new data" {
+		t.Fatalf("expected new data, got %q", b)
+	}
+}
+
+func TestFallbackDelete(t *testing.T) {
+	ctx := context.Background()
+	driver, _, fallback := newFallbackDriver(t)
+
+	// Deleting a key only in the
+	// fallback should remove it there.
+	if err := driver.Delete(ctx, "old"); err != nil {
+		t.Fatal(err)
+	}
+
+	if have, _ := fallback.Stat(ctx, "old"); have {
+		t.Fatal("expected old key removed from fallback storage")
+	}
+
+	if err := driver.Delete(ctx, "old"); !errors.Is(err, gtsstorage.ErrNotFound) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
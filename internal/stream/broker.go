@@ -0,0 +1,109 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package stream
+
+import (
+	"context"
+	"sync"
+)
+
+// Envelope wraps a Message
+// for relaying via a Broker.
+type Envelope struct {
+
+	// ID of the account whose streams
+	// the message should be posted to.
+	// Empty means all accounts' streams.
+	AccountID string `json:"account_id,omitempty"`
+
+	// ID of the status the message
+	// is about, if it should be posted
+	// to the streams of all accounts
+	// passing the Filter of each node.
+	StatusID string `json:"status_id,omitempty"`
+
+	// ID of the message, which is
+	// excluded from Message's JSON.
+	MessageID string `json:"message_id"`
+
+	// The message itself.
+	Message Message `json:"message"`
+}
+
+// Broker relays stream messages between
+// nodes serving the streaming API, so that
+// messages posted on one node reach streams
+// opened on any of them (including itself).
+type Broker interface {
+	// Publish publishes the given envelope
+	// to all nodes listening on the broker.
+	Publish(ctx context.Context, env Envelope) error
+
+	// Listen starts passing envelopes published by
+	// any node to the given function, in the order
+	// they were published, until the broker is closed.
+	Listen(fn func(context.Context, Envelope)) error
+
+	// Close stops listening,
+	// releasing any resources.
+	Close() error
+}
+
+// MemoryBroker is an in-memory Broker implementation,
+// which relays published envelopes back to all listeners
+// in the same process. It is useful for single node setups,
+// and for simulating multiple nodes in tests by having
+// multiple Streams relay through the same MemoryBroker.
+type MemoryBroker struct {
+	fns   []func(context.Context, Envelope)
+	mutex sync.Mutex
+}
+
+// Publish synchronously passes the given
+// envelope to each listener in turn.
+func (b *MemoryBroker) Publish(ctx context.Context, env Envelope) error {
+	// Holding the lock for the duration of
+	// publishing ensures envelopes reach
+	// each listener in publish order.
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for _, fn := range b.fns {
+		fn(ctx, env)
+	}
+
+	return nil
+}
+
+// Listen adds the given function
+// to those published envelopes
+// will be passed to.
+func (b *MemoryBroker) Listen(fn func(context.Context, Envelope)) error {
+	b.mutex.Lock()
+	b.fns = append(b.fns, fn)
+	b.mutex.Unlock()
+	return nil
+}
+
+// Close removes all listeners.
+func (b *MemoryBroker) Close() error {
+	b.mutex.Lock()
+	b.fns = nil
+	b.mutex.Unlock()
+	return nil
+}
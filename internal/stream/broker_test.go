@@ -0,0 +1,207 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package stream_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+)
+
+func TestMemoryBrokerRelay(t *testing.T) {
+	ctx, cncl := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cncl()
+
+	// Simulate two nodes
+	// sharing one broker.
+	var (
+		broker stream.MemoryBroker
+		nodeA  stream.Streams
+		nodeB  stream.Streams
+	)
+
+	if err := nodeA.Relay(&broker); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := nodeB.Relay(&broker); err != nil {
+		t.Fatal(err)
+	}
+
+	// Open a stream on node B only.
+	str := nodeB.Open("account", stream.TimelineHome)
+	defer str.Close()
+
+	// Post messages on node A.
+	for _, payload := range []string{"1", "2", "3"} {
+		nodeA.Post(ctx, "account", stream.Message{
+			Stream:  []string{stream.TimelineHome},
+			Event:   stream.EventTypeUpdate,
+			Payload: payload,
+		})
+	}
+
+	// Message for another account
+	// shouldn't reach the stream.
+	nodeA.Post(ctx, "other", stream.Message{
+		Stream:  []string{stream.TimelineHome},
+		Event:   stream.EventTypeUpdate,
+		Payload: "nope",
+	})
+
+	// Post to all accounts on node A.
+	nodeA.PostAll(ctx, stream.Message{
+		Stream:  []string{stream.TimelineHome},
+		Event:   stream.EventTypeDelete,
+		Payload: "4",
+	})
+
+	// All relevant messages should arrive
+	// on node B's stream, in order.
+	for _, expect := range []string{"1", "2", "3", "4"} {
+		msg, ok := str.Recv(ctx)
+		if !ok {
+			t.Fatalf("expected message %s, got none", expect)
+		}
+
+		if msg.Payload != expect {
+			t.Fatalf("expected message %s, got %s", expect, msg.Payload)
+		}
+	}
+}
+
+func TestMemoryBrokerPostStatus(t *testing.T) {
+	ctx, cncl := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cncl()
+
+	var (
+		broker stream.MemoryBroker
+		nodeA  stream.Streams
+		nodeB  stream.Streams
+	)
+
+	// Node A only publishes, so has
+	// no subscribers of its own.
+	nodeA.PublishTo(&broker)
+
+	if err := nodeB.Relay(&broker); err != nil {
+		t.Fatal(err)
+	}
+
+	// Node B only lets "allowed" see the status.
+	nodeB.SetFilter(func(_ context.Context, accountID string, statusID string) bool {
+		return accountID == "allowed" && statusID == "status"
+	})
+
+	tag := stream.HashtagStreamType(stream.TimelineHashtag, "example")
+	allowed := nodeB.Open("allowed", tag)
+	defer allowed.Close()
+	denied := nodeB.Open("denied", tag)
+	defer denied.Close()
+
+	nodeA.PostStatus(ctx, "status", stream.Message{
+		Stream:  []string{tag},
+		Event:   stream.EventTypeUpdate,
+		Payload: "1",
+	})
+
+	msg, ok := allowed.Recv(ctx)
+	if !ok || msg.Payload != "1" {
+		t.Fatalf("expected message 1, got %+v", msg)
+	}
+
+	// Nothing should reach the denied stream.
+	recvCtx, recvCncl := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer recvCncl()
+	if msg, ok := denied.Recv(recvCtx); ok {
+		t.Fatalf("expected no message, got %+v", msg)
+	}
+}
+
+// jsonBroker wraps MemoryBroker, JSON encoding
+// and decoding published envelopes as a broker
+// relaying between processes would.
+type jsonBroker struct {
+	stream.MemoryBroker
+}
+
+func (b *jsonBroker) Publish(ctx context.Context, env stream.Envelope) error {
+	data, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+
+	var decoded stream.Envelope
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	return b.MemoryBroker.Publish(ctx, decoded)
+}
+
+func TestBrokerMessageID(t *testing.T) {
+	ctx, cncl := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cncl()
+
+	var (
+		broker jsonBroker
+		nodeA  stream.Streams
+		nodeB  stream.Streams
+	)
+
+	if err := nodeA.Relay(&broker); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := nodeB.Relay(&broker); err != nil {
+		t.Fatal(err)
+	}
+
+	// Open a stream for the
+	// same account on each node.
+	strA := nodeA.Open("account", stream.TimelineHome)
+	defer strA.Close()
+	strB := nodeB.Open("account", stream.TimelineHome)
+	defer strB.Close()
+
+	nodeA.Post(ctx, "account", stream.Message{
+		Stream:  []string{stream.TimelineHome},
+		Event:   stream.EventTypeUpdate,
+		Payload: "1",
+	})
+
+	msgA, ok := strA.Recv(ctx)
+	if !ok {
+		t.Fatal("expected message on node A, got none")
+	}
+
+	msgB, ok := strB.Recv(ctx)
+	if !ok {
+		t.Fatal("expected message on node B, got none")
+	}
+
+	// Both nodes should know the message by
+	// the same ID, so streams can be resumed
+	// on a different node to the one dropped.
+	if msgA.ID == "" || msgA.ID != msgB.ID {
+		t.Fatalf("expected matching message IDs, got %q and %q", msgA.ID, msgB.ID)
+	}
+}
@@ -0,0 +1,327 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	// pgChannel is the Postgres
+	// NOTIFY channel used for
+	// relaying stream messages.
+	pgChannel = "gotosocial_stream"
+
+	// pgMaxChunk is the max number of payload bytes
+	// sent in one NOTIFY. Postgres limits payloads to
+	// under 8000 bytes; this leaves room for the header.
+	pgMaxChunk = 7680
+
+	// pgMaxBackoff is the longest
+	// time to wait between attempts
+	// to re-establish a listener.
+	pgMaxBackoff = 30 * time.Second
+)
+
+// PostgresBroker is a Broker implementation using
+// Postgres LISTEN/NOTIFY, allowing multiple nodes
+// sharing a Postgres database to relay stream
+// messages between them without extra services.
+//
+// Envelopes are JSON encoded and, as NOTIFY
+// payloads are limited in size, may be split
+// into multiple chunks that are sent in a single
+// transaction, and reassembled by listeners.
+type PostgresBroker struct {
+	cfg *pgx.ConnConfig
+
+	// dedicated publishing connection;
+	// using a single connection guarantees
+	// publish order is maintained.
+	pub    *pgx.Conn
+	pubMu  sync.Mutex
+	closed bool
+
+	// cancels listener.
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewPostgresBroker returns a new PostgresBroker
+// which will open its own connections to the
+// database using the given connection config.
+func NewPostgresBroker(cfg *pgx.ConnConfig) *PostgresBroker {
+	return &PostgresBroker{cfg: cfg}
+}
+
+// Publish sends the given envelope to all listening nodes.
+func (b *PostgresBroker) Publish(ctx context.Context, env Envelope) error {
+	data, err := json.Marshal(env)
+	if err != nil {
+		return gtserror.Newf("error marshaling envelope: %w", err)
+	}
+
+	// Split the encoded envelope
+	// into notify-able chunks.
+	chunks := pgChunks(string(data))
+
+	b.pubMu.Lock()
+	defer b.pubMu.Unlock()
+
+	if b.closed {
+		return errors.New("broker closed")
+	}
+
+	if b.pub == nil || b.pub.IsClosed() {
+		// (Re)connect the publishing conn.
+		b.pub, err = pgx.ConnectConfig(ctx, b.cfg)
+		if err != nil {
+			b.pub = nil
+			return gtserror.Newf("error connecting: %w", err)
+		}
+	}
+
+	msgID := id.NewULID()
+
+	// Notifications sent in one transaction are delivered
+	// together, in order, and only once it is committed.
+	if err := pgx.BeginFunc(ctx, b.pub, func(tx pgx.Tx) error {
+		for i, chunk := range chunks {
+			payload := msgID + ":" +
+				strconv.Itoa(i) + ":" +
+				strconv.Itoa(len(chunks)) + ":" +
+				chunk
+
+			if _, err := tx.Exec(ctx,
+				"SELECT pg_notify($1, $2)",
+				pgChannel, payload,
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return gtserror.Newf("error notifying: %w", err)
+	}
+
+	return nil
+}
+
+// Listen connects a listener to the database, then, in
+// a separate goroutine, passes received envelopes to the
+// given function until Close is called. If the listener
+// connection is lost, it will attempt to reconnect.
+func (b *PostgresBroker) Listen(fn func(context.Context, Envelope)) error {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Connect first, so that
+	// configuration errors are
+	// returned to the caller.
+	conn, err := b.listen(ctx)
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	b.cancel = cancel
+	b.done = make(chan struct{})
+
+	go func() {
+		defer close(b.done)
+
+		backoff := time.Second
+		for {
+			if conn != nil {
+				err := b.receive(ctx, conn, fn)
+				_ = conn.Close(context.Background())
+				conn = nil
+
+				if ctx.Err() != nil {
+					// Broker closed.
+					return
+				}
+
+				log.Errorf(ctx, "stream listener connection lost: %v", err)
+				backoff = time.Second
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+
+			if conn, err = b.listen(ctx); err != nil {
+				log.Errorf(ctx, "error reconnecting stream listener: %v", err)
+				backoff = min(2*backoff, pgMaxBackoff)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// listen opens a new connection to
+// the database, and starts listening
+// on the stream notify channel.
+func (b *PostgresBroker) listen(ctx context.Context) (*pgx.Conn, error) {
+	conn, err := pgx.ConnectConfig(ctx, b.cfg)
+	if err != nil {
+		return nil, gtserror.Newf("error connecting: %w", err)
+	}
+
+	if _, err := conn.Exec(ctx,
+		"LISTEN "+pgx.Identifier{pgChannel}.Sanitize(),
+	); err != nil {
+		_ = conn.Close(context.Background())
+		return nil, gtserror.Newf("error listening: %w", err)
+	}
+
+	return conn, nil
+}
+
+// receive waits on notifications from given listening
+// connection, reassembling chunked envelopes, and passing
+// them to fn. Only returns on error or canceled context.
+func (b *PostgresBroker) receive(ctx context.Context, conn *pgx.Conn, fn func(context.Context, Envelope)) error {
+	var (
+		// ID and data of the
+		// envelope currently
+		// being reassembled.
+		msgID string
+		buf   strings.Builder
+	)
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		chunkID, idx, total, chunk, err := pgParseChunk(n.Payload)
+		if err != nil {
+			log.Errorf(ctx, "invalid stream notification: %v", err)
+			continue
+		}
+
+		if idx == 0 {
+			// Start of a new envelope.
+			msgID = chunkID
+			buf.Reset()
+		} else if chunkID != msgID {
+			// Chunks of a transaction are
+			// never interleaved with others,
+			// so this can only happen if we
+			// joined mid-way; just drop it.
+			continue
+		}
+
+		buf.WriteString(chunk)
+
+		if idx != total-1 {
+			// Wait for more.
+			continue
+		}
+
+		var env Envelope
+		if err := json.Unmarshal([]byte(buf.String()), &env); err != nil {
+			log.Errorf(ctx, "error unmarshaling stream envelope: %v", err)
+			continue
+		}
+
+		msgID = ""
+		buf.Reset()
+
+		fn(ctx, env)
+	}
+}
+
+// Close stops the listener (if any), and closes connections.
+func (b *PostgresBroker) Close() error {
+	if b.cancel != nil {
+		b.cancel()
+		<-b.done
+	}
+
+	b.pubMu.Lock()
+	defer b.pubMu.Unlock()
+	b.closed = true
+
+	if b.pub != nil {
+		err := b.pub.Close(context.Background())
+		b.pub = nil
+		return err
+	}
+
+	return nil
+}
+
+// pgChunks splits the given data into chunks no
+// longer than pgMaxChunk bytes, without splitting
+// multi-byte characters across chunks.
+func pgChunks(data string) []string {
+	chunks := make([]string, 0, len(data)/pgMaxChunk+1)
+	for len(data) > pgMaxChunk {
+		i := pgMaxChunk
+		for i > 0 && !utf8.RuneStart(data[i]) {
+			i--
+		}
+		chunks = append(chunks, data[:i])
+		data = data[i:]
+	}
+	return append(chunks, data)
+}
+
+// pgParseChunk parses a notification payload
+// as formatted by PostgresBroker.Publish.
+func pgParseChunk(payload string) (msgID string, idx int, total int, chunk string, err error) {
+	parts := strings.SplitN(payload, ":", 4)
+	if len(parts) != 4 {
+		return "", 0, 0, "", errors.New("malformed header")
+	}
+
+	msgID = parts[0]
+	chunk = parts[3]
+
+	if idx, err = strconv.Atoi(parts[1]); err != nil {
+		return "", 0, 0, "", fmt.Errorf("malformed chunk index: %w", err)
+	}
+
+	if total, err = strconv.Atoi(parts[2]); err != nil {
+		return "", 0, 0, "", fmt.Errorf("malformed chunk total: %w", err)
+	}
+
+	if idx < 0 || idx >= total {
+		return "", 0, 0, "", fmt.Errorf("chunk index %d out of range %d", idx, total)
+	}
+
+	return msgID, idx, total, chunk, nil
+}
@@ -0,0 +1,97 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package stream
+
+import (
+	"time"
+)
+
+const (
+	// replayLen is the max number of recent
+	// messages kept per account for replay.
+	replayLen = 32
+
+	// replayTTL is how long messages are kept for
+	// replay, and how long an account's replay
+	// buffer is kept after its last stream closed.
+	replayTTL = 5 * time.Minute
+)
+
+// replay is a short buffer of messages recently
+// posted to one account, allowing clients that
+// reconnect to resume from the last message they
+// saw (eg., via Last-Event-ID for event streams).
+type replay struct {
+	msgs []replayMsg
+
+	// closed is when the account's last
+	// open stream was closed; zero while
+	// the account has open streams.
+	closed time.Time
+}
+
+type replayMsg struct {
+	msg Message
+	at  time.Time
+}
+
+// expired returns whether this replay buffer
+// can be dropped, ie., the account has had no
+// open streams for longer than replayTTL.
+func (r *replay) expired(now time.Time) bool {
+	return !r.closed.IsZero() && now.Sub(r.closed) > replayTTL
+}
+
+// add adds the given message to the buffer,
+// dropping the oldest and expired messages.
+func (r *replay) add(msg Message, now time.Time) {
+	r.msgs = append(r.msgs, replayMsg{msg: msg, at: now})
+
+	// Count leading messages to drop.
+	var drop int
+	if len(r.msgs) > replayLen {
+		drop = len(r.msgs) - replayLen
+	}
+	for drop < len(r.msgs) && now.Sub(r.msgs[drop].at) > replayTTL {
+		drop++
+	}
+
+	if drop > 0 {
+		r.msgs = append(r.msgs[:0], r.msgs[drop:]...)
+	}
+}
+
+// since returns unexpired messages posted after
+// the message with given ID, or nil if the ID isn't
+// (or is no longer) in the buffer.
+func (r *replay) since(lastID string, now time.Time) []Message {
+	for i := len(r.msgs) - 1; i >= 0; i-- {
+		if r.msgs[i].msg.ID != lastID {
+			continue
+		}
+
+		msgs := make([]Message, 0, len(r.msgs)-i-1)
+		for _, m := range r.msgs[i+1:] {
+			if now.Sub(m.at) <= replayTTL {
+				msgs = append(msgs, m.msg)
+			}
+		}
+		return msgs
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package stream_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+)
+
+func TestStreamsResume(t *testing.T) {
+	ctx, cncl := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cncl()
+
+	var streams stream.Streams
+
+	post := func(payload string) {
+		streams.Post(ctx, "account", stream.Message{
+			Stream:  []string{stream.TimelineHome},
+			Event:   stream.EventTypeUpdate,
+			Payload: payload,
+		})
+	}
+
+	// Open stream and receive first message.
+	str := streams.Open("account", stream.TimelineHome)
+	post("1")
+	msg, ok := str.Recv(ctx)
+	if !ok {
+		t.Fatal("stream closed")
+	}
+	if msg.ID == "" {
+		t.Fatal("message has no ID")
+	}
+	lastID := msg.ID
+
+	// Drop the stream, and post
+	// more while it's disconnected.
+	str.Close()
+	post("2")
+	post("3")
+
+	// Resume from last seen message.
+	str = streams.Resume("account", lastID, stream.TimelineHome)
+	defer str.Close()
+	post("4")
+
+	// Missed messages should come first, in order.
+	for _, expect := range []string{"2", "3", "4"} {
+		msg, ok := str.Recv(ctx)
+		if !ok {
+			t.Fatal("stream closed")
+		}
+		if msg.Payload != expect {
+			t.Fatalf("expected payload %q, got %q", expect, msg.Payload)
+		}
+	}
+
+	// Resuming from an unknown
+	// ID shouldn't replay anything.
+	other := streams.Resume("account", "unknown", stream.TimelineHome)
+	defer other.Close()
+	post("5")
+	if msg, _ := other.Recv(ctx); msg.Payload != "5" {
+		t.Fatalf("expected payload %q, got %q", "5", msg.Payload)
+	}
+}
@@ -21,8 +21,13 @@ import (
 	"context"
 	"maps"
 	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
 const (
@@ -71,6 +76,15 @@ const (
 	// TimelineList:
 	// Updates to a specific list.
 	TimelineList = "list"
+
+	// TimelineHashtag:
+	// All public posts using a specific hashtag.
+	TimelineHashtag = "hashtag"
+
+	// TimelineHashtagLocal:
+	// All public posts originating from this
+	// server using a specific hashtag.
+	TimelineHashtagLocal = "hashtag:local"
 )
 
 // AllStatusTimelines contains all Timelines
@@ -82,15 +96,127 @@ var AllStatusTimelines = []string{
 	TimelineHome,
 	TimelineDirect,
 	TimelineList,
+	TimelineHashtag,
+	TimelineHashtagLocal,
 }
 
+// HashtagStreamType returns the stream type used to track
+// subscriptions to the given hashtag timeline (ie., one of
+// TimelineHashtag or TimelineHashtagLocal) for given tag name.
+// The returned type looks like `hashtag#example` or
+// `hashtag:local#example`; the `#` separator ensures that a
+// tag named `local` can't be confused with the local timeline.
+func HashtagStreamType(timeline string, tag string) string {
+	tag = strings.TrimPrefix(tag, "#")
+	tag = strings.ToLower(tag)
+	return timeline + "#" + tag
+}
+
+// ClientStream returns the `stream` array sent to clients
+// alongside messages for the given stream type. Hashtag
+// stream types are split back into the timeline and tag,
+// eg., `["hashtag", "example"]`, as clients expect.
+func ClientStream(streamType string) []string {
+	if timeline, tag, ok := strings.Cut(streamType, "#"); ok {
+		return []string{timeline, tag}
+	}
+	return []string{streamType}
+}
+
+// Filter reports whether a message about the status
+// with given ID may be delivered to streams belonging
+// to the account with given ID.
+type Filter func(ctx context.Context, accountID string, statusID string) bool
+
 type Streams struct {
 	streams map[string][]*Stream
+	replays map[string]*replay
+	broker  Broker
+	filter  Filter
 	mutex   sync.Mutex
 }
 
+// Relay sets the Broker that Streams will relay posted messages
+// through, and starts listening for messages published to the
+// broker (from this or any other node), delivering them to open
+// streams on this node. Must be called before Streams is used.
+//
+// Without a Broker, messages are delivered in-process only.
+func (s *Streams) Relay(broker Broker) error {
+	s.PublishTo(broker)
+	return broker.Listen(s.receive)
+}
+
+// PublishTo is like Relay, but only publishes posted messages
+// to the given Broker, without listening for messages from it.
+// This is for nodes which post messages but serve no streams.
+func (s *Streams) PublishTo(broker Broker) {
+	s.mutex.Lock()
+	s.broker = broker
+	s.mutex.Unlock()
+}
+
+// SetFilter sets the Filter used to check which
+// accounts' streams messages posted with PostStatus
+// are delivered to. Must be called before Streams is
+// used; without a Filter, such messages are dropped.
+func (s *Streams) SetFilter(filter Filter) {
+	s.mutex.Lock()
+	s.filter = filter
+	s.mutex.Unlock()
+}
+
+// receive delivers the given envelope, as
+// received from the broker, to local streams.
+func (s *Streams) receive(ctx context.Context, env Envelope) {
+	// Message IDs aren't included in
+	// (websocket) message JSON, so are
+	// relayed separately in the envelope.
+	env.Message.ID = env.MessageID
+
+	switch {
+	case env.StatusID != "":
+		s.postStatus(ctx, env.StatusID, env.Message)
+	case env.AccountID == "":
+		s.postAll(ctx, env.Message)
+	default:
+		s.post(ctx, env.AccountID, env.Message)
+	}
+}
+
+// publish publishes the given envelope via the configured broker,
+// if any. It returns whether there was a broker to publish to,
+// and whether the envelope was published successfully.
+func (s *Streams) publish(ctx context.Context, env Envelope) (published bool, ok bool) {
+	s.mutex.Lock()
+	broker := s.broker
+	s.mutex.Unlock()
+
+	if broker == nil {
+		return false, false
+	}
+
+	// Relay the message ID
+	// (see receive).
+	env.MessageID = env.Message.ID
+
+	if err := broker.Publish(ctx, env); err != nil {
+		log.Errorf(ctx, "error publishing stream message: %v", err)
+		return true, false
+	}
+
+	return true, true
+}
+
 // Open will open open a new Stream for given account ID and stream types, the given context will be passed to Stream.
 func (s *Streams) Open(accountID string, streamTypes ...string) *Stream {
+	return s.Resume(accountID, "", streamTypes...)
+}
+
+// Resume is like Open, but the returned Stream will first receive any recent messages
+// for given account ID and stream types posted after the message with given ID, if
+// that message is still in the account's short replay buffer.
+func (s *Streams) Resume(accountID string, lastMsgID string, streamTypes ...string) *Stream {
 	if len(streamTypes) == 0 {
 		panic("no stream types given")
 	}
@@ -98,7 +224,6 @@ func (s *Streams) Open(accountID string, streamTypes ...string) *Stream {
 	// Prep new Stream.
 	str := new(Stream)
 	str.done = make(chan struct{})
-	str.msgCh = make(chan Message, 50) // TODO: make configurable
 	for _, streamType := range streamTypes {
 		str.Subscribe(streamType)
 	}
@@ -110,8 +235,37 @@ func (s *Streams) Open(accountID string, streamTypes ...string) *Stream {
 	s.mutex.Lock()
 
 	if s.streams == nil {
-		// Main stream-map needs allocating.
+		// Main stream-maps need allocating.
 		s.streams = make(map[string][]*Stream)
+		s.replays = make(map[string]*replay)
+	}
+
+	// Get (or create) account's replay buffer,
+	// and mark the account as having open streams.
+	r := s.replays[accountID]
+	if r == nil {
+		r = new(replay)
+		s.replays[accountID] = r
+	}
+	r.closed = time.Time{}
+
+	var replayed []Message
+	if lastMsgID != "" {
+		// Gather missed messages this
+		// stream would have received.
+		for _, msg := range r.since(lastMsgID, time.Now()) {
+			if stype := str.getStreamType(msg.Stream...); stype != "" {
+				msg.Stream = []string{stype}
+				replayed = append(replayed, msg)
+			}
+		}
+	}
+
+	// Size msg channel so missed messages can be queued
+	// up front, *before* any newly posted messages.
+	str.msgCh = make(chan Message, 50+len(replayed)) // TODO: make configurable
+	for _, msg := range replayed {
+		str.msgCh <- msg
 	}
 
 	// Add new stream for account.
@@ -128,7 +282,21 @@ func (s *Streams) Open(accountID string, streamTypes ...string) *Stream {
 		strs = slices.DeleteFunc(strs, func(s *Stream) bool {
 			return s == str // remove 'str' ptr
 		})
-		s.streams[accountID] = strs
+		if len(strs) == 0 {
+			// No more open streams; keep
+			// replay buffer around a while
+			// in case the client reconnects.
+			delete(s.streams, accountID)
+			if r := s.replays[accountID]; r != nil {
+				closed := time.Now()
+				r.closed = closed
+				time.AfterFunc(replayTTL, func() {
+					s.dropReplay(accountID, r, closed)
+				})
+			}
+		} else {
+			s.streams[accountID] = strs
+		}
 		s.mutex.Unlock()
 	}
 
@@ -138,13 +306,134 @@ func (s *Streams) Open(accountID string, streamTypes ...string) *Stream {
 	return str
 }
 
-// Post will post the given message to all streams of given account ID matching type.
+// record adds the given message to the replay buffer of the
+// given account, if it has open streams or recently did,
+// dropping the buffer if it's expired. Caller must hold lock.
+func (s *Streams) record(accountID string, msg Message) {
+	r := s.replays[accountID]
+	if r == nil {
+		// Account hasn't
+		// opened streams.
+		return
+	}
+
+	now := time.Now()
+	if r.expired(now) {
+		delete(s.replays, accountID)
+		return
+	}
+
+	r.add(msg, now)
+}
+
+// dropReplay drops the given replay buffer of the given
+// account, if the account hasn't opened any streams since
+// they were all closed at the given time.
+func (s *Streams) dropReplay(accountID string, r *replay, closed time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.replays[accountID] == r && r.closed.Equal(closed) {
+		delete(s.replays, accountID)
+	}
+}
+
+// Subscribers returns the IDs of all accounts with at least
+// one open stream on this node matching any given type.
+func (s *Streams) Subscribers(streamTypes ...string) []string {
+	var accountIDs []string
+
+	// Acquire lock.
+	s.mutex.Lock()
+
+	// Iterate ALL stored streams.
+	for accountID, strs := range s.streams {
+		for _, str := range strs {
+			if str.getStreamType(streamTypes...) != "" {
+				accountIDs = append(accountIDs, accountID)
+				break
+			}
+		}
+	}
+
+	// Done with lock.
+	s.mutex.Unlock()
+
+	return accountIDs
+}
+
+// Post will post the given message to all streams of given account ID matching type,
+// relaying it via the configured Broker (if any) to reach streams on all nodes.
 func (s *Streams) Post(ctx context.Context, accountID string, msg Message) bool {
+	// Give message a unique ID, used for
+	// resuming streams. This is set once
+	// here, before publishing, so that it's
+	// the same on every node it reaches.
+	msg.ID = id.NewULID()
+
+	if published, ok := s.publish(ctx, Envelope{
+		AccountID: accountID,
+		Message:   msg,
+	}); published {
+		return ok
+	}
+	return s.post(ctx, accountID, msg)
+}
+
+// PostStatus will post the given message about the status with given ID to all streams
+// with matching types belonging to accounts which pass the Filter (see SetFilter), relaying
+// it via the configured Broker (if any) to reach streams on all nodes. As the filtering
+// is done by each node, this is suitable for messages which are of interest to accounts
+// unknown to the poster, eg., statuses posted to hashtag streams.
+func (s *Streams) PostStatus(ctx context.Context, statusID string, msg Message) bool {
+	// Give message a unique ID (see Post).
+	msg.ID = id.NewULID()
+
+	if published, ok := s.publish(ctx, Envelope{
+		StatusID: statusID,
+		Message:  msg,
+	}); published {
+		return ok
+	}
+	return s.postStatus(ctx, statusID, msg)
+}
+
+// postStatus will post the given message about given status ID
+// to all local streams with matching types, for accounts passing
+// the filter. Only accounts with open streams are checked.
+func (s *Streams) postStatus(ctx context.Context, statusID string, msg Message) bool {
+	s.mutex.Lock()
+	filter := s.filter
+	s.mutex.Unlock()
+
+	if filter == nil {
+		return false
+	}
+
+	ok := true
+
+	// Filter outside of main mutex, as
+	// this may involve database calls.
+	for _, accountID := range s.Subscribers(msg.Stream...) {
+		if !filter(ctx, accountID, statusID) {
+			continue
+		}
+
+		ok = s.post(ctx, accountID, msg) && ok
+	}
+
+	return ok
+}
+
+// post will post the given message to all local streams of given account ID matching type.
+func (s *Streams) post(ctx context.Context, accountID string, msg Message) bool {
 	var deferred []func() bool
 
 	// Acquire lock.
 	s.mutex.Lock()
 
+	// Record message for replay.
+	s.record(accountID, msg)
+
 	// Iterate all streams stored for account.
 	for _, str := range s.streams[accountID] {
 
@@ -159,6 +448,7 @@ func (s *Streams) Post(ctx context.Context, accountID string, msg Message) bool
 			// Use a message copy to *only*
 			// include the supported stream.
 			msgCopy := Message{
+				ID:      msg.ID,
 				Stream:  []string{stype},
 				Event:   msg.Event,
 				Payload: msg.Payload,
@@ -188,13 +478,33 @@ func (s *Streams) Post(ctx context.Context, accountID string, msg Message) bool
 	return ok
 }
 
-// PostAll will post the given message to all streams with matching types.
+// PostAll will post the given message to all streams with matching types,
+// relaying it via the configured Broker (if any) to reach streams on all nodes.
 func (s *Streams) PostAll(ctx context.Context, msg Message) bool {
+	// Give message a unique ID (see Post).
+	msg.ID = id.NewULID()
+
+	if published, ok := s.publish(ctx, Envelope{
+		Message: msg,
+	}); published {
+		return ok
+	}
+	return s.postAll(ctx, msg)
+}
+
+// postAll will post the given message to all local streams with matching types.
+func (s *Streams) postAll(ctx context.Context, msg Message) bool {
 	var deferred []func() bool
 
 	// Acquire lock.
 	s.mutex.Lock()
 
+	// Record message for replay
+	// by all accounts with buffers.
+	for accountID := range s.replays {
+		s.record(accountID, msg)
+	}
+
 	// Iterate ALL stored streams.
 	for _, strs := range s.streams {
 		for _, str := range strs {
@@ -210,6 +520,7 @@ func (s *Streams) PostAll(ctx context.Context, msg Message) bool {
 				// Use a message copy to *only*
 				// include the supported stream.
 				msgCopy := Message{
+					ID:      msg.ID,
 					Stream:  []string{stype},
 					Event:   msg.Event,
 					Payload: msg.Payload,
@@ -367,6 +678,11 @@ func (s *Stream) cas(fn func(map[string]struct{}) bool) {
 // one streamed message.
 type Message struct {
 
+	// Unique ID of this message, used
+	// for resuming streams; not
+	// included in websocket messages.
+	ID string `json:"-"`
+
 	// All the stream types this
 	// message should be delivered to.
 	Stream []string `json:"stream"`
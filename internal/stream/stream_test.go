@@ -0,0 +1,40 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package stream_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+)
+
+func TestClientStream(t *testing.T) {
+	for _, test := range []struct {
+		streamType string
+		expect     []string
+	}{
+		{stream.TimelineHome, []string{"user"}},
+		{stream.HashtagStreamType(stream.TimelineHashtag, "Example"), []string{"hashtag", "example"}},
+		{stream.HashtagStreamType(stream.TimelineHashtagLocal, "local"), []string{"hashtag:local", "local"}},
+	} {
+		if got := stream.ClientStream(test.streamType); !slices.Equal(got, test.expect) {
+			t.Errorf("expected %q for %s, got %q", test.expect, test.streamType, got)
+		}
+	}
+}
@@ -18,7 +18,6 @@
 package timeline
 
 import (
-	"container/list"
 	"context"
 	"errors"
 
@@ -49,10 +48,7 @@ func (t *timeline) indexXBetweenIDs(ctx context.Context, amount int, behindID st
 	defer t.Unlock()
 
 	// Lazily init indexed items.
-	if t.items.data == nil {
-		t.items.data = &list.List{}
-		t.items.data.Init()
-	}
+	t.initItems(ctx)
 
 	// Start by mapping out the list so we know what
 	// we have to do. Depending on the current state
@@ -127,6 +123,13 @@ func (t *timeline) indexXBetweenIDs(ctx context.Context, amount int, behindID st
 	// Index all the items we got. We already have
 	// a lock on the timeline, so don't call IndexOne
 	// here, since that will also try to get a lock!
+	inserted := make([]Timelineable, 0, len(items))
+	defer func() {
+		// Persist whatever
+		// we managed to index.
+		t.persist(ctx, inserted)
+	}()
+
 	for _, item := range items {
 		entry := &indexedItemsEntry{
 			itemID:           item.GetID(),
@@ -135,9 +138,14 @@ func (t *timeline) indexXBetweenIDs(ctx context.Context, amount int, behindID st
 			boostOfAccountID: item.GetBoostOfAccountID(),
 		}
 
-		if _, err := t.items.insertIndexed(ctx, entry); err != nil {
+		ok, err := t.items.insertIndexed(ctx, entry)
+		if err != nil {
 			return gtserror.Newf("error inserting entry with itemID %s into index: %w", entry.itemID, err)
 		}
+
+		if ok {
+			inserted = append(inserted, item)
+		}
 	}
 
 	return nil
@@ -228,6 +236,9 @@ func (t *timeline) IndexAndPrepareOne(ctx context.Context, statusID string, boos
 	t.Lock()
 	defer t.Unlock()
 
+	// Lazily init indexed items.
+	t.initItems(ctx)
+
 	postIndexEntry := &indexedItemsEntry{
 		itemID:           statusID,
 		boostOfID:        boostOfID,
@@ -243,6 +254,8 @@ func (t *timeline) IndexAndPrepareOne(ctx context.Context, statusID string, boos
 		return false, nil
 	}
 
+	t.persist(ctx, []Timelineable{postIndexEntry})
+
 	preparable, err := t.prepareFunction(ctx, t.timelineID, statusID)
 	if err != nil {
 		return true, gtserror.Newf("error preparing: %w", err)
@@ -37,6 +37,26 @@ type indexedItemsEntry struct {
 	prepared         Preparable
 }
 
+// GetID implements Timelineable{}.
+func (e *indexedItemsEntry) GetID() string {
+	return e.itemID
+}
+
+// GetAccountID implements Timelineable{}.
+func (e *indexedItemsEntry) GetAccountID() string {
+	return e.accountID
+}
+
+// GetBoostOfID implements Timelineable{}.
+func (e *indexedItemsEntry) GetBoostOfID() string {
+	return e.boostOfID
+}
+
+// GetBoostOfAccountID implements Timelineable{}.
+func (e *indexedItemsEntry) GetBoostOfAccountID() string {
+	return e.boostOfAccountID
+}
+
 // WARNING: ONLY CALL THIS FUNCTION IF YOU ALREADY HAVE
 // A LOCK ON THE TIMELINE CONTAINING THIS INDEXEDITEMS!
 func (i *indexedItems) insertIndexed(ctx context.Context, newEntry *indexedItemsEntry) (bool, error) {
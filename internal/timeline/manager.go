@@ -94,13 +94,18 @@ type Manager interface {
 }
 
 // NewManager returns a new timeline manager.
-func NewManager(grabFunction GrabFunction, filterFunction FilterFunction, prepareFunction PrepareFunction, skipInsertFunction SkipInsertFunction) Manager {
+//
+// Store is optional, and may be nil. If set, indexed items
+// of timelines will be persisted in store, and timelines
+// will be warmed up from store on first access.
+func NewManager(grabFunction GrabFunction, filterFunction FilterFunction, prepareFunction PrepareFunction, skipInsertFunction SkipInsertFunction, store Store) Manager {
 	return &manager{
 		timelines:          sync.Map{},
 		grabFunction:       grabFunction,
 		filterFunction:     filterFunction,
 		prepareFunction:    prepareFunction,
 		skipInsertFunction: skipInsertFunction,
+		store:              store,
 	}
 }
 
@@ -110,6 +115,7 @@ type manager struct {
 	filterFunction     FilterFunction
 	prepareFunction    PrepareFunction
 	skipInsertFunction SkipInsertFunction
+	store              Store
 }
 
 func (m *manager) Start() error {
@@ -117,6 +123,9 @@ func (m *manager) Start() error {
 	// through all stored timelines once per hour,
 	// and cleans up old entries if that timeline
 	// hasn't been accessed in the last hour.
+	//
+	// If timelines are persisted, the persisted
+	// timelines are also compacted at this point.
 	go func() {
 		for now := range time.NewTicker(1 * time.Hour).C {
 			now := now // rescope
@@ -149,6 +158,9 @@ func (m *manager) Start() error {
 
 			// Execute the function for each timeline.
 			m.timelines.Range(f)
+
+			// Trim persisted timelines, if any.
+			m.compactStore(context.Background())
 		}
 	}()
 
@@ -170,11 +182,29 @@ func (m *manager) IngestOne(ctx context.Context, timelineID string, item Timelin
 }
 
 func (m *manager) Remove(ctx context.Context, timelineID string, itemID string) (int, error) {
-	return m.getOrCreateTimeline(ctx, timelineID).Remove(ctx, itemID)
+	// Remove from memory first, so the item
+	// is gone from the timeline even if we
+	// fail to remove it from the store.
+	removed, err := m.getOrCreateTimeline(ctx, timelineID).Remove(ctx, itemID)
+
+	if m.store != nil {
+		if err := m.store.Remove(ctx, timelineID, itemID); err != nil {
+			log.Errorf(ctx, "error removing persisted item %s: %v", itemID, err)
+		}
+	}
+
+	return removed, err
 }
 
 func (m *manager) RemoveTimeline(ctx context.Context, timelineID string) error {
 	m.timelines.Delete(timelineID)
+
+	if m.store != nil {
+		if err := m.store.RemoveTimeline(ctx, timelineID); err != nil {
+			return gtserror.Newf("error removing persisted timeline %s: %w", timelineID, err)
+		}
+	}
+
 	return nil
 }
 
@@ -201,6 +231,14 @@ func (m *manager) WipeItemFromAllTimelines(ctx context.Context, itemID string) e
 		return true // always continue range
 	})
 
+	if m.store != nil {
+		// Also wipe from timelines that
+		// aren't currently held in memory.
+		if err := m.store.RemoveFromAll(ctx, itemID); err != nil {
+			errs.Append(err)
+		}
+	}
+
 	if err := errs.Combine(); err != nil {
 		return gtserror.Newf("error(s) wiping status %s: %w", itemID, errs.Combine())
 	}
@@ -209,7 +247,15 @@ func (m *manager) WipeItemFromAllTimelines(ctx context.Context, itemID string) e
 }
 
 func (m *manager) WipeItemsFromAccountID(ctx context.Context, timelineID string, accountID string) error {
+	// Remove from memory first, as in Remove.
 	_, err := m.getOrCreateTimeline(ctx, timelineID).RemoveAllByOrBoosting(ctx, accountID)
+
+	if m.store != nil {
+		if err := m.store.RemoveAllByOrBoosting(ctx, timelineID, accountID); err != nil {
+			log.Errorf(ctx, "error wiping persisted items from account %s: %v", accountID, err)
+		}
+	}
+
 	return err
 }
 
@@ -252,8 +298,27 @@ func (m *manager) getOrCreateTimeline(ctx context.Context, timelineID string) Ti
 
 	// Timeline did not yet exist in sync.Map.
 	// Create + store it.
-	timeline := NewTimeline(ctx, timelineID, m.grabFunction, m.filterFunction, m.prepareFunction, m.skipInsertFunction)
+	timeline := NewTimeline(ctx, timelineID, m.grabFunction, m.filterFunction, m.prepareFunction, m.skipInsertFunction, m.store)
 	m.timelines.Store(timelineID, timeline)
 
 	return timeline
 }
+
+// compactStore compacts the manager's
+// store of persisted timelines, if any.
+func (m *manager) compactStore(ctx context.Context) {
+	if m.store == nil {
+		// Not persisted.
+		return
+	}
+
+	amountTrimmed, err := m.store.Compact(ctx)
+	if err != nil {
+		log.Errorf(ctx, "error compacting persisted timelines: %v", err)
+		return
+	}
+
+	if amountTrimmed > 0 {
+		log.Infof(ctx, "trimmed %d items from persisted timelines", amountTrimmed)
+	}
+}
@@ -0,0 +1,92 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package timeline
+
+import (
+	"container/list"
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// initItems lazily initializes the indexed items of
+// the timeline, warming them up from the timeline's
+// store of persisted items, if it has one.
+//
+// WARNING: ONLY CALL THIS FUNCTION IF YOU ALREADY HAVE
+// A LOCK ON THE TIMELINE!
+func (t *timeline) initItems(ctx context.Context) {
+	if t.items.data != nil {
+		// Already initialized.
+		return
+	}
+
+	t.items.data = &list.List{}
+	t.items.data.Init()
+
+	if t.store == nil {
+		// Nothing to
+		// warm up from.
+		return
+	}
+
+	items, err := t.store.Load(ctx, t.timelineID)
+	if err != nil {
+		// Not fatal, we can still
+		// rebuild the timeline index
+		// using the grab function.
+		log.Errorf(ctx, "error loading persisted timeline %s: %v", t.timelineID, err)
+		return
+	}
+
+	// Items were persisted after passing through
+	// insertIndexed already, and are returned newest
+	// first, so we can just push them to the back.
+	for _, item := range items {
+		t.items.data.PushBack(&indexedItemsEntry{
+			itemID:           item.GetID(),
+			boostOfID:        item.GetBoostOfID(),
+			accountID:        item.GetAccountID(),
+			boostOfAccountID: item.GetBoostOfAccountID(),
+		})
+	}
+}
+
+// persist puts the given items in the
+// timeline's store, if it has one.
+func (t *timeline) persist(ctx context.Context, items []Timelineable) {
+	if t.store == nil || len(items) == 0 {
+		return
+	}
+
+	if err := t.store.Put(ctx, t.timelineID, items); err != nil {
+		log.Errorf(ctx, "error persisting items in timeline %s: %v", t.timelineID, err)
+	}
+}
+
+// unpersist removes the item with the given ID
+// from the timeline's store, if it has one.
+func (t *timeline) unpersist(ctx context.Context, itemID string) {
+	if t.store == nil {
+		return
+	}
+
+	if err := t.store.Remove(ctx, t.timelineID, itemID); err != nil {
+		log.Errorf(ctx, "error removing item %s from persisted timeline %s: %v", itemID, t.timelineID, err)
+	}
+}
@@ -0,0 +1,137 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package timeline_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	tlprocessor "github.com/superseriousbusiness/gotosocial/internal/processing/timeline"
+	"github.com/superseriousbusiness/gotosocial/internal/timeline"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+)
+
+type StoreTestSuite struct {
+	TimelineStandardTestSuite
+}
+
+// newManager returns a new home timeline manager
+// which persists timelines, using the given grab
+// function, to simulate a (re)started instance.
+func (suite *StoreTestSuite) newManager(grab timeline.GrabFunction) timeline.Manager {
+	config.SetTimelinesPersistEnabled(true)
+	store := tlprocessor.HomeTimelineStore(suite.state)
+	suite.NotNil(store)
+
+	return timeline.NewManager(
+		grab,
+		tlprocessor.HomeTimelineFilter(suite.state, visibility.NewFilter(suite.state)),
+		tlprocessor.HomeTimelineStatusPrepare(suite.state, typeutils.NewConverter(suite.state)),
+		tlprocessor.SkipInsert(),
+		store,
+	)
+}
+
+func (suite *StoreTestSuite) TestWarmFromStore() {
+	var (
+		ctx       = context.Background()
+		accountID = suite.testAccounts["local_account_1"].ID
+		manager   = suite.newManager(tlprocessor.HomeTimelineGrab(suite.state))
+	)
+
+	// Build the timeline from the db,
+	// which should persist its index.
+	statuses, err := manager.GetTimeline(ctx, accountID, "", "", "", 20, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEmpty(statuses)
+
+	entries, err := suite.state.DB.GetTimelineEntries(ctx, gtsmodel.TimelineTypeHome, accountID, 0)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.GreaterOrEqual(len(entries), len(statuses))
+
+	// "Restart" with a grab function that
+	// has nothing to give, so timeline has
+	// to be warmed up from the store instead.
+	restarted := suite.newManager(func(context.Context, string, string, string, string, int) ([]timeline.Timelineable, bool, error) {
+		return nil, true, nil
+	})
+
+	warmed, err := restarted.GetTimeline(ctx, accountID, "", "", "", 20, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Len(warmed, len(statuses))
+	for i := range statuses {
+		suite.Equal(statuses[i].GetID(), warmed[i].GetID())
+	}
+
+	// Removing an item should
+	// also remove it from the store.
+	removedID := warmed[0].GetID()
+	if _, err := restarted.Remove(ctx, accountID, removedID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	entries, err = suite.state.DB.GetTimelineEntries(ctx, gtsmodel.TimelineTypeHome, accountID, 0)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for _, entry := range entries {
+		suite.NotEqual(removedID, entry.ItemID)
+	}
+}
+
+func (suite *StoreTestSuite) TestCompact() {
+	var (
+		ctx       = context.Background()
+		accountID = suite.testAccounts["local_account_1"].ID
+		manager   = suite.newManager(tlprocessor.HomeTimelineGrab(suite.state))
+	)
+
+	if _, err := manager.GetTimeline(ctx, accountID, "", "", "", 20, false); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Compact the store down to 2 items.
+	config.SetTimelinesPersistMaxLength(2)
+	trimmed, err := tlprocessor.HomeTimelineStore(suite.state).Compact(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Positive(trimmed)
+
+	entries, err := suite.state.DB.GetTimelineEntries(ctx, gtsmodel.TimelineTypeHome, accountID, 0)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(entries, 2)
+}
+
+func TestStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(StoreTestSuite))
+}
@@ -63,6 +63,39 @@ type SkipInsertFunction func(ctx context.Context,
 	nextItemBoostOfAccountID string,
 	depth int) (bool, error)
 
+// Store persists the indexed items of timelines, so that a timeline
+// can be warmed up from it on first access after a restart, instead
+// of having to rebuild the whole index using its GrabFunction.
+//
+// A Store is optional; managers created without one keep their
+// timelines in memory only. Errors from a Store are logged, but
+// don't prevent the timeline from working from memory.
+type Store interface {
+	// Load returns persisted items of the given timeline,
+	// newest first, up to the store's max timeline length.
+	Load(ctx context.Context, timelineID string) ([]Timelineable, error)
+
+	// Put persists the given items in the given timeline.
+	Put(ctx context.Context, timelineID string, items []Timelineable) error
+
+	// Remove removes the item with the given ID from the given timeline.
+	Remove(ctx context.Context, timelineID string, itemID string) error
+
+	// RemoveFromAll removes the item with the given ID from all timelines.
+	RemoveFromAll(ctx context.Context, itemID string) error
+
+	// RemoveAllByOrBoosting removes all items created by
+	// or boosting the given accountID from the given timeline.
+	RemoveAllByOrBoosting(ctx context.Context, timelineID string, accountID string) error
+
+	// RemoveTimeline removes all items of the given timeline.
+	RemoveTimeline(ctx context.Context, timelineID string) error
+
+	// Compact trims each persisted timeline down to the store's
+	// max timeline length, returning the amount of items removed.
+	Compact(ctx context.Context) (int, error)
+}
+
 // Timeline represents a timeline for one account, and contains indexed and prepared items.
 type Timeline interface {
 	/*
@@ -140,6 +173,7 @@ type timeline struct {
 	grabFunction    GrabFunction
 	filterFunction  FilterFunction
 	prepareFunction PrepareFunction
+	store           Store
 	timelineID      string
 	lastGot         time.Time
 	sync.Mutex
@@ -151,6 +185,10 @@ func (t *timeline) TimelineID() string {
 
 // NewTimeline returns a new Timeline with
 // the given ID, using the given functions.
+//
+// If store is not nil, the timeline's index
+// is lazily warmed up from store on first use,
+// and kept in sync with store after that.
 func NewTimeline(
 	ctx context.Context,
 	timelineID string,
@@ -158,6 +196,7 @@ func NewTimeline(
 	filterFunction FilterFunction,
 	prepareFunction PrepareFunction,
 	skipInsertFunction SkipInsertFunction,
+	store Store,
 ) Timeline {
 	return &timeline{
 		items: &indexedItems{
@@ -166,6 +205,7 @@ func NewTimeline(
 		grabFunction:    grabFunction,
 		filterFunction:  filterFunction,
 		prepareFunction: prepareFunction,
+		store:           store,
 		timelineID:      timelineID,
 		lastGot:         time.Time{},
 	}
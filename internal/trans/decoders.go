@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/mitchellh/mapstructure"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	transmodel "github.com/superseriousbusiness/gotosocial/internal/trans/model"
 )
 
@@ -51,7 +52,7 @@ func (i *importer) accountDecode(e transmodel.Entry) (*transmodel.Account, error
 	if err != nil {
 		return nil, fmt.Errorf("accountDecode: error parsing account public key: %s", err)
 	}
-	a.PublicKey = publicKey
+	a.PublicKey = gtsmodel.PublicKey{Key: publicKey}
 
 	if a.Domain == "" {
 		// extract private key (local account)
@@ -63,7 +64,7 @@ func (i *importer) accountDecode(e transmodel.Entry) (*transmodel.Account, error
 		if err != nil {
 			return nil, fmt.Errorf("accountDecode: error parsing account private key: %s", err)
 		}
-		a.PrivateKey = privateKey
+		a.PrivateKey = gtsmodel.PrivateKey{Key: privateKey}
 	}
 
 	return a, nil
@@ -24,17 +24,20 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
-	"os"
+	"io"
 
 	transmodel "github.com/superseriousbusiness/gotosocial/internal/trans/model"
 )
 
 // accountEncode handles special fields like private + public keys on accounts
-func (e *exporter) accountEncode(ctx context.Context, f *os.File, a *transmodel.Account) error {
+func (e *exporter) accountEncode(ctx context.Context, f io.Writer, a *transmodel.Account) error {
 	a.Type = transmodel.TransAccount
 
 	// marshal public key
-	encodedPublicKey := x509.MarshalPKCS1PublicKey(a.PublicKey)
+	if a.PublicKey.Key == nil {
+		return errors.New("account has no public key")
+	}
+	encodedPublicKey := x509.MarshalPKCS1PublicKey(a.PublicKey.Key)
 	if encodedPublicKey == nil {
 		return errors.New("could not MarshalPKCS1PublicKey")
 	}
@@ -46,7 +49,10 @@ func (e *exporter) accountEncode(ctx context.Context, f *os.File, a *transmodel.
 
 	if a.Domain == "" {
 		// marshal private key for local account
-		encodedPrivateKey := x509.MarshalPKCS1PrivateKey(a.PrivateKey)
+		if a.PrivateKey.Key == nil {
+			return errors.New("local account has no private key")
+		}
+		encodedPrivateKey := x509.MarshalPKCS1PrivateKey(a.PrivateKey.Key)
 		if encodedPrivateKey == nil {
 			return errors.New("could not MarshalPKCS1PrivateKey")
 		}
@@ -65,7 +71,7 @@ func (e *exporter) accountEncode(ctx context.Context, f *os.File, a *transmodel.
 //
 // Beware, the 'type' key on the passed interface should already have been set, since simpleEncode won't know
 // what type it is! If you try to decode stuff you've encoded with a missing type key, you're going to have a bad time.
-func (e *exporter) simpleEncode(ctx context.Context, file *os.File, i interface{}, id string) error {
+func (e *exporter) simpleEncode(ctx context.Context, file io.Writer, i interface{}, id string) error {
 	_, alreadyWritten := e.writtenIDs[id]
 	if alreadyWritten {
 		// this exporter has already exported an entry with this ID, no need to do it twice
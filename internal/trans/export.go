@@ -19,13 +19,13 @@ package trans
 import (
 	"context"
 	"fmt"
-	"os"
+	"io"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	transmodel "github.com/superseriousbusiness/gotosocial/internal/trans/model"
 )
 
-func (e *exporter) exportAccounts(ctx context.Context, where []db.Where, file *os.File) ([]*transmodel.Account, error) {
+func (e *exporter) exportAccounts(ctx context.Context, where []db.Where, file io.Writer) ([]*transmodel.Account, error) {
 	// select using the 'where' we've been provided
 	accounts := []*transmodel.Account{}
 	if err := e.db.GetWhere(ctx, where, &accounts); err != nil {
@@ -42,7 +42,7 @@ func (e *exporter) exportAccounts(ctx context.Context, where []db.Where, file *o
 	return accounts, nil
 }
 
-func (e *exporter) exportBlocks(ctx context.Context, accounts []*transmodel.Account, file *os.File) ([]*transmodel.Block, error) {
+func (e *exporter) exportBlocks(ctx context.Context, accounts []*transmodel.Account, file io.Writer) ([]*transmodel.Block, error) {
 	blocksUnique := make(map[string]*transmodel.Block)
 
 	// for each account we want to export both where it's blocking and where it's blocked
@@ -85,7 +85,7 @@ func (e *exporter) exportBlocks(ctx context.Context, accounts []*transmodel.Acco
 	return blocks, nil
 }
 
-func (e *exporter) exportDomainBlocks(ctx context.Context, file *os.File) ([]*transmodel.DomainBlock, error) {
+func (e *exporter) exportDomainBlocks(ctx context.Context, file io.Writer) ([]*transmodel.DomainBlock, error) {
 	domainBlocks := []*transmodel.DomainBlock{}
 
 	if err := e.db.GetAll(ctx, &domainBlocks); err != nil {
@@ -102,7 +102,7 @@ func (e *exporter) exportDomainBlocks(ctx context.Context, file *os.File) ([]*tr
 	return domainBlocks, nil
 }
 
-func (e *exporter) exportFollows(ctx context.Context, accounts []*transmodel.Account, file *os.File) ([]*transmodel.Follow, error) {
+func (e *exporter) exportFollows(ctx context.Context, accounts []*transmodel.Account, file io.Writer) ([]*transmodel.Follow, error) {
 	followsUnique := make(map[string]*transmodel.Follow)
 
 	// for each account we want to export both where it's following and where it's followed
@@ -145,7 +145,7 @@ func (e *exporter) exportFollows(ctx context.Context, accounts []*transmodel.Acc
 	return follows, nil
 }
 
-func (e *exporter) exportFollowRequests(ctx context.Context, accounts []*transmodel.Account, file *os.File) ([]*transmodel.FollowRequest, error) {
+func (e *exporter) exportFollowRequests(ctx context.Context, accounts []*transmodel.Account, file io.Writer) ([]*transmodel.FollowRequest, error) {
 	frsUnique := make(map[string]*transmodel.FollowRequest)
 
 	// for each account we want to export both where it's following and where it's followed
@@ -188,7 +188,7 @@ func (e *exporter) exportFollowRequests(ctx context.Context, accounts []*transmo
 	return followRequests, nil
 }
 
-func (e *exporter) exportInstances(ctx context.Context, file *os.File) ([]*transmodel.Instance, error) {
+func (e *exporter) exportInstances(ctx context.Context, file io.Writer) ([]*transmodel.Instance, error) {
 	instances := []*transmodel.Instance{}
 
 	if err := e.db.GetAll(ctx, &instances); err != nil {
@@ -205,7 +205,7 @@ func (e *exporter) exportInstances(ctx context.Context, file *os.File) ([]*trans
 	return instances, nil
 }
 
-func (e *exporter) exportUsers(ctx context.Context, file *os.File) ([]*transmodel.User, error) {
+func (e *exporter) exportUsers(ctx context.Context, file io.Writer) ([]*transmodel.User, error) {
 	users := []*transmodel.User{}
 
 	if err := e.db.GetAll(ctx, &users); err != nil {
@@ -221,3 +221,462 @@ func (e *exporter) exportUsers(ctx context.Context, file *os.File) ([]*transmode
 
 	return users, nil
 }
+
+func (e *exporter) exportStatuses(ctx context.Context, file io.Writer) ([]*transmodel.Status, error) {
+	statuses := []*transmodel.Status{}
+
+	if err := e.db.GetAll(ctx, &statuses); err != nil {
+		return nil, fmt.Errorf("exportStatuses: error selecting statuses: %s", err)
+	}
+
+	for _, s := range statuses {
+		s.Type = transmodel.TransStatus
+		if err := e.simpleEncode(ctx, file, s, s.ID); err != nil {
+			return nil, fmt.Errorf("exportStatuses: error encoding status: %s", err)
+		}
+	}
+
+	return statuses, nil
+}
+
+func (e *exporter) exportStatusToTags(ctx context.Context, file io.Writer) ([]*transmodel.StatusToTag, error) {
+	statusToTags := []*transmodel.StatusToTag{}
+
+	if err := e.db.GetAll(ctx, &statusToTags); err != nil {
+		return nil, fmt.Errorf("exportStatusToTags: error selecting status to tag entries: %s", err)
+	}
+
+	for _, s := range statusToTags {
+		s.Type = transmodel.TransStatusToTag
+		if err := e.simpleEncode(ctx, file, s, s.StatusID+s.TagID); err != nil {
+			return nil, fmt.Errorf("exportStatusToTags: error encoding status to tag: %s", err)
+		}
+	}
+
+	return statusToTags, nil
+}
+
+func (e *exporter) exportStatusToEmojis(ctx context.Context, file io.Writer) ([]*transmodel.StatusToEmoji, error) {
+	statusToEmojis := []*transmodel.StatusToEmoji{}
+
+	if err := e.db.GetAll(ctx, &statusToEmojis); err != nil {
+		return nil, fmt.Errorf("exportStatusToEmojis: error selecting status to emoji entries: %s", err)
+	}
+
+	for _, s := range statusToEmojis {
+		s.Type = transmodel.TransStatusToEmoji
+		if err := e.simpleEncode(ctx, file, s, s.StatusID+s.EmojiID); err != nil {
+			return nil, fmt.Errorf("exportStatusToEmojis: error encoding status to emoji: %s", err)
+		}
+	}
+
+	return statusToEmojis, nil
+}
+
+func (e *exporter) exportMentions(ctx context.Context, file io.Writer) ([]*transmodel.Mention, error) {
+	mentions := []*transmodel.Mention{}
+
+	if err := e.db.GetAll(ctx, &mentions); err != nil {
+		return nil, fmt.Errorf("exportMentions: error selecting mentions: %s", err)
+	}
+
+	for _, m := range mentions {
+		m.Type = transmodel.TransMention
+		if err := e.simpleEncode(ctx, file, m, m.ID); err != nil {
+			return nil, fmt.Errorf("exportMentions: error encoding mention: %s", err)
+		}
+	}
+
+	return mentions, nil
+}
+
+func (e *exporter) exportTags(ctx context.Context, file io.Writer) ([]*transmodel.Tag, error) {
+	tags := []*transmodel.Tag{}
+
+	if err := e.db.GetAll(ctx, &tags); err != nil {
+		return nil, fmt.Errorf("exportTags: error selecting tags: %s", err)
+	}
+
+	for _, t := range tags {
+		t.Type = transmodel.TransTag
+		if err := e.simpleEncode(ctx, file, t, t.ID); err != nil {
+			return nil, fmt.Errorf("exportTags: error encoding tag: %s", err)
+		}
+	}
+
+	return tags, nil
+}
+
+func (e *exporter) exportPolls(ctx context.Context, file io.Writer) ([]*transmodel.Poll, error) {
+	polls := []*transmodel.Poll{}
+
+	if err := e.db.GetAll(ctx, &polls); err != nil {
+		return nil, fmt.Errorf("exportPolls: error selecting polls: %s", err)
+	}
+
+	for _, p := range polls {
+		p.Type = transmodel.TransPoll
+		if err := e.simpleEncode(ctx, file, p, p.ID); err != nil {
+			return nil, fmt.Errorf("exportPolls: error encoding poll: %s", err)
+		}
+	}
+
+	return polls, nil
+}
+
+func (e *exporter) exportPollVotes(ctx context.Context, file io.Writer) ([]*transmodel.PollVote, error) {
+	pollVotes := []*transmodel.PollVote{}
+
+	if err := e.db.GetAll(ctx, &pollVotes); err != nil {
+		return nil, fmt.Errorf("exportPollVotes: error selecting poll votes: %s", err)
+	}
+
+	for _, v := range pollVotes {
+		v.Type = transmodel.TransPollVote
+		if err := e.simpleEncode(ctx, file, v, v.ID); err != nil {
+			return nil, fmt.Errorf("exportPollVotes: error encoding poll vote: %s", err)
+		}
+	}
+
+	return pollVotes, nil
+}
+
+func (e *exporter) exportLists(ctx context.Context, file io.Writer) ([]*transmodel.List, error) {
+	lists := []*transmodel.List{}
+
+	if err := e.db.GetAll(ctx, &lists); err != nil {
+		return nil, fmt.Errorf("exportLists: error selecting lists: %s", err)
+	}
+
+	for _, l := range lists {
+		l.Type = transmodel.TransList
+		if err := e.simpleEncode(ctx, file, l, l.ID); err != nil {
+			return nil, fmt.Errorf("exportLists: error encoding list: %s", err)
+		}
+	}
+
+	return lists, nil
+}
+
+func (e *exporter) exportListEntries(ctx context.Context, file io.Writer) ([]*transmodel.ListEntry, error) {
+	listEntries := []*transmodel.ListEntry{}
+
+	if err := e.db.GetAll(ctx, &listEntries); err != nil {
+		return nil, fmt.Errorf("exportListEntries: error selecting list entries: %s", err)
+	}
+
+	for _, l := range listEntries {
+		l.Type = transmodel.TransListEntry
+		if err := e.simpleEncode(ctx, file, l, l.ID); err != nil {
+			return nil, fmt.Errorf("exportListEntries: error encoding list entry: %s", err)
+		}
+	}
+
+	return listEntries, nil
+}
+
+func (e *exporter) exportEmojis(ctx context.Context, file io.Writer) ([]*transmodel.Emoji, error) {
+	emojis := []*transmodel.Emoji{}
+
+	if err := e.db.GetAll(ctx, &emojis); err != nil {
+		return nil, fmt.Errorf("exportEmojis: error selecting emojis: %s", err)
+	}
+
+	for _, em := range emojis {
+		em.Type = transmodel.TransEmoji
+		if err := e.simpleEncode(ctx, file, em, em.ID); err != nil {
+			return nil, fmt.Errorf("exportEmojis: error encoding emoji: %s", err)
+		}
+	}
+
+	return emojis, nil
+}
+
+func (e *exporter) exportEmojiCategories(ctx context.Context, file io.Writer) ([]*transmodel.EmojiCategory, error) {
+	emojiCategories := []*transmodel.EmojiCategory{}
+
+	if err := e.db.GetAll(ctx, &emojiCategories); err != nil {
+		return nil, fmt.Errorf("exportEmojiCategories: error selecting emoji categories: %s", err)
+	}
+
+	for _, c := range emojiCategories {
+		c.Type = transmodel.TransEmojiCategory
+		if err := e.simpleEncode(ctx, file, c, c.ID); err != nil {
+			return nil, fmt.Errorf("exportEmojiCategories: error encoding emoji category: %s", err)
+		}
+	}
+
+	return emojiCategories, nil
+}
+
+func (e *exporter) exportMediaAttachments(ctx context.Context, file io.Writer) ([]*transmodel.MediaAttachment, error) {
+	attachments := []*transmodel.MediaAttachment{}
+
+	if err := e.db.GetAll(ctx, &attachments); err != nil {
+		return nil, fmt.Errorf("exportMediaAttachments: error selecting media attachments: %s", err)
+	}
+
+	for _, a := range attachments {
+		a.Type = transmodel.TransMediaAttachment
+		if err := e.simpleEncode(ctx, file, a, a.ID); err != nil {
+			return nil, fmt.Errorf("exportMediaAttachments: error encoding media attachment: %s", err)
+		}
+	}
+
+	return attachments, nil
+}
+
+func (e *exporter) exportMarkers(ctx context.Context, file io.Writer) ([]*transmodel.Marker, error) {
+	markers := []*transmodel.Marker{}
+
+	if err := e.db.GetAll(ctx, &markers); err != nil {
+		return nil, fmt.Errorf("exportMarkers: error selecting markers: %s", err)
+	}
+
+	for _, m := range markers {
+		m.Type = transmodel.TransMarker
+		if err := e.simpleEncode(ctx, file, m, m.AccountID+m.Name); err != nil {
+			return nil, fmt.Errorf("exportMarkers: error encoding marker: %s", err)
+		}
+	}
+
+	return markers, nil
+}
+
+func (e *exporter) exportStatusBookmarks(ctx context.Context, file io.Writer) ([]*transmodel.StatusBookmark, error) {
+	bookmarks := []*transmodel.StatusBookmark{}
+
+	if err := e.db.GetAll(ctx, &bookmarks); err != nil {
+		return nil, fmt.Errorf("exportStatusBookmarks: error selecting status bookmarks: %s", err)
+	}
+
+	for _, b := range bookmarks {
+		b.Type = transmodel.TransStatusBookmark
+		if err := e.simpleEncode(ctx, file, b, b.ID); err != nil {
+			return nil, fmt.Errorf("exportStatusBookmarks: error encoding status bookmark: %s", err)
+		}
+	}
+
+	return bookmarks, nil
+}
+
+func (e *exporter) exportStatusFaves(ctx context.Context, file io.Writer) ([]*transmodel.StatusFave, error) {
+	faves := []*transmodel.StatusFave{}
+
+	if err := e.db.GetAll(ctx, &faves); err != nil {
+		return nil, fmt.Errorf("exportStatusFaves: error selecting status faves: %s", err)
+	}
+
+	for _, f := range faves {
+		f.Type = transmodel.TransStatusFave
+		if err := e.simpleEncode(ctx, file, f, f.ID); err != nil {
+			return nil, fmt.Errorf("exportStatusFaves: error encoding status fave: %s", err)
+		}
+	}
+
+	return faves, nil
+}
+
+func (e *exporter) exportReports(ctx context.Context, file io.Writer) ([]*transmodel.Report, error) {
+	reports := []*transmodel.Report{}
+
+	if err := e.db.GetAll(ctx, &reports); err != nil {
+		return nil, fmt.Errorf("exportReports: error selecting reports: %s", err)
+	}
+
+	for _, r := range reports {
+		r.Type = transmodel.TransReport
+		if err := e.simpleEncode(ctx, file, r, r.ID); err != nil {
+			return nil, fmt.Errorf("exportReports: error encoding report: %s", err)
+		}
+	}
+
+	return reports, nil
+}
+
+func (e *exporter) exportFeaturedTags(ctx context.Context, file io.Writer) ([]*transmodel.FeaturedTag, error) {
+	featuredTags := []*transmodel.FeaturedTag{}
+
+	if err := e.db.GetAll(ctx, &featuredTags); err != nil {
+		return nil, fmt.Errorf("exportFeaturedTags: error selecting featured tags: %s", err)
+	}
+
+	for _, t := range featuredTags {
+		t.Type = transmodel.TransFeaturedTag
+		if err := e.simpleEncode(ctx, file, t, t.ID); err != nil {
+			return nil, fmt.Errorf("exportFeaturedTags: error encoding featured tag: %s", err)
+		}
+	}
+
+	return featuredTags, nil
+}
+
+func (e *exporter) exportFollowedTags(ctx context.Context, file io.Writer) ([]*transmodel.FollowedTag, error) {
+	followedTags := []*transmodel.FollowedTag{}
+
+	if err := e.db.GetAll(ctx, &followedTags); err != nil {
+		return nil, fmt.Errorf("exportFollowedTags: error selecting followed tags: %s", err)
+	}
+
+	for _, t := range followedTags {
+		t.Type = transmodel.TransFollowedTag
+		if err := e.simpleEncode(ctx, file, t, t.ID); err != nil {
+			return nil, fmt.Errorf("exportFollowedTags: error encoding followed tag: %s", err)
+		}
+	}
+
+	return followedTags, nil
+}
+
+func (e *exporter) exportEndorsements(ctx context.Context, file io.Writer) ([]*transmodel.Endorsement, error) {
+	endorsements := []*transmodel.Endorsement{}
+
+	if err := e.db.GetAll(ctx, &endorsements); err != nil {
+		return nil, fmt.Errorf("exportEndorsements: error selecting endorsements: %s", err)
+	}
+
+	for _, en := range endorsements {
+		en.Type = transmodel.TransEndorsement
+		if err := e.simpleEncode(ctx, file, en, en.ID); err != nil {
+			return nil, fmt.Errorf("exportEndorsements: error encoding endorsement: %s", err)
+		}
+	}
+
+	return endorsements, nil
+}
+
+func (e *exporter) exportNotificationPolicies(ctx context.Context, file io.Writer) ([]*transmodel.NotificationPolicy, error) {
+	notificationPolicies := []*transmodel.NotificationPolicy{}
+
+	if err := e.db.GetAll(ctx, &notificationPolicies); err != nil {
+		return nil, fmt.Errorf("exportNotificationPolicies: error selecting notification policies: %s", err)
+	}
+
+	for _, p := range notificationPolicies {
+		p.Type = transmodel.TransNotificationPolicy
+		if err := e.simpleEncode(ctx, file, p, p.ID); err != nil {
+			return nil, fmt.Errorf("exportNotificationPolicies: error encoding notification policy: %s", err)
+		}
+	}
+
+	return notificationPolicies, nil
+}
+
+func (e *exporter) exportNotificationRequests(ctx context.Context, file io.Writer) ([]*transmodel.NotificationRequest, error) {
+	notificationRequests := []*transmodel.NotificationRequest{}
+
+	if err := e.db.GetAll(ctx, &notificationRequests); err != nil {
+		return nil, fmt.Errorf("exportNotificationRequests: error selecting notification requests: %s", err)
+	}
+
+	for _, r := range notificationRequests {
+		r.Type = transmodel.TransNotificationRequest
+		if err := e.simpleEncode(ctx, file, r, r.ID); err != nil {
+			return nil, fmt.Errorf("exportNotificationRequests: error encoding notification request: %s", err)
+		}
+	}
+
+	return notificationRequests, nil
+}
+
+func (e *exporter) exportNotificationPermissions(ctx context.Context, file io.Writer) ([]*transmodel.NotificationPermission, error) {
+	notificationPermissions := []*transmodel.NotificationPermission{}
+
+	if err := e.db.GetAll(ctx, &notificationPermissions); err != nil {
+		return nil, fmt.Errorf("exportNotificationPermissions: error selecting notification permissions: %s", err)
+	}
+
+	for _, p := range notificationPermissions {
+		p.Type = transmodel.TransNotificationPermission
+		if err := e.simpleEncode(ctx, file, p, p.ID); err != nil {
+			return nil, fmt.Errorf("exportNotificationPermissions: error encoding notification permission: %s", err)
+		}
+	}
+
+	return notificationPermissions, nil
+}
+
+func (e *exporter) exportBookmarkCollections(ctx context.Context, file io.Writer) ([]*transmodel.BookmarkCollection, error) {
+	bookmarkCollections := []*transmodel.BookmarkCollection{}
+
+	if err := e.db.GetAll(ctx, &bookmarkCollections); err != nil {
+		return nil, fmt.Errorf("exportBookmarkCollections: error selecting bookmark collections: %s", err)
+	}
+
+	for _, c := range bookmarkCollections {
+		c.Type = transmodel.TransBookmarkCollection
+		if err := e.simpleEncode(ctx, file, c, c.ID); err != nil {
+			return nil, fmt.Errorf("exportBookmarkCollections: error encoding bookmark collection: %s", err)
+		}
+	}
+
+	return bookmarkCollections, nil
+}
+
+func (e *exporter) exportBookmarkCollectionEntries(ctx context.Context, file io.Writer) ([]*transmodel.BookmarkCollectionEntry, error) {
+	bookmarkCollectionEntries := []*transmodel.BookmarkCollectionEntry{}
+
+	if err := e.db.GetAll(ctx, &bookmarkCollectionEntries); err != nil {
+		return nil, fmt.Errorf("exportBookmarkCollectionEntries: error selecting bookmark collection entries: %s", err)
+	}
+
+	for _, ce := range bookmarkCollectionEntries {
+		ce.Type = transmodel.TransBookmarkCollectionEntry
+		if err := e.simpleEncode(ctx, file, ce, ce.ID); err != nil {
+			return nil, fmt.Errorf("exportBookmarkCollectionEntries: error encoding bookmark collection entry: %s", err)
+		}
+	}
+
+	return bookmarkCollectionEntries, nil
+}
+
+func (e *exporter) exportMediaHashes(ctx context.Context, file io.Writer) ([]*transmodel.MediaHash, error) {
+	mediaHashes := []*transmodel.MediaHash{}
+
+	if err := e.db.GetAll(ctx, &mediaHashes); err != nil {
+		return nil, fmt.Errorf("exportMediaHashes: error selecting media hashes: %s", err)
+	}
+
+	for _, h := range mediaHashes {
+		h.Type = transmodel.TransMediaHash
+		if err := e.simpleEncode(ctx, file, h, h.ID); err != nil {
+			return nil, fmt.Errorf("exportMediaHashes: error encoding media hash: %s", err)
+		}
+	}
+
+	return mediaHashes, nil
+}
+
+func (e *exporter) exportDomainMediaPolicies(ctx context.Context, file io.Writer) ([]*transmodel.DomainMediaPolicy, error) {
+	domainMediaPolicies := []*transmodel.DomainMediaPolicy{}
+
+	if err := e.db.GetAll(ctx, &domainMediaPolicies); err != nil {
+		return nil, fmt.Errorf("exportDomainMediaPolicies: error selecting domain media policies: %s", err)
+	}
+
+	for _, p := range domainMediaPolicies {
+		p.Type = transmodel.TransDomainMediaPolicy
+		if err := e.simpleEncode(ctx, file, p, p.ID); err != nil {
+			return nil, fmt.Errorf("exportDomainMediaPolicies: error encoding domain media policy: %s", err)
+		}
+	}
+
+	return domainMediaPolicies, nil
+}
+
+func (e *exporter) exportAccountStorageUsages(ctx context.Context, file io.Writer) ([]*transmodel.AccountStorageUsage, error) {
+	accountStorageUsages := []*transmodel.AccountStorageUsage{}
+
+	if err := e.db.GetAll(ctx, &accountStorageUsages); err != nil {
+		return nil, fmt.Errorf("exportAccountStorageUsages: error selecting account storage usages: %s", err)
+	}
+
+	for _, u := range accountStorageUsages {
+		u.Type = transmodel.TransAccountStorageUsage
+		if err := e.simpleEncode(ctx, file, u, "storage"+u.AccountID); err != nil {
+			return nil, fmt.Errorf("exportAccountStorageUsages: error encoding account storage usage: %s", err)
+		}
+	}
+
+	return accountStorageUsages, nil
+}
@@ -21,22 +21,30 @@ import (
 	"context"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 )
 
 // Exporter wraps functionality for exporting entries from the database to a file.
 type Exporter interface {
 	ExportMinimal(ctx context.Context, path string) error
+
+	// ExportFull exports the entire instance, including statuses
+	// and media files, into a tar archive at the given path.
+	ExportFull(ctx context.Context, path string) error
 }
 
 type exporter struct {
 	db         db.DB
+	storage    *storage.Driver
 	writtenIDs map[string]bool
 }
 
-// NewExporter returns a new Exporter that will use the given db.
-func NewExporter(db db.DB) Exporter {
+// NewExporter returns a new Exporter that will use the given db and storage.
+// Storage may be nil if only minimal exports are going to be performed.
+func NewExporter(db db.DB, storage *storage.Driver) Exporter {
 	return &exporter{
 		db:         db,
+		storage:    storage,
 		writtenIDs: make(map[string]bool),
 	}
 }
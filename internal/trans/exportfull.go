@@ -0,0 +1,443 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	transmodel "github.com/superseriousbusiness/gotosocial/internal/trans/model"
+)
+
+const (
+	archiveManifest = "manifest.json" // name of the manifest in a full archive
+	archiveData     = "data.json"     // name of the line-separated json entries in a full archive
+	archiveMedia    = "media/"        // prefix of media files in a full archive
+)
+
+func (e *exporter) ExportFull(ctx context.Context, path string) error {
+	if path == "" {
+		return errors.New("ExportFull: path empty")
+	}
+
+	if e.storage == nil {
+		return errors.New("ExportFull: no storage driver set")
+	}
+
+	// Entries are first written out to a temporary file,
+	// since tar requires the size of each archive member
+	// to be known before we can start writing it.
+	data, err := os.CreateTemp("", "gotosocial-export-*.json")
+	if err != nil {
+		return fmt.Errorf("ExportFull: error creating temporary data file: %s", err)
+	}
+	defer os.Remove(data.Name())
+	defer data.Close()
+
+	entries, mediaKeys, err := e.exportFullData(ctx, data)
+	if err != nil {
+		return fmt.Errorf("ExportFull: error exporting entries: %s", err)
+	}
+
+	manifest, err := json.Marshal(&transmodel.Manifest{
+		Version:         transmodel.ManifestVersion,
+		CreatedAt:       time.Now(),
+		Host:            config.GetHost(),
+		AccountDomain:   config.GetAccountDomain(),
+		SoftwareVersion: config.GetSoftwareVersion(),
+		Entries:         entries,
+		Media:           len(mediaKeys),
+	})
+	if err != nil {
+		return fmt.Errorf("ExportFull: error encoding manifest: %s", err)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("ExportFull: couldn't export to %s: %s", path, err)
+	}
+	defer file.Close()
+
+	tw := tar.NewWriter(file)
+
+	if err := writeTarEntry(tw, archiveManifest, int64(len(manifest)), bytes.NewReader(manifest)); err != nil {
+		return fmt.Errorf("ExportFull: error writing manifest: %s", err)
+	}
+
+	if err := writeTarFile(tw, archiveData, data); err != nil {
+		return fmt.Errorf("ExportFull: error writing entries: %s", err)
+	}
+
+	// Media is spooled through a single temporary file,
+	// which is truncated again after each key is written.
+	spool, err := os.CreateTemp("", "gotosocial-export-media-*")
+	if err != nil {
+		return fmt.Errorf("ExportFull: error creating temporary media file: %s", err)
+	}
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+
+	for _, key := range mediaKeys {
+		if err := e.exportMedia(ctx, tw, spool, key); err != nil {
+			return fmt.Errorf("ExportFull: error exporting media %s: %s", key, err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("ExportFull: error closing archive: %s", err)
+	}
+
+	log.Infof(ctx, "exported %d entries and %d media files", len(e.writtenIDs), len(mediaKeys))
+	return neatClose(file)
+}
+
+// exportFullData writes every entry needed to restore the instance to file,
+// returning the number of entries of each type, and the storage keys of any
+// media files which should be included in the archive alongside the entries.
+//
+// Some tables are deliberately left out of the archive: OAuth applications,
+// clients, tokens + sessions (users just log in again after a restore), along
+// with the push subscriptions tied to those tokens; timeline entries, which
+// are rebuilt as they're needed; and media blobs, which are rebuilt from the
+// imported attachments. Email domain blocks, rules, account notes, account
+// emoji joins, threads, thread mutes, notifications, tombstones + imports
+// are not included yet. See ImportFullTestSuite.TestTablesCovered.
+func (e *exporter) exportFullData(ctx context.Context, file io.Writer) (map[transmodel.Type]int, []string, error) {
+	entries := make(map[transmodel.Type]int)
+
+	instances, err := e.exportInstances(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransInstance] = len(instances)
+
+	domainBlocks, err := e.exportDomainBlocks(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransDomainBlock] = len(domainBlocks)
+
+	// export every account we know about, local or remote
+	accounts, err := e.exportAccounts(ctx, []db.Where{{Key: "id", Not: true, Value: nil}}, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransAccount] = len(accounts)
+
+	localAccounts := make([]*transmodel.Account, 0, len(accounts))
+	for _, a := range accounts {
+		if a.Domain == "" {
+			localAccounts = append(localAccounts, a)
+		}
+	}
+
+	users, err := e.exportUsers(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransUser] = len(users)
+
+	// relationships are only ever stored when they involve a local
+	// account, so selecting by local accounts gets all of them
+	blocks, err := e.exportBlocks(ctx, localAccounts, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransBlock] = len(blocks)
+
+	follows, err := e.exportFollows(ctx, localAccounts, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransFollow] = len(follows)
+
+	followRequests, err := e.exportFollowRequests(ctx, localAccounts, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransFollowRequest] = len(followRequests)
+
+	emojiCategories, err := e.exportEmojiCategories(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransEmojiCategory] = len(emojiCategories)
+
+	emojis, err := e.exportEmojis(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransEmoji] = len(emojis)
+
+	attachments, err := e.exportMediaAttachments(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransMediaAttachment] = len(attachments)
+
+	tags, err := e.exportTags(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransTag] = len(tags)
+
+	statuses, err := e.exportStatuses(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransStatus] = len(statuses)
+
+	statusToTags, err := e.exportStatusToTags(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransStatusToTag] = len(statusToTags)
+
+	statusToEmojis, err := e.exportStatusToEmojis(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransStatusToEmoji] = len(statusToEmojis)
+
+	mentions, err := e.exportMentions(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransMention] = len(mentions)
+
+	polls, err := e.exportPolls(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransPoll] = len(polls)
+
+	pollVotes, err := e.exportPollVotes(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransPollVote] = len(pollVotes)
+
+	lists, err := e.exportLists(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransList] = len(lists)
+
+	listEntries, err := e.exportListEntries(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransListEntry] = len(listEntries)
+
+	bookmarks, err := e.exportStatusBookmarks(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransStatusBookmark] = len(bookmarks)
+
+	faves, err := e.exportStatusFaves(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransStatusFave] = len(faves)
+
+	markers, err := e.exportMarkers(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransMarker] = len(markers)
+
+	reports, err := e.exportReports(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransReport] = len(reports)
+
+	featuredTags, err := e.exportFeaturedTags(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransFeaturedTag] = len(featuredTags)
+
+	followedTags, err := e.exportFollowedTags(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransFollowedTag] = len(followedTags)
+
+	endorsements, err := e.exportEndorsements(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransEndorsement] = len(endorsements)
+
+	notificationPolicies, err := e.exportNotificationPolicies(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransNotificationPolicy] = len(notificationPolicies)
+
+	notificationRequests, err := e.exportNotificationRequests(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransNotificationRequest] = len(notificationRequests)
+
+	notificationPermissions, err := e.exportNotificationPermissions(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransNotificationPermission] = len(notificationPermissions)
+
+	bookmarkCollections, err := e.exportBookmarkCollections(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransBookmarkCollection] = len(bookmarkCollections)
+
+	bookmarkCollectionEntries, err := e.exportBookmarkCollectionEntries(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransBookmarkCollectionEntry] = len(bookmarkCollectionEntries)
+
+	mediaHashes, err := e.exportMediaHashes(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransMediaHash] = len(mediaHashes)
+
+	domainMediaPolicies, err := e.exportDomainMediaPolicies(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransDomainMediaPolicy] = len(domainMediaPolicies)
+
+	storageUsages, err := e.exportAccountStorageUsages(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries[transmodel.TransAccountStorageUsage] = len(storageUsages)
+
+	// gather the storage keys of all media we
+	// currently hold, skipping any which have
+	// gone missing from storage in the meantime
+	keys := make([]string, 0, 2*(len(attachments)+len(emojis)))
+	for _, a := range attachments {
+		if a.Cached == nil || !*a.Cached {
+			continue
+		}
+		keys = append(keys, a.File.Path, a.Thumbnail.Path)
+		if a.File.OriginalPath != "" {
+			keys = append(keys, a.File.OriginalPath)
+		}
+	}
+	for _, em := range emojis {
+		if em.Cached == nil || !*em.Cached {
+			continue
+		}
+		keys = append(keys, em.ImagePath, em.ImageStaticPath)
+	}
+
+	mediaKeys := make([]string, 0, len(keys))
+	seen := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		if _, ok := seen[key]; ok || key == "" {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		has, err := e.storage.Has(ctx, key)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error checking storage for %s: %s", key, err)
+		}
+
+		if !has {
+			log.Warnf(ctx, "media %s missing from storage, skipping it", key)
+			continue
+		}
+
+		mediaKeys = append(mediaKeys, key)
+	}
+
+	return entries, mediaKeys, nil
+}
+
+// exportMedia streams the media file stored under key into
+// the archive, using spool to determine the size of the file.
+func (e *exporter) exportMedia(ctx context.Context, tw *tar.Writer, spool *os.File, key string) error {
+	rc, err := e.storage.GetStream(ctx, key)
+	if err != nil {
+		return fmt.Errorf("error opening stream: %s", err)
+	}
+	defer rc.Close()
+
+	if err := spool.Truncate(0); err != nil {
+		return fmt.Errorf("error truncating spool file: %s", err)
+	}
+
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("error seeking spool file: %s", err)
+	}
+
+	if _, err := io.Copy(spool, rc); err != nil {
+		return fmt.Errorf("error reading from storage: %s", err)
+	}
+
+	return writeTarFile(tw, archiveMedia+key, spool)
+}
+
+// writeTarFile writes the whole contents of file to the archive under name.
+func writeTarFile(tw *tar.Writer, name string, file *os.File) error {
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	return writeTarEntry(tw, name, info.Size(), file)
+}
+
+// writeTarEntry writes size bytes from r to the archive under name.
+func writeTarEntry(tw *tar.Writer, name string, size int64, r io.Reader) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     size,
+		Mode:     0o600,
+		ModTime:  time.Now(),
+	}); err != nil {
+		return err
+	}
+
+	_, err := io.CopyN(tw, r, size)
+	return err
+}
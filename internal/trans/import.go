@@ -39,7 +39,18 @@ func (i *importer) Import(ctx context.Context, path string) error {
 		return fmt.Errorf("Import: couldn't export to %s: %s", path, err)
 	}
 
-	decoder := json.NewDecoder(file)
+	if err := i.importEntries(ctx, file); err != nil {
+		return fmt.Errorf("Import: %s", err)
+	}
+
+	log.Infof(ctx, "reached end of file")
+	return neatClose(file)
+}
+
+// importEntries reads line-separated json entries from r
+// until EOF, adding each of them to the database in turn.
+func (i *importer) importEntries(ctx context.Context, r io.Reader) error {
+	decoder := json.NewDecoder(r)
 	decoder.UseNumber()
 
 	for {
@@ -47,13 +58,12 @@ func (i *importer) Import(ctx context.Context, path string) error {
 		err := decoder.Decode(&entry)
 		if err != nil {
 			if err == io.EOF {
-				log.Infof(ctx, "reached end of file")
-				return neatClose(file)
+				return nil
 			}
-			return fmt.Errorf("Import: error decoding in readLoop: %s", err)
+			return fmt.Errorf("error decoding in readLoop: %s", err)
 		}
 		if err := i.inputEntry(ctx, entry); err != nil {
-			return fmt.Errorf("Import: error inputting entry: %s", err)
+			return fmt.Errorf("error inputting entry: %s", err)
 		}
 	}
 }
@@ -135,12 +145,78 @@ func (i *importer) inputEntry(ctx context.Context, entry transmodel.Entry) error
 		}
 		log.Infof(ctx, "added user with id %s", user.ID)
 		return nil
+	case transmodel.TransStatus:
+		return i.simpleInput(ctx, entry, &transmodel.Status{}, "status")
+	case transmodel.TransStatusToTag:
+		return i.simpleInput(ctx, entry, &transmodel.StatusToTag{}, "status to tag entry")
+	case transmodel.TransStatusToEmoji:
+		return i.simpleInput(ctx, entry, &transmodel.StatusToEmoji{}, "status to emoji entry")
+	case transmodel.TransMention:
+		return i.simpleInput(ctx, entry, &transmodel.Mention{}, "mention")
+	case transmodel.TransTag:
+		return i.simpleInput(ctx, entry, &transmodel.Tag{}, "tag")
+	case transmodel.TransPoll:
+		return i.simpleInput(ctx, entry, &transmodel.Poll{}, "poll")
+	case transmodel.TransPollVote:
+		return i.simpleInput(ctx, entry, &transmodel.PollVote{}, "poll vote")
+	case transmodel.TransList:
+		return i.simpleInput(ctx, entry, &transmodel.List{}, "list")
+	case transmodel.TransListEntry:
+		return i.simpleInput(ctx, entry, &transmodel.ListEntry{}, "list entry")
+	case transmodel.TransEmoji:
+		return i.simpleInput(ctx, entry, &transmodel.Emoji{}, "emoji")
+	case transmodel.TransEmojiCategory:
+		return i.simpleInput(ctx, entry, &transmodel.EmojiCategory{}, "emoji category")
+	case transmodel.TransMediaAttachment:
+		return i.simpleInput(ctx, entry, &transmodel.MediaAttachment{}, "media attachment")
+	case transmodel.TransMarker:
+		return i.simpleInput(ctx, entry, &transmodel.Marker{}, "marker")
+	case transmodel.TransStatusBookmark:
+		return i.simpleInput(ctx, entry, &transmodel.StatusBookmark{}, "status bookmark")
+	case transmodel.TransStatusFave:
+		return i.simpleInput(ctx, entry, &transmodel.StatusFave{}, "status fave")
+	case transmodel.TransReport:
+		return i.simpleInput(ctx, entry, &transmodel.Report{}, "report")
+	case transmodel.TransFeaturedTag:
+		return i.simpleInput(ctx, entry, &transmodel.FeaturedTag{}, "featured tag")
+	case transmodel.TransFollowedTag:
+		return i.simpleInput(ctx, entry, &transmodel.FollowedTag{}, "followed tag")
+	case transmodel.TransEndorsement:
+		return i.simpleInput(ctx, entry, &transmodel.Endorsement{}, "endorsement")
+	case transmodel.TransNotificationPolicy:
+		return i.simpleInput(ctx, entry, &transmodel.NotificationPolicy{}, "notification policy")
+	case transmodel.TransNotificationRequest:
+		return i.simpleInput(ctx, entry, &transmodel.NotificationRequest{}, "notification request")
+	case transmodel.TransNotificationPermission:
+		return i.simpleInput(ctx, entry, &transmodel.NotificationPermission{}, "notification permission")
+	case transmodel.TransBookmarkCollection:
+		return i.simpleInput(ctx, entry, &transmodel.BookmarkCollection{}, "bookmark collection")
+	case transmodel.TransBookmarkCollectionEntry:
+		return i.simpleInput(ctx, entry, &transmodel.BookmarkCollectionEntry{}, "bookmark collection entry")
+	case transmodel.TransMediaHash:
+		return i.simpleInput(ctx, entry, &transmodel.MediaHash{}, "media hash")
+	case transmodel.TransDomainMediaPolicy:
+		return i.simpleInput(ctx, entry, &transmodel.DomainMediaPolicy{}, "domain media policy")
+	case transmodel.TransAccountStorageUsage:
+		return i.simpleInput(ctx, entry, &transmodel.AccountStorageUsage{}, "account storage usage")
 	}
 
 	log.Errorf(ctx, "didn't recognize transtype '%s', skipping it", t)
 	return nil
 }
 
+// simpleInput decodes the given entry into target, and adds it to the database.
+func (i *importer) simpleInput(ctx context.Context, entry transmodel.Entry, target interface{}, kind string) error {
+	if err := i.simpleDecode(entry, target); err != nil {
+		return fmt.Errorf("inputEntry: error decoding entry into %s: %s", kind, err)
+	}
+	if err := i.putInDB(ctx, target); err != nil {
+		return fmt.Errorf("inputEntry: error adding %s to database: %s", kind, err)
+	}
+	log.Debugf(ctx, "added %s", kind)
+	return nil
+}
+
 func (i *importer) putInDB(ctx context.Context, entry interface{}) error {
 	return i.db.Put(ctx, entry)
 }
@@ -21,20 +21,28 @@ import (
 	"context"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 )
 
 // Importer wraps functionality for importing entries from a file into the database.
 type Importer interface {
 	Import(ctx context.Context, path string) error
+
+	// ImportFull restores a tar archive created by
+	// Exporter.ExportFull into an empty database + storage.
+	ImportFull(ctx context.Context, path string) error
 }
 
 type importer struct {
-	db db.DB
+	db      db.DB
+	storage *storage.Driver
 }
 
-// NewImporter returns a new Importer interface that uses the given db.
-func NewImporter(db db.DB) Importer {
+// NewImporter returns a new Importer interface that uses the given db and storage.
+// Storage may be nil if only minimal imports are going to be performed.
+func NewImporter(db db.DB, storage *storage.Driver) Importer {
 	return &importer{
-		db: db,
+		db:      db,
+		storage: storage,
 	}
 }
@@ -0,0 +1,206 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/regexes"
+	transmodel "github.com/superseriousbusiness/gotosocial/internal/trans/model"
+)
+
+func (i *importer) ImportFull(ctx context.Context, path string) error {
+	if path == "" {
+		return errors.New("ImportFull: path empty")
+	}
+
+	if i.storage == nil {
+		return errors.New("ImportFull: no storage driver set")
+	}
+
+	// Restoring over the top of an existing instance
+	// would leave us with a mess of conflicting entries,
+	// so only ever allow a full import into an empty db.
+	accounts := []*gtsmodel.Account{}
+	if err := i.db.GetWhere(ctx, []db.Where{{Key: "id", Not: true, Value: nil}}, &accounts); err != nil {
+		return fmt.Errorf("ImportFull: error checking for existing accounts: %s", err)
+	}
+
+	if len(accounts) != 0 {
+		return errors.New("ImportFull: database already contains accounts, full import requires an empty database")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("ImportFull: couldn't import from %s: %s", path, err)
+	}
+	defer file.Close()
+
+	var (
+		tr       = tar.NewReader(file)
+		manifest *transmodel.Manifest
+		media    int
+	)
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return fmt.Errorf("ImportFull: error reading archive: %s", err)
+		}
+
+		switch {
+		case hdr.Name == archiveManifest:
+			manifest, err = readManifest(tr)
+			if err != nil {
+				return fmt.Errorf("ImportFull: %s", err)
+			}
+
+		case manifest == nil:
+			// The manifest is always written first.
+			return errors.New("ImportFull: archive does not start with a manifest")
+
+		case hdr.Name == archiveData:
+			if err := i.importEntries(ctx, tr); err != nil {
+				return fmt.Errorf("ImportFull: %s", err)
+			}
+
+		case strings.HasPrefix(hdr.Name, archiveMedia):
+			key, err := mediaKey(hdr.Name)
+			if err != nil {
+				return fmt.Errorf("ImportFull: %s", err)
+			}
+
+			if _, err := i.storage.PutStream(ctx, key, tr); err != nil {
+				return fmt.Errorf("ImportFull: error storing media %s: %s", key, err)
+			}
+			media++
+
+		default:
+			log.Warnf(ctx, "unexpected archive member %s, skipping it", hdr.Name)
+		}
+	}
+
+	if manifest == nil {
+		return errors.New("ImportFull: archive contained no manifest")
+	}
+
+	// Blob storage is shared between media, so
+	// rebuild the references held on each blob.
+	if err := i.importBlobs(ctx); err != nil {
+		return fmt.Errorf("ImportFull: %s", err)
+	}
+
+	if media != manifest.Media {
+		log.Warnf(ctx, "manifest lists %d media files but archive contained %d", manifest.Media, media)
+	}
+
+	log.Infof(ctx, "imported archive created at %s with %d media files", manifest.CreatedAt, media)
+	return neatClose(file)
+}
+
+// importBlobs adds a media blob reference for each
+// content-addressed file used by imported attachments.
+func (i *importer) importBlobs(ctx context.Context) error {
+	attachments := []*gtsmodel.MediaAttachment{}
+	if err := i.db.GetAll(ctx, &attachments); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return fmt.Errorf("error getting media attachments: %s", err)
+	}
+
+	for _, a := range attachments {
+		if a.Cached == nil || !*a.Cached {
+			// Uncached media
+			// holds no refs.
+			continue
+		}
+
+		for _, blob := range []*gtsmodel.MediaBlob{
+			{Path: a.File.Path, FileSize: a.File.FileSize},
+			{Path: a.Thumbnail.Path, FileSize: a.Thumbnail.FileSize},
+			// Size of kept originals isn't tracked.
+			{Path: a.File.OriginalPath},
+		} {
+			parts := regexes.BlobPath.FindStringSubmatch(blob.Path)
+			if len(parts) != 4 {
+				// Not a blob.
+				continue
+			}
+
+			blob.Hash = parts[2]
+			if err := i.db.AcquireMediaBlob(ctx, blob, nil); err != nil {
+				return fmt.Errorf("error acquiring media blob %s: %s", blob.Path, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// mediaKey returns the storage key for the given
+// archive media member name, refusing any name that
+// doesn't point at a media file or blob in storage.
+func mediaKey(name string) (string, error) {
+	key := strings.TrimPrefix(name, archiveMedia)
+	if key == "" ||
+		path.IsAbs(key) ||
+		path.Clean(key) != key ||
+		strings.Contains(key, "..") {
+		return "", fmt.Errorf("invalid media archive member %s", name)
+	}
+
+	if !regexes.FilePath.MatchString(key) &&
+		!regexes.BlobPath.MatchString(key) {
+		return "", fmt.Errorf("media archive member %s is not a storage path", name)
+	}
+
+	return key, nil
+}
+
+// readManifest reads + validates the archive manifest from r.
+func readManifest(r io.Reader) (*transmodel.Manifest, error) {
+	manifest := &transmodel.Manifest{}
+	if err := json.NewDecoder(r).Decode(manifest); err != nil {
+		return nil, fmt.Errorf("error decoding manifest: %s", err)
+	}
+
+	if manifest.Version < 1 || manifest.Version > transmodel.ManifestVersion {
+		return nil, fmt.Errorf("unsupported archive version %d", manifest.Version)
+	}
+
+	// Keys + URIs are all tied to the host the
+	// archive was exported from, so restoring it
+	// anywhere else would just break federation.
+	if host := config.GetHost(); manifest.Host != host {
+		return nil, fmt.Errorf("archive was exported from host %s, not %s", manifest.Host, host)
+	}
+
+	return manifest, nil
+}
@@ -0,0 +1,281 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans_test
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/trans"
+	transmodel "github.com/superseriousbusiness/gotosocial/internal/trans/model"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ImportFullTestSuite struct {
+	TransTestSuite
+}
+
+func (suite *ImportFullTestSuite) TestImportFullOK() {
+	ctx := context.Background()
+
+	storage := testrig.NewInMemoryStorage()
+	testrig.StandardStorageSetup(storage, "../../testrig/media")
+	defer testrig.StandardStorageTeardown(storage)
+
+	statusesBefore := []*gtsmodel.Status{}
+	err := suite.db.GetAll(ctx, &statusesBefore)
+	suite.NoError(err)
+
+	attachmentBefore := testrig.NewTestAttachments()["admin_account_status_1_attachment_1"]
+	accountBefore, err := suite.db.GetAccountByID(ctx, suite.testAccounts["local_account_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// add a couple of entries to tables
+	// which have no standard test models
+	featuredTagBefore := &gtsmodel.FeaturedTag{
+		ID:        "01HT9ZJ5GZ8QDVZ2ZS0J2V5RJX",
+		AccountID: accountBefore.ID,
+		TagID:     testrig.NewTestTags()["welcome"].ID,
+	}
+	err = suite.db.Put(ctx, featuredTagBefore)
+	suite.NoError(err)
+
+	policyBefore := &gtsmodel.DomainMediaPolicy{
+		ID:                 "01HT9ZJSMN2TW0BJ0Z4J6J5ZQK",
+		Domain:             "fossbros-anonymous.io",
+		Policy:             gtsmodel.MediaPolicyProxy,
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}
+	err = suite.db.Put(ctx, policyBefore)
+	suite.NoError(err)
+
+	// export to a temporary archive
+	tempFilePath := fmt.Sprintf("%s/%s.tar", suite.T().TempDir(), uuid.NewString())
+	exporter := trans.NewExporter(suite.db, storage)
+	err = exporter.ExportFull(ctx, tempFilePath)
+	suite.NoError(err)
+
+	var state state.State
+	state.Caches.Init()
+
+	// restore into a fresh database + storage
+	newDB := testrig.NewTestDB(&state)
+	newStorage := testrig.NewInMemoryStorage()
+
+	importer := trans.NewImporter(newDB, newStorage)
+	err = importer.ImportFull(ctx, tempFilePath)
+	suite.NoError(err)
+
+	// all statuses should have come across
+	statusesAfter := []*gtsmodel.Status{}
+	err = newDB.GetAll(ctx, &statusesAfter)
+	suite.NoError(err)
+	suite.Len(statusesAfter, len(statusesBefore))
+
+	// attachment metadata should be intact
+	attachmentAfter := &gtsmodel.MediaAttachment{}
+	err = newDB.GetByID(ctx, attachmentBefore.ID, attachmentAfter)
+	suite.NoError(err)
+	suite.Equal(attachmentBefore.File.Path, attachmentAfter.File.Path)
+	suite.Equal(attachmentBefore.FileMeta.Original.Width, attachmentAfter.FileMeta.Original.Width)
+	suite.Equal(attachmentBefore.Blurhash, attachmentAfter.Blurhash)
+
+	// keys should have come across for signing
+	accountAfter := &gtsmodel.Account{}
+	err = newDB.GetByID(ctx, accountBefore.ID, accountAfter)
+	suite.NoError(err)
+	suite.True(accountBefore.PublicKey.Key.Equal(accountAfter.PublicKey.Key))
+	suite.True(accountBefore.PrivateKey.Key.Equal(accountAfter.PrivateKey.Key))
+
+	// as should the entries added above
+	featuredTagAfter := &gtsmodel.FeaturedTag{}
+	err = newDB.GetByID(ctx, featuredTagBefore.ID, featuredTagAfter)
+	suite.NoError(err)
+	suite.Equal(featuredTagBefore.TagID, featuredTagAfter.TagID)
+
+	policyAfter := &gtsmodel.DomainMediaPolicy{}
+	err = newDB.GetByID(ctx, policyBefore.ID, policyAfter)
+	suite.NoError(err)
+	suite.Equal(policyBefore.Policy, policyAfter.Policy)
+
+	// and so should the file itself
+	b, err := newStorage.Get(ctx, attachmentBefore.File.Path)
+	suite.NoError(err)
+	suite.NotEmpty(b)
+
+	// importing again should fail, since the database is no longer empty
+	err = importer.ImportFull(ctx, tempFilePath)
+	suite.ErrorContains(err, "full import requires an empty database")
+}
+
+func (suite *ImportFullTestSuite) TestImportFullBadMediaPath() {
+	ctx := context.Background()
+
+	for _, name := range []string{
+		"media/../../etc/passwd",
+		"media//01F8MH17FWEB39HZJ76B6VXSKF/attachment/original/01F8MH6NEM8D7527KZAECTCR76.jpg",
+		"media/01F8MH17FWEB39HZJ76B6VXSKF/attachment/../../../config.yaml",
+		"media/whatever.txt",
+	} {
+		// write an archive with a valid
+		// manifest followed by the bad media
+		tempFilePath := fmt.Sprintf("%s/%s.tar", suite.T().TempDir(), uuid.NewString())
+		file, err := os.Create(tempFilePath)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		manifest, err := json.Marshal(&transmodel.Manifest{
+			Version: transmodel.ManifestVersion,
+			Host:    config.GetHost(),
+		})
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		tw := tar.NewWriter(file)
+		for _, member := range []struct {
+			name string
+			data []byte
+		}{
+			{name: "manifest.json", data: manifest},
+			{name: name, data: []byte("naughty")},
+		} {
+			suite.NoError(tw.WriteHeader(&tar.Header{
+				Typeflag: tar.TypeReg,
+				Name:     member.name,
+				Size:     int64(len(member.data)),
+				Mode:     0o644,
+			}))
+			_, err := tw.Write(member.data)
+			suite.NoError(err)
+		}
+		suite.NoError(tw.Close())
+		suite.NoError(file.Close())
+
+		var state state.State
+		state.Caches.Init()
+
+		newDB := testrig.NewTestDB(&state)
+		newStorage := testrig.NewInMemoryStorage()
+
+		importer := trans.NewImporter(newDB, newStorage)
+		err = importer.ImportFull(ctx, tempFilePath)
+		suite.ErrorContains(err, "media archive member", name)
+	}
+}
+
+func (suite *ImportFullTestSuite) TestTablesCovered() {
+	// Every table should either be included in a full
+	// export, with a trans model backed by the same table,
+	// or be deliberately left out of it (nil). If this
+	// fails after adding a new table, either export it,
+	// or add it here and document why in exportFullData.
+	covered := map[string]interface{}{
+		"Account":                 &transmodel.Account{},
+		"AccountStorageUsage":     &transmodel.AccountStorageUsage{},
+		"AccountNote":             nil,
+		"AccountToEmoji":          nil,
+		"Application":             nil,
+		"Block":                   &transmodel.Block{},
+		"BookmarkCollection":      &transmodel.BookmarkCollection{},
+		"BookmarkCollectionEntry": &transmodel.BookmarkCollectionEntry{},
+		"Client":                  nil,
+		"DomainBlock":             &transmodel.DomainBlock{},
+		"DomainMediaPolicy":       &transmodel.DomainMediaPolicy{},
+		"EmailDomainBlock":        nil,
+		"Emoji":                   &transmodel.Emoji{},
+		"EmojiCategory":           &transmodel.EmojiCategory{},
+		"Endorsement":             &transmodel.Endorsement{},
+		"FeaturedTag":             &transmodel.FeaturedTag{},
+		"Follow":                  &transmodel.Follow{},
+		"FollowRequest":           &transmodel.FollowRequest{},
+		"FollowedTag":             &transmodel.FollowedTag{},
+		"Import":                  nil,
+		"Instance":                &transmodel.Instance{},
+		"List":                    &transmodel.List{},
+		"ListEntry":               &transmodel.ListEntry{},
+		"Marker":                  &transmodel.Marker{},
+		"MediaAttachment":         &transmodel.MediaAttachment{},
+		"MediaBlob":               nil,
+		"MediaHash":               &transmodel.MediaHash{},
+		"Mention":                 &transmodel.Mention{},
+		"Notification":            nil,
+		"NotificationPermission":  &transmodel.NotificationPermission{},
+		"NotificationPolicy":      &transmodel.NotificationPolicy{},
+		"NotificationRequest":     &transmodel.NotificationRequest{},
+		"Poll":                    &transmodel.Poll{},
+		"PollVote":                &transmodel.PollVote{},
+		"Report":                  &transmodel.Report{},
+		"RouterSession":           nil,
+		"Rule":                    nil,
+		"Status":                  &transmodel.Status{},
+		"StatusBookmark":          &transmodel.StatusBookmark{},
+		"StatusFave":              &transmodel.StatusFave{},
+		"StatusToEmoji":           &transmodel.StatusToEmoji{},
+		"StatusToTag":             &transmodel.StatusToTag{},
+		"Tag":                     &transmodel.Tag{},
+		"Thread":                  nil,
+		"ThreadMute":              nil,
+		"ThreadToStatus":          nil,
+		"TimelineEntry":           nil,
+		"Token":                   nil,
+		"Tombstone":               nil,
+		"User":                    &transmodel.User{},
+		"WebPushSubscription":     nil,
+	}
+
+	bunDB := suite.db.(*bundb.DBService).DB()
+	for _, model := range testrig.TestModels() {
+		modelType := reflect.TypeOf(model).Elem()
+
+		transModel, ok := covered[modelType.Name()]
+		if !ok {
+			suite.Failf("table not covered", "%s is neither exported nor excluded", modelType.Name())
+			continue
+		}
+
+		if transModel == nil {
+			// Excluded.
+			continue
+		}
+
+		suite.Equal(
+			bunDB.Table(modelType).Name,
+			bunDB.Table(reflect.TypeOf(transModel).Elem()).Name,
+			"trans model for %s uses a different table", modelType.Name(),
+		)
+	}
+}
+
+func TestImportFullTestSuite(t *testing.T) {
+	suite.Run(t, &ImportFullTestSuite{})
+}
@@ -0,0 +1,37 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import "time"
+
+// ManifestVersion is the current version of the
+// full backup archive format. It should be bumped
+// whenever the archive layout changes in a way that
+// older importers won't be able to handle.
+const ManifestVersion = 1
+
+// Manifest describes the contents of a full backup archive.
+type Manifest struct {
+	Version         int          `json:"version"`
+	CreatedAt       time.Time    `json:"createdAt"`
+	Host            string       `json:"host"`
+	AccountDomain   string       `json:"accountDomain"`
+	SoftwareVersion string       `json:"softwareVersion"`
+	Entries         map[Type]int `json:"entries"`
+	Media           int          `json:"media"`
+}
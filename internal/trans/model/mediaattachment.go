@@ -0,0 +1,97 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import "time"
+
+// MediaAttachment represents the metadata of a media attachment as serialized
+// in an exported file. The attachment file + thumbnail themselves are stored
+// separately in the archive, under their storage paths.
+type MediaAttachment struct {
+	Type              Type                     `json:"type" bun:"-"`
+	ID                string                   `json:"id" bun:",nullzero"`
+	CreatedAt         *time.Time               `json:"createdAt" bun:",nullzero"`
+	UpdatedAt         *time.Time               `json:"updatedAt" bun:",nullzero"`
+	StatusID          string                   `json:"statusID,omitempty" bun:",nullzero"`
+	URL               string                   `json:"url,omitempty" bun:",nullzero"`
+	RemoteURL         string                   `json:"remoteURL,omitempty" bun:",nullzero"`
+	MediaType         string                   `json:"mediaType" bun:"type,nullzero"`
+	FileMeta          MediaAttachmentFileMeta  `json:"fileMeta" bun:",embed:"`
+	AccountID         string                   `json:"accountID" bun:",nullzero"`
+	Description       string                   `json:"description,omitempty"`
+	ScheduledStatusID string                   `json:"scheduledStatusID,omitempty" bun:",nullzero"`
+	Blurhash          string                   `json:"blurhash,omitempty" bun:",nullzero"`
+	Processing        int                      `json:"processing"`
+	File              MediaAttachmentFile      `json:"file" bun:",embed:file_"`
+	Thumbnail         MediaAttachmentThumbnail `json:"thumbnail" bun:",embed:thumbnail_"`
+	Avatar            *bool                    `json:"avatar"`
+	Header            *bool                    `json:"header"`
+	Cached            *bool                    `json:"cached"`
+	Quarantined       *bool                    `json:"quarantined,omitempty"`
+}
+
+// MediaAttachmentFileMeta represents the file metadata of a media attachment.
+type MediaAttachmentFileMeta struct {
+	Original MediaAttachmentOriginal `json:"original" bun:"embed:original_"`
+	Small    MediaAttachmentSmall    `json:"small" bun:"embed:small_"`
+	Focus    MediaAttachmentFocus    `json:"focus" bun:"embed:focus_"`
+}
+
+// MediaAttachmentOriginal represents metadata of the original media file.
+type MediaAttachmentOriginal struct {
+	Width     int      `json:"width"`
+	Height    int      `json:"height"`
+	Size      int      `json:"size"`
+	Aspect    float32  `json:"aspect"`
+	Duration  *float32 `json:"duration,omitempty"`
+	Framerate *float32 `json:"framerate,omitempty"`
+	Bitrate   *uint64  `json:"bitrate,omitempty"`
+}
+
+// MediaAttachmentSmall represents metadata of the media thumbnail.
+type MediaAttachmentSmall struct {
+	Width  int     `json:"width"`
+	Height int     `json:"height"`
+	Size   int     `json:"size"`
+	Aspect float32 `json:"aspect"`
+}
+
+// MediaAttachmentFocus represents the focus point of a media attachment.
+type MediaAttachmentFocus struct {
+	X float32 `json:"x"`
+	Y float32 `json:"y"`
+}
+
+// MediaAttachmentFile represents the stored file of a media attachment.
+type MediaAttachmentFile struct {
+	Path         string     `json:"path" bun:",nullzero"`
+	ContentType  string     `json:"contentType" bun:",nullzero"`
+	FileSize     int        `json:"fileSize"`
+	UpdatedAt    *time.Time `json:"updatedAt" bun:",nullzero"`
+	OriginalPath string     `json:"originalPath,omitempty" bun:",nullzero"`
+}
+
+// MediaAttachmentThumbnail represents the stored thumbnail of a media attachment.
+type MediaAttachmentThumbnail struct {
+	Path        string     `json:"path" bun:",nullzero"`
+	ContentType string     `json:"contentType" bun:",nullzero"`
+	FileSize    int        `json:"fileSize"`
+	UpdatedAt   *time.Time `json:"updatedAt" bun:",nullzero"`
+	URL         string     `json:"url,omitempty" bun:",nullzero"`
+	RemoteURL   string     `json:"remoteURL,omitempty" bun:",nullzero"`
+}
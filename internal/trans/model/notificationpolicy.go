@@ -0,0 +1,54 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package trans
+
+import "time"
+
+// NotificationPolicy represents an account's notification filtering policy as serialized in an exported file.
+type NotificationPolicy struct {
+	Type                  Type       `json:"type" bun:"-"`
+	ID                    string     `json:"id" bun:",nullzero"`
+	CreatedAt             *time.Time `json:"createdAt" bun:",nullzero"`
+	UpdatedAt             *time.Time `json:"updatedAt" bun:",nullzero"`
+	AccountID             string     `json:"accountID" bun:",nullzero"`
+	FilterNotFollowing    *bool      `json:"filterNotFollowing"`
+	FilterNotFollowers    *bool      `json:"filterNotFollowers"`
+	FilterNewAccounts     *bool      `json:"filterNewAccounts"`
+	NewAccountsDays       int        `json:"newAccountsDays"`
+	FilterPrivateMentions *bool      `json:"filterPrivateMentions"`
+}
+
+// NotificationRequest represents a group of filtered notifications as serialized in an exported file.
+type NotificationRequest struct {
+	Type               Type       `json:"type" bun:"-"`
+	ID                 string     `json:"id" bun:",nullzero"`
+	CreatedAt          *time.Time `json:"createdAt" bun:",nullzero"`
+	UpdatedAt          *time.Time `json:"updatedAt" bun:",nullzero"`
+	AccountID          string     `json:"accountID" bun:",nullzero"`
+	FromAccountID      string     `json:"fromAccountID" bun:",nullzero"`
+	LastStatusID       string     `json:"lastStatusID,omitempty" bun:",nullzero"`
+	NotificationsCount int        `json:"notificationsCount"`
+}
+
+// NotificationPermission represents an accepted notification request as serialized in an exported file.
+type NotificationPermission struct {
+	Type          Type       `json:"type" bun:"-"`
+	ID            string     `json:"id" bun:",nullzero"`
+	CreatedAt     *time.Time `json:"createdAt" bun:",nullzero"`
+	AccountID     string     `json:"accountID" bun:",nullzero"`
+	FromAccountID string     `json:"fromAccountID" bun:",nullzero"`
+}
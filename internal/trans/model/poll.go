@@ -0,0 +1,44 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import "time"
+
+// Poll represents a status poll as serialized in an exported file.
+type Poll struct {
+	Type       Type       `json:"type" bun:"-"`
+	ID         string     `json:"id" bun:",nullzero"`
+	Multiple   *bool      `json:"multiple"`
+	HideCounts *bool      `json:"hideCounts"`
+	Options    []string   `json:"options" bun:",nullzero"`
+	Votes      []int      `json:"votes" bun:",nullzero"`
+	Voters     *int       `json:"voters"`
+	StatusID   string     `json:"statusID" bun:",nullzero"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty" bun:",nullzero"`
+	ClosedAt   *time.Time `json:"closedAt,omitempty" bun:",nullzero"`
+}
+
+// PollVote represents a vote in a poll as serialized in an exported file.
+type PollVote struct {
+	Type      Type       `json:"type" bun:"-"`
+	ID        string     `json:"id" bun:",nullzero"`
+	Choices   []int      `json:"choices" bun:",nullzero"`
+	AccountID string     `json:"accountID" bun:",nullzero"`
+	PollID    string     `json:"pollID" bun:",nullzero"`
+	CreatedAt *time.Time `json:"createdAt" bun:",nullzero"`
+}
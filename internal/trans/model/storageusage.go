@@ -0,0 +1,32 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package trans
+
+import "time"
+
+// AccountStorageUsage represents an account's storage usage + quota as serialized in an exported file.
+type AccountStorageUsage struct {
+	Type       Type       `json:"type" bun:"-"`
+	AccountID  string     `json:"accountID" bun:",nullzero"`
+	CreatedAt  *time.Time `json:"createdAt" bun:",nullzero"`
+	UpdatedAt  *time.Time `json:"updatedAt" bun:",nullzero"`
+	MediaSize  int        `json:"mediaSize"`
+	AvatarSize int        `json:"avatarSize"`
+	HeaderSize int        `json:"headerSize"`
+	EmojiSize  int        `json:"emojiSize"`
+	Quota      *int       `json:"quota,omitempty"`
+}
@@ -33,6 +33,34 @@ const (
 	TransFollowRequest    Type = "followRequest"
 	TransInstance         Type = "instance"
 	TransUser             Type = "user"
+	TransStatus           Type = "status"
+	TransStatusToTag      Type = "statusToTag"
+	TransStatusToEmoji    Type = "statusToEmoji"
+	TransMention          Type = "mention"
+	TransTag              Type = "tag"
+	TransPoll             Type = "poll"
+	TransPollVote         Type = "pollVote"
+	TransList             Type = "list"
+	TransListEntry        Type = "listEntry"
+	TransEmoji            Type = "emoji"
+	TransEmojiCategory    Type = "emojiCategory"
+	TransMediaAttachment  Type = "mediaAttachment"
+	TransMarker           Type = "marker"
+	TransStatusBookmark   Type = "statusBookmark"
+	TransStatusFave       Type = "statusFave"
+	TransReport           Type = "report"
+
+	TransFeaturedTag             Type = "featuredTag"
+	TransFollowedTag             Type = "followedTag"
+	TransEndorsement             Type = "endorsement"
+	TransNotificationPolicy      Type = "notificationPolicy"
+	TransNotificationRequest     Type = "notificationRequest"
+	TransNotificationPermission  Type = "notificationPermission"
+	TransBookmarkCollection      Type = "bookmarkCollection"
+	TransBookmarkCollectionEntry Type = "bookmarkCollectionEntry"
+	TransMediaHash               Type = "mediaHash"
+	TransDomainMediaPolicy       Type = "domainMediaPolicy"
+	TransAccountStorageUsage     Type = "accountStorageUsage"
 )
 
 // Entry is used for deserializing trans entries into a rough interface so that
@@ -31,6 +31,7 @@ type User struct {
 	EncryptedPassword   string     `json:"encryptedPassword" bun:",nullzero"`
 	CurrentSignInAt     *time.Time `json:"currentSignInAt,omitempty" bun:",nullzero"`
 	LastSignInAt        *time.Time `json:"lastSignInAt,omitempty" bun:",nullzero"`
+	LastActiveAt        *time.Time `json:"lastActiveAt,omitempty" bun:",nullzero"`
 	InviteID            string     `json:"inviteID,omitempty" bun:",nullzero"`
 	ChosenLanguages     []string   `json:"chosenLanguages,omitempty" bun:",nullzero"`
 	FilteredLanguages   []string   `json:"filteredLanguage,omitempty" bun:",nullzero"`
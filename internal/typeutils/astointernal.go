@@ -196,7 +196,11 @@ func (c *Converter) ASRepresentationToAccount(ctx context.Context, accountable a
 		acct.FeaturedCollectionURI = featuredURI.String()
 	}
 
-	// TODO: FeaturedTagsURI
+	// Extract a FeaturedTagsURI, but only trust if equal to / subdomain of account's domain.
+	if featuredTagsURI := ap.GetFeaturedTags(accountable); // nocollapse
+	featuredTagsURI != nil && dns.CompareDomainName(acct.Domain, featuredTagsURI.Host) >= 2 {
+		acct.FeaturedTagsURI = featuredTagsURI.String()
+	}
 
 	// Moved and AlsoKnownAsURIs,
 	// needed for account migrations.
@@ -391,6 +395,32 @@ func (c *Converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 		}
 	}
 
+	// status.QuoteOfURI
+	// status.QuoteOfID
+	// status.QuoteOf
+	//
+	// Status that this status quotes, if applicable.
+	// As with inReplyTo, if we don't have it in the
+	// database we set the URI and deref it later.
+	if quoteOf := ap.GetQuote(statusable); quoteOf != nil {
+		quoteOfURI := quoteOf.String()
+		status.QuoteOfURI = quoteOfURI
+
+		// Check if we already have the quoted status.
+		quoteOf, err := c.state.DB.GetStatusByURI(ctx, quoteOfURI)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("error getting quoted status %s from db: %w", quoteOfURI, err)
+			return nil, err
+		}
+
+		if quoteOf != nil {
+			// We have it in the DB! Set
+			// appropriate fields here and now.
+			status.QuoteOfID = quoteOf.ID
+			status.QuoteOf = quoteOf
+		}
+	}
+
 	// Calculate intended visibility of the status.
 	status.Visibility, err = ap.ExtractVisibility(
 		statusable,
@@ -192,6 +192,48 @@ func (suite *ASToInternalTestSuite) TestParseReplyWithMention() {
 	suite.Equal(gtsmodel.VisibilityUnlocked, status.Visibility)
 }
 
+func (suite *ASToInternalTestSuite) TestParseMisskeyQuote() {
+	quotedStatus := suite.testStatuses["local_account_1_status_1"]
+
+	t := suite.jsonToType(`{
+  "@context": [
+    "https://www.w3.org/ns/activitystreams",
+    {
+      "misskey": "https://misskey-hub.net/ns#",
+      "_misskey_quote": "misskey:_misskey_quote",
+      "quoteUrl": "as:quoteUrl"
+    }
+  ],
+  "id": "http://fossbros-anonymous.io/notes/9qhzp6dxt2",
+  "type": "Note",
+  "attributedTo": "http://fossbros-anonymous.io/users/foss_satan",
+  "content": "<p>wow look at this</p>",
+  "_misskey_quote": "` + quotedStatus.URI + `",
+  "quoteUrl": "` + quotedStatus.URI + `",
+  "published": "2024-03-28T10:00:00.000Z",
+  "to": [
+    "https://www.w3.org/ns/activitystreams#Public"
+  ],
+  "cc": [
+    "http://fossbros-anonymous.io/users/foss_satan/followers"
+  ]
+}`)
+
+	statusable, ok := t.(ap.Statusable)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	status, err := suite.typeconverter.ASStatusToStatus(context.Background(), statusable)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(quotedStatus.URI, status.QuoteOfURI)
+	suite.Equal(quotedStatus.ID, status.QuoteOfID)
+	suite.Equal(quotedStatus.ID, status.QuoteOf.ID)
+}
+
 func (suite *ASToInternalTestSuite) TestParseOwncastService() {
 	t := suite.jsonToType(owncastService)
 	rep, ok := t.(ap.Accountable)
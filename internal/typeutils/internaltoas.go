@@ -118,7 +118,24 @@ func (c *Converter) AccountToAS(ctx context.Context, a *gtsmodel.Account) (vocab
 	person.SetTootFeatured(featuredProp)
 
 	// featuredTags
-	// NOT IMPLEMENTED
+	// Featured hashtags.
+	if a.FeaturedTagsURI != "" {
+		featuredTagsURI, err := url.Parse(a.FeaturedTagsURI)
+		if err != nil {
+			return nil, err
+		}
+		ap.SetFeaturedTags(person, featuredTagsURI)
+	}
+
+	// endorsements
+	// Endorsed (featured) accounts.
+	if a.IsLocal() {
+		endorsementsURI, err := url.Parse(uris.GenerateURIsForAccount(a.Username).EndorsementsURI)
+		if err != nil {
+			return nil, err
+		}
+		ap.SetEndorsements(person, endorsementsURI)
+	}
 
 	// preferredUsername
 	// Used for Webfinger lookup. Must be unique on the domain, and must correspond to a Webfinger acct: URI.
@@ -483,6 +500,16 @@ func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Stat
 		status.SetActivityStreamsInReplyTo(inReplyToProp)
 	}
 
+	// quoteUrl, _misskey_quote, quoteUri
+	if s.QuoteOfURI != "" {
+		qURI, err := url.Parse(s.QuoteOfURI)
+		if err != nil {
+			return nil, gtserror.Newf("error parsing url %s: %w", s.QuoteOfURI, err)
+		}
+
+		ap.SetQuote(status, qURI)
+	}
+
 	// published
 	publishedProp := streams.NewActivityStreamsPublishedProperty()
 	publishedProp.Set(s.CreatedAt)
@@ -1623,6 +1650,76 @@ func (c *Converter) StatusesToASFeaturedCollection(ctx context.Context, featured
 	return collection, nil
 }
 
+// FeaturedTagsToASCollection converts a slice of gts model featured tags
+// into an activitystreams Collection of Hashtags, suitable for serving at
+// an account's featuredTags URI. Tags must be populated on the featured tags.
+func (c *Converter) FeaturedTagsToASCollection(ctx context.Context, featuredTagsID string, featuredTags []*gtsmodel.FeaturedTag) (vocab.ActivityStreamsCollection, error) {
+	collection := streams.NewActivityStreamsCollection()
+
+	collectionIDProp := streams.NewJSONLDIdProperty()
+	featuredTagsIDURI, err := url.Parse(featuredTagsID)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing url %s", featuredTagsID)
+	}
+	collectionIDProp.SetIRI(featuredTagsIDURI)
+	collection.SetJSONLDId(collectionIDProp)
+
+	itemsProp := streams.NewActivityStreamsItemsProperty()
+	for _, f := range featuredTags {
+		if f.Tag == nil {
+			return nil, gtserror.Newf("featured tag %s tag not populated", f.ID)
+		}
+
+		tag, err := c.TagToAS(ctx, f.Tag)
+		if err != nil {
+			return nil, gtserror.Newf("error converting tag %s: %w", f.TagID, err)
+		}
+		itemsProp.AppendTootHashtag(tag)
+	}
+	collection.SetActivityStreamsItems(itemsProp)
+
+	totalItemsProp := streams.NewActivityStreamsTotalItemsProperty()
+	totalItemsProp.Set(len(featuredTags))
+	collection.SetActivityStreamsTotalItems(totalItemsProp)
+
+	return collection, nil
+}
+
+// EndorsementsToASCollection converts a slice of gts model endorsements
+// into an activitystreams Collection of account URIs, suitable for serving
+// at an account's endorsements URI. Target accounts must be populated.
+func (c *Converter) EndorsementsToASCollection(ctx context.Context, endorsementsID string, endorsements []*gtsmodel.Endorsement) (vocab.ActivityStreamsCollection, error) {
+	collection := streams.NewActivityStreamsCollection()
+
+	collectionIDProp := streams.NewJSONLDIdProperty()
+	endorsementsIDURI, err := url.Parse(endorsementsID)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing url %s", endorsementsID)
+	}
+	collectionIDProp.SetIRI(endorsementsIDURI)
+	collection.SetJSONLDId(collectionIDProp)
+
+	itemsProp := streams.NewActivityStreamsItemsProperty()
+	for _, e := range endorsements {
+		if e.TargetAccount == nil {
+			return nil, gtserror.Newf("endorsement %s target account not populated", e.ID)
+		}
+
+		uri, err := url.Parse(e.TargetAccount.URI)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing url %s", e.TargetAccount.URI)
+		}
+		itemsProp.AppendIRI(uri)
+	}
+	collection.SetActivityStreamsItems(itemsProp)
+
+	totalItemsProp := streams.NewActivityStreamsTotalItemsProperty()
+	totalItemsProp.Set(len(endorsements))
+	collection.SetActivityStreamsTotalItems(totalItemsProp)
+
+	return collection, nil
+}
+
 // ReportToASFlag converts a gts model report into an activitystreams FLAG, suitable for federation.
 func (c *Converter) ReportToASFlag(ctx context.Context, r *gtsmodel.Report) (vocab.ActivityStreamsFlag, error) {
 	flag := streams.NewActivityStreamsFlag()
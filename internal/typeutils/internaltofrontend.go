@@ -24,6 +24,7 @@ import (
 	"math"
 	"strconv"
 	"strings"
+	"time"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
@@ -48,6 +49,7 @@ const (
 	instanceAccountsMaxProfileFields            = 6 // FIXME: https://github.com/superseriousbusiness/gotosocial/issues/1876
 	instanceSourceURL                           = "https://github.com/superseriousbusiness/gotosocial"
 	instanceMastodonVersion                     = "3.5.3"
+	instanceUsageActivePeriod                   = 4 * 7 * 24 * time.Hour // 4 weeks
 )
 
 var instanceStatusesSupportedMimeTypes = []string{
@@ -448,6 +450,13 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 }
 
 func (c *Converter) AppToAPIAppSensitive(ctx context.Context, a *gtsmodel.Application) (*apimodel.Application, error) {
+	// Include our VAPID key, for
+	// apps that use Web Push.
+	instance, err := c.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return nil, gtserror.Newf("error getting instance: %w", err)
+	}
+
 	return &apimodel.Application{
 		ID:           a.ID,
 		Name:         a.Name,
@@ -455,6 +464,7 @@ func (c *Converter) AppToAPIAppSensitive(ctx context.Context, a *gtsmodel.Applic
 		RedirectURI:  a.RedirectURI,
 		ClientID:     a.ClientID,
 		ClientSecret: a.ClientSecret,
+		VapidKey:     instance.VAPIDPublicKey,
 	}, nil
 }
 
@@ -475,6 +485,13 @@ func (c *Converter) AttachmentToAPIAttachment(ctx context.Context, a *gtsmodel.M
 		Type: strings.ToLower(string(a.Type)),
 	}
 
+	if util.PtrValueOr(a.Quarantined, false) {
+		// Media matched the media hash blocklist,
+		// don't show or link to it in any way.
+		apiAttachment.Type = strings.ToLower(string(gtsmodel.FileTypeUnknown))
+		return apiAttachment, nil
+	}
+
 	// Don't try to serialize meta for
 	// unknown attachments, there's no point.
 	if a.Type != gtsmodel.FileTypeUnknown {
@@ -528,7 +545,7 @@ func (c *Converter) AttachmentToAPIAttachment(ctx context.Context, a *gtsmodel.M
 			Y: a.FileMeta.Focus.Y,
 		}
 
-	case gtsmodel.FileTypeVideo:
+	case gtsmodel.FileTypeVideo, gtsmodel.FileTypeGifv:
 		if i := a.FileMeta.Original.Duration; i != nil {
 			apiAttachment.Meta.Original.Duration = *i
 		}
@@ -541,6 +558,15 @@ func (c *Converter) AttachmentToAPIAttachment(ctx context.Context, a *gtsmodel.M
 			apiAttachment.Meta.Original.FrameRate = fr + "/1"
 		}
 
+		if i := a.FileMeta.Original.Bitrate; i != nil {
+			apiAttachment.Meta.Original.Bitrate = int(*i)
+		}
+
+	case gtsmodel.FileTypeAudio:
+		if i := a.FileMeta.Original.Duration; i != nil {
+			apiAttachment.Meta.Original.Duration = *i
+		}
+
 		if i := a.FileMeta.Original.Bitrate; i != nil {
 			apiAttachment.Meta.Original.Bitrate = int(*i)
 		}
@@ -660,6 +686,33 @@ func (c *Converter) TagToAPITag(ctx context.Context, t *gtsmodel.Tag, stubHistor
 	}, nil
 }
 
+// FeaturedTagToAPIFeaturedTag converts a gts model featured tag into
+// its api (frontend) representation, including usage stats for the tag
+// by the account featuring it. Tag must be populated on the featured tag.
+func (c *Converter) FeaturedTagToAPIFeaturedTag(ctx context.Context, f *gtsmodel.FeaturedTag) (*apimodel.FeaturedTag, error) {
+	if f.Tag == nil {
+		return nil, gtserror.Newf("featured tag %s tag not populated", f.ID)
+	}
+
+	count, lastStatus, err := c.state.DB.GetAccountTagStats(ctx, f.AccountID, f.TagID)
+	if err != nil {
+		return nil, gtserror.Newf("error getting stats for featured tag %s: %w", f.ID, err)
+	}
+
+	var lastStatusAt *string
+	if !lastStatus.IsZero() {
+		lastStatusAt = util.Ptr(util.FormatISO8601(lastStatus))
+	}
+
+	return &apimodel.FeaturedTag{
+		ID:            f.ID,
+		Name:          strings.ToLower(f.Tag.Name),
+		URL:           uris.URIForTag(f.Tag.Name),
+		StatusesCount: count,
+		LastStatusAt:  lastStatusAt,
+	}, nil
+}
+
 // StatusToAPIStatus converts a gts model status into its api
 // (frontend) representation for serialization on the API.
 //
@@ -899,6 +952,10 @@ func (c *Converter) statusToFrontend(
 		apiStatus.Reblog = &apimodel.StatusReblogged{reblog}
 	}*/
 
+	if s.QuoteOfURI != "" {
+		apiStatus.Quote = c.statusToAPIQuote(ctx, s, requestingAccount)
+	}
+
 	if app := s.CreatedWithApplication; app != nil {
 		apiStatus.Application, err = c.AppToAPIAppPublic(ctx, app)
 		if err != nil {
@@ -930,6 +987,41 @@ func (c *Converter) statusToFrontend(
 	return apiStatus, nil
 }
 
+// statusToAPIQuote returns the API representation of the
+// status quoted by s, taking account of whether the quoted
+// status is visible to the requesting account (which may be
+// nil), ie., whether it's been blocked, deleted, etc.
+func (c *Converter) statusToAPIQuote(
+	ctx context.Context,
+	s *gtsmodel.Status,
+	requestingAccount *gtsmodel.Account,
+) *apimodel.StatusQuote {
+	if s.QuoteOf == nil {
+		// Quoted status not (yet)
+		// known, or since deleted.
+		return &apimodel.StatusQuote{
+			State: apimodel.StatusQuoteStateDeleted,
+		}
+	}
+
+	visible, err := c.filter.StatusVisible(ctx, requestingAccount, s.QuoteOf)
+	if err != nil {
+		log.Errorf(ctx, "error checking visibility of quoted status %s: %v", s.QuoteOfID, err)
+		visible = false
+	}
+
+	if !visible {
+		return &apimodel.StatusQuote{
+			State: apimodel.StatusQuoteStateUnauthorized,
+		}
+	}
+
+	return &apimodel.StatusQuote{
+		State:          apimodel.StatusQuoteStateAccepted,
+		QuotedStatusID: util.Ptr(s.QuoteOfID),
+	}
+}
+
 // VisToAPIVis converts a gts visibility into its api equivalent
 func (c *Converter) VisToAPIVis(ctx context.Context, m gtsmodel.Visibility) apimodel.Visibility {
 	switch m {
@@ -974,6 +1066,40 @@ func (c *Converter) InstanceRuleToAdminAPIRule(r *gtsmodel.Rule) *apimodel.Admin
 	}
 }
 
+// InstanceStorageUsageToAdminAPIStorageUsage converts instance storage usage into its api equivalent for serving at /api/v1/admin/storage
+func (c *Converter) InstanceStorageUsageToAdminAPIStorageUsage(u *gtsmodel.InstanceStorageUsage) *apimodel.AdminStorageUsage {
+	return &apimodel.AdminStorageUsage{
+		Total:               u.Total(),
+		LocalMedia:          u.LocalMediaSize,
+		LocalAvatars:        u.LocalAvatarSize,
+		LocalHeaders:        u.LocalHeaderSize,
+		LocalEmoji:          u.LocalEmojiSize,
+		RemoteMedia:         u.RemoteMediaSize,
+		RemoteEmoji:         u.RemoteEmojiSize,
+		DefaultAccountQuota: int(config.GetMediaAccountQuota()),
+	}
+}
+
+// AccountStorageUsageToAdminAPIStorageUsage converts account storage usage into its api equivalent for serving at /api/v1/admin/accounts/:id/storage
+func (c *Converter) AccountStorageUsageToAdminAPIStorageUsage(u *gtsmodel.AccountStorageUsage) *apimodel.AdminAccountStorageUsage {
+	quota := int(config.GetMediaAccountQuota())
+	if u.Quota != nil {
+		quota = *u.Quota
+	}
+
+	return &apimodel.AdminAccountStorageUsage{
+		AccountID:   u.AccountID,
+		Total:       u.Total(),
+		Media:       u.MediaSize,
+		Avatar:      u.AvatarSize,
+		Header:      u.HeaderSize,
+		Emoji:       u.EmojiSize,
+		Quota:       quota,
+		CustomQuota: u.Quota != nil,
+		UpdatedAt:   util.FormatISO8601(u.UpdatedAt),
+	}
+}
+
 // InstanceToAPIV1Instance converts a gts instance into its api equivalent for serving at /api/v1/instance
 func (c *Converter) InstanceToAPIV1Instance(ctx context.Context, i *gtsmodel.Instance) (*apimodel.InstanceV1, error) {
 	instance := &apimodel.InstanceV1{
@@ -1096,7 +1222,6 @@ func (c *Converter) InstanceToAPIV2Instance(ctx context.Context, i *gtsmodel.Ins
 		SourceURL:       instanceSourceURL,
 		Description:     i.Description,
 		DescriptionText: i.DescriptionText,
-		Usage:           apimodel.InstanceV2Usage{}, // todo: not implemented
 		Languages:       config.GetInstanceLanguages().TagStrs(),
 		Rules:           c.InstanceRulesToAPIRules(i.Rules),
 		Terms:           i.Terms,
@@ -1107,6 +1232,13 @@ func (c *Converter) InstanceToAPIV2Instance(ctx context.Context, i *gtsmodel.Ins
 		instance.Version = toMastodonVersion(instance.Version)
 	}
 
+	// usage
+	activeMonth, err := c.state.DB.CountInstanceActiveUsers(ctx, time.Now().Add(-instanceUsageActivePeriod))
+	if err != nil {
+		return nil, fmt.Errorf("InstanceToAPIV2Instance: db error counting active users: %w", err)
+	}
+	instance.Usage.Users.ActiveMonth = activeMonth
+
 	// thumbnail
 	thumbnail := apimodel.InstanceV2Thumbnail{}
 
@@ -1154,6 +1286,7 @@ func (c *Converter) InstanceToAPIV2Instance(ctx context.Context, i *gtsmodel.Ins
 	instance.Configuration.Accounts.MaxFeaturedTags = instanceAccountsMaxFeaturedTags
 	instance.Configuration.Accounts.MaxProfileFields = instanceAccountsMaxProfileFields
 	instance.Configuration.Emojis.EmojiSizeLimit = int(config.GetMediaEmojiLocalMaxSize())
+	instance.Configuration.VAPID.PublicKey = i.VAPIDPublicKey
 
 	// registrations
 	instance.Registrations.Enabled = config.GetAccountsRegistrationOpen()
@@ -1446,9 +1579,48 @@ func (c *Converter) ListToAPIList(ctx context.Context, l *gtsmodel.List) (*apimo
 		ID:            l.ID,
 		Title:         l.Title,
 		RepliesPolicy: string(l.RepliesPolicy),
+		Exclusive:     util.PtrValueOr(l.Exclusive, false),
+	}, nil
+}
+
+// BookmarkCollectionToAPIBookmarkCollection converts a gts model bookmark collection into an api model bookmark collection, for serving at /api/v1/bookmark_collections.
+func (c *Converter) BookmarkCollectionToAPIBookmarkCollection(ctx context.Context, b *gtsmodel.BookmarkCollection) (*apimodel.BookmarkCollection, error) {
+	return &apimodel.BookmarkCollection{
+		ID:        b.ID,
+		Title:     b.Title,
+		CreatedAt: util.FormatISO8601(b.CreatedAt),
+		UpdatedAt: util.FormatISO8601(b.UpdatedAt),
 	}, nil
 }
 
+// ImportToAPIImport converts a gts model import into an api model import, for serving at /api/v1/import.
+func (c *Converter) ImportToAPIImport(ctx context.Context, i *gtsmodel.Import) (*apimodel.Import, error) {
+	apiImport := &apimodel.Import{
+		ID:             i.ID,
+		Type:           i.Type.String(),
+		Mode:           i.Mode.String(),
+		State:          "in_progress",
+		TotalItems:     i.TotalItems,
+		ProcessedItems: i.ProcessedItems,
+		FailedItems:    i.FailedItems,
+		Errors:         i.Errors,
+		CreatedAt:      util.FormatISO8601(i.CreatedAt),
+	}
+
+	if i.Completed() {
+		apiImport.State = "finished"
+		apiImport.CompletedAt = util.FormatISO8601(i.CompletedAt)
+	}
+
+	if apiImport.Errors == nil {
+		// Return empty
+		// array, not null.
+		apiImport.Errors = []string{}
+	}
+
+	return apiImport, nil
+}
+
 // MarkersToAPIMarker converts several gts model markers into an api marker, for serving at /api/v1/markers
 func (c *Converter) MarkersToAPIMarker(ctx context.Context, markers []*gtsmodel.Marker) (*apimodel.Marker, error) {
 	apiMarker := &apimodel.Marker{}
@@ -1470,6 +1642,81 @@ func (c *Converter) MarkersToAPIMarker(ctx context.Context, markers []*gtsmodel.
 	return apiMarker, nil
 }
 
+// PushSubscriptionToAPIPushSubscription converts a gts model Web Push subscription into an api push subscription, for serving at /api/v1/push/subscription
+func (c *Converter) PushSubscriptionToAPIPushSubscription(ctx context.Context, s *gtsmodel.WebPushSubscription) (*apimodel.PushSubscription, error) {
+	instance, err := c.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return nil, gtserror.Newf("error getting instance: %w", err)
+	}
+
+	return &apimodel.PushSubscription{
+		ID:        s.ID,
+		Endpoint:  s.Endpoint,
+		ServerKey: instance.VAPIDPublicKey,
+		Alerts: &apimodel.PushSubscriptionAlerts{
+			Follow:        s.Alerts(gtsmodel.NotificationFollow),
+			FollowRequest: s.Alerts(gtsmodel.NotificationFollowRequest),
+			Favourite:     s.Alerts(gtsmodel.NotificationFave),
+			Mention:       s.Alerts(gtsmodel.NotificationMention),
+			Reblog:        s.Alerts(gtsmodel.NotificationReblog),
+			Poll:          s.Alerts(gtsmodel.NotificationPoll),
+			Status:        s.Alerts(gtsmodel.NotificationStatus),
+		},
+		Policy: string(s.Policy),
+	}, nil
+}
+
+// NotificationPolicyToAPINotificationPolicy converts a gts model notification policy into an api notification policy,
+// including a summary of the policy owner's pending notification requests, for serving at /api/v1/notifications/policy
+func (c *Converter) NotificationPolicyToAPINotificationPolicy(ctx context.Context, p *gtsmodel.NotificationPolicy) (*apimodel.NotificationPolicy, error) {
+	requests, notifications, err := c.state.DB.CountAccountNotificationRequests(ctx, p.AccountID)
+	if err != nil {
+		return nil, gtserror.Newf("error counting notification requests: %w", err)
+	}
+
+	return &apimodel.NotificationPolicy{
+		FilterNotFollowing:    *p.FilterNotFollowing,
+		FilterNotFollowers:    *p.FilterNotFollowers,
+		FilterNewAccounts:     *p.FilterNewAccounts,
+		NewAccountsDays:       p.NewAccountsDays,
+		FilterPrivateMentions: *p.FilterPrivateMentions,
+		Summary: apimodel.NotificationPolicySummary{
+			PendingRequestsCount:      requests,
+			PendingNotificationsCount: notifications,
+		},
+	}, nil
+}
+
+// NotificationRequestToAPINotificationRequest converts a gts model notification request into an api notification request,
+// for serving at /api/v1/notifications/requests. The request should already be populated.
+func (c *Converter) NotificationRequestToAPINotificationRequest(ctx context.Context, r *gtsmodel.NotificationRequest) (*apimodel.NotificationRequest, error) {
+	if r.FromAccount == nil {
+		return nil, gtserror.New("notification request from account was nil")
+	}
+
+	apiAccount, err := c.AccountToAPIAccountPublic(ctx, r.FromAccount)
+	if err != nil {
+		return nil, gtserror.Newf("error converting account to api: %w", err)
+	}
+
+	var apiStatus *apimodel.Status
+	if r.LastStatus != nil {
+		apiStatus, err = c.StatusToAPIStatus(ctx, r.LastStatus, r.Account)
+		if err != nil {
+			return nil, gtserror.Newf("error converting status to api: %w", err)
+		}
+	}
+
+	return &apimodel.NotificationRequest{
+		ID:                 r.ID,
+		CreatedAt:          util.FormatISO8601(r.CreatedAt),
+		UpdatedAt:          util.FormatISO8601(r.UpdatedAt),
+		Account:            apiAccount,
+		NotificationsCount: r.NotificationsCount,
+		LastStatus:         apiStatus,
+	}, nil
+}
+
 // PollToAPIPoll converts a database (gtsmodel) Poll into an API model representation appropriate for the given requesting account.
 func (c *Converter) PollToAPIPoll(ctx context.Context, requester *gtsmodel.Account, poll *gtsmodel.Poll) (*apimodel.Poll, error) {
 	// Ensure the poll model is fully populated for src status.
@@ -969,7 +969,14 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV1ToFrontend() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "image/heic",
+        "image/avif",
+        "video/mp4",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/flac",
+        "audio/wav",
+        "audio/mp4"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -1094,7 +1101,14 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV2ToFrontend() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "image/heic",
+        "image/avif",
+        "video/mp4",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/flac",
+        "audio/wav",
+        "audio/mp4"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -1113,6 +1127,9 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV2ToFrontend() {
     },
     "emojis": {
       "emoji_size_limit": 51200
+    },
+    "vapid": {
+      "public_key": "BLbdmf1NCfOCmExfx_SN8VF8ZX5UEQ89cD8WzNuoH4GjkX22jrsgjMlR-lgy4tvJmBxNuu6wFIdDLKMoZVOTdyU"
     }
   },
   "registrations": {
@@ -36,6 +36,7 @@ const (
 	LikedPath        = "liked"         // LikedPath represents the activitypub liked location
 	CollectionsPath  = "collections"   // CollectionsPath represents the activitypub collections location
 	FeaturedPath     = "featured"      // FeaturedPath represents the activitypub featured location
+	EndorsementsPath = "endorsements"  // EndorsementsPath represents the activitypub endorsements location
 	PublicKeyPath    = "main-key"      // PublicKeyPath is for serving an account's public key
 	FollowPath       = "follow"        // FollowPath used to generate the URI for an individual follow or follow request
 	UpdatePath       = "updates"       // UpdatePath is used to generate the URI for an account update
@@ -72,6 +73,10 @@ type UserURIs struct {
 	LikedURI string
 	// The activitypub URI for this user's featured collections, eg., https://example.org/users/example_user/collections/featured
 	FeaturedCollectionURI string
+	// The activitypub URI for this user's featured hashtags collection, eg., https://example.org/users/example_user/collections/tags
+	FeaturedTagsURI string
+	// The activitypub URI for this user's endorsed accounts collection, eg., https://example.org/users/example_user/collections/endorsements
+	EndorsementsURI string
 	// The URI for this user's public key, eg., https://example.org/users/example_user/publickey
 	PublicKeyURI string
 }
@@ -146,6 +151,8 @@ func GenerateURIsForAccount(username string) *UserURIs {
 	followingURI := fmt.Sprintf("%s/%s", userURI, FollowingPath)
 	likedURI := fmt.Sprintf("%s/%s", userURI, LikedPath)
 	collectionURI := fmt.Sprintf("%s/%s/%s", userURI, CollectionsPath, FeaturedPath)
+	featuredTagsURI := fmt.Sprintf("%s/%s/%s", userURI, CollectionsPath, TagsPath)
+	endorsementsURI := fmt.Sprintf("%s/%s/%s", userURI, CollectionsPath, EndorsementsPath)
 	publicKeyURI := fmt.Sprintf("%s/%s", userURI, PublicKeyPath)
 
 	return &UserURIs{
@@ -161,6 +168,8 @@ func GenerateURIsForAccount(username string) *UserURIs {
 		FollowingURI:          followingURI,
 		LikedURI:              likedURI,
 		FeaturedCollectionURI: collectionURI,
+		FeaturedTagsURI:       featuredTagsURI,
+		EndorsementsURI:       endorsementsURI,
 		PublicKeyURI:          publicKeyURI,
 	}
 }
@@ -218,6 +227,25 @@ func StoragePathForAttachment(
 	)
 }
 
+// StoragePathForBlob generates a storage path for a
+// content-addressed media file, from the hex-encoded
+// hash of its contents. Blobs are spread across
+// directories by the first byte of their hash.
+//
+// Will produce something like:
+//
+//	"blobs/9f/9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08.gif"
+func StoragePathForBlob(hash string, extension string) string {
+	const format = "blobs/%s/%s.%s"
+
+	return fmt.Sprintf(
+		format,
+		hash[:2],
+		hash,
+		extension,
+	)
+}
+
 // URIForEmoji generates an
 // ActivityPub URI for an emoji.
 //
@@ -44,6 +44,7 @@ const (
 	maximumProfileFieldLength     = 255
 	maximumProfileFields          = 6
 	maximumListTitleLength        = 200
+	maximumCollectionTitleLength  = 200
 )
 
 // Password returns a helpful error if the given password
@@ -295,6 +296,19 @@ func ListRepliesPolicy(repliesPolicy gtsmodel.RepliesPolicy) error {
 	}
 }
 
+// BookmarkCollectionTitle validates the title of a new or updated bookmark collection.
+func BookmarkCollectionTitle(title string) error {
+	if title == "" {
+		return fmt.Errorf("bookmark collection title must be provided, and must be no more than %d chars", maximumCollectionTitleLength)
+	}
+
+	if length := len([]rune(title)); length > maximumCollectionTitleLength {
+		return fmt.Errorf("bookmark collection title length must be no more than %d chars, provided title was %d chars", maximumCollectionTitleLength, length)
+	}
+
+	return nil
+}
+
 // MarkerName checks that the desired marker timeline name is valid.
 func MarkerName(name string) error {
 	if name == "" {
@@ -21,8 +21,10 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 )
 
 type StatusRequestHandler interface {
@@ -39,6 +41,30 @@ type StatusRequestHandler interface {
 
 type statusRequestHandler struct {
 	weaver.Implements[StatusRequestHandler]
+
+	// broker relays stream messages created while handling
+	// requests to the nodes serving the streaming API, if
+	// configured. It's opened once for the component rather
+	// than per request, as it holds its own db connection.
+	broker stream.Broker
+}
+
+// Init opens the streaming broker, if configured,
+// closing it when the app context is shut down.
+func (r *statusRequestHandler) Init(context.Context) error {
+	if config.GetStreamingBackend() != config.StreamingBackendPostgres {
+		return nil
+	}
+
+	pgConfig, err := bundb.PostgresConnConfig()
+	if err != nil {
+		return fmt.Errorf("error deriving streaming postgres config: %w", err)
+	}
+
+	broker := stream.NewPostgresBroker(pgConfig)
+	onShutdown(broker.Close)
+	r.broker = broker
+	return nil
 }
 
 func (r *statusRequestHandler) DoOperation(
@@ -130,6 +156,7 @@ func (r *statusRequestHandler) DoOperation(
 		mediaManager,
 		&state,
 		nil,
+		webpush.NewSender(&state, client),
 	)
 
 	// Set state client / federator asynchronous worker enqueue functions
@@ -140,6 +167,12 @@ func (r *statusRequestHandler) DoOperation(
 	state.Workers.ProcessFromClientAPI = processor.Workers().ProcessFromClientAPI
 	state.Workers.ProcessFromFediAPI = processor.Workers().ProcessFromFediAPI
 
+	// Publish streaming API messages
+	// to other nodes, if configured.
+	if r.broker != nil {
+		processor.Stream().PublishTo(r.broker)
+	}
+
 	apiStatus, _ := processor.Status().Create(
 		ctx,
 		requester,
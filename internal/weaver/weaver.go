@@ -2,7 +2,9 @@ package weaver
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/ServiceWeaver/weaver"
 )
@@ -38,3 +40,36 @@ func (a *AppContext) Main() {
 		fmt.Printf("Unable to create service weaver component: %s\n", err)
 	}
 }
+
+// shutdownFns are funcs registered by components
+// run in this process to release their resources.
+var shutdownFns struct {
+	fns   []func() error
+	mutex sync.Mutex
+}
+
+// onShutdown registers the given func to be
+// called when the app context is shut down.
+func onShutdown(fn func() error) {
+	shutdownFns.mutex.Lock()
+	shutdownFns.fns = append(shutdownFns.fns, fn)
+	shutdownFns.mutex.Unlock()
+}
+
+// Shutdown releases resources held by
+// components run in this process.
+func (a *AppContext) Shutdown() error {
+	shutdownFns.mutex.Lock()
+	fns := shutdownFns.fns
+	shutdownFns.fns = nil
+	shutdownFns.mutex.Unlock()
+
+	var errs []error
+	for _, fn := range fns {
+		if err := fn(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -121,6 +121,8 @@ func (m *Module) profileGETHandler(c *gin.Context) {
 		maxStatusID    = apiutil.ParseMaxID(c.Query(apiutil.MaxIDKey), "")
 		paging         = maxStatusID != ""
 		pinnedStatuses []*apimodel.Status
+		featuredTags   []*apimodel.FeaturedTag
+		endorsements   []*apimodel.Account
 	)
 
 	if !paging {
@@ -131,6 +133,20 @@ func (m *Module) profileGETHandler(c *gin.Context) {
 			apiutil.WebErrorHandler(c, errWithCode, instanceGet)
 			return
 		}
+
+		// And featured hashtags.
+		featuredTags, errWithCode = m.processor.Account().AccountFeaturedTagsGet(ctx, nil, targetAccount.ID)
+		if errWithCode != nil {
+			apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+			return
+		}
+
+		// And endorsed accounts.
+		endorsements, errWithCode = m.processor.Account().WebEndorsementsGet(ctx, targetAccount.ID)
+		if errWithCode != nil {
+			apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+			return
+		}
 	}
 
 	// Get statuses from maxStatusID onwards (or from top if empty string).
@@ -157,6 +173,8 @@ func (m *Module) profileGETHandler(c *gin.Context) {
 			"statuses":         statusResp.Items,
 			"statuses_next":    statusResp.NextLink,
 			"pinned_statuses":  pinnedStatuses,
+			"featured_tags":    featuredTags,
+			"endorsements":     endorsements,
 			"show_back_to_top": paging,
 		},
 	}
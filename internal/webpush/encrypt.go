@@ -0,0 +1,195 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// recordSize is the record size advertised
+	// in the aes128gcm header; each payload is
+	// encrypted as a single record, so it must
+	// fit in here along with padding delimiter
+	// and authentication tag.
+	recordSize = 4096
+
+	// maxPayloadSize is the largest plaintext
+	// payload that fits in a single record.
+	maxPayloadSize = recordSize - 1 - 16
+)
+
+// encrypt encrypts the given payload for the push subscription with the given
+// base64url-encoded P-256 public key and auth secret, using "aes128gcm" content
+// encoding as described in RFC 8291 (https://www.rfc-editor.org/rfc/rfc8291).
+// The returned bytes are ready to be used as a push message request body.
+func encrypt(payload []byte, p256dh string, auth string) ([]byte, error) {
+	if len(payload) > maxPayloadSize {
+		return nil, gtserror.Newf("payload too large (%d bytes)", len(payload))
+	}
+
+	uaPublicBytes, err := decodeBase64(p256dh)
+	if err != nil {
+		return nil, gtserror.Newf("error decoding p256dh: %w", err)
+	}
+
+	uaPublic, err := ecdh.P256().NewPublicKey(uaPublicBytes)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing p256dh: %w", err)
+	}
+
+	authSecret, err := decodeBase64(auth)
+	if err != nil {
+		return nil, gtserror.Newf("error decoding auth: %w", err)
+	}
+
+	// Generate an ephemeral key pair
+	// just for this one push message.
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, gtserror.Newf("error generating key: %w", err)
+	}
+	asPublicBytes := asPrivate.PublicKey().Bytes()
+
+	ecdhSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, gtserror.Newf("error deriving shared secret: %w", err)
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, gtserror.Newf("error generating salt: %w", err)
+	}
+
+	// Derive the input keying material from the
+	// shared secret, keyed on the auth secret and
+	// both public keys (RFC 8291 section 3.3).
+	keyInfo := make([]byte, 0, 14+len(uaPublicBytes)+len(asPublicBytes))
+	keyInfo = append(keyInfo, "WebPush: info\x00"...)
+	keyInfo = append(keyInfo, uaPublicBytes...)
+	keyInfo = append(keyInfo, asPublicBytes...)
+
+	ikm, err := derive(ecdhSecret, authSecret, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	// Derive content encryption key and
+	// nonce (RFC 8188 sections 2.2, 2.3).
+	cek, err := derive(ikm, salt, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := derive(ikm, salt, []byte("Content-Encoding: nonce\x00"), 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, gtserror.Newf("error creating cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, gtserror.Newf("error creating gcm: %w", err)
+	}
+
+	// Write the header: salt, record
+	// size, and our ephemeral public
+	// key as the key ID.
+	body := make([]byte, 0, 16+4+1+len(asPublicBytes)+len(payload)+1+gcm.Overhead())
+	body = append(body, salt...)
+	body = binary.BigEndian.AppendUint32(body, recordSize)
+	body = append(body, byte(len(asPublicBytes)))
+	body = append(body, asPublicBytes...)
+
+	// Append the single encrypted record,
+	// with a delimiter marking it as last.
+	plaintext := make([]byte, 0, len(payload)+1)
+	plaintext = append(plaintext, payload...)
+	plaintext = append(plaintext, 0x02)
+	body = gcm.Seal(body, nonce, plaintext, nil)
+
+	return body, nil
+}
+
+// derive performs HKDF-SHA-256 with the given input
+// keying material, salt and info, returning n bytes.
+func derive(secret, salt, info []byte, n int) ([]byte, error) {
+	out := make([]byte, n)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, info), out); err != nil {
+		return nil, gtserror.Newf("error deriving key: %w", err)
+	}
+	return out, nil
+}
+
+// decodeBase64 decodes the given base64url string,
+// tolerating padding and the standard alphabet too,
+// since clients aren't all strict about encoding.
+func decodeBase64(s string) ([]byte, error) {
+	for _, enc := range []*base64.Encoding{
+		base64.RawURLEncoding,
+		base64.URLEncoding,
+		base64.RawStdEncoding,
+		base64.StdEncoding,
+	} {
+		if b, err := enc.DecodeString(s); err == nil {
+			return b, nil
+		}
+	}
+	return nil, errors.New("invalid base64")
+}
+
+// ValidateKeys checks that the given base64url-encoded
+// P-256 public key and auth secret of a push subscription
+// can be used to encrypt push messages.
+func ValidateKeys(p256dh string, auth string) error {
+	uaPublicBytes, err := decodeBase64(p256dh)
+	if err != nil {
+		return fmt.Errorf("invalid p256dh: %w", err)
+	}
+
+	if _, err := ecdh.P256().NewPublicKey(uaPublicBytes); err != nil {
+		return fmt.Errorf("invalid p256dh: %w", err)
+	}
+
+	authSecret, err := decodeBase64(auth)
+	if err != nil {
+		return fmt.Errorf("invalid auth: %w", err)
+	}
+
+	if len(authSecret) != 16 {
+		return fmt.Errorf("invalid auth: expected 16 bytes, got %d", len(authSecret))
+	}
+
+	return nil
+}
@@ -0,0 +1,317 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"codeberg.org/gruf/go-byteutil"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+// pushTTL is how long push services should
+// hold on to undelivered push messages.
+const pushTTL = 48 * time.Hour
+
+// maxBodyRunes is the maximum length
+// of the body text of a push message.
+const maxBodyRunes = 140
+
+// Sender sends Web Push alerts to the push subscriptions of local accounts.
+type Sender interface {
+	// Send sends an alert for the given notification to each push subscription
+	// of the notification's target account that wants it, according to the
+	// subscription's alert types and policy. apiNotif is the notification's
+	// API representation, used to fill in the alert text.
+	//
+	// Subscriptions whose push endpoint has gone away are removed.
+	Send(ctx context.Context, notif *gtsmodel.Notification, apiNotif *apimodel.Notification) error
+}
+
+// NewSender returns a new Web Push Sender,
+// which delivers push messages using client.
+func NewSender(state *state.State, client *httpclient.Client) Sender {
+	return &sender{
+		state:  state,
+		client: client,
+	}
+}
+
+// NewNoopSender returns a Web Push
+// Sender that doesn't send anything.
+func NewNoopSender() Sender {
+	return noopSender{}
+}
+
+type noopSender struct{}
+
+func (noopSender) Send(context.Context, *gtsmodel.Notification, *apimodel.Notification) error {
+	return nil
+}
+
+type sender struct {
+	state  *state.State
+	client *httpclient.Client
+}
+
+// payload is the JSON structure of push messages,
+// as understood by Mastodon-compatible clients.
+type payload struct {
+	AccessToken      string `json:"access_token"`
+	PreferredLocale  string `json:"preferred_locale"`
+	NotificationID   string `json:"notification_id"`
+	NotificationType string `json:"notification_type"`
+	Icon             string `json:"icon"`
+	Title            string `json:"title"`
+	Body             string `json:"body"`
+}
+
+func (s *sender) Send(ctx context.Context, notif *gtsmodel.Notification, apiNotif *apimodel.Notification) error {
+	subscriptions, err := s.state.DB.GetWebPushSubscriptionsByAccountID(ctx, notif.TargetAccountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting push subscriptions: %w", err)
+	}
+
+	if len(subscriptions) == 0 {
+		// Nothing to do.
+		return nil
+	}
+
+	instance, err := s.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return gtserror.Newf("error getting instance: %w", err)
+	}
+
+	key, err := parseVAPIDKey(instance.VAPIDPublicKey, instance.VAPIDPrivateKey)
+	if err != nil {
+		return err
+	}
+
+	// Identify ourselves to push
+	// services using the contact
+	// email if set, else our URI.
+	subject := instance.URI
+	if instance.ContactEmail != "" {
+		subject = "mailto:" + instance.ContactEmail
+	}
+
+	// Payload fields shared by all subscriptions.
+	p := payload{
+		NotificationID:   apiNotif.ID,
+		NotificationType: apiNotif.Type,
+		Icon:             apiNotif.Account.Avatar,
+		Title:            title(apiNotif),
+		Body:             body(apiNotif),
+	}
+
+	if user, err := s.state.DB.GetUserByAccountID(ctx, notif.TargetAccountID); err == nil {
+		p.PreferredLocale = user.Locale
+	}
+
+	var errs gtserror.MultiError
+
+	for _, subscription := range subscriptions {
+		if !subscription.Alerts(notif.NotificationType) {
+			// Alerts for this type not wanted.
+			continue
+		}
+
+		allowed, err := s.policyAllows(ctx, subscription.Policy, notif)
+		if err != nil {
+			errs.Appendf("error checking push policy: %w", err)
+			continue
+		}
+
+		if !allowed {
+			continue
+		}
+
+		// Clients use the access token to fetch more info
+		// about the notification, so each subscription's
+		// payload needs the token it was created with.
+		token := new(gtsmodel.Token)
+		if err := s.state.DB.GetByID(ctx, subscription.TokenID, token); err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				errs.Appendf("error getting push subscription token: %w", err)
+				continue
+			}
+
+			// Token has been revoked,
+			// so drop the subscription.
+			if err := s.state.DB.DeleteWebPushSubscriptionByID(ctx, subscription.ID); err != nil {
+				errs.Appendf("error deleting push subscription: %w", err)
+			}
+			continue
+		}
+
+		p.AccessToken = token.Access
+
+		if err := s.send(ctx, subscription, key, subject, &p); err != nil {
+			errs.Appendf("error sending push to subscription %s: %w", subscription.ID, err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+// policyAllows returns whether the given push subscription
+// policy allows sending an alert for the given notification.
+func (s *sender) policyAllows(ctx context.Context, policy gtsmodel.WebPushPolicy, notif *gtsmodel.Notification) (bool, error) {
+	switch policy {
+	case gtsmodel.WebPushPolicyNone:
+		return false, nil
+
+	case gtsmodel.WebPushPolicyFollowed:
+		// Target must follow origin.
+		return s.state.DB.IsFollowing(ctx, notif.TargetAccountID, notif.OriginAccountID)
+
+	case gtsmodel.WebPushPolicyFollower:
+		// Origin must follow target.
+		return s.state.DB.IsFollowing(ctx, notif.OriginAccountID, notif.TargetAccountID)
+
+	default:
+		return true, nil
+	}
+}
+
+// send encrypts the given payload for the given subscription,
+// and delivers it to the subscription's push endpoint.
+func (s *sender) send(
+	ctx context.Context,
+	subscription *gtsmodel.WebPushSubscription,
+	key *vapidKey,
+	subject string,
+	p *payload,
+) error {
+	endpoint, err := url.Parse(subscription.Endpoint)
+	if err != nil {
+		return gtserror.Newf("invalid endpoint: %w", err)
+	}
+
+	b, err := json.Marshal(p)
+	if err != nil {
+		return gtserror.Newf("error marshaling payload: %w", err)
+	}
+
+	b, err = encrypt(b, subscription.P256dh, subscription.Auth)
+	if err != nil {
+		return err
+	}
+
+	authorization, err := key.authorization(endpoint, subject, time.Now())
+	if err != nil {
+		return err
+	}
+
+	// Use rewindable bytes reader for body.
+	var reqBody byteutil.ReadNopCloser
+	reqBody.Reset(b)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), &reqBody)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("TTL", strconv.Itoa(int(pushTTL.Seconds())))
+	req.Header.Set("Urgency", "normal")
+
+	rsp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	switch code := rsp.StatusCode; {
+	case code == http.StatusNotFound || code == http.StatusGone:
+		// The subscription has expired or
+		// been unsubscribed; stop sending.
+		log.Debugf(ctx, "push endpoint gone, deleting subscription %s", subscription.ID)
+		return s.state.DB.DeleteWebPushSubscriptionByID(ctx, subscription.ID)
+
+	case code < 200 || code > 299:
+		return gtserror.NewFromResponse(rsp)
+	}
+
+	return nil
+}
+
+// title returns the title text of
+// a push alert for the given notification.
+func title(notif *apimodel.Notification) string {
+	name := notif.Account.DisplayName
+	if name == "" {
+		name = "@" + notif.Account.Acct
+	}
+
+	switch gtsmodel.NotificationType(notif.Type) {
+	case gtsmodel.NotificationFollow:
+		return name + " followed you"
+	case gtsmodel.NotificationFollowRequest:
+		return name + " requested to follow you"
+	case gtsmodel.NotificationMention:
+		return "You were mentioned by " + name
+	case gtsmodel.NotificationReblog:
+		return name + " boosted your post"
+	case gtsmodel.NotificationFave:
+		return name + " favourited your post"
+	case gtsmodel.NotificationPoll:
+		return "A poll has ended"
+	case gtsmodel.NotificationStatus:
+		return name + " just posted"
+	default:
+		return "New notification from " + name
+	}
+}
+
+// body returns the body text of a push alert
+// for the given notification, ie., the text of
+// its status, or else the bio of its account.
+func body(notif *apimodel.Notification) string {
+	var body string
+	switch {
+	case notif.Status != nil && notif.Status.SpoilerText != "":
+		body = notif.Status.SpoilerText
+	case notif.Status != nil:
+		body = text.SanitizeToPlaintext(notif.Status.Content)
+	default:
+		body = text.SanitizeToPlaintext(notif.Account.Note)
+	}
+
+	if r := []rune(body); len(r) > maxBodyRunes {
+		body = string(r[:maxBodyRunes-1]) + "…"
+	}
+
+	return body
+}
@@ -0,0 +1,111 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/url"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// vapidTokenTTL is how long the VAPID
+// tokens we generate are valid for.
+const vapidTokenTTL = 12 * time.Hour
+
+// vapidKey is a parsed VAPID key pair.
+type vapidKey struct {
+	private *ecdsa.PrivateKey
+	public  string // base64url encoded
+}
+
+// parseVAPIDKey parses the given base64url-encoded
+// P-256 key pair, as stored on the instance model.
+func parseVAPIDKey(public string, private string) (*vapidKey, error) {
+	publicBytes, err := decodeBase64(public)
+	if err != nil {
+		return nil, gtserror.Newf("error decoding VAPID public key: %w", err)
+	}
+
+	privateBytes, err := decodeBase64(private)
+	if err != nil {
+		return nil, gtserror.Newf("error decoding VAPID private key: %w", err)
+	}
+
+	curve := elliptic.P256()
+	x, y := elliptic.Unmarshal(curve, publicBytes) //nolint:staticcheck
+	if x == nil {
+		return nil, gtserror.New("invalid VAPID public key")
+	}
+
+	return &vapidKey{
+		private: &ecdsa.PrivateKey{
+			PublicKey: ecdsa.PublicKey{Curve: curve, X: x, Y: y},
+			D:         new(big.Int).SetBytes(privateBytes),
+		},
+		public: public,
+	}, nil
+}
+
+// authorization returns an Authorization header value for a push
+// message to the given endpoint, identifying this server as the
+// sender using VAPID (https://www.rfc-editor.org/rfc/rfc8292).
+// Subject should be a mailto: or https: contact URI.
+func (k *vapidKey) authorization(endpoint *url.URL, subject string, now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{
+		"typ": "JWT",
+		"alg": "ES256",
+	})
+	if err != nil {
+		return "", gtserror.Newf("error marshaling JWT header: %w", err)
+	}
+
+	claims, err := json.Marshal(map[string]any{
+		"aud": endpoint.Scheme + "://" + endpoint.Host,
+		"exp": now.Add(vapidTokenTTL).Unix(),
+		"sub": subject,
+	})
+	if err != nil {
+		return "", gtserror.Newf("error marshaling JWT claims: %w", err)
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(header) +
+		"." + base64.RawURLEncoding.EncodeToString(claims)
+
+	// Sign, and encode the signature as
+	// fixed-width r || s, as JWS requires.
+	digest := sha256.Sum256([]byte(token))
+	r, s, err := ecdsa.Sign(rand.Reader, k.private, digest[:])
+	if err != nil {
+		return "", gtserror.Newf("error signing JWT: %w", err)
+	}
+
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+	token += "." + base64.RawURLEncoding.EncodeToString(sig)
+
+	return "vapid t=" + token + ", k=" + k.public, nil
+}
@@ -0,0 +1,285 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush_test
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+	"golang.org/x/crypto/hkdf"
+)
+
+type WebPushTestSuite struct {
+	state        state.State
+	sender       webpush.Sender
+	testAccounts map[string]*gtsmodel.Account
+	testTokens   map[string]*gtsmodel.Token
+	suite.Suite
+}
+
+func TestWebPushTestSuite(t *testing.T) {
+	suite.Run(t, &WebPushTestSuite{})
+}
+
+func (suite *WebPushTestSuite) SetupSuite() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+}
+
+func (suite *WebPushTestSuite) SetupTest() {
+	suite.state.Caches.Init()
+
+	_ = testrig.NewTestDB(&suite.state)
+	testrig.StandardDBSetup(suite.state.DB, nil)
+
+	// Allow pushes to our local test server.
+	client := httpclient.New(httpclient.Config{
+		AllowRanges: []netip.Prefix{
+			netip.MustParsePrefix("127.0.0.1/8"),
+		},
+	})
+
+	suite.sender = webpush.NewSender(&suite.state, client)
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testTokens = testrig.NewTestTokens()
+}
+
+func (suite *WebPushTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.state.DB)
+}
+
+// subscribe creates a push subscription for local_account_1
+// to the given endpoint, returning the subscription's private
+// key and auth secret, for decrypting push messages.
+func (suite *WebPushTestSuite) subscribe(endpoint string) (*ecdh.PrivateKey, []byte) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	auth := make([]byte, 16)
+	if _, err := rand.Read(auth); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.state.DB.PutWebPushSubscription(context.Background(), &gtsmodel.WebPushSubscription{
+		ID:           "01HSHR7ZD7TZ4FD9K5EKFDE4YC",
+		AccountID:    suite.testAccounts["local_account_1"].ID,
+		TokenID:      suite.testTokens["local_account_1"].ID,
+		Endpoint:     endpoint,
+		P256dh:       base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+		Auth:         base64.RawURLEncoding.EncodeToString(auth),
+		AlertMention: util.Ptr(true),
+		Policy:       gtsmodel.WebPushPolicyAll,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return key, auth
+}
+
+// notification returns a mention notification
+// for local_account_1, and its api representation.
+func (suite *WebPushTestSuite) notification(notifType gtsmodel.NotificationType) (*gtsmodel.Notification, *apimodel.Notification) {
+	notif := &gtsmodel.Notification{
+		ID:               "01HSHRAS2EJQ4QE1E6CJ6TKG5M",
+		NotificationType: notifType,
+		TargetAccountID:  suite.testAccounts["local_account_1"].ID,
+		OriginAccountID:  suite.testAccounts["admin_account"].ID,
+	}
+
+	apiNotif := &apimodel.Notification{
+		ID:   notif.ID,
+		Type: string(notifType),
+		Account: &apimodel.Account{
+			Acct:   "admin",
+			Avatar: "http://localhost:8080/avatar.png",
+		},
+		Status: &apimodel.Status{
+			Content: "<p>hello <span class=\"h-card\">@the_mighty_zork</span></p>",
+		},
+	}
+
+	return notif, apiNotif
+}
+
+func (suite *WebPushTestSuite) TestSend() {
+	var (
+		header http.Header
+		body   []byte
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	key, auth := suite.subscribe(srv.URL + "/push/some-subscription")
+
+	notif, apiNotif := suite.notification(gtsmodel.NotificationMention)
+	if err := suite.sender.Send(context.Background(), notif, apiNotif); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal("aes128gcm", header.Get("Content-Encoding"))
+	suite.Equal("172800", header.Get("TTL"))
+	suite.True(strings.HasPrefix(header.Get("Authorization"), "vapid t="))
+	suite.True(strings.HasSuffix(header.Get("Authorization"), ", k=BLbdmf1NCfOCmExfx_SN8VF8ZX5UEQ89cD8WzNuoH4GjkX22jrsgjMlR-lgy4tvJmBxNuu6wFIdDLKMoZVOTdyU"))
+
+	plaintext, err := decrypt(body, key, auth)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var payload map[string]string
+	if err := json.Unmarshal(plaintext, &payload); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(suite.testTokens["local_account_1"].Access, payload["access_token"])
+	suite.Equal(notif.ID, payload["notification_id"])
+	suite.Equal("mention", payload["notification_type"])
+	suite.Equal("http://localhost:8080/avatar.png", payload["icon"])
+	suite.Equal("You were mentioned by @admin", payload["title"])
+	suite.Equal("hello @the_mighty_zork", payload["body"])
+}
+
+func (suite *WebPushTestSuite) TestSendAlertNotWanted() {
+	var sent bool
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = true
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	suite.subscribe(srv.URL + "/push/some-subscription")
+
+	// Subscription only wants mentions.
+	notif, apiNotif := suite.notification(gtsmodel.NotificationFave)
+	if err := suite.sender.Send(context.Background(), notif, apiNotif); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.False(sent)
+}
+
+func (suite *WebPushTestSuite) TestSendGone() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer srv.Close()
+
+	suite.subscribe(srv.URL + "/push/some-subscription")
+
+	notif, apiNotif := suite.notification(gtsmodel.NotificationMention)
+	if err := suite.sender.Send(context.Background(), notif, apiNotif); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Subscription should now be gone.
+	_, err := suite.state.DB.GetWebPushSubscriptionByTokenID(
+		context.Background(),
+		suite.testTokens["local_account_1"].ID,
+	)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+// decrypt decrypts the given push message body as
+// the user agent with the given key and auth secret,
+// per RFC 8291.
+func decrypt(body []byte, key *ecdh.PrivateKey, auth []byte) ([]byte, error) {
+	if len(body) < 21 {
+		return nil, errors.New("body too short")
+	}
+
+	salt := body[:16]
+	_ = binary.BigEndian.Uint32(body[16:20])
+	idlen := int(body[20])
+	asPublicBytes := body[21 : 21+idlen]
+	ciphertext := body[21+idlen:]
+
+	asPublic, err := ecdh.P256().NewPublicKey(asPublicBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	ecdhSecret, err := key.ECDH(asPublic)
+	if err != nil {
+		return nil, err
+	}
+
+	derive := func(secret, salt, info []byte, n int) []byte {
+		out := make([]byte, n)
+		_, _ = io.ReadFull(hkdf.New(sha256.New, secret, salt, info), out)
+		return out
+	}
+
+	keyInfo := append([]byte("WebPush: info\x00"), key.PublicKey().Bytes()...)
+	keyInfo = append(keyInfo, asPublicBytes...)
+	ikm := derive(ecdhSecret, auth, keyInfo, 32)
+	cek := derive(ikm, salt, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce := derive(ikm, salt, []byte("Content-Encoding: nonce\x00"), 12)
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Strip padding delimiter.
+	if len(plaintext) == 0 || plaintext[len(plaintext)-1] != 0x02 {
+		return nil, errors.New("missing padding delimiter")
+	}
+
+	return plaintext[:len(plaintext)-1], nil
+}
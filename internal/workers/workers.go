@@ -61,6 +61,11 @@ type Workers struct {
 	// Media manager worker pools.
 	Media runners.WorkerPool
 
+	// WebPush provides a worker pool for delivering
+	// Web Push alerts, kept separate so that slow push
+	// services don't hold up processing of notifications.
+	WebPush runners.WorkerPool
+
 	// prevent pass-by-value.
 	_ nocopy
 }
@@ -83,6 +88,10 @@ func (w *Workers) Start() {
 	tryUntil("starting media workerpool", 5, func() bool {
 		return w.Media.Start(8*maxprocs, 80*maxprocs)
 	})
+
+	tryUntil("starting web push workerpool", 5, func() bool {
+		return w.WebPush.Start(2*maxprocs, 200*maxprocs)
+	})
 }
 
 // Stop will stop all of the contained worker pools (and global scheduler).
@@ -91,6 +100,7 @@ func (w *Workers) Stop() {
 	tryUntil("stopping client API workerpool", 5, w.ClientAPI.Stop)
 	tryUntil("stopping federator workerpool", 5, w.Federator.Stop)
 	tryUntil("stopping media workerpool", 5, w.Media.Stop)
+	tryUntil("stopping web push workerpool", 5, w.WebPush.Stop)
 }
 
 // nocopy when embedded will signal linter to
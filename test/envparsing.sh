@@ -58,7 +58,9 @@ EXPECT=$(cat << "EOF"
         "visibility-mem-ratio": 2,
         "webfinger-mem-ratio": 0.1
     },
+    "category": "",
     "config-path": "internal/config/testdata/test.yaml",
+    "conflict": "",
     "db-address": ":memory:",
     "db-database": "gotosocial_prod",
     "db-max-open-conns-multiplier": 3,
@@ -74,6 +76,7 @@ EXPECT=$(cat << "EOF"
     "db-user": "sex-haver",
     "dry-run": true,
     "email": "",
+    "from": "",
     "host": "example.com",
     "http-client": {
         "allow-ips": [],
@@ -103,12 +106,17 @@ EXPECT=$(cat << "EOF"
     "log-db-queries": true,
     "log-level": "info",
     "log-timestamp-format": "banana",
+    "media-account-quota": 420,
     "media-cleanup-every": 86400000000000,
     "media-cleanup-from": "00:00",
     "media-description-max-chars": 5000,
     "media-description-min-chars": 69,
     "media-emoji-local-max-size": 420,
     "media-emoji-remote-max-size": 420,
+    "media-ffmpeg-path": "/usr/local/bin/ffmpeg",
+    "media-gif-keep-original": true,
+    "media-gif-transcode": "webp",
+    "media-heif-transcode": true,
     "media-image-max-size": 420,
     "media-remote-cache-days": 30,
     "media-video-max-size": 420,
@@ -151,6 +159,7 @@ EXPECT=$(cat << "EOF"
     "statuses-poll-max-options": 1,
     "statuses-poll-option-max-chars": 50,
     "storage-backend": "local",
+    "storage-fallback-backend": "s3",
     "storage-local-base-path": "/root/store",
     "storage-s3-access-key": "minio",
     "storage-s3-bucket": "gts",
@@ -158,11 +167,16 @@ EXPECT=$(cat << "EOF"
     "storage-s3-proxy": true,
     "storage-s3-secret-key": "miniostorage",
     "storage-s3-use-ssl": false,
+    "streaming-backend": "local",
     "syslog-address": "127.0.0.1:6969",
     "syslog-enabled": true,
     "syslog-protocol": "udp",
+    "throttle": 0,
+    "timelines-persist-enabled": false,
+    "timelines-persist-max-length": 400,
     "tls-certificate-chain": "",
     "tls-certificate-key": "",
+    "to": "",
     "tracing-enabled": false,
     "tracing-endpoint": "localhost:4317",
     "tracing-insecure-transport": true,
@@ -228,6 +242,11 @@ GTS_MEDIA_DESCRIPTION_MAX_CHARS=5000 \
 GTS_MEDIA_REMOTE_CACHE_DAYS=30 \
 GTS_MEDIA_EMOJI_LOCAL_MAX_SIZE=420 \
 GTS_MEDIA_EMOJI_REMOTE_MAX_SIZE=420 \
+GTS_MEDIA_HEIF_TRANSCODE=true \
+GTS_MEDIA_ACCOUNT_QUOTA=420 \
+GTS_MEDIA_GIF_TRANSCODE='webp' \
+GTS_MEDIA_GIF_KEEP_ORIGINAL=true \
+GTS_MEDIA_FFMPEG_PATH='/usr/local/bin/ffmpeg' \
 GTS_METRICS_AUTH_ENABLED=false \
 GTS_METRICS_ENABLED=false \
 GTS_STORAGE_BACKEND='local' \
@@ -238,6 +257,7 @@ GTS_STORAGE_S3_ENDPOINT='localhost:9000' \
 GTS_STORAGE_S3_USE_SSL='false' \
 GTS_STORAGE_S3_PROXY='true' \
 GTS_STORAGE_S3_BUCKET='gts' \
+GTS_STORAGE_FALLBACK_BACKEND='s3' \
 GTS_STATUSES_MAX_CHARS=69 \
 GTS_STATUSES_CW_MAX_CHARS=420 \
 GTS_STATUSES_POLL_MAX_OPTIONS=1 \
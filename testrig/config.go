@@ -94,6 +94,11 @@ var testDefaults = config.Configuration{
 	MediaEmojiRemoteMaxSize:  102400,         // 100KiB
 	MediaCleanupFrom:         "00:00",        // midnight.
 	MediaCleanupEvery:        24 * time.Hour, // 1/day.
+	MediaHEIFTranscode:       false,
+	MediaAccountQuota:        0, // no limit
+	MediaGIFTranscode:        "off",
+	MediaGIFKeepOriginal:     false,
+	MediaFFmpegPath:          "ffmpeg",
 
 	// the testrig only uses in-memory storage, so we can
 	// safely set this value to 'test' to avoid running storage
@@ -141,6 +146,8 @@ var testDefaults = config.Configuration{
 	SyslogProtocol: "udp",
 	SyslogAddress:  "localhost:514",
 
+	StreamingBackend: "local",
+
 	AdvancedCookiesSamesite:      "lax",
 	AdvancedRateLimitRequests:    0, // disabled
 	AdvancedThrottlingMultiplier: 0, // disabled
@@ -20,6 +20,7 @@ package testrig
 import (
 	"context"
 	"os"
+	"slices"
 	"strconv"
 
 	"github.com/superseriousbusiness/gotosocial/internal/config"
@@ -37,8 +38,12 @@ var testModels = []interface{}{
 	&gtsmodel.Block{},
 	&gtsmodel.DomainBlock{},
 	&gtsmodel.EmailDomainBlock{},
+	&gtsmodel.Endorsement{},
 	&gtsmodel.Follow{},
 	&gtsmodel.FollowRequest{},
+	&gtsmodel.FeaturedTag{},
+	&gtsmodel.FollowedTag{},
+	&gtsmodel.Import{},
 	&gtsmodel.List{},
 	&gtsmodel.ListEntry{},
 	&gtsmodel.Marker{},
@@ -51,6 +56,8 @@ var testModels = []interface{}{
 	&gtsmodel.StatusToTag{},
 	&gtsmodel.StatusFave{},
 	&gtsmodel.StatusBookmark{},
+	&gtsmodel.BookmarkCollection{},
+	&gtsmodel.BookmarkCollectionEntry{},
 	&gtsmodel.Tag{},
 	&gtsmodel.Thread{},
 	&gtsmodel.ThreadMute{},
@@ -67,6 +74,15 @@ var testModels = []interface{}{
 	&gtsmodel.Report{},
 	&gtsmodel.Rule{},
 	&gtsmodel.AccountNote{},
+	&gtsmodel.WebPushSubscription{},
+	&gtsmodel.NotificationPolicy{},
+	&gtsmodel.NotificationRequest{},
+	&gtsmodel.NotificationPermission{},
+	&gtsmodel.TimelineEntry{},
+	&gtsmodel.MediaBlob{},
+	&gtsmodel.MediaHash{},
+	&gtsmodel.DomainMediaPolicy{},
+	&gtsmodel.AccountStorageUsage{},
 }
 
 // NewTestDB returns a new initialized, empty database for testing.
@@ -108,6 +124,11 @@ func NewTestDB(state *state.State) db.DB {
 	return testDB
 }
 
+// TestModels returns the models that CreateTestTables creates tables for.
+func TestModels() []interface{} {
+	return slices.Clone(testModels)
+}
+
 // CreateTestTables creates prerequisite test tables in the database, but doesn't populate them.
 func CreateTestTables(db db.DB) {
 	ctx := context.Background()
@@ -316,6 +316,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			FollowersURI:            "http://localhost:8080/users/localhost:8080/followers",
 			FollowingURI:            "http://localhost:8080/users/localhost:8080/following",
 			FeaturedCollectionURI:   "http://localhost:8080/users/localhost:8080/collections/featured",
+			FeaturedTagsURI:         "http://localhost:8080/users/localhost:8080/collections/tags",
 			ActorType:               ap.ActorPerson,
 			PrivateKey:              gtsmodel.PrivateKey{},
 			PublicKey:               gtsmodel.PublicKey{},
@@ -353,6 +354,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			FollowersURI:            "http://localhost:8080/users/weed_lord420/followers",
 			FollowingURI:            "http://localhost:8080/users/weed_lord420/following",
 			FeaturedCollectionURI:   "http://localhost:8080/users/weed_lord420/collections/featured",
+			FeaturedTagsURI:         "http://localhost:8080/users/weed_lord420/collections/tags",
 			ActorType:               ap.ActorPerson,
 			PrivateKey:              gtsmodel.PrivateKey{},
 			PublicKey:               gtsmodel.PublicKey{},
@@ -393,6 +395,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			FollowersURI:            "http://localhost:8080/users/admin/followers",
 			FollowingURI:            "http://localhost:8080/users/admin/following",
 			FeaturedCollectionURI:   "http://localhost:8080/users/admin/collections/featured",
+			FeaturedTagsURI:         "http://localhost:8080/users/admin/collections/tags",
 			ActorType:               ap.ActorPerson,
 			PrivateKey:              gtsmodel.PrivateKey{},
 			PublicKey:               gtsmodel.PublicKey{},
@@ -431,6 +434,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			FollowersURI:            "http://localhost:8080/users/the_mighty_zork/followers",
 			FollowingURI:            "http://localhost:8080/users/the_mighty_zork/following",
 			FeaturedCollectionURI:   "http://localhost:8080/users/the_mighty_zork/collections/featured",
+			FeaturedTagsURI:         "http://localhost:8080/users/the_mighty_zork/collections/tags",
 			ActorType:               ap.ActorPerson,
 			PrivateKey:              gtsmodel.PrivateKey{},
 			PublicKey:               gtsmodel.PublicKey{},
@@ -489,6 +493,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			FollowersURI:          "http://localhost:8080/users/1happyturtle/followers",
 			FollowingURI:          "http://localhost:8080/users/1happyturtle/following",
 			FeaturedCollectionURI: "http://localhost:8080/users/1happyturtle/collections/featured",
+			FeaturedTagsURI:       "http://localhost:8080/users/1happyturtle/collections/tags",
 			ActorType:             ap.ActorPerson,
 			PrivateKey:            gtsmodel.PrivateKey{},
 			PublicKey:             gtsmodel.PublicKey{},
@@ -1311,6 +1316,8 @@ func NewTestInstances() map[string]*gtsmodel.Instance {
 			ContactEmail:           "admin@example.org",
 			ContactAccountUsername: "admin",
 			ContactAccountID:       "01F8MH17FWEB39HZJ76B6VXSKF",
+			VAPIDPublicKey:         "BLbdmf1NCfOCmExfx_SN8VF8ZX5UEQ89cD8WzNuoH4GjkX22jrsgjMlR-lgy4tvJmBxNuu6wFIdDLKMoZVOTdyU",
+			VAPIDPrivateKey:        "Il0AXsKERBuyyWiMdVeDsWLpJZyMcYTEeGepJeGVrPA",
 		},
 		"fossbros-anonymous.io": {
 			ID:        "01G5H6YMJQKR86QZKXXQ2S95FZ",
@@ -2477,6 +2484,7 @@ func NewTestLists() map[string]*gtsmodel.List {
 			Title:         "Cool Ass Posters From This Instance",
 			AccountID:     "01F8MH1H7YV1Z7D2C8K2730QBF",
 			RepliesPolicy: gtsmodel.RepliesPolicyFollowed,
+			Exclusive:     util.Ptr(false),
 		},
 	}
 }
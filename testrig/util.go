@@ -48,6 +48,7 @@ func StartNoopWorkers(state *state.State) {
 	_ = state.Workers.ClientAPI.Start(1, 10)
 	_ = state.Workers.Federator.Start(1, 10)
 	_ = state.Workers.Media.Start(1, 10)
+	_ = state.Workers.WebPush.Start(1, 10)
 }
 
 // Starts workers on the provided state using processing functions from the given
@@ -62,6 +63,7 @@ func StartWorkers(state *state.State, wProcessor *wprocessor.Processor) {
 	_ = state.Workers.ClientAPI.Start(1, 10)
 	_ = state.Workers.Federator.Start(1, 10)
 	_ = state.Workers.Media.Start(1, 10)
+	_ = state.Workers.WebPush.Start(1, 10)
 }
 
 func StopWorkers(state *state.State) {
@@ -69,6 +71,7 @@ func StopWorkers(state *state.State) {
 	_ = state.Workers.ClientAPI.Stop()
 	_ = state.Workers.Federator.Stop()
 	_ = state.Workers.Media.Stop()
+	_ = state.Workers.WebPush.Stop()
 }
 
 func StartTimelines(state *state.State, filter *visibility.Filter, converter *typeutils.Converter) {
@@ -77,6 +80,7 @@ func StartTimelines(state *state.State, filter *visibility.Filter, converter *ty
 		tlprocessor.HomeTimelineFilter(state, filter),
 		tlprocessor.HomeTimelineStatusPrepare(state, converter),
 		tlprocessor.SkipInsert(),
+		tlprocessor.HomeTimelineStore(state),
 	)
 	if err := state.Timelines.Home.Start(); err != nil {
 		panic(fmt.Sprintf("error starting home timeline: %s", err))
@@ -87,6 +91,7 @@ func StartTimelines(state *state.State, filter *visibility.Filter, converter *ty
 		tlprocessor.ListTimelineFilter(state, filter),
 		tlprocessor.ListTimelineStatusPrepare(state, converter),
 		tlprocessor.SkipInsert(),
+		tlprocessor.ListTimelineStore(state),
 	)
 	if err := state.Timelines.List.Start(); err != nil {
 		panic(fmt.Sprintf("error starting list timeline: %s", err))